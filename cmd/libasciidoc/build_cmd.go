@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/site"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// NewBuildCmd returns the `build` command
+func NewBuildCmd() *cobra.Command {
+
+	var outputDir string
+	var attributes []string
+	var css []string
+	var safeMode string
+	var diagnosticsFormat string
+	var failureLevel string
+	var strict bool
+
+	buildCmd := &cobra.Command{
+		Use:   "build [flags] DIR",
+		Short: "Convert the documents in the directory into a site of HTML pages, in which the cross references between the documents are resolved",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sourceDir := args[0]
+			if info, err := os.Stat(sourceDir); err != nil {
+				return errors.Wrapf(err, "unable to build '%s'", sourceDir)
+			} else if !info.IsDir() {
+				return errors.Errorf("unable to build '%s': not a directory", sourceDir)
+			}
+			if outputDir == "" {
+				return errors.New("missing output directory")
+			}
+			if diagnosticsFormat != humanDiagnosticsFormat && diagnosticsFormat != jsonDiagnosticsFormat {
+				return errors.Errorf("unsupported diagnostics format: '%s'", diagnosticsFormat)
+			}
+			threshold, failOnProblems, err := parseFailureLevel(failureLevel, strict)
+			if err != nil {
+				return err
+			}
+			mode, err := configuration.ParseSafeMode(safeMode)
+			if err != nil {
+				return err
+			}
+			result, err := site.Build(sourceDir, outputDir,
+				configuration.WithAttributes(parseAttributes(attributes)),
+				configuration.WithCSS(css),
+				configuration.WithSafeMode(mode),
+			)
+			if err != nil {
+				return err
+			}
+			if err := printDiagnostics(cmd.ErrOrStderr(), diagnosticsFormat, result.Diagnostics); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%d page(s) generated in '%s'\n", len(result.Pages), outputDir)
+			if failOnProblems {
+				return checkFailureLevel(cmd.ErrOrStderr(), diagnosticsFormat == humanDiagnosticsFormat, result.Diagnostics, threshold)
+			}
+			return nil
+		},
+	}
+	buildCmd.SilenceUsage = true
+	flags := buildCmd.Flags()
+	flags.StringVarP(&outputDir, "out-dir", "o", "", "the directory in which the pages are generated")
+	flags.StringArrayVarP(&attributes, "attribute", "a", []string{}, "a document attribute to set in the form of name, name!, or name=value pair")
+	flags.StringArrayVarP(&css, "css", "", []string{}, "the paths to the CSS files to link to the pages")
+	flags.StringVarP(&safeMode, "safe-mode", "S", configuration.SafeModeUnsafe.String(), "safe mode to restrict the access to the file system and the raw content [unsafe|safe|server|secure]")
+	flags.StringVar(&diagnosticsFormat, "diagnostics-format", humanDiagnosticsFormat, "format of the problems found in the documents, printed on STDERR [human|json]")
+	flags.StringVar(&failureLevel, "failure-level", "", "minimum severity of the problems found in the documents which makes the command fail [INFO|WARN|ERROR]")
+	flags.BoolVar(&strict, "strict", false, "fail if any warning or error is found in the documents (same as --failure-level=WARN)")
+	return buildCmd
+}
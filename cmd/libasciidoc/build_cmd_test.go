@@ -0,0 +1,56 @@
+package main_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	main "github.com/bytesparadise/libasciidoc/cmd/libasciidoc"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("build cmd", func() {
+
+	It("should build the site", func() {
+		// given
+		outputDir, err := os.MkdirTemp("", "libasciidoc-build")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.RemoveAll, outputDir)
+		buildCmd := main.NewBuildCmd()
+		buf := new(bytes.Buffer)
+		buildCmd.SetOut(buf)
+		buildCmd.SetErr(new(bytes.Buffer))
+		buildCmd.SetArgs([]string{"-o", outputDir, "test"})
+		// when
+		err = buildCmd.Execute()
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buf.String()).To(ContainSubstring("page(s) generated in '" + outputDir + "'"))
+		Expect(filepath.Join(outputDir, "test.html")).To(BeAnExistingFile())
+		Expect(filepath.Join(outputDir, "index.html")).To(BeAnExistingFile())
+	})
+
+	It("should fail when the output directory is missing", func() {
+		// given
+		buildCmd := main.NewBuildCmd()
+		buildCmd.SetOutput(new(bytes.Buffer))
+		buildCmd.SetArgs([]string{"test"})
+		// when
+		err := buildCmd.Execute()
+		// then
+		Expect(err).To(MatchError("missing output directory"))
+	})
+
+	It("should fail to build a file", func() {
+		// given
+		buildCmd := main.NewBuildCmd()
+		buildCmd.SetOutput(new(bytes.Buffer))
+		buildCmd.SetArgs([]string{"-o", "out", "test/test.adoc"})
+		// when
+		err := buildCmd.Execute()
+		// then
+		Expect(err).To(MatchError("unable to build 'test/test.adoc': not a directory"))
+	})
+})
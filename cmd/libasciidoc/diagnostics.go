@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	humanDiagnosticsFormat = "human"
+	jsonDiagnosticsFormat  = "json"
+)
+
+// parseFailureLevel returns the minimum severity of the diagnostics which should make the command fail,
+// or `false` if the command should not fail because of the diagnostics.
+func parseFailureLevel(failureLevel string, strict bool) (types.Severity, bool, error) {
+	if failureLevel == "" {
+		if strict {
+			return types.SeverityWarning, true, nil
+		}
+		return types.SeverityInfo, false, nil
+	}
+	threshold, err := types.ParseSeverity(failureLevel)
+	if err != nil {
+		return types.SeverityInfo, false, errors.Wrap(err, "invalid failure level")
+	}
+	if strict && threshold > types.SeverityWarning {
+		threshold = types.SeverityWarning
+	}
+	return threshold, true, nil
+}
+
+// checkFailureLevel returns an error if at least one of the given diagnostics has the given severity (or higher),
+// after printing a summary of the problems (if `printSummary` is true)
+func checkFailureLevel(w io.Writer, printSummary bool, diagnostics []types.Diagnostic, threshold types.Severity) error {
+	count := 0
+	for _, d := range diagnostics {
+		if d.Severity >= threshold {
+			count++
+		}
+	}
+	if count == 0 {
+		return nil
+	}
+	if printSummary {
+		if _, err := fmt.Fprintln(w, summarize(diagnostics)); err != nil {
+			return err
+		}
+	}
+	return errors.Errorf("failed because of %d problem(s) at or above the '%s' failure level", count, threshold)
+}
+
+// summarize returns the number of diagnostics per severity, from the highest severity to the lowest (eg: `1 error, 2 warnings`)
+func summarize(diagnostics []types.Diagnostic) string {
+	counts := map[types.Severity]int{}
+	for _, d := range diagnostics {
+		counts[d.Severity]++
+	}
+	result := []string{}
+	for _, s := range []types.Severity{types.SeverityError, types.SeverityWarning, types.SeverityInfo} {
+		switch counts[s] {
+		case 0:
+			continue
+		case 1:
+			result = append(result, fmt.Sprintf("1 %s", s))
+		default:
+			result = append(result, fmt.Sprintf("%d %ss", counts[s], s))
+		}
+	}
+	return strings.Join(result, ", ")
+}
+
+// printDiagnostics prints the given diagnostics in the given format (`human` or `json`).
+// In the `json` format, the diagnostics are always printed as an array (which may be empty)
+func printDiagnostics(w io.Writer, format string, diagnostics []types.Diagnostic) error {
+	switch format {
+	case humanDiagnosticsFormat:
+		for _, d := range diagnostics {
+			if _, err := fmt.Fprintln(w, d.String()); err != nil {
+				return err
+			}
+		}
+		return nil
+	case jsonDiagnosticsFormat:
+		if diagnostics == nil {
+			diagnostics = []types.Diagnostic{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(diagnostics)
+	default:
+		return errors.Errorf("unsupported diagnostics format: '%s'", format)
+	}
+}
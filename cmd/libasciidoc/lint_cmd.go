@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/bytesparadise/libasciidoc"
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	"github.com/bytesparadise/libasciidoc/pkg/validator"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	textLintFormat       = "text"
+	jsonLintFormat       = "json"
+	checkstyleLintFormat = "checkstyle"
+)
+
+// NewLintCmd returns the `lint` command
+func NewLintCmd() *cobra.Command {
+
+	var format string
+	var attributes []string
+	var enabledRules []string
+	var disabledRules []string
+	var failureLevel string
+	var listRules bool
+	var safeMode string
+
+	lintCmd := &cobra.Command{
+		Use:   "lint [flags] FILE...",
+		Short: "Check the documents for common problems",
+		Args:  cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if listRules {
+				return printRules(cmd.OutOrStdout())
+			}
+			if len(args) == 0 {
+				return errors.New("missing document(s) to lint")
+			}
+			if format != textLintFormat && format != jsonLintFormat && format != checkstyleLintFormat {
+				return errors.Errorf("unsupported lint format: '%s'", format)
+			}
+			threshold, err := types.ParseSeverity(failureLevel)
+			if err != nil {
+				return errors.Wrap(err, "invalid failure level")
+			}
+			mode, err := configuration.ParseSafeMode(safeMode)
+			if err != nil {
+				return err
+			}
+			settings := []configuration.Setting{
+				configuration.WithAttributes(parseAttributes(attributes)),
+				configuration.WithSafeMode(mode),
+			}
+			for _, rules := range []struct {
+				ids     []string
+				enabled bool
+			}{
+				{ids: enabledRules, enabled: true},
+				{ids: disabledRules, enabled: false},
+			} {
+				for _, id := range rules.ids {
+					if _, found := validator.LookupRule(id); !found {
+						return errors.Errorf("unknown linter rule: '%s'", id)
+					}
+					settings = append(settings, configuration.WithLintRule(id, rules.enabled))
+				}
+			}
+			results := make([]lintResult, len(args))
+			problems := []types.Diagnostic{}
+			for i, sourcePath := range args {
+				config := configuration.NewConfiguration(append(settings, configuration.WithFilename(sourcePath))...)
+				p, err := libasciidoc.LintFile(config)
+				if err != nil {
+					return err
+				}
+				results[i] = lintResult{
+					Filename: sourcePath,
+					Problems: p,
+				}
+				problems = append(problems, p...)
+			}
+			if err := printLintResults(cmd.OutOrStdout(), format, results); err != nil {
+				return err
+			}
+			return checkFailureLevel(cmd.OutOrStdout(), format == textLintFormat, problems, threshold)
+		},
+	}
+	lintCmd.SilenceUsage = true
+	flags := lintCmd.Flags()
+	flags.StringVarP(&format, "format", "f", textLintFormat, "format of the problems found in the documents [text|json|checkstyle]")
+	flags.StringArrayVarP(&attributes, "attribute", "a", []string{}, "a document attribute to set in the form of name, name!, or name=value pair")
+	flags.StringArrayVar(&enabledRules, "enable", []string{}, "the ID of a linter rule to enable")
+	flags.StringArrayVar(&disabledRules, "disable", []string{}, "the ID of a linter rule to disable")
+	flags.StringVar(&failureLevel, "failure-level", "ERROR", "minimum severity of the problems found in the documents which makes the command fail [INFO|WARN|ERROR]")
+	flags.StringVarP(&safeMode, "safe-mode", "S", configuration.SafeModeUnsafe.String(), "safe mode to restrict the access to the file system [unsafe|safe|server|secure]")
+	flags.BoolVar(&listRules, "list-rules", false, "list the linter rules")
+	return lintCmd
+}
+
+// lintResult the problems found in a document
+type lintResult struct {
+	Filename string             `json:"file"`
+	Problems []types.Diagnostic `json:"problems"`
+}
+
+// printRules prints the ID, default severity and description of all the linter rules
+func printRules(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, r := range validator.Rules() {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.ID, r.Severity, r.Description)
+	}
+	return tw.Flush()
+}
+
+// printLintResults prints the problems found in the documents, in the given format (`text`, `json` or `checkstyle`)
+func printLintResults(w io.Writer, format string, results []lintResult) error {
+	switch format {
+	case textLintFormat:
+		for _, r := range results {
+			for _, p := range r.Problems {
+				if p.Range.IsZero() {
+					// problem about the whole document
+					if _, err := fmt.Fprintf(w, "%s: %s\n", r.Filename, p); err != nil {
+						return err
+					}
+					continue
+				}
+				if _, err := fmt.Fprintln(w, p.String()); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	case jsonLintFormat:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	case checkstyleLintFormat:
+		return printCheckstyle(w, results)
+	default:
+		return errors.Errorf("unsupported lint format: '%s'", format)
+	}
+}
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr,omitempty"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// printCheckstyle prints the problems in the Checkstyle XML format, grouped by file
+// (the problems found in an included file are listed under this file)
+func printCheckstyle(w io.Writer, results []lintResult) error {
+	report := checkstyleReport{
+		Version: "4.3",
+		Files:   []checkstyleFile{},
+	}
+	indexes := map[string]int{}
+	fileOf := func(name string) *checkstyleFile {
+		if i, found := indexes[name]; found {
+			return &report.Files[i]
+		}
+		indexes[name] = len(report.Files)
+		report.Files = append(report.Files, checkstyleFile{
+			Name: name,
+		})
+		return &report.Files[len(report.Files)-1]
+	}
+	for _, r := range results {
+		fileOf(r.Filename)
+		for _, p := range r.Problems {
+			name := p.Range.Start.File
+			if name == "" {
+				name = r.Filename
+			}
+			f := fileOf(name)
+			f.Errors = append(f.Errors, checkstyleError{
+				Line:     p.Range.Start.Line,
+				Column:   p.Range.Start.Column,
+				Severity: p.Severity.String(),
+				Message:  p.Message,
+				Source:   "libasciidoc." + p.Code,
+			})
+		}
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,111 @@
+package main_test
+
+import (
+	"bytes"
+
+	main "github.com/bytesparadise/libasciidoc/cmd/libasciidoc"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("lint cmd", func() {
+
+	It("should report problems in text format", func() {
+		// given
+		lintCmd := main.NewLintCmd()
+		buf := new(bytes.Buffer)
+		lintCmd.SetOutput(buf)
+		lintCmd.SetArgs([]string{"test/doc_with_problems.adoc"})
+		// when
+		err := lintCmd.Execute()
+		// then
+		Expect(err).To(MatchError("failed because of 1 problem(s) at or above the 'error' failure level"))
+		Expect(buf.String()).To(ContainSubstring(`test/doc_with_problems.adoc:3:1: info: section 'Empty Section' is empty [empty-section]
+test/doc_with_problems.adoc:7:5: warning: possible invalid reference: unknown [dangling-xref]
+test/doc_with_problems.adoc:9:1: error: unterminated listing block [unclosed-block]
+1 error, 1 warning, 1 info
+`))
+	})
+
+	It("should report problems in JSON format", func() {
+		// given
+		lintCmd := main.NewLintCmd()
+		buf := new(bytes.Buffer)
+		lintCmd.SetOutput(buf)
+		lintCmd.SetArgs([]string{"-f", "json", "--failure-level", "WARN", "--disable", "unclosed-block", "--disable", "empty-section", "test/doc_with_problems.adoc"})
+		// when
+		err := lintCmd.Execute()
+		// then
+		Expect(err).To(MatchError("failed because of 1 problem(s) at or above the 'warning' failure level"))
+		Expect(buf.String()).To(HavePrefix(`[
+  {
+    "file": "test/doc_with_problems.adoc",
+    "problems": [
+      {
+        "severity": "warning",
+        "code": "dangling-xref",
+        "message": "possible invalid reference: unknown",
+`))
+		Expect(buf.String()).NotTo(ContainSubstring("unclosed-block"))
+		Expect(buf.String()).NotTo(ContainSubstring("empty-section"))
+	})
+
+	It("should report problems in checkstyle format", func() {
+		// given
+		lintCmd := main.NewLintCmd()
+		buf := new(bytes.Buffer)
+		lintCmd.SetOutput(buf)
+		lintCmd.SetArgs([]string{"-f", "checkstyle", "--disable", "unclosed-block", "test/doc_with_problems.adoc", "test/test.adoc"})
+		// when
+		err := lintCmd.Execute()
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buf.String()).To(Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="4.3">
+  <file name="test/doc_with_problems.adoc">
+    <error line="3" column="1" severity="info" message="section &#39;Empty Section&#39; is empty" source="libasciidoc.empty-section"></error>
+    <error line="7" column="5" severity="warning" message="possible invalid reference: unknown" source="libasciidoc.dangling-xref"></error>
+  </file>
+  <file name="test/test.adoc"></file>
+</checkstyle>
+`))
+	})
+
+	It("should list the rules", func() {
+		// given
+		lintCmd := main.NewLintCmd()
+		buf := new(bytes.Buffer)
+		lintCmd.SetOutput(buf)
+		lintCmd.SetArgs([]string{"--list-rules"})
+		// when
+		err := lintCmd.Execute()
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buf.String()).To(MatchRegexp(`unclosed-block\s+error\s+delimited blocks which are not closed`))
+	})
+
+	It("should fail with unknown rule", func() {
+		// given
+		lintCmd := main.NewLintCmd()
+		buf := new(bytes.Buffer)
+		lintCmd.SetOutput(buf)
+		lintCmd.SetArgs([]string{"--disable", "unknown", "test/test.adoc"})
+		// when
+		err := lintCmd.Execute()
+		// then
+		Expect(err).To(MatchError("unknown linter rule: 'unknown'"))
+	})
+
+	It("should fail with unsupported format", func() {
+		// given
+		lintCmd := main.NewLintCmd()
+		buf := new(bytes.Buffer)
+		lintCmd.SetOutput(buf)
+		lintCmd.SetArgs([]string{"-f", "yaml", "test/test.adoc"})
+		// when
+		err := lintCmd.Execute()
+		// then
+		Expect(err).To(MatchError("unsupported lint format: 'yaml'"))
+	})
+})
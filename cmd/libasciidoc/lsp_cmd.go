@@ -0,0 +1,45 @@
+package main
+
+import (
+	"github.com/bytesparadise/libasciidoc"
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/lsp"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// NewLspCmd returns the `lsp` command
+func NewLspCmd() *cobra.Command {
+
+	var attributes []string
+	var safeMode string
+
+	lspCmd := &cobra.Command{
+		Use:   "lsp [flags]",
+		Short: "Start a language server which communicates over stdio",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// stdout is reserved to the messages sent to the client
+			log.SetOutput(cmd.ErrOrStderr())
+			mode, err := configuration.ParseSafeMode(safeMode)
+			if err != nil {
+				return err
+			}
+			version := libasciidoc.BuildTag
+			if version == "" {
+				version = libasciidoc.BuildCommit
+			}
+			return lsp.NewServer(version,
+				configuration.WithAttributes(parseAttributes(attributes)),
+				configuration.WithSafeMode(mode),
+			).Serve(cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+	lspCmd.SilenceUsage = true
+	flags := lspCmd.Flags()
+	flags.StringArrayVarP(&attributes, "attribute", "a", []string{}, "a document attribute to set in the form of name, name!, or name=value pair")
+	flags.StringVarP(&safeMode, "safe-mode", "S", configuration.SafeModeUnsafe.String(), "safe mode to restrict the access to the file system [unsafe|safe|server|secure]")
+	flags.Bool("stdio", true, "communicate over stdio (the only supported transport)")
+	return lspCmd
+}
@@ -0,0 +1,50 @@
+package main_test
+
+import (
+	"bytes"
+	"fmt"
+
+	main "github.com/bytesparadise/libasciidoc/cmd/libasciidoc"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("lsp cmd", func() {
+
+	message := func(content string) string {
+		return fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(content), content)
+	}
+
+	It("should initialize and shutdown", func() {
+		// given
+		lspCmd := main.NewLspCmd()
+		in := bytes.NewBufferString(message(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"processId":null,"rootUri":null}}`) +
+			message(`{"jsonrpc":"2.0","id":2,"method":"shutdown"}`) +
+			message(`{"jsonrpc":"2.0","method":"exit"}`))
+		out := new(bytes.Buffer)
+		lspCmd.SetIn(in)
+		lspCmd.SetOut(out)
+		lspCmd.SetErr(new(bytes.Buffer))
+		lspCmd.SetArgs([]string{"--stdio"})
+		// when
+		err := lspCmd.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out.String()).To(ContainSubstring(`"serverInfo":{"name":"libasciidoc"`))
+		Expect(out.String()).To(ContainSubstring(`{"jsonrpc":"2.0","id":2,"result":null}`))
+	})
+
+	It("should fail when the input is closed", func() {
+		// given
+		lspCmd := main.NewLspCmd()
+		lspCmd.SetIn(new(bytes.Buffer))
+		lspCmd.SetOut(new(bytes.Buffer))
+		lspCmd.SetErr(new(bytes.Buffer))
+		lspCmd.SetArgs([]string{})
+		// when
+		err := lspCmd.Execute()
+		// then
+		Expect(err).To(MatchError("connection closed before the exit notification"))
+	})
+})
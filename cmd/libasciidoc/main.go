@@ -13,6 +13,14 @@ func main() {
 	rootCmd := NewRootCmd()
 	versionCmd := NewVersionCmd()
 	rootCmd.AddCommand(versionCmd)
+	lintCmd := NewLintCmd()
+	rootCmd.AddCommand(lintCmd)
+	lspCmd := NewLspCmd()
+	rootCmd.AddCommand(lspCmd)
+	serveCmd := NewServeCmd()
+	rootCmd.AddCommand(serveCmd)
+	buildCmd := NewBuildCmd()
+	rootCmd.AddCommand(buildCmd)
 	rootCmd.SetHelpCommand(helpCommand)
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
@@ -1,15 +1,22 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/bytesparadise/libasciidoc"
 	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/preview"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/manpage"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
 
+	"github.com/pkg/errors"
 	pkgprofile "github.com/pkg/profile"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -25,6 +32,13 @@ func NewRootCmd() *cobra.Command {
 	var backend string
 	var attributes []string
 	var profile string
+	var diagnosticsFormat string
+	var failureLevel string
+	var strict bool
+	var safeMode string
+	var watch bool
+	var interval time.Duration
+	var chunkLevel int
 
 	rootCmd := &cobra.Command{
 		Use:   "libasciidoc [flags] FILE",
@@ -45,31 +59,97 @@ func NewRootCmd() *cobra.Command {
 			log.SetOutput(cmd.OutOrStdout())
 			return nil
 		},
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			if len(args) == 0 {
 				return helpCommand.RunE(cmd, args)
 			}
+			if diagnosticsFormat != humanDiagnosticsFormat && diagnosticsFormat != jsonDiagnosticsFormat {
+				return errors.Errorf("unsupported diagnostics format: '%s'", diagnosticsFormat)
+			}
+			threshold, failOnProblems, err := parseFailureLevel(failureLevel, strict)
+			if err != nil {
+				return err
+			}
+			mode, err := configuration.ParseSafeMode(safeMode)
+			if err != nil {
+				return err
+			}
+			// print the problems found in all documents, even if the conversion of a document failed
+			diagnostics := types.NewDiagnostics()
+			defer func() {
+				if perr := printDiagnostics(cmd.ErrOrStderr(), diagnosticsFormat, diagnostics.All()); perr != nil && err == nil {
+					err = perr
+				}
+				if failOnProblems && err == nil {
+					err = checkFailureLevel(cmd.ErrOrStderr(), diagnosticsFormat == humanDiagnosticsFormat, diagnostics.All(), threshold)
+				}
+			}()
 			if profile == "cpu" {
 				defer pkgprofile.Start(pkgprofile.CPUProfile).Stop()
 			}
+			if watch && outputName == "-" {
+				return errors.New("unable to watch the documents when the output is STDOUT")
+			}
+			if chunkLevel > 0 && outputName == "-" {
+				return errors.New("unable to write the chunks of the documents to STDOUT")
+			}
 			attrs := parseAttributes(attributes)
+			convert := func(sourcePath string) (types.Metadata, error) {
+				config := configuration.NewConfiguration(
+					configuration.WithFilename(sourcePath),
+					configuration.WithAttributes(attrs),
+					configuration.WithCSS(css),
+					configuration.WithBackEnd(backend),
+					configuration.WithHeaderFooter(!noHeaderFooter),
+					configuration.WithSafeMode(mode),
+					configuration.WithDiagnostics(diagnostics))
+				if backend == "manpage" && outputName == "" {
+					// the name of the output file is based on the name of the manpage (eg: `git-foo.1`)
+					return convertManpage(sourcePath, config)
+				}
+				if chunkLevel > 0 {
+					return convertChunks(sourcePath, outputName, chunkLevel, config)
+				}
+				out, close := getOut(cmd, sourcePath, outputName, backend)
+				if out == nil {
+					return types.Metadata{}, nil
+				}
+				defer close() //nolint:errcheck
+				// log.Debugf("Starting to process file %v", path)
+				return libasciidoc.ConvertFile(out, config)
+			}
+			watcher := preview.NewWatcher()
 			for _, sourcePath := range args {
-				out, close := getOut(cmd, sourcePath, outputName)
-				if out != nil {
-					defer close() //nolint:errcheck
-					// log.Debugf("Starting to process file %v", path)
-					config := configuration.NewConfiguration(
-						configuration.WithFilename(sourcePath),
-						configuration.WithAttributes(attrs),
-						configuration.WithCSS(css),
-						configuration.WithBackEnd(backend),
-						configuration.WithHeaderFooter(!noHeaderFooter))
-					_, err := libasciidoc.ConvertFile(out, config)
+				metadata, err := convert(sourcePath)
+				if err != nil {
+					return err
+				}
+				watcher.Watch(sourcePath, metadata.Files...)
+			}
+			if !watch {
+				return nil
+			}
+			// print the problems found so far, and convert the documents again when they (or the files they include) change
+			if err := printDiagnostics(cmd.ErrOrStderr(), diagnosticsFormat, diagnostics.All()); err != nil {
+				return err
+			}
+			diagnostics = types.NewDiagnostics()
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+			watcher.Run(ctx, interval, func(sourcePaths []string) {
+				for _, sourcePath := range sourcePaths {
+					log.Infof("converting '%s' again", sourcePath)
+					metadata, err := convert(sourcePath)
 					if err != nil {
-						return err
+						log.WithError(err).Errorf("unable to convert '%s'", sourcePath)
 					}
+					watcher.Watch(sourcePath, metadata.Files...)
 				}
-			}
+				if err := printDiagnostics(cmd.ErrOrStderr(), diagnosticsFormat, diagnostics.All()); err != nil {
+					log.WithError(err).Error("unable to print the diagnostics")
+				}
+				diagnostics = types.NewDiagnostics()
+			})
 			return nil
 		},
 	}
@@ -82,6 +162,13 @@ func NewRootCmd() *cobra.Command {
 	flags.StringArrayVarP(&attributes, "attribute", "a", []string{}, "a document attribute to set in the form of name, name!, or name=value pair")
 	flags.StringVarP(&backend, "backend", "b", "html5", "backend to format the file")
 	flags.StringVar(&profile, "profile", "", "enable profiling")
+	flags.StringVar(&failureLevel, "failure-level", "", "minimum severity of the problems found in the documents which makes the command fail [INFO|WARN|ERROR]")
+	flags.BoolVar(&strict, "strict", false, "fail if any warning or error is found in the documents (same as --failure-level=WARN)")
+	flags.StringVarP(&safeMode, "safe-mode", "S", configuration.SafeModeUnsafe.String(), "safe mode to restrict the access to the file system and the raw content [unsafe|safe|server|secure]")
+	flags.BoolVarP(&watch, "watch", "w", false, "convert the documents again when they (or the files they include) change, until interrupted")
+	flags.DurationVar(&interval, "watch-interval", 500*time.Millisecond, "the interval at which the files are checked for changes in watch mode")
+	flags.StringVar(&diagnosticsFormat, "diagnostics-format", humanDiagnosticsFormat, "format of the problems found in the documents, printed on STDERR [human|json]")
+	flags.IntVar(&chunkLevel, "chunk-level", 0, "split the documents in pages at the sections of the given level (eg: 1 for a page per chapter), written in the output directory (default: the directory of the input file)")
 	return rootCmd
 }
 
@@ -97,7 +184,7 @@ func newCloseFileFunc(c io.Closer) closeFunc {
 	}
 }
 
-func getOut(cmd *cobra.Command, sourcePath, outputName string) (io.Writer, closeFunc) {
+func getOut(cmd *cobra.Command, sourcePath, outputName, backend string) (io.Writer, closeFunc) {
 	if outputName == "-" {
 		// outfile is STDOUT
 		return cmd.OutOrStdout(), defaultCloseFunc()
@@ -111,7 +198,7 @@ func getOut(cmd *cobra.Command, sourcePath, outputName string) (io.Writer, close
 	} else if sourcePath != "" {
 		// outfile is based on sourcePath
 		path, _ := filepath.Abs(sourcePath)
-		outname := strings.TrimSuffix(path, filepath.Ext(path)) + ".html"
+		outname := strings.TrimSuffix(path, filepath.Ext(path)) + outputExtension(backend)
 		outfile, err := os.Create(outname)
 		if err != nil {
 			log.Warnf("Cannot create output file - %v, skipping", outname)
@@ -122,6 +209,74 @@ func getOut(cmd *cobra.Command, sourcePath, outputName string) (io.Writer, close
 	return cmd.OutOrStdout(), defaultCloseFunc()
 }
 
+// converts the given manpage source into a file named after the manpage name and volume number,
+// in the same directory as the source
+func convertManpage(sourcePath string, config *configuration.Configuration) (types.Metadata, error) {
+	buf := &bytes.Buffer{}
+	metadata, err := libasciidoc.ConvertFile(buf, config)
+	if err != nil {
+		return metadata, err
+	}
+	name, volnum := manpage.SplitTitle(metadata.Title)
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(sourcePath), filepath.Ext(sourcePath))
+	}
+	name = config.Attributes.GetAsStringWithDefault(types.AttrManName, name)
+	volnum = config.Attributes.GetAsStringWithDefault(types.AttrManVolNum, volnum)
+	// the name of the output file comes from the document, so it must not point outside of the source directory
+	filename := name + "." + volnum
+	if strings.ContainsAny(filename, `/\`) || strings.Contains(filename, "..") {
+		return metadata, errors.Errorf("invalid manpage name: '%s'", filename)
+	}
+	path, _ := filepath.Abs(sourcePath)
+	outname := filepath.Join(filepath.Dir(path), filename)
+	if err := os.WriteFile(outname, buf.Bytes(), 0644); err != nil { //nolint:gosec
+		return metadata, errors.Wrapf(err, "unable to write manpage in '%s'", outname)
+	}
+	return metadata, nil
+}
+
+// converts the given source into multiple pages, written in the given output directory
+// (or in the same directory as the source if no output directory is given)
+func convertChunks(sourcePath, outputDir string, level int, config *configuration.Configuration) (types.Metadata, error) {
+	chunks, metadata, err := libasciidoc.ConvertFileToChunks(config, level)
+	if err != nil {
+		return metadata, err
+	}
+	if outputDir == "" {
+		path, _ := filepath.Abs(sourcePath)
+		outputDir = filepath.Dir(path)
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return metadata, errors.Wrapf(err, "unable to create '%s'", outputDir)
+	}
+	for _, c := range chunks {
+		outname := filepath.Join(outputDir, c.Name)
+		if err := os.WriteFile(outname, []byte(c.Content), 0644); err != nil { //nolint:gosec
+			return metadata, errors.Wrapf(err, "unable to write chunk in '%s'", outname)
+		}
+	}
+	return metadata, nil
+}
+
+// returns the extension of the output file for the given backend
+func outputExtension(backend string) string {
+	switch backend {
+	case "docbook", "docbook5":
+		return ".xml"
+	case "json", "ast":
+		return ".json"
+	case "yaml":
+		return ".yaml"
+	case "markdown", "md":
+		return ".md"
+	case "text", "txt":
+		return ".txt"
+	default:
+		return ".html"
+	}
+}
+
 // converts the `name`, `!name` and `name=value` into a map
 func parseAttributes(attributes []string) map[string]interface{} {
 	result := make(map[string]interface{}, len(attributes))
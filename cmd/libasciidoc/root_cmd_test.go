@@ -2,7 +2,9 @@ package main_test
 
 import (
 	"bytes"
+	"context"
 	"os"
+	"path/filepath"
 
 	main "github.com/bytesparadise/libasciidoc/cmd/libasciidoc"
 
@@ -31,6 +33,8 @@ var _ = Describe("root cmd", func() {
 		buf := new(bytes.Buffer)
 		root.SetOutput(buf)
 		root.SetArgs([]string{"test/test.adoc"})
+		// the output is written next to the source document, and removed afterwards
+		DeferCleanup(os.Remove, "test/test.html")
 		// when
 		err := root.Execute()
 		// then
@@ -40,6 +44,105 @@ var _ = Describe("root cmd", func() {
 		Expect(content).ToNot(BeEmpty())
 	})
 
+	It("render with DocBook backend and file output", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-b", "docbook5", "test/test.adoc"})
+		// the output is written next to the source document, and removed afterwards
+		DeferCleanup(os.Remove, "test/test.xml")
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		content, err := os.ReadFile("test/test.xml")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring(`<article xmlns="http://docbook.org/ns/docbook"`))
+	})
+
+	It("render with manpage backend and file output", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-b", "manpage", "test/git-foo.adoc"})
+		// the output is written next to the source document, and removed afterwards
+		DeferCleanup(os.Remove, "test/git-foo.1")
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		content, err := os.ReadFile("test/git-foo.1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring(`.TH "GIT\-FOO" "1"`))
+	})
+
+	It("should not write the manpage outside of the source directory", func() {
+		// given
+		dir, err := os.MkdirTemp("", "libasciidoc-manpage")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+		Expect(os.Mkdir(filepath.Join(dir, "src"), 0700)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "src", "doc.adoc"), []byte("= ../evil(1)\n\n== Name\n\nevil - does evil things\n\n== Synopsis\n\n*evil*"), 0600)).To(Succeed())
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-b", "manpage", filepath.Join(dir, "src", "doc.adoc")})
+		// when
+		err = root.Execute()
+		// then
+		Expect(err).To(MatchError("invalid manpage name: '../evil.1'"))
+		Expect(filepath.Join(dir, "evil.1")).NotTo(BeAnExistingFile())
+	})
+
+	It("render with manpage backend and another doctype", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-s", "-o", "-", "-b", "manpage", "-a", "doctype=article", "--failure-level", "ERROR", "test/test.adoc"})
+		// when
+		err := root.Execute()
+		// then the document is not validated as a manpage
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buf.String()).NotTo(ContainSubstring("[invalid-document]"))
+	})
+
+	It("render with Markdown backend and file output", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-b", "markdown", "test/test.adoc"})
+		// the output is written next to the source document, and removed afterwards
+		DeferCleanup(os.Remove, "test/test.md")
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		content, err := os.ReadFile("test/test.md")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(HavePrefix("```\nmultiple\n"))
+	})
+
+	It("render with text backend and file output", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-b", "text", "test/test.adoc"})
+		// the output is written next to the source document, and removed afterwards
+		DeferCleanup(os.Remove, "test/test.txt")
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		content, err := os.ReadFile("test/test.txt")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("    multiple\n\n    paragraphs\n"))
+	})
+
 	It("fail to parse bad log level", func() {
 		// given
 		root := main.NewRootCmd()
@@ -94,12 +197,262 @@ var _ = Describe("root cmd", func() {
 		// then
 		Expect(err).ToNot(HaveOccurred())
 		Expect(buf.String()).ToNot(BeEmpty())
-		// console output also includes a warning message
-		Expect(buf.String()).To(ContainSubstring(`unable to find entry for attribute with key 'foo2' in context"
-<div class="paragraph">
+		Expect(buf.String()).To(ContainSubstring(`<div class="paragraph">
 <p>bar1 and {foo2}</p>
 </div>
 `))
+		// console output also includes a diagnostic
+		Expect(buf.String()).To(ContainSubstring("test/doc_with_attributes.adoc:5:12: warning: unable to find entry for attribute with key 'foo2' in context [unresolved-attribute]"))
+	})
+
+	It("render with diagnostics in JSON format", func() {
+		// given
+		root := main.NewRootCmd()
+		out := new(bytes.Buffer)
+		root.SetOut(out)
+		errOut := new(bytes.Buffer)
+		root.SetErr(errOut)
+		root.SetArgs([]string{"-s", "-o", "-", "-a!foo2", "--diagnostics-format", "json", "test/doc_with_attributes.adoc"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(errOut.String()).To(MatchJSON(`[
+			{
+				"severity": "warning",
+				"code": "unresolved-attribute",
+				"message": "unable to find entry for attribute with key 'foo1' in context",
+				"range": {
+					"start": {"file": "test/doc_with_attributes.adoc", "line": 5, "column": 1},
+					"end": {"file": "test/doc_with_attributes.adoc", "line": 5, "column": 7}
+				}
+			},
+			{
+				"severity": "warning",
+				"code": "unresolved-attribute",
+				"message": "unable to find entry for attribute with key 'foo2' in context",
+				"range": {
+					"start": {"file": "test/doc_with_attributes.adoc", "line": 5, "column": 12},
+					"end": {"file": "test/doc_with_attributes.adoc", "line": 5, "column": 18}
+				}
+			}
+		]`))
+	})
+
+	Context("safe mode", func() {
+
+		It("should fail to include file outside of the document directory in safe mode", func() {
+			// given
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"-s", "-o", "-", "--safe-mode", "safe", "test/doc_with_include.adoc"})
+			// when
+			err := root.Execute()
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("path is outside of the base directory in safe mode"))
+		})
+
+		It("should replace include directive with link in secure mode", func() {
+			// given
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"-s", "-o", "-", "-S", "secure", "test/doc_with_include.adoc"})
+			// when
+			err := root.Execute()
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buf.String()).To(Equal(`<div class="paragraph">
+<p><a href="../../../test/includes/chapter-a.adoc" class="bare include">../../../test/includes/chapter-a.adoc</a></p>
+</div>
+`))
+		})
+
+		It("should fail with unknown safe mode", func() {
+			// given
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"--safe-mode", "paranoid", "test/doc_with_include.adoc"})
+			// when
+			err := root.Execute()
+			// then
+			Expect(err).To(MatchError("unknown safe mode: 'paranoid'"))
+		})
+	})
+
+	It("should fail with unsupported diagnostics format", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"--diagnostics-format", "xml", "test/test.adoc"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).To(MatchError("unsupported diagnostics format: 'xml'"))
+	})
+
+	Context("failure level", func() {
+
+		It("should fail when warnings are found", func() {
+			// given
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"-s", "-o", "-", "-a!foo2", "--failure-level", "WARN", "test/doc_with_attributes.adoc"})
+			// when
+			err := root.Execute()
+			// then
+			Expect(err).To(MatchError("failed because of 2 problem(s) at or above the 'warning' failure level"))
+			Expect(buf.String()).To(ContainSubstring("test/doc_with_attributes.adoc:5:12: warning: unable to find entry for attribute with key 'foo2' in context [unresolved-attribute]"))
+			Expect(buf.String()).To(ContainSubstring("2 warnings\n"))
+		})
+
+		It("should fail when warnings are found in strict mode", func() {
+			// given
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"-s", "-o", "-", "--strict", "test/doc_with_attributes.adoc"})
+			// when
+			err := root.Execute()
+			// then
+			Expect(err).To(MatchError("failed because of 2 problem(s) at or above the 'warning' failure level"))
+		})
+
+		It("should not fail when only warnings are found", func() {
+			// given
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"-s", "-o", "-", "--failure-level", "ERROR", "test/doc_with_attributes.adoc"})
+			// when
+			err := root.Execute()
+			// then
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should fail when errors are found", func() {
+			// given
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"-s", "-o", "-", "-b", "manpage", "--failure-level", "ERROR", "test/test.adoc"})
+			// when
+			err := root.Execute()
+			// then
+			Expect(err).To(MatchError(HavePrefix("failed because of")))
+			Expect(buf.String()).To(ContainSubstring("error: manpage document is missing the 'Name' section [invalid-document]"))
+		})
+
+		It("should not fail when no problem is found", func() {
+			// given
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"-s", "-o", "-", "--failure-level", "INFO", "test/test.adoc"})
+			// when
+			err := root.Execute()
+			// then
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should fail with invalid failure level", func() {
+			// given
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"--failure-level", "FATAL", "test/test.adoc"})
+			// when
+			err := root.Execute()
+			// then
+			Expect(err).To(MatchError("invalid failure level: unknown severity: 'FATAL'"))
+		})
+	})
+
+	Context("chunked output", func() {
+
+		It("should split the document in pages", func() {
+			// given
+			dir, err := os.MkdirTemp("", "libasciidoc-chunks")
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(os.RemoveAll, dir)
+			Expect(os.WriteFile(filepath.Join(dir, "doc.adoc"), []byte("= Doc\n\npreamble\n\n== Section A\n\ncontent"), 0600)).To(Succeed())
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"--chunk-level", "1", "-o", filepath.Join(dir, "out"), filepath.Join(dir, "doc.adoc")})
+			// when
+			err = root.Execute()
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			content, err := os.ReadFile(filepath.Join(dir, "out", "doc.html"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring(`<a class="next" rel="next" href="section_a.html">Section A</a>`))
+			content, err = os.ReadFile(filepath.Join(dir, "out", "section_a.html"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring(`<h2 id="_section_a">Section A</h2>`))
+		})
+
+		It("should fail to write the pages to STDOUT", func() {
+			// given
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"--chunk-level", "1", "-o", "-", "test/test.adoc"})
+			// when
+			err := root.Execute()
+			// then
+			Expect(err).To(MatchError("unable to write the chunks of the documents to STDOUT"))
+		})
+	})
+
+	Context("watch mode", func() {
+
+		It("should convert the document again when an included file changes", func() {
+			// given
+			dir, err := os.MkdirTemp("", "libasciidoc-watch")
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(os.RemoveAll, dir)
+			Expect(os.WriteFile(filepath.Join(dir, "doc.adoc"), []byte("include::chapter.adoc[]"), 0600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "chapter.adoc"), []byte("first version"), 0600)).To(Succeed())
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"-s", "--watch", "--watch-interval", "10ms", filepath.Join(dir, "doc.adoc")})
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			done := make(chan error, 1)
+			// when
+			go func() {
+				done <- root.ExecuteContext(ctx)
+			}()
+			// then
+			output := func() string {
+				content, _ := os.ReadFile(filepath.Join(dir, "doc.html"))
+				return string(content)
+			}
+			Eventually(output).Should(ContainSubstring("<p>first version</p>"))
+			Expect(os.WriteFile(filepath.Join(dir, "chapter.adoc"), []byte("second version"), 0600)).To(Succeed())
+			Eventually(output).Should(ContainSubstring("<p>second version</p>"))
+			cancel()
+			Eventually(done).Should(Receive(BeNil()))
+		})
+
+		It("should fail to watch when the output is STDOUT", func() {
+			// given
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"--watch", "-o", "-", "test/test.adoc"})
+			// when
+			err := root.Execute()
+			// then
+			Expect(err).To(MatchError("unable to watch the documents when the output is STDOUT"))
+		})
 	})
 
 	It("render multiple files", func() {
@@ -108,6 +461,9 @@ var _ = Describe("root cmd", func() {
 		buf := new(bytes.Buffer)
 		root.SetOutput(buf)
 		root.SetArgs([]string{"-s", "test/admonition.adoc", "test/test.adoc"})
+		// the outputs are written next to the source documents, and removed afterwards
+		DeferCleanup(os.Remove, "test/admonition.html")
+		DeferCleanup(os.Remove, "test/test.html")
 		// when
 		err := root.Execute()
 		// then
@@ -120,6 +476,8 @@ var _ = Describe("root cmd", func() {
 		buf := new(bytes.Buffer)
 		root.SetOutput(buf)
 		root.SetArgs([]string{"-s", "test/doesnotexist.adoc", "test/test.adoc"})
+		// the output is written next to the source document, and removed afterwards
+		DeferCleanup(os.Remove, "test/doesnotexist.html")
 		// when
 		err := root.Execute()
 		// then
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/preview"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// NewServeCmd returns the `serve` command
+func NewServeCmd() *cobra.Command {
+
+	var address string
+	var attributes []string
+	var css []string
+	var safeMode string
+	var interval time.Duration
+
+	serveCmd := &cobra.Command{
+		Use:   "serve [flags] [FILE|DIR]",
+		Short: "Render the document or the documents in the directory over HTTP, and reload the pages when they change",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := "."
+			if len(args) > 0 {
+				root = args[0]
+			}
+			if _, err := os.Stat(root); err != nil {
+				return errors.Wrapf(err, "unable to serve '%s'", root)
+			}
+			mode, err := configuration.ParseSafeMode(safeMode)
+			if err != nil {
+				return err
+			}
+			server := preview.NewServer(root,
+				configuration.WithAttributes(parseAttributes(attributes)),
+				configuration.WithCSS(css),
+				configuration.WithSafeMode(mode),
+			)
+			listener, err := net.Listen("tcp", address)
+			if err != nil {
+				return errors.Wrapf(err, "unable to listen on '%s'", address)
+			}
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+			go server.Watch(ctx, interval)
+			httpServer := &http.Server{
+				Handler:           server,
+				ReadHeaderTimeout: 10 * time.Second,
+				// also close the live-reload streams when interrupted
+				BaseContext: func(net.Listener) context.Context {
+					return ctx
+				},
+			}
+			go func() {
+				<-ctx.Done()
+				httpServer.Shutdown(context.Background()) //nolint:errcheck
+			}()
+			fmt.Fprintf(cmd.OutOrStdout(), "serving '%s' on http://%s/\n", root, listener.Addr())
+			if err := httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+				return err
+			}
+			return nil
+		},
+	}
+	serveCmd.SilenceUsage = true
+	flags := serveCmd.Flags()
+	flags.StringVar(&address, "address", "localhost:8080", "the address on which the server listens")
+	flags.StringArrayVarP(&attributes, "attribute", "a", []string{}, "a document attribute to set in the form of name, name!, or name=value pair")
+	flags.StringArrayVarP(&css, "css", "", []string{}, "the paths to the CSS files to link to the documents")
+	flags.StringVarP(&safeMode, "safe-mode", "S", configuration.SafeModeUnsafe.String(), "safe mode to restrict the access to the file system and the raw content [unsafe|safe|server|secure]")
+	flags.DurationVar(&interval, "interval", 500*time.Millisecond, "the interval at which the files are checked for changes")
+	return serveCmd
+}
@@ -0,0 +1,37 @@
+package main_test
+
+import (
+	"bytes"
+
+	main "github.com/bytesparadise/libasciidoc/cmd/libasciidoc"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("serve cmd", func() {
+
+	It("should fail to serve unknown file", func() {
+		// given
+		serveCmd := main.NewServeCmd()
+		buf := new(bytes.Buffer)
+		serveCmd.SetOutput(buf)
+		serveCmd.SetArgs([]string{"test/unknown.adoc"})
+		// when
+		err := serveCmd.Execute()
+		// then
+		Expect(err).To(MatchError("unable to serve 'test/unknown.adoc': stat test/unknown.adoc: no such file or directory"))
+	})
+
+	It("should fail with unknown safe mode", func() {
+		// given
+		serveCmd := main.NewServeCmd()
+		buf := new(bytes.Buffer)
+		serveCmd.SetOutput(buf)
+		serveCmd.SetArgs([]string{"-S", "unknown", "test/test.adoc"})
+		// when
+		err := serveCmd.Execute()
+		// then
+		Expect(err).To(MatchError("unknown safe mode: 'unknown'"))
+	})
+})
@@ -4,13 +4,13 @@ package libasciidoc
 
 import (
 	"io"
-	"os"
 	"strings"
 	"time"
 
 	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	"github.com/bytesparadise/libasciidoc/pkg/parser"
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 	"github.com/bytesparadise/libasciidoc/pkg/validator"
 
@@ -28,16 +28,18 @@ var (
 )
 
 // ConvertFile converts the content of the given filename into an output document.
+// The file is read from `config.FS` if set, or from the OS file system otherwise.
 // The conversion result is written in the given writer `output`, whereas the document metadata (title, etc.) (or an error if a problem occurred) is returned
-// as the result of the function call.  The output format is determined by config.Backend (HTML5 default).
+// as the result of the function call. The metadata also contains the diagnostics (ie, the problems found while processing the document),
+// which are also returned along with an error.  The output format is determined by config.Backend (HTML5 default).
 func ConvertFile(output io.Writer, config *configuration.Configuration) (types.Metadata, error) {
-	file, err := os.Open(config.Filename)
+	file, err := configuration.OpenFile(config.FS, config.Filename)
 	if err != nil {
 		return types.Metadata{}, errors.Wrapf(err, "error opening %s", config.Filename)
 	}
 	defer file.Close()
 	// use the file mtime as the `last updated` value
-	stat, err := os.Stat(config.Filename)
+	stat, err := file.Stat()
 	if err != nil {
 		return types.Metadata{}, errors.Wrapf(err, "error opening %s", config.Filename)
 	}
@@ -47,6 +49,7 @@ func ConvertFile(output io.Writer, config *configuration.Configuration) (types.M
 
 // Convert converts the content of the given reader `r` into a full output document, written in the given writer `output`.
 // Returns an error if a problem occurred. The default will be HTML5, but depends on the config.BackEnd value.
+// The returned metadata contains the diagnostics collected in `config.Diagnostics`.
 func Convert(source io.Reader, output io.Writer, config *configuration.Configuration) (types.Metadata, error) {
 
 	var start, endOfPreprocess, emdOfParse, endOfValidate, endOfRender time.Time
@@ -58,24 +61,86 @@ func Convert(source io.Reader, output io.Writer, config *configuration.Configura
 		log.Infof("time to render     %d microseconds", endOfRender.Sub(endOfValidate).Microseconds())
 		log.Infof("total time         %d microseconds", endOfRender.Sub(start).Microseconds())
 	}()
-	p, err := parser.Preprocess(source, config)
+	p, sources, err := parser.PreprocessWithSourceMap(source, config)
 	if err != nil {
-		return types.Metadata{}, err
+		return metadataWithDiagnostics(types.Metadata{}, config), err
 	}
 	endOfPreprocess = time.Now()
 	// log.Debugf("parsing the asciidoc source...")
-	doc, err := parser.ParseDocument(strings.NewReader(p), config)
+	doc, err := parser.ParseDocument(strings.NewReader(p), config, parser.WithSourceMap(sources))
 	if err != nil {
-		return types.Metadata{}, err
+		return metadataWithDiagnostics(types.Metadata{}, config), err
 	}
 	emdOfParse = time.Now()
 	// validate the document
+	if err := validate(doc, config); err != nil {
+		return metadataWithDiagnostics(types.Metadata{}, config), err
+	}
+	endOfValidate = time.Now()
+	// render
+	metadata, err := render(doc, config, output)
+	if err != nil {
+		return metadataWithDiagnostics(types.Metadata{}, config), err
+	}
+	metadata.Files = sources.Files()
+	endOfRender = time.Now()
+	// log.Debugf("Done processing document")
+	return metadataWithDiagnostics(metadata, config), nil
+
+}
+
+// ConvertFileToChunks converts the content of the given filename into multiple pages, split at the sections of the given level
+// (see `ConvertToChunks()`). The file is read from `config.FS` if set, or from the OS file system otherwise.
+func ConvertFileToChunks(config *configuration.Configuration, level int) ([]sgml.Chunk, types.Metadata, error) {
+	file, err := configuration.OpenFile(config.FS, config.Filename)
+	if err != nil {
+		return nil, types.Metadata{}, errors.Wrapf(err, "error opening %s", config.Filename)
+	}
+	defer file.Close()
+	// use the file mtime as the `last updated` value
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, types.Metadata{}, errors.Wrapf(err, "error opening %s", config.Filename)
+	}
+	config.LastUpdated = stat.ModTime()
+	return ConvertToChunks(file, config, level)
+}
+
+// ConvertToChunks converts the content of the given reader `r` into multiple pages: the sections whose level is lower than or equal
+// to the given level are rendered in pages of their own (see `sgml.RenderChunks()`). The postprocessors (if any) are applied on each page.
+// Only the HTML5 and XHTML5 backends support this mode.
+func ConvertToChunks(source io.Reader, config *configuration.Configuration, level int) ([]sgml.Chunk, types.Metadata, error) {
+	p, sources, err := parser.PreprocessWithSourceMap(source, config)
+	if err != nil {
+		return nil, metadataWithDiagnostics(types.Metadata{}, config), err
+	}
+	doc, err := parser.ParseDocument(strings.NewReader(p), config, parser.WithSourceMap(sources))
+	if err != nil {
+		return nil, metadataWithDiagnostics(types.Metadata{}, config), err
+	}
+	if err := validate(doc, config); err != nil {
+		return nil, metadataWithDiagnostics(types.Metadata{}, config), err
+	}
+	chunks, metadata, err := renderer.RenderChunks(doc, config, level)
+	if err != nil {
+		return nil, metadataWithDiagnostics(types.Metadata{}, config), err
+	}
+	for i := range chunks {
+		if chunks[i].Content, err = postprocess(chunks[i].Content, config); err != nil {
+			return nil, metadataWithDiagnostics(types.Metadata{}, config), err
+		}
+	}
+	metadata.Files = sources.Files()
+	return chunks, metadataWithDiagnostics(metadata, config), nil
+}
+
+// validate validates the document, and reports the problems in the diagnostics of the configuration
+func validate(doc *types.Document, config *configuration.Configuration) error {
 	doctype := config.Attributes.GetAsStringWithDefault(types.AttrDocType, "article")
 	problems, err := validator.Validate(doc, doctype)
 	if err != nil {
-		return types.Metadata{}, err
+		return err
 	}
-	endOfValidate = time.Now()
 	if len(problems) > 0 {
 		// if any problem found, change the doctype to render the document as a regular article
 		log.Warnf("changing doctype to 'article' because problems were found in the document: %v", problems)
@@ -83,19 +148,78 @@ func Convert(source io.Reader, output io.Writer, config *configuration.Configura
 		for _, problem := range problems {
 			switch problem.Severity {
 			case validator.Error:
-				log.Error(problem.Message)
+				config.Diagnostics.Errorf(types.DiagnosticInvalidDocument, problem.Range, "%s", problem.Message)
 			case validator.Warning:
-				log.Warn(problem.Message)
+				config.Diagnostics.Warnf(types.DiagnosticInvalidDocument, problem.Range, "%s", problem.Message)
 			}
 		}
 	}
-	// render
-	metadata, err := renderer.Render(doc, config, output)
+	return nil
+}
+
+// render renders the document in the given output, after applying the postprocessors (if any) on the rendered content
+func render(doc *types.Document, config *configuration.Configuration, output io.Writer) (types.Metadata, error) {
+	if len(config.Extensions.Postprocessors) == 0 {
+		return renderer.Render(doc, config, output)
+	}
+	buf := &strings.Builder{}
+	metadata, err := renderer.Render(doc, config, buf)
 	if err != nil {
 		return types.Metadata{}, err
 	}
-	endOfRender = time.Now()
-	// log.Debugf("Done processing document")
+	result, err := postprocess(buf.String(), config)
+	if err != nil {
+		return types.Metadata{}, err
+	}
+	if _, err := io.WriteString(output, result); err != nil {
+		return types.Metadata{}, errors.Wrap(err, "unable to write the output")
+	}
 	return metadata, nil
+}
+
+// postprocess applies the postprocessors (if any) on the given rendered content
+func postprocess(result string, config *configuration.Configuration) (string, error) {
+	var err error
+	for _, process := range config.Extensions.Postprocessors {
+		if result, err = process(result); err != nil {
+			return "", errors.Wrap(err, "unable to postprocess the output")
+		}
+	}
+	return result, nil
+}
+
+// metadataWithDiagnostics returns the given metadata along with the problems found while processing the document
+func metadataWithDiagnostics(metadata types.Metadata, config *configuration.Configuration) types.Metadata {
+	metadata.Diagnostics = config.Diagnostics.All()
+	return metadata
+}
 
+// LintFile checks the content of the given filename with the linter rules enabled in the configuration
+// (see `validator.Rules()`), and returns the problems that were found.
+func LintFile(config *configuration.Configuration) ([]types.Diagnostic, error) {
+	file, err := configuration.OpenFile(config.FS, config.Filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening %s", config.Filename)
+	}
+	defer file.Close()
+	return Lint(file, config)
+}
+
+// Lint checks the content of the given reader `r` with the linter rules enabled in the configuration
+// (see `validator.Rules()`), and returns the problems that were found.
+// The problems found while parsing the document are also collected in `config.Diagnostics`.
+func Lint(source io.Reader, config *configuration.Configuration) ([]types.Diagnostic, error) {
+	if config.Diagnostics == nil {
+		// the diagnostics are needed by some rules
+		config.Diagnostics = types.NewDiagnostics()
+	}
+	p, sources, err := parser.PreprocessWithSourceMap(source, config)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parser.ParseDocument(strings.NewReader(p), config, parser.WithSourceMap(sources))
+	if err != nil {
+		return nil, err
+	}
+	return validator.Lint(doc, config), nil
 }
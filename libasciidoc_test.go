@@ -3,6 +3,8 @@ package libasciidoc_test
 import (
 	"os"
 	"strings"
+	"sync"
+	"testing/fstest"
 	"time"
 
 	"github.com/bytesparadise/libasciidoc"
@@ -51,6 +53,8 @@ var _ = Describe("documents", func() {
 				info, err := os.Stat("test/compat/demo.adoc")
 				Expect(err).NotTo(HaveOccurred())
 
+				included := "test/compat/include.adoc" // in the same form as the path of the root document
+
 				// when
 				output, metadata, err := RenderHTMLFromFile("test/compat/demo.adoc",
 					configuration.WithAttribute("libasciidoc-version", "0.7.0"),
@@ -106,6 +110,7 @@ var _ = Describe("documents", func() {
 							},
 						},
 					},
+					Files: []string{"test/compat/demo.adoc", included},
 				}))
 			})
 
@@ -174,6 +179,105 @@ var _ = Describe("documents", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
+		Context("from a file system", func() {
+
+			It("should render document with included files and images", func() {
+				modTime := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+				fsys := fstest.MapFS{
+					"docs/index.adoc": &fstest.MapFile{
+						Data: []byte(`= Title
+:data-uri:
+
+include::chapters/chapter-a.adoc[leveloffset=+1]
+
+image::../images/dot.png[dot]`),
+						ModTime: modTime,
+					},
+					"docs/chapters/chapter-a.adoc": &fstest.MapFile{
+						Data: []byte(`= Chapter A
+
+content`),
+					},
+					"images/dot.png": &fstest.MapFile{
+						Data: []byte("dot"),
+					},
+				}
+				out := &strings.Builder{}
+				metadata, err := libasciidoc.ConvertFile(out,
+					configuration.NewConfiguration(
+						configuration.WithFilename("docs/index.adoc"),
+						configuration.WithFS(fsys)))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(metadata.Diagnostics).To(BeEmpty())
+				Expect(out.String()).To(MatchHTML(`<div class="sect1">
+<h2 id="_chapter_a">Chapter A</h2>
+<div class="sectionbody">
+<div class="paragraph">
+<p>content</p>
+</div>
+<div class="imageblock">
+<div class="content">
+<img src="data:image/png;base64,ZG90" alt="dot">
+</div>
+</div>
+</div>
+</div>
+`))
+				Expect(metadata.LastUpdated).To(Equal(modTime.Format(configuration.LastUpdatedFormat)))
+			})
+
+			It("should fail if document does not exist", func() {
+				out := &strings.Builder{}
+				_, err := libasciidoc.ConvertFile(out,
+					configuration.NewConfiguration(
+						configuration.WithFilename("docs/unknown.adoc"),
+						configuration.WithFS(fstest.MapFS{})))
+				Expect(err).To(MatchError("error opening docs/unknown.adoc: open docs/unknown.adoc: file does not exist"))
+			})
+		})
+	})
+
+	Context("concurrent conversions", func() {
+
+		// verifies that the conversions do not interfere with each other (eg: when resolving the path of
+		// the files to include), in particular when the tests are run with the race detector
+		It("should convert documents with nested includes in parallel", func() {
+			convert := func(source string) (string, error) {
+				out := &strings.Builder{}
+				_, err := libasciidoc.Convert(strings.NewReader(source), out, configuration.NewConfiguration(
+					configuration.WithFilename("test.adoc"),
+					configuration.WithLastUpdated(lastUpdated)))
+				return out.String(), err
+			}
+			sources := []string{
+				strings.Repeat("include::test/includes/parent-include.adoc[]\n\n", 10),
+				strings.Repeat("include::test/includes/parent-include-relative-offset.adoc[]\n\n", 10),
+				strings.Repeat("include::test/includes/table_parent.adoc[]\n\n", 10),
+			}
+			// expected results, when the documents are converted sequentially
+			expected := make([]string, len(sources))
+			for i, source := range sources {
+				var err error
+				expected[i], err = convert(source)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			const count = 30
+			results := make([]string, count)
+			errs := make([]error, count)
+			wg := sync.WaitGroup{}
+			for i := 0; i < count; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					results[i], errs[i] = convert(sources[i%len(sources)])
+				}(i)
+			}
+			wg.Wait()
+			for i := 0; i < count; i++ {
+				Expect(errs[i]).NotTo(HaveOccurred())
+				Expect(results[i]).To(Equal(expected[i%len(sources)]))
+			}
+		})
 	})
 
 	Context("manpage docs", func() {
@@ -398,7 +502,7 @@ Last updated {{ .LastUpdated }}
 </html>
 `
 				out := &strings.Builder{}
-				_, err := libasciidoc.Convert(
+				metadata, err := libasciidoc.Convert(
 					strings.NewReader(source),
 					out,
 					configuration.NewConfiguration(
@@ -415,7 +519,21 @@ Last updated {{ .LastUpdated }}
 						LastUpdated: lastUpdated.Format(configuration.LastUpdatedFormat),
 					}))
 				Expect(logs).To(ContainJSONLog(log.WarnLevel, "changing doctype to 'article' because problems were found in the document"))
-				Expect(logs).To(ContainJSONLog(log.ErrorLevel, "manpage document is missing the 'Name' section"))
+				Expect(metadata.Diagnostics).To(ConsistOf(types.Diagnostic{
+					Severity: types.SeverityError,
+					Code:     types.DiagnosticInvalidDocument,
+					Message:  "manpage document is missing the 'Name' section",
+					Range: types.SourceRange{
+						Start: types.SourcePosition{
+							Line:   5,
+							Column: 1,
+						},
+						End: types.SourcePosition{
+							Line:   7,
+							Column: 70,
+						},
+					},
+				}))
 			})
 
 			It("should render html", func() {
@@ -1,6 +1,7 @@
 package configuration
 
 import (
+	"io/fs"
 	"time"
 
 	"github.com/bytesparadise/libasciidoc/pkg/types"
@@ -14,8 +15,15 @@ func NewConfiguration(settings ...Setting) *Configuration {
 		Attributes: map[string]interface{}{
 			"basebackend-html": true, // along with default backend, to support `ifdef::basebackend-html` conditionals out-of-the-box
 		},
-		BackEnd: "html5", // default backend
-		Macros:  map[string]MacroTemplate{},
+		BackEnd:     "html5", // default backend
+		Macros:      map[string]MacroTemplate{},
+		Diagnostics: types.NewDiagnostics(),
+		LintRules:   map[string]bool{},
+		Extensions: Extensions{
+			BlockProcessors:       map[string]BlockProcessor{},
+			BlockMacroProcessors:  map[string]MacroProcessor{},
+			InlineMacroProcessors: map[string]MacroProcessor{},
+		},
 	}
 	// default backed
 	WithBackEnd("html5")(config)
@@ -23,6 +31,7 @@ func NewConfiguration(settings ...Setting) *Configuration {
 	for _, set := range settings {
 		set(config)
 	}
+	config.SafeMode.setAttributes(config.Attributes)
 	if log.IsLevelEnabled(log.DebugLevel) {
 		log.Debugf("new configuration: %s", spew.Sdump(config))
 	}
@@ -38,6 +47,12 @@ type Configuration struct {
 	CSS                   []string
 	BackEnd               string
 	Macros                map[string]MacroTemplate
+	Diagnostics           *types.Diagnostics // collects the problems found while processing the document
+	LintRules             map[string]bool    // the linter rules which are explicitly enabled or disabled, indexed by ID (all rules are enabled by default)
+	Extensions            Extensions         // the extensions which process the document during its conversion
+	FS                    fs.FS              // the file system from which the files are read (the OS file system if nil)
+	SafeMode              SafeMode           // restricts the access to the file system and the raw content of the documents
+	BaseDir               string             // the directory in which the files to read must be located in the `safe` mode and higher modes (default: the directory of the document)
 }
 
 const (
@@ -90,22 +105,29 @@ func WithCSS(hrefs []string) Setting {
 	}
 }
 
-// WithBackEnd sets the backend format, valid values are "html", "html5", "xhtml", "xhtml5", and "" (defaults to html5)
+// WithBackEnd sets the backend format, valid values are "html", "html5", "xhtml", "xhtml5", "docbook", "docbook5", "manpage", "markdown" (also "md"), "text" (also "txt"), "json" (also "ast"), "yaml" and "" (defaults to html5)
 func WithBackEnd(backend string) Setting {
 	return func(config *Configuration) {
 		config.Attributes.Set("backend", backend)
-		switch backend {
-		case "html", "html5", "xhtml", "xhtml5":
-			config.Attributes.Set("basebackend-html", true)
-		default:
-			config.Attributes.Unset("basebackend-html")
-		}
 		config.BackEnd = backend
+		// also set the `basebackend-*` attribute, to support `ifdef::basebackend-html[]` and `ifdef::basebackend-docbook[]` conditionals
 		switch backend {
 		case "html", "html5", "xhtml", "xhtml5":
 			config.Attributes.Set("basebackend-html", true)
+			config.Attributes.Unset("basebackend-docbook")
+		case "docbook", "docbook5":
+			config.Attributes.Set("basebackend-docbook", true)
+			config.Attributes.Unset("basebackend-html")
+		case "manpage":
+			// the `manpage` backend applies on documents with the `manpage` doctype, unless another doctype was set
+			if !config.Attributes.Has(types.AttrDocType) {
+				config.Attributes.Set(types.AttrDocType, "manpage")
+			}
+			config.Attributes.Unset("basebackend-html")
+			config.Attributes.Unset("basebackend-docbook")
 		default:
 			config.Attributes.Unset("basebackend-html")
+			config.Attributes.Unset("basebackend-docbook")
 		}
 	}
 }
@@ -124,3 +146,17 @@ func WithMacroTemplate(name string, t MacroTemplate) Setting {
 		config.Macros[name] = t
 	}
 }
+
+// WithDiagnostics sets the collector of the problems found while processing the document
+func WithDiagnostics(d *types.Diagnostics) Setting {
+	return func(config *Configuration) {
+		config.Diagnostics = d
+	}
+}
+
+// WithLintRule enables or disables the linter rule with the given ID
+func WithLintRule(id string, enabled bool) Setting {
+	return func(config *Configuration) {
+		config.LintRules[id] = enabled
+	}
+}
@@ -0,0 +1,108 @@
+package configuration
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+)
+
+// Extensions the extensions which process the document at the different stages of its conversion:
+//
+// 1. the preprocessors, on the lines of the source document
+// 2. the include processors, when a file is included
+// 3. the block processors and the block/inline macro processors, on the elements of the parsed document
+// 4. the tree processors, on the whole parsed document
+// 5. the postprocessors, on the rendered output
+type Extensions struct {
+	Preprocessors         []Preprocessor
+	IncludeProcessors     []IncludeProcessor
+	BlockProcessors       map[string]BlockProcessor // indexed by block style
+	BlockMacroProcessors  map[string]MacroProcessor // indexed by macro name
+	InlineMacroProcessors map[string]MacroProcessor // indexed by macro name
+	TreeProcessors        []TreeProcessor
+	Postprocessors        []Postprocessor
+}
+
+// Preprocessor processes the lines of the source document, before the file inclusions and conditionals are processed.
+// Note: the positions in the source of the parsed elements refer to the lines returned by the preprocessor.
+type Preprocessor func(lines []string) ([]string, error)
+
+// IncludeProcessor returns the content to include for the given target of an `include::` directive (before the `lines` or `tags` attributes are applied),
+// or `false` if the target is not handled, in which case the next processor (or eventually, the file system) is used
+type IncludeProcessor func(target string, attributes types.Attributes) (string, bool, error)
+
+// BlockProcessor processes a block whose style is the name of the processor (eg: a paragraph or a delimited block with the `[name]` attribute),
+// and returns the element to use in replacement (or nil to remove the block)
+type BlockProcessor func(block types.WithAttributes) (interface{}, error)
+
+// MacroProcessor processes a block macro (`name::value[attributes]`) or an inline macro (`name:value[attributes]`)
+// whose name is the name of the processor, and returns the element to use in replacement (or nil to remove the macro)
+type MacroProcessor func(macro *types.UserMacro) (interface{}, error)
+
+// TreeProcessor processes the whole document, once it has been parsed and the block and macro processors were applied
+type TreeProcessor func(doc *types.Document) error
+
+// Postprocessor processes the output of the renderer, and returns the final output
+type Postprocessor func(output string) (string, error)
+
+// WithPreprocessor registers the given preprocessor
+func WithPreprocessor(p Preprocessor) Setting {
+	return func(config *Configuration) {
+		config.Extensions.Preprocessors = append(config.Extensions.Preprocessors, p)
+	}
+}
+
+// WithIncludeProcessor registers the given include processor
+func WithIncludeProcessor(p IncludeProcessor) Setting {
+	return func(config *Configuration) {
+		config.Extensions.IncludeProcessors = append(config.Extensions.IncludeProcessors, p)
+	}
+}
+
+// WithBlockProcessor registers the given processor for the blocks with the given style
+func WithBlockProcessor(style string, p BlockProcessor) Setting {
+	return func(config *Configuration) {
+		config.Extensions.BlockProcessors[style] = p
+	}
+}
+
+// WithBlockMacroProcessor registers the given processor for the block macros with the given name
+func WithBlockMacroProcessor(name string, p MacroProcessor) Setting {
+	return func(config *Configuration) {
+		config.Extensions.BlockMacroProcessors[name] = p
+	}
+}
+
+// WithInlineMacroProcessor registers the given processor for the inline macros with the given name
+func WithInlineMacroProcessor(name string, p MacroProcessor) Setting {
+	return func(config *Configuration) {
+		config.Extensions.InlineMacroProcessors[name] = p
+	}
+}
+
+// WithTreeProcessor registers the given tree processor
+func WithTreeProcessor(p TreeProcessor) Setting {
+	return func(config *Configuration) {
+		config.Extensions.TreeProcessors = append(config.Extensions.TreeProcessors, p)
+	}
+}
+
+// WithPostprocessor registers the given postprocessor
+func WithPostprocessor(p Postprocessor) Setting {
+	return func(config *Configuration) {
+		config.Extensions.Postprocessors = append(config.Extensions.Postprocessors, p)
+	}
+}
+
+// MacroNames returns the names of the user macros, including the macros handled by the block and inline macro processors
+func (c *Configuration) MacroNames() map[string]bool {
+	result := make(map[string]bool, len(c.Macros)+len(c.Extensions.BlockMacroProcessors)+len(c.Extensions.InlineMacroProcessors))
+	for name := range c.Macros {
+		result[name] = true
+	}
+	for name := range c.Extensions.BlockMacroProcessors {
+		result[name] = true
+	}
+	for name := range c.Extensions.InlineMacroProcessors {
+		result[name] = true
+	}
+	return result
+}
@@ -0,0 +1,44 @@
+package configuration
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// WithFS sets the file system from which the document, the included files and the embedded images are read
+// (eg: an `embed.FS` or a file system backed by a database). The paths of the files are resolved relatively
+// to the root of the file system. If not set, the files are read from the OS file system.
+func WithFS(fsys fs.FS) Setting {
+	return func(config *Configuration) {
+		config.FS = fsys
+	}
+}
+
+// OpenFile opens the file with the given name in the given file system, or in the OS file system if `fsys` is nil
+func OpenFile(fsys fs.FS, name string) (fs.File, error) {
+	if fsys == nil {
+		return os.Open(name)
+	}
+	return fsys.Open(FSPath(name))
+}
+
+// ReadFile reads the content of the file with the given name in the given file system, or in the OS file system if `fsys` is nil
+func ReadFile(fsys fs.FS, name string) ([]byte, error) {
+	if fsys == nil {
+		return os.ReadFile(name)
+	}
+	return fs.ReadFile(fsys, FSPath(name))
+}
+
+// FSPath converts the given file name into a valid path in an `fs.FS`, ie, a slash-separated path, without any leading slash.
+// Note: a path which refers to a parent of the root of the file system (eg: `../doc.adoc`) remains invalid.
+func FSPath(name string) string {
+	p := strings.TrimPrefix(path.Clean(filepath.ToSlash(name)), "/")
+	if p == "" {
+		return "."
+	}
+	return p
+}
@@ -0,0 +1,113 @@
+package configuration
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+// SafeMode the safe mode, which restricts the access to the file system and the raw content of the documents
+// (same levels as in Asciidoctor)
+type SafeMode int
+
+const (
+	// SafeModeUnsafe no restriction (default)
+	SafeModeUnsafe SafeMode = 0
+	// SafeModeSafe the files to include (and the images to embed) must be within the base directory,
+	// and absolute paths are not allowed
+	SafeModeSafe SafeMode = 1
+	// SafeModeServer same as `SafeModeSafe`, and the passthrough blocks and macros are rendered as regular (escaped) content
+	SafeModeServer SafeMode = 10
+	// SafeModeSecure same as `SafeModeServer`, and the `include::` directives are replaced by links to their target
+	SafeModeSecure SafeMode = 20
+)
+
+func (m SafeMode) String() string {
+	switch m {
+	case SafeModeUnsafe:
+		return "unsafe"
+	case SafeModeSafe:
+		return "safe"
+	case SafeModeServer:
+		return "server"
+	case SafeModeSecure:
+		return "secure"
+	default:
+		return fmt.Sprintf("safemode(%d)", int(m))
+	}
+}
+
+// ParseSafeMode returns the safe mode with the given name (`unsafe`, `safe`, `server` or `secure`) or level (`0`, `1`, `10` or `20`)
+func ParseSafeMode(name string) (SafeMode, error) {
+	for _, m := range []SafeMode{SafeModeUnsafe, SafeModeSafe, SafeModeServer, SafeModeSecure} {
+		if strings.EqualFold(name, m.String()) || name == fmt.Sprintf("%d", int(m)) {
+			return m, nil
+		}
+	}
+	return SafeModeUnsafe, errors.Errorf("unknown safe mode: '%s'", name)
+}
+
+// AllowsIncludes returns `true` if the `include::` directives can read files
+func (m SafeMode) AllowsIncludes() bool {
+	return m < SafeModeSecure
+}
+
+// AllowsPassthroughs returns `true` if the content of the passthrough blocks and macros can be rendered as-is
+func (m SafeMode) AllowsPassthroughs() bool {
+	return m < SafeModeServer
+}
+
+// CheckPath returns an error if the file with the given path cannot be read in this safe mode,
+// ie, if it is not within the given base directory (in the `safe` mode and higher modes)
+func (m SafeMode) CheckPath(baseDir, path string) error {
+	if m < SafeModeSafe {
+		return nil
+	}
+	base, err := filepath.Abs(baseDir)
+	if err != nil {
+		return err
+	}
+	p, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if rel, err := filepath.Rel(base, p); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return errors.Errorf("path is outside of the base directory in %s mode: %s", m, p)
+	}
+	return nil
+}
+
+// setAttributes sets the `safe-mode-name`, `safe-mode-level` and `safe-mode-<name>` attributes
+func (m SafeMode) setAttributes(attrs types.Attributes) {
+	attrs.Set("safe-mode-name", m.String())
+	attrs.Set("safe-mode-level", fmt.Sprintf("%d", int(m)))
+	attrs.Set("safe-mode-"+m.String(), "")
+}
+
+// WithSafeMode sets the safe mode
+func WithSafeMode(mode SafeMode) Setting {
+	return func(config *Configuration) {
+		config.SafeMode = mode
+	}
+}
+
+// WithBaseDir sets the base directory, in which the files to include must be located in the `safe` mode and higher modes
+// (default: the directory of the document)
+func WithBaseDir(dir string) Setting {
+	return func(config *Configuration) {
+		config.BaseDir = dir
+	}
+}
+
+// GetBaseDir returns the base directory, ie, the directory configured with `WithBaseDir`,
+// or the directory of the document if not set
+func (c *Configuration) GetBaseDir() string {
+	if c.BaseDir != "" {
+		return c.BaseDir
+	}
+	return filepath.Dir(c.Filename)
+}
@@ -0,0 +1,86 @@
+package lsp
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+)
+
+// completion returns the names of the attributes when the cursor is within an attribute reference (`{...`),
+// or the IDs of the elements when the cursor is within a cross reference (`<<...` or `xref:...`)
+func (d *document) completion(p Position) []CompletionItem {
+	if p.Line >= len(d.lines) {
+		return []CompletionItem{}
+	}
+	line := d.lines[p.Line]
+	prefix := line[:byteOffset(line, p.Character)]
+	switch {
+	case isOpen(prefix, "{", "}"):
+		return d.attributeNames()
+	case isOpen(prefix, "<<", ">>"), isOpen(prefix, "xref:", "["):
+		return d.elementIDs()
+	default:
+		return []CompletionItem{}
+	}
+}
+
+// isOpen returns true if the given text contains the opening delimiter which is not followed by the closing delimiter
+func isOpen(text, opening, closing string) bool {
+	i := strings.LastIndex(text, opening)
+	return i >= 0 && !strings.Contains(text[i+len(opening):], closing) && !strings.Contains(text[i+len(opening):], " ")
+}
+
+func (d *document) attributeNames() []CompletionItem {
+	names := map[string]string{}
+	for _, name := range types.PredefinedAttributes() {
+		names[name] = "predefined attribute"
+	}
+	for name := range d.config.Attributes {
+		names[name] = "attribute"
+	}
+	if d.doc != nil {
+		types.Walk(d.doc, func(element interface{}) bool {
+			if a, ok := element.(*types.AttributeDeclaration); ok {
+				names[a.Name] = "document attribute"
+			}
+			return true
+		})
+	}
+	result := make([]CompletionItem, 0, len(names))
+	for name, detail := range names {
+		result = append(result, CompletionItem{
+			Label:  name,
+			Kind:   CompletionItemKindVariable,
+			Detail: detail,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Label < result[j].Label
+	})
+	return result
+}
+
+func (d *document) elementIDs() []CompletionItem {
+	result := []CompletionItem{}
+	if d.doc == nil {
+		return result
+	}
+	for id, title := range d.doc.ElementReferences {
+		item := CompletionItem{
+			Label: id,
+			Kind:  CompletionItemKindReference,
+		}
+		if t, ok := title.([]interface{}); ok {
+			item.Detail, _ = sgml.RenderPlainText(t, sgml.WithoutEscape())
+		} else if t, ok := title.(string); ok {
+			item.Detail = t
+		}
+		result = append(result, item)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Label < result[j].Label
+	})
+	return result
+}
@@ -0,0 +1,117 @@
+package lsp
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+)
+
+var includeRegexp = regexp.MustCompile(`^include::(.+?)\[`)
+
+var attributeReferenceRegexp = regexp.MustCompile(`\{([\w-]+)\}`)
+
+// definition returns the location of the element targeted by the cross reference at the given position,
+// or the location of the file included by the `include::` directive at the given position
+func (d *document) definition(p Position) []Location {
+	if l, found := d.includeDefinition(p); found {
+		return []Location{l}
+	}
+	if d.doc == nil {
+		return []Location{}
+	}
+	xref := d.crossReferenceAt(d.sourcePosition(p))
+	if xref == nil {
+		return []Location{}
+	}
+	id, ok := xref.ID.(string)
+	if !ok {
+		return []Location{}
+	}
+	if target := d.elementWithID(id); target != nil {
+		if r, found := types.SourceRangeOf(target); found {
+			return []Location{d.location(r)}
+		}
+	}
+	return []Location{}
+}
+
+func (d *document) includeDefinition(p Position) (Location, bool) {
+	if p.Line >= len(d.lines) {
+		return Location{}, false
+	}
+	m := includeRegexp.FindStringSubmatch(d.lines[p.Line])
+	if m == nil {
+		return Location{}, false
+	}
+	attrs := d.attributes()
+	path := attributeReferenceRegexp.ReplaceAllStringFunc(m[1], func(ref string) string {
+		if v, found := attrs[ref[1:len(ref)-1]]; found {
+			return v
+		}
+		return ref
+	})
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(d.filename), path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return Location{}, false
+	}
+	return Location{
+		URI: fileURI(path),
+	}, true
+}
+
+// crossReferenceAt returns the cross reference at the given position, or nil if there is none
+func (d *document) crossReferenceAt(p types.SourcePosition) *types.InternalCrossReference {
+	var result *types.InternalCrossReference
+	types.Walk(d.doc, func(element interface{}) bool {
+		if result != nil {
+			return false
+		}
+		if xref, ok := element.(*types.InternalCrossReference); ok && xref.SourceRange.Contains(p) {
+			result = xref
+			return false
+		}
+		return true
+	})
+	return result
+}
+
+// elementWithID returns the element with the given ID, or nil if there is none
+func (d *document) elementWithID(id string) interface{} {
+	var result interface{}
+	types.Walk(d.doc, func(element interface{}) bool {
+		if result != nil {
+			return false
+		}
+		if e, ok := element.(types.WithAttributes); ok {
+			if v, found := e.GetAttributes().GetAsString(types.AttrID); found && v == id {
+				result = element
+				return false
+			}
+		}
+		return true
+	})
+	return result
+}
+
+// attributes returns the value of the attributes set in the configuration and declared in the document
+func (d *document) attributes() map[string]string {
+	result := map[string]string{}
+	for k, v := range d.config.Attributes {
+		if s, ok := v.(string); ok {
+			result[k] = s
+		}
+	}
+	for _, line := range d.lines {
+		if strings.HasPrefix(line, ":") {
+			if i := strings.Index(line[1:], ":"); i > 0 && !strings.ContainsAny(line[1:i+1], " !") {
+				result[line[1:i+1]] = strings.TrimSpace(line[i+2:])
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,272 @@
+package lsp
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/parser"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// document a document opened in the client, along with the result of its parsing
+type document struct {
+	uri      string
+	filename string // the path of the document on the file system (used to resolve the files to include)
+	version  int
+	content  string
+	lines    []string
+	config   *configuration.Configuration
+	parsed   *parser.IncrementalDocument // nil if the document could not be parsed
+	doc      *types.Document             // nil if the document could not be parsed
+	problems []types.Diagnostic
+}
+
+func newDocument(uri string, version int, text string, settings []configuration.Setting) *document {
+	filename := filenameOf(uri)
+	d := &document{
+		uri:      uri,
+		filename: filename,
+		version:  version,
+		config: configuration.NewConfiguration(append(append([]configuration.Setting{}, settings...),
+			configuration.WithFilename(filename))...),
+	}
+	d.parse(text)
+	return d
+}
+
+// parse parses the given content of the document, and collects the problems which were found
+func (d *document) parse(text string) {
+	d.setContent(text)
+	parsed, err := parser.ParseDocumentIncrementally(strings.NewReader(text), d.config)
+	d.setParsed(parsed, err)
+}
+
+// change replaces the given range of the content with the new text, parses again the portion of the document
+// which is affected by the change, and collects the problems which were found
+func (d *document) change(r Range, newText string) {
+	start, end := d.offsets(r)
+	text := d.content[:start] + newText + d.content[end:]
+	if d.parsed == nil {
+		d.parse(text)
+		return
+	}
+	d.setContent(text)
+	parsed, err := d.parsed.Update(parser.TextEdit{
+		Start: start,
+		End:   end,
+		Text:  newText,
+	})
+	d.setParsed(parsed, err)
+}
+
+// setContent sets the content of the document, and clears the problems of the previous content
+// (which are all reported again while parsing the new content)
+func (d *document) setContent(text string) {
+	d.content = text
+	d.lines = strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	d.config.Diagnostics = types.NewDiagnostics()
+}
+
+func (d *document) setParsed(parsed *parser.IncrementalDocument, err error) {
+	d.parsed = parsed
+	d.doc = nil
+	if err != nil {
+		// the problem is also in the diagnostics
+		log.WithError(err).Debugf("unable to parse '%s'", d.uri)
+	} else {
+		d.doc = parsed.Document
+	}
+	d.problems = d.config.Diagnostics.All()
+}
+
+// offsets returns the offsets in the content of the start and end of the given range
+func (d *document) offsets(r Range) (int, int) {
+	lines := strings.Split(d.content, "\n")
+	offset := func(p Position) int {
+		o := 0
+		for i := 0; i < p.Line && i < len(lines); i++ {
+			o += len(lines[i]) + 1
+		}
+		if p.Line < len(lines) {
+			o += byteOffset(lines[p.Line], p.Character)
+		}
+		if o > len(d.content) {
+			o = len(d.content)
+		}
+		return o
+	}
+	start, end := offset(r.Start), offset(r.End)
+	if end < start {
+		end = start
+	}
+	return start, end
+}
+
+// diagnostics returns the problems found in the document.
+// The problems found in the included files are reported on the `include::` directive in the document.
+func (d *document) diagnostics() []Diagnostic {
+	result := []Diagnostic{}
+	for _, p := range d.problems {
+		r := p.Range
+		message := p.Message
+		if !r.IsZero() && r.Start.File != d.filename {
+			if len(p.IncludedFrom) == 0 {
+				continue
+			}
+			message = fmt.Sprintf("%s (in %s)", message, r.Start)
+			r = d.lineRange(p.IncludedFrom[len(p.IncludedFrom)-1])
+		}
+		result = append(result, Diagnostic{
+			Range:    d.rangeOf(r),
+			Severity: severityOf(p.Severity),
+			Code:     p.Code,
+			Source:   "libasciidoc",
+			Message:  message,
+		})
+	}
+	return result
+}
+
+func severityOf(s types.Severity) DiagnosticSeverity {
+	switch s {
+	case types.SeverityError:
+		return DiagnosticSeverityError
+	case types.SeverityWarning:
+		return DiagnosticSeverityWarning
+	default:
+		return DiagnosticSeverityInformation
+	}
+}
+
+// sourcePosition converts the given position in the client into a position in the source of the document
+func (d *document) sourcePosition(p Position) types.SourcePosition {
+	column := p.Character
+	if p.Line < len(d.lines) {
+		column = runeOffset(d.lines[p.Line], p.Character)
+	}
+	return types.SourcePosition{
+		File:   d.filename,
+		Line:   p.Line + 1,
+		Column: column + 1,
+	}
+}
+
+// position converts the given position in the source into a position in the client
+func (d *document) position(p types.SourcePosition) Position {
+	line, character := p.Line-1, p.Column-1
+	if line < 0 || character < 0 {
+		return Position{}
+	}
+	if p.File == d.filename && line < len(d.lines) {
+		character = utf16Offset(d.lines[line], character)
+	}
+	return Position{
+		Line:      line,
+		Character: character,
+	}
+}
+
+// rangeOf converts the given range in the source into a range in the client
+func (d *document) rangeOf(r types.SourceRange) Range {
+	start := d.position(r.Start)
+	end := start
+	if !r.End.IsZero() {
+		end = d.position(r.End)
+	}
+	return Range{
+		Start: start,
+		End:   end,
+	}
+}
+
+// lineRange returns the range of the whole line which starts at the given position
+func (d *document) lineRange(p types.SourcePosition) types.SourceRange {
+	end := p
+	if p.Line-1 < len(d.lines) {
+		end.Column = len([]rune(d.lines[p.Line-1])) + 1
+	}
+	return types.SourceRange{
+		Start: p,
+		End:   end,
+	}
+}
+
+// location returns the location of the given range, which may be in another file (eg: an included file)
+func (d *document) location(r types.SourceRange) Location {
+	uri := d.uri
+	if r.Start.File != d.filename {
+		uri = fileURI(r.Start.File)
+	}
+	return Location{
+		URI:   uri,
+		Range: d.rangeOf(r),
+	}
+}
+
+// filenameOf returns the path of the file with the given URI (or the URI itself if it does not use the `file` scheme)
+func filenameOf(uri string) string {
+	if u, err := url.Parse(uri); err == nil && u.Scheme == "file" {
+		return filepath.FromSlash(u.Path)
+	}
+	return uri
+}
+
+// fileURI returns the URI of the file with the given path
+func fileURI(path string) string {
+	if p, err := filepath.Abs(path); err == nil {
+		path = p
+	}
+	return (&url.URL{
+		Scheme: "file",
+		Path:   filepath.ToSlash(path),
+	}).String()
+}
+
+// utf16Offset returns the number of UTF-16 code units of the first `runes` characters of the given line
+func utf16Offset(line string, runes int) int {
+	result := 0
+	for _, r := range line {
+		if runes == 0 {
+			return result
+		}
+		result += utf16Len(r)
+		runes--
+	}
+	return result + runes
+}
+
+// runeOffset returns the number of characters in the first `units` UTF-16 code units of the given line
+func runeOffset(line string, units int) int {
+	result := 0
+	for _, r := range line {
+		if units <= 0 {
+			return result
+		}
+		units -= utf16Len(r)
+		result++
+	}
+	return result + units
+}
+
+// byteOffset returns the number of bytes in the first `units` UTF-16 code units of the given line
+func byteOffset(line string, units int) int {
+	for i, r := range line {
+		if units <= 0 {
+			return i
+		}
+		units -= utf16Len(r)
+	}
+	return len(line)
+}
+
+func utf16Len(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,97 @@
+package lsp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml/html5"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+// hover returns the preview of the element targeted by the cross reference at the given position,
+// or the preview of the block at the given position, rendered in HTML
+func (d *document) hover(p Position) (*Hover, error) {
+	if d.doc == nil {
+		return nil, nil
+	}
+	pos := d.sourcePosition(p)
+	var element interface{}
+	var r *Range
+	if xref := d.crossReferenceAt(pos); xref != nil {
+		if id, ok := xref.ID.(string); ok {
+			element = d.elementWithID(id)
+		}
+		xr := d.rangeOf(xref.SourceRange)
+		r = &xr
+	} else {
+		element = blockAt(d.doc.Elements, pos)
+	}
+	if element == nil {
+		return nil, nil
+	}
+	if s, ok := element.(*types.Section); ok {
+		// only render the title and the first block of the section
+		preview := *s
+		preview.Elements = nil
+		for _, e := range s.Elements {
+			if _, ok := e.(*types.Section); !ok {
+				preview.Elements = []interface{}{e}
+				break
+			}
+		}
+		element = &preview
+	}
+	out := &strings.Builder{}
+	if _, err := html5.Render(&types.Document{
+		Elements:          []interface{}{element},
+		ElementReferences: d.doc.ElementReferences,
+		Footnotes:         d.doc.Footnotes,
+	}, configuration.NewConfiguration(configuration.WithFilename(d.filename)), out); err != nil {
+		return nil, errors.Wrap(err, "unable to render the preview")
+	}
+	return &Hover{
+		Contents: MarkupContent{
+			Kind:  "markdown",
+			Value: fmt.Sprintf("```html\n%s\n```", strings.TrimSpace(out.String())),
+		},
+		Range: r,
+	}, nil
+}
+
+// blockAt returns the innermost block (or section) at the given position, or nil if there is none
+func blockAt(elements []interface{}, p types.SourcePosition) interface{} {
+	for _, element := range elements {
+		switch e := element.(type) {
+		case *types.Preamble:
+			if b := blockAt(e.Elements, p); b != nil {
+				return b
+			}
+		case *types.Section:
+			if b := blockAt(e.Elements, p); b != nil {
+				return b
+			}
+			if e.SourceRange.Contains(p) {
+				return e
+			}
+		case *types.List:
+			if e.SourceRange.Contains(p) {
+				return e
+			}
+		case *types.DelimitedBlock:
+			if e.SourceRange.Contains(p) {
+				if b := blockAt(e.Elements, p); b != nil {
+					return b
+				}
+				return e
+			}
+		case *types.Paragraph, *types.Table, *types.ImageBlock:
+			if r, found := types.SourceRangeOf(e); found && r.Contains(p) {
+				return e
+			}
+		}
+	}
+	return nil
+}
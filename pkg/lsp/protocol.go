@@ -0,0 +1,208 @@
+package lsp
+
+// the subset of the Language Server Protocol structures which are used by the server
+// see https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/
+
+// Position a position in a text document: zero-based line and character offset (counted in UTF-16 code units)
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// Range a range in a text document, from its start position (inclusive) to its end position (exclusive)
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// Location a range in a given document
+type Location struct {
+	URI   string `json:"uri"`
+	Range Range  `json:"range"`
+}
+
+// TextDocumentIdentifier identifies a text document
+type TextDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+// VersionedTextDocumentIdentifier identifies a specific version of a text document
+type VersionedTextDocumentIdentifier struct {
+	URI     string `json:"uri"`
+	Version int    `json:"version"`
+}
+
+// TextDocumentItem a text document transferred from the client to the server
+type TextDocumentItem struct {
+	URI        string `json:"uri"`
+	LanguageID string `json:"languageId"`
+	Version    int    `json:"version"`
+	Text       string `json:"text"`
+}
+
+// TextDocumentPositionParams the parameters of the requests about a position in a text document
+type TextDocumentPositionParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	Position     Position               `json:"position"`
+}
+
+// InitializeParams the parameters of the `initialize` request
+type InitializeParams struct {
+	ProcessID *int    `json:"processId"`
+	RootURI   *string `json:"rootUri"`
+}
+
+// InitializeResult the result of the `initialize` request
+type InitializeResult struct {
+	Capabilities ServerCapabilities `json:"capabilities"`
+	ServerInfo   ServerInfo         `json:"serverInfo"`
+}
+
+// ServerInfo the name and version of the server
+type ServerInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// ServerCapabilities the capabilities of the server
+type ServerCapabilities struct {
+	TextDocumentSync       TextDocumentSyncOptions `json:"textDocumentSync"`
+	DocumentSymbolProvider bool                    `json:"documentSymbolProvider"`
+	DefinitionProvider     bool                    `json:"definitionProvider"`
+	HoverProvider          bool                    `json:"hoverProvider"`
+	CompletionProvider     CompletionOptions       `json:"completionProvider"`
+}
+
+// TextDocumentSyncKind how the text documents are synced between the client and the server
+type TextDocumentSyncKind int
+
+const (
+	// TextDocumentSyncFull the whole content of the document is sent by the client on each change
+	TextDocumentSyncFull TextDocumentSyncKind = 1
+)
+
+// TextDocumentSyncOptions the synchronization options of the server
+type TextDocumentSyncOptions struct {
+	OpenClose bool                 `json:"openClose"`
+	Change    TextDocumentSyncKind `json:"change"`
+	Save      bool                 `json:"save"`
+}
+
+// CompletionOptions the completion options of the server
+type CompletionOptions struct {
+	TriggerCharacters []string `json:"triggerCharacters"`
+}
+
+// DidOpenTextDocumentParams the parameters of the `textDocument/didOpen` notification
+type DidOpenTextDocumentParams struct {
+	TextDocument TextDocumentItem `json:"textDocument"`
+}
+
+// DidChangeTextDocumentParams the parameters of the `textDocument/didChange` notification
+type DidChangeTextDocumentParams struct {
+	TextDocument   VersionedTextDocumentIdentifier  `json:"textDocument"`
+	ContentChanges []TextDocumentContentChangeEvent `json:"contentChanges"`
+}
+
+// TextDocumentContentChangeEvent a change in a text document: the new content of the whole document if the range is nil,
+// otherwise the new content of the given range
+type TextDocumentContentChangeEvent struct {
+	Range *Range `json:"range,omitempty"`
+	Text  string `json:"text"`
+}
+
+// DidSaveTextDocumentParams the parameters of the `textDocument/didSave` notification
+type DidSaveTextDocumentParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+// DidCloseTextDocumentParams the parameters of the `textDocument/didClose` notification
+type DidCloseTextDocumentParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+// DocumentSymbolParams the parameters of the `textDocument/documentSymbol` request
+type DocumentSymbolParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+// DiagnosticSeverity the severity of a diagnostic
+type DiagnosticSeverity int
+
+const (
+	// DiagnosticSeverityError reports an error
+	DiagnosticSeverityError DiagnosticSeverity = 1
+	// DiagnosticSeverityWarning reports a warning
+	DiagnosticSeverityWarning DiagnosticSeverity = 2
+	// DiagnosticSeverityInformation reports an information
+	DiagnosticSeverityInformation DiagnosticSeverity = 3
+)
+
+// Diagnostic a problem in a text document
+type Diagnostic struct {
+	Range    Range              `json:"range"`
+	Severity DiagnosticSeverity `json:"severity"`
+	Code     string             `json:"code,omitempty"`
+	Source   string             `json:"source"`
+	Message  string             `json:"message"`
+}
+
+// PublishDiagnosticsParams the parameters of the `textDocument/publishDiagnostics` notification
+type PublishDiagnosticsParams struct {
+	URI         string       `json:"uri"`
+	Version     *int         `json:"version,omitempty"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// SymbolKind the kind of a symbol
+type SymbolKind int
+
+const (
+	// SymbolKindString the kind of the symbols of the sections
+	SymbolKindString SymbolKind = 15
+)
+
+// DocumentSymbol a symbol in a text document, along with its children
+type DocumentSymbol struct {
+	Name           string           `json:"name"`
+	Detail         string           `json:"detail,omitempty"`
+	Kind           SymbolKind       `json:"kind"`
+	Range          Range            `json:"range"`
+	SelectionRange Range            `json:"selectionRange"`
+	Children       []DocumentSymbol `json:"children,omitempty"`
+}
+
+// CompletionItemKind the kind of a completion item
+type CompletionItemKind int
+
+const (
+	// CompletionItemKindVariable the kind of the completion items of the attribute names
+	CompletionItemKindVariable CompletionItemKind = 6
+	// CompletionItemKindReference the kind of the completion items of the element IDs
+	CompletionItemKindReference CompletionItemKind = 18
+)
+
+// CompletionItem a completion proposal
+type CompletionItem struct {
+	Label  string             `json:"label"`
+	Kind   CompletionItemKind `json:"kind"`
+	Detail string             `json:"detail,omitempty"`
+}
+
+// CompletionList a list of completion proposals
+type CompletionList struct {
+	IsIncomplete bool             `json:"isIncomplete"`
+	Items        []CompletionItem `json:"items"`
+}
+
+// MarkupContent some content in the `plaintext` or `markdown` format
+type MarkupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// Hover the information to display when hovering a position in a text document
+type Hover struct {
+	Contents MarkupContent `json:"contents"`
+	Range    *Range        `json:"range,omitempty"`
+}
@@ -0,0 +1,315 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Server a language server for Asciidoc documents, which communicates with the client (ie, the editor)
+// via the JSON-RPC messages of the Language Server Protocol.
+// The documents are parsed each time they are opened or changed in the client, and the problems
+// found while parsing them are published to the client.
+type Server struct {
+	name        string
+	version     string
+	settings    []configuration.Setting // the settings of the configuration used to parse the documents
+	documents   map[string]*document    // the documents opened in the client, indexed by URI
+	out         io.Writer
+	outMutex    sync.Mutex
+	initialized bool
+	shutdown    bool
+}
+
+// NewServer returns a new server with the given version, which parses the documents with the given settings
+func NewServer(version string, settings ...configuration.Setting) *Server {
+	return &Server{
+		name:      "libasciidoc",
+		version:   version,
+		settings:  settings,
+		documents: map[string]*document{},
+	}
+}
+
+// Serve reads and handles the messages sent by the client on `in` until the `exit` notification is received,
+// and writes the responses and notifications on `out`.
+// Returns an error if the `exit` notification was received before the `shutdown` request, or if the input was closed.
+func (s *Server) Serve(in io.Reader, out io.Writer) error {
+	s.out = out
+	r := bufio.NewReader(in)
+	for {
+		content, err := readMessage(r)
+		if err == io.EOF {
+			return errors.New("connection closed before the exit notification")
+		} else if err != nil {
+			return err
+		}
+		req := request{}
+		if err := json.Unmarshal(content, &req); err != nil {
+			s.reply(json.RawMessage("null"), nil, &responseError{
+				Code:    codeParseError,
+				Message: fmt.Sprintf("unable to parse message: %v", err),
+			})
+			continue
+		}
+		if req.Method == "exit" {
+			if !s.shutdown {
+				return errors.New("exit notification received before the shutdown request")
+			}
+			return nil
+		}
+		s.handle(req)
+	}
+}
+
+func (s *Server) handle(req request) {
+	if req.Method == "" {
+		// response to a request sent by the server (not expected)
+		return
+	}
+	result, err := s.dispatch(req)
+	if req.isNotification() {
+		if err != nil {
+			log.WithError(err).Errorf("unable to handle the '%s' notification", req.Method)
+		}
+		return
+	}
+	s.reply(req.ID, result, err)
+}
+
+func (s *Server) dispatch(req request) (interface{}, error) {
+	if !s.initialized && req.Method != "initialize" {
+		return nil, &responseError{
+			Code:    codeServerNotInitialized,
+			Message: "server not initialized",
+		}
+	}
+	if s.shutdown {
+		return nil, &responseError{
+			Code:    codeInvalidRequest,
+			Message: "server is shut down",
+		}
+	}
+	switch req.Method {
+	case "initialize":
+		p := InitializeParams{}
+		if err := decode(req.Params, &p); err != nil {
+			return nil, err
+		}
+		return s.initialize(p), nil
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		p := DidOpenTextDocumentParams{}
+		if err := decode(req.Params, &p); err != nil {
+			return nil, err
+		}
+		return nil, s.didOpen(p)
+	case "textDocument/didChange":
+		p := DidChangeTextDocumentParams{}
+		if err := decode(req.Params, &p); err != nil {
+			return nil, err
+		}
+		return nil, s.didChange(p)
+	case "textDocument/didSave":
+		p := DidSaveTextDocumentParams{}
+		if err := decode(req.Params, &p); err != nil {
+			return nil, err
+		}
+		return nil, s.didSave(p)
+	case "textDocument/didClose":
+		p := DidCloseTextDocumentParams{}
+		if err := decode(req.Params, &p); err != nil {
+			return nil, err
+		}
+		return nil, s.didClose(p)
+	case "textDocument/documentSymbol":
+		p := DocumentSymbolParams{}
+		if err := decode(req.Params, &p); err != nil {
+			return nil, err
+		}
+		d, err := s.document(p.TextDocument.URI)
+		if err != nil {
+			return nil, err
+		}
+		return d.symbols(), nil
+	case "textDocument/definition":
+		p := TextDocumentPositionParams{}
+		if err := decode(req.Params, &p); err != nil {
+			return nil, err
+		}
+		d, err := s.document(p.TextDocument.URI)
+		if err != nil {
+			return nil, err
+		}
+		return d.definition(p.Position), nil
+	case "textDocument/completion":
+		p := TextDocumentPositionParams{}
+		if err := decode(req.Params, &p); err != nil {
+			return nil, err
+		}
+		d, err := s.document(p.TextDocument.URI)
+		if err != nil {
+			return nil, err
+		}
+		return CompletionList{
+			Items: d.completion(p.Position),
+		}, nil
+	case "textDocument/hover":
+		p := TextDocumentPositionParams{}
+		if err := decode(req.Params, &p); err != nil {
+			return nil, err
+		}
+		d, err := s.document(p.TextDocument.URI)
+		if err != nil {
+			return nil, err
+		}
+		return d.hover(p.Position)
+	default:
+		if strings.HasPrefix(req.Method, "$/") && req.isNotification() {
+			// optional notifications can be ignored
+			return nil, nil
+		}
+		return nil, &responseError{
+			Code:    codeMethodNotFound,
+			Message: fmt.Sprintf("method not found: '%s'", req.Method),
+		}
+	}
+}
+
+func (s *Server) initialize(_ InitializeParams) InitializeResult {
+	s.initialized = true
+	return InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync: TextDocumentSyncOptions{
+				OpenClose: true,
+				Change:    TextDocumentSyncFull,
+				Save:      true,
+			},
+			DocumentSymbolProvider: true,
+			DefinitionProvider:     true,
+			HoverProvider:          true,
+			CompletionProvider: CompletionOptions{
+				TriggerCharacters: []string{"{", "<", ":"},
+			},
+		},
+		ServerInfo: ServerInfo{
+			Name:    s.name,
+			Version: s.version,
+		},
+	}
+}
+
+func (s *Server) didOpen(p DidOpenTextDocumentParams) error {
+	d := newDocument(p.TextDocument.URI, p.TextDocument.Version, p.TextDocument.Text, s.settings)
+	s.documents[d.uri] = d
+	return s.publishDiagnostics(d)
+}
+
+func (s *Server) didChange(p DidChangeTextDocumentParams) error {
+	d, err := s.document(p.TextDocument.URI)
+	if err != nil {
+		return err
+	}
+	for _, c := range p.ContentChanges {
+		if c.Range == nil {
+			d.parse(c.Text)
+			continue
+		}
+		// also supports incremental changes, in which case only the portion of the document affected by the change is parsed again
+		d.change(*c.Range, c.Text)
+	}
+	d.version = p.TextDocument.Version
+	return s.publishDiagnostics(d)
+}
+
+func (s *Server) didSave(p DidSaveTextDocumentParams) error {
+	d, err := s.document(p.TextDocument.URI)
+	if err != nil {
+		return err
+	}
+	// parse the document again, since the files it includes may have changed in the meantime
+	d.parse(d.content)
+	return s.publishDiagnostics(d)
+}
+
+func (s *Server) didClose(p DidCloseTextDocumentParams) error {
+	delete(s.documents, p.TextDocument.URI)
+	// clear the diagnostics of the document
+	return s.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+		URI:         p.TextDocument.URI,
+		Diagnostics: []Diagnostic{},
+	})
+}
+
+func (s *Server) document(uri string) (*document, error) {
+	if d, found := s.documents[uri]; found {
+		return d, nil
+	}
+	return nil, &responseError{
+		Code:    codeInvalidParams,
+		Message: fmt.Sprintf("unknown document: '%s'", uri),
+	}
+}
+
+func (s *Server) publishDiagnostics(d *document) error {
+	version := d.version
+	return s.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+		URI:         d.uri,
+		Version:     &version,
+		Diagnostics: d.diagnostics(),
+	})
+}
+
+func (s *Server) notify(method string, params interface{}) error {
+	s.outMutex.Lock()
+	defer s.outMutex.Unlock()
+	return writeMessage(s.out, notification{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+	})
+}
+
+func (s *Server) reply(id json.RawMessage, result interface{}, err error) {
+	resp := response{
+		JSONRPC: "2.0",
+		ID:      id,
+	}
+	if err != nil {
+		e, ok := err.(*responseError)
+		if !ok {
+			e = &responseError{
+				Code:    codeInternalError,
+				Message: err.Error(),
+			}
+		}
+		resp.Error = e
+	} else {
+		r, err := json.Marshal(result)
+		if err != nil {
+			resp.Error = &responseError{
+				Code:    codeInternalError,
+				Message: err.Error(),
+			}
+		} else {
+			resp.Result = r
+		}
+	}
+	s.outMutex.Lock()
+	defer s.outMutex.Unlock()
+	if err := writeMessage(s.out, resp); err != nil {
+		log.WithError(err).Error("unable to reply")
+	}
+}
@@ -0,0 +1,246 @@
+package lsp_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"path/filepath"
+	"strconv"
+
+	"github.com/bytesparadise/libasciidoc/pkg/lsp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("language server", func() {
+
+	filename, _ := filepath.Abs("../../test/includes/doc.adoc")
+	uri := "file://" + filepath.ToSlash(filename)
+
+	const source = `= Title
+:product: libasciidoc
+
+== Introduction
+
+See <<_usage>> and {prod
+
+include::chapter-a.adoc[]
+
+[[_usage]]
+== Usage
+
+Use {product} with <<`
+
+	// session sends the `initialize` request, the `didOpen` notification with the given source,
+	// the given requests, and the `shutdown` request followed by the `exit` notification
+	session := func(source string, requests ...string) []map[string]interface{} {
+		in := &bytes.Buffer{}
+		write(in, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"processId":null,"rootUri":null,"capabilities":{}}}`)
+		write(in, `{"jsonrpc":"2.0","method":"initialized","params":{}}`)
+		write(in, fmt.Sprintf(`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":%q,"languageId":"asciidoc","version":1,"text":%s}}}`, uri, quote(source)))
+		for i, r := range requests {
+			write(in, fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":%s}`, 10+i, r))
+		}
+		write(in, `{"jsonrpc":"2.0","id":2,"method":"shutdown"}`)
+		write(in, `{"jsonrpc":"2.0","method":"exit"}`)
+		out := &bytes.Buffer{}
+		err := lsp.NewServer("test").Serve(in, out)
+		Expect(err).NotTo(HaveOccurred())
+		return read(out)
+	}
+
+	position := func(method string, line, character int) string {
+		return fmt.Sprintf(`%q,"params":{"textDocument":{"uri":%q},"position":{"line":%d,"character":%d}}`, method, uri, line, character)
+	}
+
+	It("should initialize", func() {
+		messages := session(source)
+		Expect(messages[0]["id"]).To(Equal(1.0))
+		Expect(messages[0]).To(HaveKeyWithValue("result", HaveKeyWithValue("serverInfo", HaveKeyWithValue("name", "libasciidoc"))))
+		Expect(messages[0]).To(HaveKeyWithValue("result", HaveKeyWithValue("capabilities", HaveKeyWithValue("hoverProvider", true))))
+		Expect(messages[len(messages)-1]).To(HaveKeyWithValue("id", 2.0))
+		Expect(messages[len(messages)-1]).To(HaveKeyWithValue("result", BeNil()))
+	})
+
+	It("should publish diagnostics", func() {
+		messages := session(`= Title
+
+== Section
+
+See <<unknown>>.`)
+		Expect(messages[1]).To(HaveKeyWithValue("method", "textDocument/publishDiagnostics"))
+		params := messages[1]["params"].(map[string]interface{})
+		Expect(params).To(HaveKeyWithValue("uri", uri))
+		Expect(params).To(HaveKeyWithValue("version", 1.0))
+		Expect(params["diagnostics"]).To(ConsistOf(map[string]interface{}{
+			"range": map[string]interface{}{
+				"start": map[string]interface{}{"line": 4.0, "character": 4.0},
+				"end":   map[string]interface{}{"line": 4.0, "character": 15.0},
+			},
+			"severity": 2.0,
+			"code":     "unresolved-xref",
+			"source":   "libasciidoc",
+			"message":  "possible invalid reference: unknown",
+		}))
+	})
+
+	It("should publish diagnostics after incremental changes", func() {
+		in := &bytes.Buffer{}
+		write(in, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"processId":null,"rootUri":null,"capabilities":{}}}`)
+		write(in, fmt.Sprintf(`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":%q,"languageId":"asciidoc","version":1,"text":%s}}}`, uri, quote(source)))
+		// complete the attribute reference with an unknown name, then insert a line before the included file
+		write(in, fmt.Sprintf(`{"jsonrpc":"2.0","method":"textDocument/didChange","params":{"textDocument":{"uri":%q,"version":2},"contentChanges":[`+
+			`{"range":{"start":{"line":5,"character":19},"end":{"line":5,"character":24}},"text":"{unknown}."},`+
+			`{"range":{"start":{"line":6,"character":0},"end":{"line":6,"character":0}},"text":"\n"}]}}`, uri))
+		write(in, fmt.Sprintf(`{"jsonrpc":"2.0","id":10,"method":"textDocument/documentSymbol","params":{"textDocument":{"uri":%q}}}`, uri))
+		write(in, `{"jsonrpc":"2.0","id":2,"method":"shutdown"}`)
+		write(in, `{"jsonrpc":"2.0","method":"exit"}`)
+		out := &bytes.Buffer{}
+		Expect(lsp.NewServer("test").Serve(in, out)).To(Succeed())
+		messages := read(out)
+		Expect(messages[2]).To(HaveKeyWithValue("method", "textDocument/publishDiagnostics"))
+		params := messages[2]["params"].(map[string]interface{})
+		Expect(params).To(HaveKeyWithValue("version", 2.0))
+		Expect(params["diagnostics"]).To(ConsistOf(And(
+			HaveKeyWithValue("code", "unresolved-attribute"),
+			HaveKeyWithValue("range", map[string]interface{}{
+				"start": map[string]interface{}{"line": 5.0, "character": 19.0},
+				"end":   map[string]interface{}{"line": 5.0, "character": 28.0},
+			}),
+		)))
+		result := messages[3]["result"].([]interface{})
+		Expect(result).To(HaveLen(2))
+		Expect(result[1]).To(And(
+			HaveKeyWithValue("name", "Usage"),
+			HaveKeyWithValue("selectionRange", map[string]interface{}{
+				"start": map[string]interface{}{"line": 11.0, "character": 3.0},
+				"end":   map[string]interface{}{"line": 11.0, "character": 8.0},
+			}),
+		))
+	})
+
+	It("should return document symbols", func() {
+		messages := session(source, fmt.Sprintf(`"textDocument/documentSymbol","params":{"textDocument":{"uri":%q}}`, uri))
+		result := messages[2]["result"].([]interface{})
+		Expect(result).To(HaveLen(2))
+		Expect(result[0]).To(And(
+			HaveKeyWithValue("name", "Introduction"),
+			HaveKeyWithValue("detail", "_Introduction"),
+			HaveKeyWithValue("selectionRange", map[string]interface{}{
+				"start": map[string]interface{}{"line": 3.0, "character": 3.0},
+				"end":   map[string]interface{}{"line": 3.0, "character": 15.0},
+			}),
+		))
+		// the `Usage` section is nested in the section of the included file
+		Expect(result[1]).To(And(
+			HaveKeyWithValue("name", "Usage"),
+			HaveKeyWithValue("detail", "_usage"),
+		))
+	})
+
+	It("should go to the definition of a cross reference", func() {
+		messages := session(source, position("textDocument/definition", 5, 7))
+		Expect(messages[2]["result"]).To(ConsistOf(HaveKeyWithValue("uri", uri)))
+		Expect(messages[2]["result"].([]interface{})[0]).To(HaveKeyWithValue("range", HaveKeyWithValue("start", map[string]interface{}{"line": 10.0, "character": 0.0})))
+	})
+
+	It("should go to the definition of an included file", func() {
+		messages := session(source, position("textDocument/definition", 7, 12))
+		included, _ := filepath.Abs("../../test/includes/chapter-a.adoc")
+		Expect(messages[2]["result"]).To(ConsistOf(HaveKeyWithValue("uri", "file://"+filepath.ToSlash(included))))
+	})
+
+	It("should complete attribute names", func() {
+		messages := session(source, position("textDocument/completion", 5, 24))
+		items := messages[2]["result"].(map[string]interface{})["items"]
+		Expect(items).To(ContainElements(
+			HaveKeyWithValue("label", "product"),
+			HaveKeyWithValue("label", "nbsp"),
+		))
+	})
+
+	It("should complete element IDs", func() {
+		messages := session(source, position("textDocument/completion", 12, 21))
+		items := messages[2]["result"].(map[string]interface{})["items"]
+		Expect(items).To(ContainElements(
+			And(HaveKeyWithValue("label", "_Introduction"), HaveKeyWithValue("detail", "Introduction")),
+			And(HaveKeyWithValue("label", "_usage"), HaveKeyWithValue("detail", "Usage")),
+		))
+	})
+
+	It("should preview the target of a cross reference", func() {
+		messages := session(source, position("textDocument/hover", 5, 7))
+		Expect(messages[2]["result"]).To(HaveKeyWithValue("contents", HaveKeyWithValue("value", And(
+			ContainSubstring("<h2 id=\"_usage\">Usage</h2>"),
+			ContainSubstring("<p>Use libasciidoc with &lt;&lt;</p>"),
+		))))
+	})
+
+	It("should preview the paragraph at the cursor", func() {
+		messages := session(source, position("textDocument/hover", 12, 2))
+		Expect(messages[2]["result"]).To(HaveKeyWithValue("contents", map[string]interface{}{
+			"kind": "markdown",
+			"value": "```html\n" + `<div class="paragraph">
+<p>Use libasciidoc with &lt;&lt;</p>
+</div>` + "\n```",
+		}))
+	})
+
+	It("should report unknown methods", func() {
+		messages := session(source, `"textDocument/formatting","params":{}`)
+		Expect(messages[2]).To(HaveKeyWithValue("error", HaveKeyWithValue("code", -32601.0)))
+	})
+
+	DescribeTable("should fail with an invalid message length",
+		func(length, expected string) {
+			in := &bytes.Buffer{}
+			fmt.Fprintf(in, "Content-Length: %s\r\n\r\n{}", length)
+			err := lsp.NewServer("test").Serve(in, &bytes.Buffer{})
+			Expect(err).To(MatchError(expected))
+		},
+		Entry("negative", "-1", "invalid message length: '-1'"),
+		Entry("not a number", "abc", "invalid message length: 'abc'"),
+		Entry("too large", "10000000000", "message length exceeds the maximum of 67108864 bytes: '10000000000'"),
+	)
+
+	It("should fail when exiting before shutdown", func() {
+		in := &bytes.Buffer{}
+		write(in, `{"jsonrpc":"2.0","method":"exit"}`)
+		err := lsp.NewServer("test").Serve(in, &bytes.Buffer{})
+		Expect(err).To(MatchError("exit notification received before the shutdown request"))
+	})
+})
+
+func write(w io.Writer, content string) {
+	fmt.Fprintf(w, "Content-Length: %d\r\n\r\n%s", len(content), content)
+}
+
+func quote(s string) string {
+	b, err := json.Marshal(s)
+	Expect(err).NotTo(HaveOccurred())
+	return string(b)
+}
+
+func read(r io.Reader) []map[string]interface{} {
+	result := []map[string]interface{}{}
+	br := bufio.NewReader(r)
+	for {
+		headers, err := textproto.NewReader(br).ReadMIMEHeader()
+		if err == io.EOF {
+			return result
+		}
+		Expect(err).NotTo(HaveOccurred())
+		length, err := strconv.Atoi(headers.Get("Content-Length"))
+		Expect(err).NotTo(HaveOccurred())
+		content := make([]byte, length)
+		_, err = io.ReadFull(br, content)
+		Expect(err).NotTo(HaveOccurred())
+		msg := map[string]interface{}{}
+		Expect(json.Unmarshal(content, &msg)).To(Succeed())
+		result = append(result, msg)
+	}
+}
@@ -0,0 +1,52 @@
+package lsp
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+)
+
+// symbols returns the tree of sections of the document
+func (d *document) symbols() []DocumentSymbol {
+	if d.doc == nil {
+		return []DocumentSymbol{}
+	}
+	return d.sectionSymbols(d.doc.Elements)
+}
+
+func (d *document) sectionSymbols(elements []interface{}) []DocumentSymbol {
+	result := []DocumentSymbol{}
+	for _, element := range elements {
+		switch e := element.(type) {
+		case *types.Preamble:
+			result = append(result, d.sectionSymbols(e.Elements)...)
+		case *types.Section:
+			r, found := types.SourceRangeOf(e)
+			if !found || r.Start.File != d.filename {
+				// section in an included file, which may contain sections of the document
+				result = append(result, d.sectionSymbols(e.Elements)...)
+				continue
+			}
+			if content, found := types.SourceRangeOfElements(e.Elements); found && content.End.File == d.filename && r.End.Line < content.End.Line {
+				r.End = content.End
+			}
+			selection, found := types.SourceRangeOfElements(e.Title)
+			if !found || selection.Start.File != d.filename {
+				selection = d.lineRange(r.Start)
+			}
+			id, _ := e.Attributes.GetAsString(types.AttrID)
+			name, err := sgml.RenderPlainText(e.Title, sgml.WithoutEscape())
+			if err != nil || name == "" {
+				name = id
+			}
+			result = append(result, DocumentSymbol{
+				Name:           name,
+				Detail:         id,
+				Kind:           SymbolKindString,
+				Range:          d.rangeOf(r),
+				SelectionRange: d.rangeOf(selection),
+				Children:       d.sectionSymbols(e.Elements),
+			})
+		}
+	}
+	return result
+}
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"io/fs"
 	"sync"
 
 	"github.com/bytesparadise/libasciidoc/pkg/configuration"
@@ -15,7 +16,14 @@ type ParseContext struct {
 	levelOffsets levelOffsets
 	attributes   *contextAttributes
 	userMacros   map[string]configuration.MacroTemplate
+	extensions   configuration.Extensions
+	fs           fs.FS // the file system from which the files to include are read (the OS file system if nil)
+	safeMode     configuration.SafeMode
+	baseDir      string // the directory in which the files to include must be located, depending on the safe mode
 	counters     map[string]interface{}
+	diagnostics  *types.Diagnostics
+	includedFrom []types.SourcePosition // positions of the `include::` directives of the file being processed, from the innermost to the outermost
+	sources      *types.SourceMap       // map of the content being processed to its position in the source files (if provided)
 }
 
 func NewParseContext(config *configuration.Configuration, options ...Option) *ParseContext {
@@ -26,7 +34,7 @@ func NewParseContext(config *configuration.Configuration, options ...Option) *Pa
 		Entrypoint("DocumentFragment"),
 		GlobalStore(frontMatterKey, true),
 		GlobalStore(documentHeaderKey, true),
-		GlobalStore(usermacrosKey, config.Macros),
+		GlobalStore(usermacrosKey, config.MacroNames()),
 		GlobalStore(enabledSubstitutionsKey, normalSubstitutions()),
 	}
 	opts = append(opts, options...)
@@ -36,7 +44,13 @@ func NewParseContext(config *configuration.Configuration, options ...Option) *Pa
 		levelOffsets: []*levelOffset{},
 		attributes:   newContextAttributes(config.Attributes),
 		userMacros:   config.Macros,
+		extensions:   config.Extensions,
+		fs:           config.FS,
+		safeMode:     config.SafeMode,
+		baseDir:      config.GetBaseDir(),
 		counters:     map[string]interface{}{},
+		diagnostics:  config.Diagnostics,
+		sources:      sourceMapOf(opts),
 	}
 }
 
@@ -47,7 +61,14 @@ func (c *ParseContext) Clone() *ParseContext {
 		levelOffsets: c.levelOffsets.clone(),
 		attributes:   c.attributes.clone(),
 		userMacros:   c.userMacros,
+		extensions:   c.extensions,
+		fs:           c.fs,
+		safeMode:     c.safeMode,
+		baseDir:      c.baseDir,
 		counters:     c.counters,
+		diagnostics:  c.diagnostics,
+		includedFrom: c.includedFrom,
+		sources:      c.sources,
 	}
 }
 
@@ -349,6 +349,9 @@ image::cookie.png[Cookie]
 									Cells: []*types.TableCell{
 										{
 											Format: "a",
+											Attributes: types.Attributes{
+												types.AttrStyle: types.AsciidocStyle,
+											},
 											Elements: []interface{}{
 												&types.ImageBlock{
 													Attributes: types.Attributes{
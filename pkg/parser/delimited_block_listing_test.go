@@ -3,9 +3,9 @@ package parser_test
 import (
 	"strings"
 
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 	. "github.com/bytesparadise/libasciidoc/testsupport"
-	log "github.com/sirupsen/logrus"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -1208,8 +1208,7 @@ and <more text> on the +
 				})
 
 				It("should fail when substitution is unknown", func() {
-					logs, reset := ConfigureLogger(log.ErrorLevel)
-					defer reset()
+					diagnostics := types.NewDiagnostics()
 					s := strings.ReplaceAll(source, "$SUBS", "unknown")
 					expected := &types.Document{
 						Elements: []interface{}{
@@ -1219,8 +1218,24 @@ and <more text> on the +
 							},
 						},
 					}
-					Expect(ParseDocument(s)).To(MatchDocument(expected))
-					Expect(logs).To(ContainJSONLogWithOffset(log.ErrorLevel, 33, 182, "unsupported substitution: 'unknown'"))
+					Expect(ParseDocument(s, configuration.WithDiagnostics(diagnostics))).To(MatchDocument(expected))
+					Expect(diagnostics.All()).To(ConsistOf(types.Diagnostic{
+						Severity: types.SeverityError,
+						Code:     types.DiagnosticParseError,
+						Message:  "unsupported substitution: 'unknown'",
+						Range: types.SourceRange{
+							Start: types.SourcePosition{
+								File:   "test.adoc",
+								Line:   3,
+								Column: 1,
+							},
+							End: types.SourcePosition{
+								File:   "test.adoc",
+								Line:   10,
+								Column: 5,
+							},
+						},
+					}))
 				})
 			})
 
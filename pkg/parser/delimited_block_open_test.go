@@ -78,6 +78,9 @@ image::another-image.png[]
 											Cells: []*types.TableCell{
 												{
 													Format: "a",
+													Attributes: types.Attributes{
+														types.AttrStyle: types.AsciidocStyle,
+													},
 													Elements: []interface{}{
 														&types.ImageBlock{
 															Attributes: types.Attributes{
@@ -92,6 +95,9 @@ image::another-image.png[]
 												},
 												{
 													Format: "a",
+													Attributes: types.Attributes{
+														types.AttrStyle: types.AsciidocStyle,
+													},
 													Elements: []interface{}{
 														&types.ImageBlock{
 															Attributes: types.Attributes{
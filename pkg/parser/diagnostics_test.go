@@ -0,0 +1,177 @@
+package parser_test
+
+import (
+	"path/filepath"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("diagnostics", func() {
+
+	rangeOf := func(file string, startLine, startColumn, endLine, endColumn int) types.SourceRange {
+		return types.SourceRange{
+			Start: types.SourcePosition{
+				File:   file,
+				Line:   startLine,
+				Column: startColumn,
+			},
+			End: types.SourcePosition{
+				File:   file,
+				Line:   endLine,
+				Column: endColumn,
+			},
+		}
+	}
+
+	It("should report unresolved cross references", func() {
+		source := `[#known]
+== Section
+
+see <<known>>, <<_Other>> and <<unknown>>
+
+== Other`
+		diagnostics := types.NewDiagnostics()
+		_, err := ParseDocument(source, configuration.WithDiagnostics(diagnostics))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diagnostics.All()).To(ConsistOf(types.Diagnostic{
+			Severity: types.SeverityWarning,
+			Code:     types.DiagnosticUnresolvedCrossReference,
+			Message:  "possible invalid reference: unknown",
+			Range:    rangeOf("test.adoc", 4, 31, 4, 42),
+		}))
+	})
+
+	It("should not report cross references to inline anchors", func() {
+		source := `a [[anchor]]paragraph
+
+see <<anchor>>`
+		diagnostics := types.NewDiagnostics()
+		_, err := ParseDocument(source, configuration.WithDiagnostics(diagnostics))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diagnostics.All()).To(BeEmpty())
+	})
+
+	It("should report unresolved attribute", func() {
+		source := `a {unknown} attribute`
+		diagnostics := types.NewDiagnostics()
+		_, err := ParseDocument(source, configuration.WithDiagnostics(diagnostics))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diagnostics.All()).To(ConsistOf(types.Diagnostic{
+			Severity: types.SeverityWarning,
+			Code:     types.DiagnosticUnresolvedAttribute,
+			Message:  "unable to find entry for attribute with key 'unknown' in context",
+			Range:    rangeOf("test.adoc", 1, 3, 1, 12),
+		}))
+	})
+
+	It("should report duplicate section IDs", func() {
+		source := `[#intro]
+== Introduction
+
+[#intro]
+== Other Introduction`
+		diagnostics := types.NewDiagnostics()
+		_, err := ParseDocument(source, configuration.WithDiagnostics(diagnostics))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diagnostics.All()).To(ConsistOf(types.Diagnostic{
+			Severity: types.SeverityWarning,
+			Code:     types.DiagnosticDuplicateID,
+			Message:  "id assigned to section already in use: intro",
+			Range:    rangeOf("test.adoc", 5, 1, 5, 22),
+		}))
+	})
+
+	It("should report unterminated delimited block", func() {
+		source := `====
+an example
+
+----
+some code
+====
+`
+		diagnostics := types.NewDiagnostics()
+		_, err := ParseDocument(source, configuration.WithDiagnostics(diagnostics))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diagnostics.All()).To(ConsistOf(
+			types.Diagnostic{
+				Severity: types.SeverityWarning,
+				Code:     types.DiagnosticUnclosedBlock,
+				Message:  "unterminated example block",
+				Range:    rangeOf("test.adoc", 1, 1, 1, 5),
+			},
+			types.Diagnostic{
+				Severity: types.SeverityWarning,
+				Code:     types.DiagnosticUnclosedBlock,
+				Message:  "unterminated listing block",
+				Range:    rangeOf("test.adoc", 4, 1, 4, 5),
+			},
+		))
+	})
+
+	It("should report unresolved file inclusion", func() {
+		source := `a paragraph
+
+include::../../test/includes/unknown.adoc[]`
+		diagnostics := types.NewDiagnostics()
+		_, err := ParseDocument(source, configuration.WithDiagnostics(diagnostics))
+		Expect(err).To(HaveOccurred())
+		Expect(diagnostics.All()).To(HaveLen(1))
+		d := diagnostics.All()[0]
+		Expect(d.Severity).To(Equal(types.SeverityError))
+		Expect(d.Code).To(Equal(types.DiagnosticUnresolvedInclude))
+		Expect(d.Message).To(ContainSubstring("Unresolved directive in test.adoc - include::../../test/includes/unknown.adoc[]"))
+		Expect(d.Range).To(Equal(rangeOf("test.adoc", 3, 1, 3, 44)))
+	})
+
+	It("should report unclosed tag in included file", func() {
+		source := `a paragraph
+
+include::../../test/includes/tag-include-unclosed.adoc[tag=unclosed]`
+		included := "../../test/includes/tag-include-unclosed.adoc" // in the same form as the path of the root document
+		diagnostics := types.NewDiagnostics()
+		_, err := ParseDocument(source, configuration.WithDiagnostics(diagnostics))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diagnostics.All()).To(ConsistOf(types.Diagnostic{
+			Severity: types.SeverityWarning,
+			Code:     types.DiagnosticUnclosedTag,
+			Message:  "detected unclosed tag 'unclosed' starting at line 6 of include file: " + included,
+			Range:    rangeOf(included, 6, 1, 6, 1),
+			IncludedFrom: []types.SourcePosition{
+				{
+					File:   "test.adoc",
+					Line:   3,
+					Column: 1,
+				},
+			},
+		}))
+	})
+
+	It("should report included file with absolute path when root document has absolute path", func() {
+		source := `include::../../test/includes/tag-include-unclosed.adoc[tag=unclosed]`
+		filename, err := filepath.Abs("test.adoc")
+		Expect(err).NotTo(HaveOccurred())
+		included, err := filepath.Abs("../../test/includes/tag-include-unclosed.adoc")
+		Expect(err).NotTo(HaveOccurred())
+		diagnostics := types.NewDiagnostics()
+		_, err = ParseDocument(source, configuration.WithFilename(filename), configuration.WithDiagnostics(diagnostics))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diagnostics.All()).To(ConsistOf(types.Diagnostic{
+			Severity: types.SeverityWarning,
+			Code:     types.DiagnosticUnclosedTag,
+			Message:  "detected unclosed tag 'unclosed' starting at line 6 of include file: " + included,
+			Range:    rangeOf(included, 6, 1, 6, 1),
+			IncludedFrom: []types.SourcePosition{
+				{
+					File:   filename,
+					Line:   1,
+					Column: 1,
+				},
+			},
+		}))
+	})
+})
@@ -4,10 +4,12 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
@@ -18,22 +20,67 @@ import (
 
 // Preprocess reads line by line to look-up and process file inclusions and conditionals (`ifdef`, `ifndef` and `ifeval`)
 func Preprocess(source io.Reader, config *configuration.Configuration, opts ...Option) (string, error) {
+	content, _, err := PreprocessWithSourceMap(source, config, opts...)
+	return content, err
+}
+
+// PreprocessWithSourceMap same as `Preprocess`, but also returns the map of the preprocessed content
+// to the position of its lines in the source files (including the files to include),
+// which can be passed to `ParseDocument` with the `WithSourceMap` option
+func PreprocessWithSourceMap(source io.Reader, config *configuration.Configuration, opts ...Option) (string, *types.SourceMap, error) {
 	ctx := NewParseContext(config, opts...) // each pipeline step will have its own clone of `ctx`
-	return preprocess(ctx, source)
+	source, err := applyPreprocessors(source, config.Extensions.Preprocessors)
+	if err != nil {
+		return "", nil, err
+	}
+	return preprocess(ctx, source, nil)
+}
+
+// applyPreprocessors returns the lines of the source, as processed by the given preprocessors (if any)
+func applyPreprocessors(source io.Reader, preprocessors []configuration.Preprocessor) (io.Reader, error) {
+	if len(preprocessors) == 0 {
+		return source, nil
+	}
+	lines := []string{}
+	scanner := bufio.NewScanner(source)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "unable to read the source document")
+	}
+	for _, process := range preprocessors {
+		var err error
+		if lines, err = process(lines); err != nil {
+			return nil, errors.Wrap(err, "unable to preprocess the source document")
+		}
+	}
+	return strings.NewReader(strings.Join(lines, "\n")), nil
 }
 
-func preprocess(ctx *ParseContext, source io.Reader) (string, error) {
+// preprocess the content of the source. If `lines` is not nil, then it contains
+// the line number in the source file of each line in the content (eg: when only some lines of a file were included)
+func preprocess(ctx *ParseContext, source io.Reader, lines []int) (string, *types.SourceMap, error) {
 	if log.IsLevelEnabled(log.DebugLevel) {
 		log.Debugf("preprocessing file inclusions in %s with leveloffset=%s", ctx.filename, spew.Sdump(ctx.levelOffsets))
 	}
 	b := &builder{
 		enabled: true,
+		sources: types.NewSourceMap(nil),
 	}
 	c := conditions{}
 	scanner := bufio.NewScanner(source)
 	t := newBlockDelimiterTracker()
-	for scanner.Scan() {
+	for n := 0; scanner.Scan(); n++ {
 		line := scanner.Bytes()
+		b.position = types.SourcePosition{
+			File:   ctx.filename,
+			Line:   n + 1,
+			Column: 1,
+		}
+		if n < len(lines) {
+			b.position.Line = lines[n]
+		}
 		element, err := Parse("", line, append(ctx.opts, Entrypoint("DocumentRawLine"))...)
 		if err != nil {
 			// log.Error(err)
@@ -53,13 +100,14 @@ func preprocess(ctx *ParseContext, source io.Reader) (string, error) {
 			case *types.RawSection:
 				b.WriteString(ctx.levelOffsets.apply(e))
 			case *types.FileInclusion:
-				f, err := includeFile(ctx.Clone(), e)
+				f, m, err := includeFile(ctx.Clone(), e, b.position)
 				if err != nil {
-					return "", err
+					ctx.diagnostics.Errorf(types.DiagnosticUnresolvedInclude, lineRange(b.position, line), "%v", err)
+					return "", nil, err
 				}
-				b.WriteString(f)
+				b.writeIncluded(f, m)
 			case *types.BlockDelimiter:
-				t.track(e.Kind, e.Length)
+				t.track(e.Kind, e.Length, lineRange(b.position, line))
 				ctx.opts = append(ctx.opts, t.withinDelimitedBlock())
 				b.WriteString(e.RawText())
 			case types.ConditionalInclusion:
@@ -73,11 +121,19 @@ func preprocess(ctx *ParseContext, source io.Reader) (string, error) {
 			case *types.EndOfCondition:
 				b.enabled = c.pop()
 			default:
-				return "", fmt.Errorf("unexpected type of element while preprocessinh document: '%T'", e)
+				return "", nil, fmt.Errorf("unexpected type of element while preprocessinh document: '%T'", e)
 			}
 		}
 	}
-	return b.String(), nil
+	// only whole files are expected to have balanced delimited blocks
+	if lines == nil {
+		for _, d := range t.stack {
+			ctx.diagnostics.Warnf(types.DiagnosticUnclosedBlock, d.sourceRange, "unterminated %s block", d.kind)
+		}
+	}
+	content := b.String()
+	b.sources.SetContent([]byte(content))
+	return content, b.sources, nil
 }
 
 type blockDelimiterTracker struct {
@@ -85,8 +141,9 @@ type blockDelimiterTracker struct {
 }
 
 type blockDelimiter struct {
-	kind   string
-	length int
+	kind        string
+	length      int
+	sourceRange types.SourceRange // range of the opening delimiter
 }
 
 func newBlockDelimiterTracker() *blockDelimiterTracker {
@@ -95,20 +152,33 @@ func newBlockDelimiterTracker() *blockDelimiterTracker {
 	}
 }
 
-func (t *blockDelimiterTracker) track(kind string, length int) {
+func (t *blockDelimiterTracker) track(kind string, length int, sourceRange types.SourceRange) {
 	switch {
 	case len(t.stack) > 0 && t.stack[len(t.stack)-1].kind == kind && t.stack[len(t.stack)-1].length == length:
 		// pop
 		t.stack = t.stack[:len(t.stack)-1]
+	case len(t.stack) > 0 && isVerbatimBlock(t.stack[len(t.stack)-1].kind):
+		// the delimiter is part of the content of the current verbatim block
 	default:
 		// push
 		t.stack = append(t.stack, blockDelimiter{
-			kind:   kind,
-			length: length,
+			kind:        kind,
+			length:      length,
+			sourceRange: sourceRange,
 		})
 	}
 }
 
+// isVerbatimBlock returns true if the content of a block of the given kind is not parsed, hence may contain other delimiters
+func isVerbatimBlock(kind string) bool {
+	switch kind {
+	case types.Listing, types.Literal, types.Fenced, types.MarkdownCode, types.Comment, types.Passthrough:
+		return true
+	default:
+		return false
+	}
+}
+
 func (t *blockDelimiterTracker) withinDelimitedBlock() Option {
 	return GlobalStore(withinDelimitedBlockKey, len(t.stack) > 0)
 }
@@ -117,30 +187,62 @@ func (t *blockDelimiterTracker) withinDelimitedBlock() Option {
 // note: there is a trade-off here: we include the whole content of the file in the current
 // fragment, making it potentially big, but at the same time we ensure that the context
 // of the inclusion (for example, within a delimited block) is not lost.
-func includeFile(ctx *ParseContext, incl *types.FileInclusion) (string, error) {
+func includeFile(ctx *ParseContext, incl *types.FileInclusion, position types.SourcePosition) (string, *types.SourceMap, error) {
 	ctx.opts = append(ctx.opts, GlobalStore(documentHeaderKey, false))
+	ctx.includedFrom = append([]types.SourcePosition{position}, ctx.includedFrom...)
 	if l, ok := incl.GetLocation().Path.([]interface{}); ok {
 		l, err := replaceAttributeRefsInSlicedValue(ctx, l)
 		if err != nil {
-			return "", err
+			return "", nil, err
 		}
 		incl.GetLocation().SetPath(l)
 	}
-	content, adoc, err := contentOf(ctx, incl)
+	content, lines, adoc, err := contentOf(ctx, incl)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 	if !adoc {
-		return string(content), nil
+		return content, includedLinesSourceMap(ctx.filename, content, lines), nil
 	}
 	ctx.opts = append(ctx.opts, sectionEnabled())
-	return preprocess(ctx, strings.NewReader(content))
+	return preprocess(ctx, strings.NewReader(content), lines)
+}
+
+// lineRange returns the range of the given line, which starts at the given position
+func lineRange(start types.SourcePosition, line []byte) types.SourceRange {
+	end := start
+	end.Column += utf8.RuneCount(line)
+	return types.SourceRange{
+		Start: start,
+		End:   end,
+	}
+}
+
+// includedLinesSourceMap returns the map of the given content, in which each line comes from the given line of the file
+func includedLinesSourceMap(filename, content string, lines []int) *types.SourceMap {
+	m := types.NewSourceMap([]byte(content))
+	offset := 0
+	for _, l := range lines {
+		m.Add(offset, types.SourcePosition{
+			File:   filename,
+			Line:   l,
+			Column: 1,
+		})
+		i := strings.IndexByte(content[offset:], '\n')
+		if i < 0 {
+			break
+		}
+		offset += i + 1
+	}
+	return m
 }
 
 type builder struct {
 	strings.Builder
 	insertLF bool
 	enabled  bool
+	position types.SourcePosition // position of the current line in the source file
+	sources  *types.SourceMap
 }
 
 func (b *builder) WriteString(s string) {
@@ -148,6 +250,7 @@ func (b *builder) WriteString(s string) {
 		return
 	}
 	b.doInsertLF()
+	b.sources.Add(b.Len(), b.position)
 	b.Builder.WriteString(s)
 }
 
@@ -156,9 +259,20 @@ func (b *builder) Write(p []byte) {
 		return
 	}
 	b.doInsertLF()
+	b.sources.Add(b.Len(), b.position)
 	b.Builder.Write(p)
 }
 
+// writeIncluded writes the content of a file to include, along with the positions of its lines in the file
+func (b *builder) writeIncluded(s string, m *types.SourceMap) {
+	if !b.enabled {
+		return
+	}
+	b.doInsertLF()
+	b.sources.Append(b.Len(), m)
+	b.Builder.WriteString(s)
+}
+
 func (b *builder) doInsertLF() {
 	if b.insertLF {
 		b.Builder.WriteString("\n")
@@ -212,45 +326,65 @@ func (c *conditions) eval() bool {
 	return true
 }
 
-func contentOf(ctx *ParseContext, incl *types.FileInclusion) (string, bool, error) {
+// contentOf returns the content of the file to include, along with the line number in the file of each line of the content
+func contentOf(ctx *ParseContext, incl *types.FileInclusion) (string, []int, bool, error) {
 	path := incl.Location.ToString()
 	currentDir := filepath.Dir(ctx.filename)
 	filename := filepath.Join(currentDir, path)
 
-	f, absPath, closeFile, err := open(filename)
-	defer closeFile()
-	if err != nil {
-		return "", false, errors.Wrapf(err, "Unresolved directive in %s - %s", ctx.filename, incl.RawText)
+	var r io.Reader
+	var includedPath string
+	if content, found, err := processInclude(ctx, path, incl.Attributes); err != nil {
+		return "", nil, false, errors.Wrapf(err, "Unresolved directive in %s - %s", ctx.filename, incl.RawText)
+	} else if found {
+		r = strings.NewReader(content)
+		includedPath = filename
+	} else if !ctx.safeMode.AllowsIncludes() {
+		// replace the directive with a link to the target, at the same position in the source file
+		return fmt.Sprintf("link:%s[role=include]", path), []int{ctx.includedFrom[0].Line}, false, nil
+	} else {
+		if err := checkIncludePath(ctx, path, filename); err != nil {
+			return "", nil, false, errors.Wrapf(err, "Unresolved directive in %s - %s", ctx.filename, incl.RawText)
+		}
+		f, p, closeFile, err := open(ctx.fs, filename)
+		defer closeFile()
+		if err != nil {
+			return "", nil, false, errors.Wrapf(err, "Unresolved directive in %s - %s", ctx.filename, incl.RawText)
+		}
+		r = f
+		includedPath = sourcePath(ctx.filename, p)
 	}
+	ctx.diagnostics.RecordInclusion(includedPath, ctx.includedFrom)
 	result := &strings.Builder{}
-	scanner := bufio.NewScanner(bufio.NewReader(f))
+	lines := []int{}
+	scanner := bufio.NewScanner(bufio.NewReader(r))
 	if log.IsLevelEnabled(log.DebugLevel) {
 		log.Debugf("reading %s", filename)
 	}
 	if lr, ok, err := lineRanges(incl); err != nil {
-		return "", false, errors.Wrapf(err, "Unresolved directive in %s - %s", ctx.filename, incl.RawText)
+		return "", nil, false, errors.Wrapf(err, "Unresolved directive in %s - %s", ctx.filename, incl.RawText)
 	} else if ok {
-		if err := readWithinLines(scanner, result, lr); err != nil {
-			return "", false, errors.Wrapf(err, "Unresolved directive in %s - %s", ctx.filename, incl.RawText)
+		if err := readWithinLines(scanner, result, &lines, lr); err != nil {
+			return "", nil, false, errors.Wrapf(err, "Unresolved directive in %s - %s", ctx.filename, incl.RawText)
 		}
 	} else if tr, ok, err := tagRanges(incl); err != nil {
-		return "", false, errors.Wrapf(err, "Unresolved directive in %s - %s", ctx.filename, incl.RawText)
+		return "", nil, false, errors.Wrapf(err, "Unresolved directive in %s - %s", ctx.filename, incl.RawText)
 	} else if ok {
-		if err := readWithinTags(path, scanner, result, tr); err != nil {
-			return "", false, errors.Wrapf(err, "Unresolved directive in %s - %s", ctx.filename, incl.RawText)
+		if err := readWithinTags(ctx.diagnostics, includedPath, scanner, result, &lines, tr); err != nil {
+			return "", nil, false, errors.Wrapf(err, "Unresolved directive in %s - %s", ctx.filename, incl.RawText)
 		}
 	} else {
-		if err := readAll(scanner, result); err != nil {
+		if err := readAll(scanner, result, &lines); err != nil {
 			log.Error(err)
-			return "", false, errors.Errorf("Unresolved directive in %s - %s", ctx.filename, incl.RawText)
+			return "", nil, false, errors.Errorf("Unresolved directive in %s - %s", ctx.filename, incl.RawText)
 		}
 	}
 	if err := scanner.Err(); err != nil {
 		log.Error(err)
-		return "", false, errors.Errorf("Unresolved directive in %s - %s", ctx.filename, incl.RawText)
+		return "", nil, false, errors.Errorf("Unresolved directive in %s - %s", ctx.filename, incl.RawText)
 	}
 	// cloning the context to avoid altering the original as we process recursively embedded file inclusions
-	ctx.filename = absPath
+	ctx.filename = includedPath
 	// if the file to include is not an Asciidoc document, just return the content as "raw lines"
 
 	// level offset
@@ -259,7 +393,7 @@ func contentOf(ctx *ParseContext, incl *types.FileInclusion) (string, bool, erro
 		offset, err := strconv.Atoi(lvl)
 		if err != nil {
 			log.Error(err)
-			return "", false, errors.Errorf("Unresolved directive in %s - %s", ctx.filename, incl.RawText)
+			return "", nil, false, errors.Errorf("Unresolved directive in %s - %s", ctx.filename, incl.RawText)
 		}
 		if strings.HasPrefix(lvl, "+") || strings.HasPrefix(lvl, "-") {
 			ctx.levelOffsets = append(ctx.levelOffsets, relativeOffset(offset))
@@ -268,9 +402,49 @@ func contentOf(ctx *ParseContext, incl *types.FileInclusion) (string, bool, erro
 		}
 	}
 	// if log.IsLevelEnabled(log.DebugLevel) {
-	// 	log.Debugf("content of '%s':\n%s", absPath, result.String())
+	// 	log.Debugf("content of '%s':\n%s", includedPath, result.String())
 	// }
-	return result.String(), IsAsciidoc(absPath), nil
+	return result.String(), lines, IsAsciidoc(includedPath), nil
+}
+
+// sourcePath returns the given absolute path of an included file in the same form as the path of the file which includes it
+// (ie, relative to the working directory if the latter is relative, as the path of the root document usually is), so that the positions
+// in all the source files are reported in the same way
+func sourcePath(includedFrom, path string) string {
+	if filepath.IsAbs(includedFrom) || !filepath.IsAbs(path) {
+		return path
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	if p, err := filepath.Rel(wd, path); err == nil {
+		return p
+	}
+	return path
+}
+
+// checkIncludePath verifies that the file to include can be read in the current safe mode:
+// in the `safe` mode and higher modes, the target cannot be an absolute path, and the file must be within the base directory.
+// Note: when the files are read from an `fs.FS`, the file system itself confines the files to include.
+func checkIncludePath(ctx *ParseContext, target, filename string) error {
+	if ctx.fs != nil || ctx.safeMode < configuration.SafeModeSafe {
+		return nil
+	}
+	if filepath.IsAbs(target) {
+		return errors.Errorf("absolute path is not allowed in %s mode: %s", ctx.safeMode, target)
+	}
+	return ctx.safeMode.CheckPath(ctx.baseDir, filename)
+}
+
+// processInclude returns the content to include for the given target, as returned by the first include processor which handles it
+func processInclude(ctx *ParseContext, target string, attributes types.Attributes) (string, bool, error) {
+	for _, process := range ctx.extensions.IncludeProcessors {
+		if content, found, err := process(target, attributes); err != nil || found {
+			return content, found, err
+		}
+	}
+	return "", false, nil
 }
 
 type levelOffsets []*levelOffset
@@ -355,7 +529,7 @@ func tagRanges(incl *types.FileInclusion) (types.TagRanges, bool, error) {
 
 // TODO: instead of reading and parsing afterwards, simply parse the lines immediately? ie: `readWithinLines` -> `parseWithinLines`
 // (also, use a specific entrypoint if the doc is not a .adoc)
-func readWithinLines(scanner *bufio.Scanner, content *strings.Builder, lineRanges types.LineRanges) error {
+func readWithinLines(scanner *bufio.Scanner, content *strings.Builder, lines *[]int, lineRanges types.LineRanges) error {
 	line := 0
 	for scanner.Scan() {
 		line++
@@ -374,6 +548,7 @@ func readWithinLines(scanner *bufio.Scanner, content *strings.Builder, lineRange
 		}
 		// TODO: stop reading if current line above highest range
 		if lineRanges.Match(line) {
+			*lines = append(*lines, line)
 			if _, err := content.Write(scanner.Bytes()); err != nil {
 				return err
 			}
@@ -385,7 +560,7 @@ func readWithinLines(scanner *bufio.Scanner, content *strings.Builder, lineRange
 	return nil
 }
 
-func readWithinTags(path string, scanner *bufio.Scanner, content *strings.Builder, expectedRanges types.TagRanges) error {
+func readWithinTags(diagnostics *types.Diagnostics, path string, scanner *bufio.Scanner, content *strings.Builder, lines *[]int, expectedRanges types.TagRanges) error {
 	// log.Debugf("limiting to tag ranges: %v", expectedRanges)
 	currentRanges := make(map[string]*types.CurrentTagRange, len(expectedRanges)) // ensure capacity
 	lineNumber := 0
@@ -412,6 +587,7 @@ func readWithinTags(path string, scanner *bufio.Scanner, content *strings.Builde
 			currentRanges[endTag.Value].EndLine = lineNumber
 		}
 		if expectedRanges.Match(lineNumber, currentRanges) && !fl.HasTag() {
+			*lines = append(*lines, lineNumber)
 			_, err := content.Write(scanner.Bytes())
 			if err != nil {
 				return err
@@ -432,15 +608,23 @@ func readWithinTags(path string, scanner *bufio.Scanner, content *strings.Builde
 			if tr, found := currentRanges[tag.Name]; !found {
 				return fmt.Errorf("tag '%s' not found in file to include", tag.Name)
 			} else if tr.EndLine == -1 {
-				log.Warnf("detected unclosed tag '%s' starting at line %d of include file: %s", tag.Name, tr.StartLine, path)
+				start := types.SourcePosition{
+					File:   path,
+					Line:   tr.StartLine,
+					Column: 1,
+				}
+				diagnostics.Warnf(types.DiagnosticUnclosedTag, types.SourceRange{
+					Start: start,
+					End:   start,
+				}, "detected unclosed tag '%s' starting at line %d of include file: %s", tag.Name, tr.StartLine, path)
 			}
 		}
 	}
 	return nil
 }
 
-func readAll(scanner *bufio.Scanner, content *strings.Builder) error {
-	for scanner.Scan() {
+func readAll(scanner *bufio.Scanner, content *strings.Builder, lines *[]int) error {
+	for line := 1; scanner.Scan(); line++ {
 		// parse the line in search for the `tag::<tag>[]` or `end:<tag>[]` macros
 		l, err := Parse("", scanner.Bytes(), Entrypoint("IncludedFileLine"))
 		if err != nil {
@@ -454,6 +638,7 @@ func readAll(scanner *bufio.Scanner, content *strings.Builder) error {
 		if fl.HasTag() {
 			continue
 		}
+		*lines = append(*lines, line)
 		_, err = content.Write(scanner.Bytes())
 		if err != nil {
 			return err
@@ -466,49 +651,27 @@ func readAll(scanner *bufio.Scanner, content *strings.Builder) error {
 	return nil
 }
 
-func open(path string) (*os.File, string, func(), error) {
-	wd, err := os.Getwd()
-	if err != nil {
-		return nil, "", func() {}, err
-	}
-	absPath, err := filepath.Abs(path)
-	if err != nil {
-		return nil, "", func() {
-			log.Debugf("restoring current working dir to: %s", wd)
-			if err := os.Chdir(wd); err != nil { // restore the previous working directory
-				log.WithError(err).Error("failed to restore previous working directory")
-			}
-		}, err
-	}
-	dir := filepath.Dir(absPath)
-	// TODO: we could skip the Chdir part if we retain the absPath in the context,
-	// and use `filepath.Join` to compute the abspath of the file to include
-	if err = os.Chdir(dir); err != nil {
-		return nil, "", func() {
-			log.Debugf("restoring current working dir to: %s", wd)
-			if err := os.Chdir(wd); err != nil { // restore the previous working directory
-				log.WithError(err).Error("failed to restore previous working directory")
-			}
-		}, err
+// open opens the file with the given path, in the given file system if not nil, or in the OS file system otherwise.
+// Also returns the absolute path of the file (or its path from the root of the given file system).
+// Note: the process working directory is never changed, since the paths of the files to include are
+// resolved from the absolute path of the including file.
+func open(fsys fs.FS, path string) (fs.File, string, func(), error) {
+	var p string
+	if fsys != nil {
+		p = configuration.FSPath(path)
+	} else {
+		var err error
+		if p, err = filepath.Abs(path); err != nil {
+			return nil, "", func() {}, err
+		}
 	}
-	// read the file per-se
-	// log.Debugf("opening '%s'", absPath)
-	f, err := os.Open(absPath)
+	f, err := configuration.OpenFile(fsys, p)
 	if err != nil {
-		return nil, absPath, func() {
-			log.Debugf("restoring current working dir to: %s", wd)
-			if err := os.Chdir(wd); err != nil { // restore the previous working directory
-				log.WithError(err).Error("failed to restore previous working directory")
-			}
-		}, err
+		return nil, p, func() {}, err
 	}
-	return f, absPath, func() {
-		log.Debugf("restoring current working dir to: %s", wd)
-		if err := os.Chdir(wd); err != nil { // restore the previous working directory
-			log.WithError(err).Error("failed to restore previous working directory")
-		}
+	return f, p, func() {
 		if err := f.Close(); err != nil {
-			log.WithError(err).Errorf("failed to close file '%s'", absPath)
+			log.WithError(err).Errorf("failed to close file '%s'", p)
 		}
 	}, nil
 }
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"testing/fstest"
 
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 	. "github.com/bytesparadise/libasciidoc/testsupport"
 
@@ -653,7 +655,7 @@ end`
 				_, err := PreparseDocument(source)
 				GinkgoT().Log(err.Error())
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Unresolved directive in test.adoc - include::{unknown}/unknown.adoc[leveloffset=+1]: chdir %s", filepath.Join(wd, "{unknown}"))))
+				Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Unresolved directive in test.adoc - include::{unknown}/unknown.adoc[leveloffset=+1]: open %s", filepath.Join(wd, "{unknown}/unknown.adoc"))))
 			})
 
 			It("should fail if file is missing in standalone block", func() {
@@ -669,7 +671,7 @@ end`
 				_, err := PreparseDocument(source)
 				GinkgoT().Log(err.Error())
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Unresolved directive in test.adoc - include::{includedir}/unknown.adoc[leveloffset=+1]: chdir %s", filepath.Join(wd, "{includedir}"))))
+				Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Unresolved directive in test.adoc - include::{includedir}/unknown.adoc[leveloffset=+1]: open %s", filepath.Join(wd, "{includedir}/unknown.adoc"))))
 			})
 
 			It("should fail if file is missing in delimited block", func() {
@@ -689,7 +691,7 @@ include::{includedir}/unknown.adoc[leveloffset=+1]
 				_, err := PreparseDocument(source)
 				GinkgoT().Log(err.Error())
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Unresolved directive in test.adoc - include::{includedir}/unknown.adoc[leveloffset=+1]: chdir %s", filepath.Join(wd, "{includedir}"))))
+				Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Unresolved directive in test.adoc - include::{includedir}/unknown.adoc[leveloffset=+1]: open %s", filepath.Join(wd, "{includedir}/unknown.adoc"))))
 			})
 		})
 
@@ -759,6 +761,117 @@ package includes
 			})
 		})
 
+		Context("in safe modes", func() {
+
+			It("should include file outside of the document directory in unsafe mode", func() {
+				source := `include::../../test/includes/chapter-a.adoc[]`
+				expected := `= Chapter A
+
+content`
+				Expect(PreparseDocument(source, configuration.WithSafeMode(configuration.SafeModeUnsafe))).To(Equal(expected))
+			})
+
+			It("should include file within the base directory in safe mode", func() {
+				source := `include::../../test/includes/chapter-a.adoc[]`
+				expected := `= Chapter A
+
+content`
+				Expect(PreparseDocument(source,
+					configuration.WithSafeMode(configuration.SafeModeSafe),
+					configuration.WithBaseDir("../.."),
+				)).To(Equal(expected))
+			})
+
+			It("should fail to include file outside of the base directory in safe mode", func() {
+				wd, err := os.Getwd()
+				Expect(err).NotTo(HaveOccurred())
+				source := `include::../../test/includes/chapter-a.adoc[]`
+				_, err = PreparseDocument(source, configuration.WithSafeMode(configuration.SafeModeSafe))
+				Expect(err).To(MatchError(fmt.Sprintf("Unresolved directive in test.adoc - include::../../test/includes/chapter-a.adoc[]: path is outside of the base directory in safe mode: %s", filepath.Join(wd, "../../test/includes/chapter-a.adoc"))))
+			})
+
+			It("should include nested files within the base directory in server mode", func() {
+				// `parent-include.adoc` includes `child-include.adoc` which includes `grandchild-include.adoc`
+				source := `include::../../test/includes/parent-include.adoc[]`
+				result, err := PreparseDocument(source,
+					configuration.WithSafeMode(configuration.SafeModeServer),
+					configuration.WithBaseDir("../../test/includes"),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(ContainSubstring("first line of grandchild"))
+			})
+
+			It("should fail to include file with absolute path in safe mode", func() {
+				source := `include::/etc/hosts[]`
+				_, err := PreparseDocument(source, configuration.WithSafeMode(configuration.SafeModeSafe))
+				Expect(err).To(MatchError("Unresolved directive in test.adoc - include::/etc/hosts[]: absolute path is not allowed in safe mode: /etc/hosts"))
+			})
+
+			It("should replace include directives with links in secure mode", func() {
+				source := `include::../../test/includes/chapter-a.adoc[]
+
+----
+include::/etc/hosts[lines=1]
+----`
+				expected := `link:../../test/includes/chapter-a.adoc[role=include]
+
+----
+link:/etc/hosts[role=include]
+----`
+				Expect(PreparseDocument(source, configuration.WithSafeMode(configuration.SafeModeSecure))).To(Equal(expected))
+			})
+		})
+
+		Context("from a file system", func() {
+
+			fsys := fstest.MapFS{
+				"chapters/chapter-a.adoc": &fstest.MapFile{
+					Data: []byte(`== Chapter A
+
+include::../common/snippets.adoc[lines=3]
+
+include::../common/snippets.adoc[tag=greetings]`),
+				},
+				"common/snippets.adoc": &fstest.MapFile{
+					Data: []byte(`first line
+second line
+third line
+// tag::greetings[]
+hello, world
+// end::greetings[]`),
+				},
+			}
+
+			It("should include files with relative paths and filters", func() {
+				source := `include::chapters/chapter-a.adoc[leveloffset=+1]`
+				expected := `=== Chapter A
+
+third line
+
+hello, world`
+				Expect(PreparseDocument(source, configuration.WithFS(fsys))).To(Equal(expected))
+			})
+
+			It("should include file with absolute path", func() {
+				source := `include::/common/snippets.adoc[lines=1..2]`
+				expected := `first line
+second line`
+				Expect(PreparseDocument(source, configuration.WithFS(fsys))).To(Equal(expected))
+			})
+
+			It("should fail if file is missing", func() {
+				source := `include::chapters/unknown.adoc[]`
+				_, err := PreparseDocument(source, configuration.WithFS(fsys))
+				Expect(err).To(MatchError("Unresolved directive in test.adoc - include::chapters/unknown.adoc[]: open chapters/unknown.adoc: file does not exist"))
+			})
+
+			It("should fail if file is outside of the file system", func() {
+				source := `include::../test/includes/chapter-a.adoc[]`
+				_, err := PreparseDocument(source, configuration.WithFS(fsys))
+				Expect(err).To(MatchError("Unresolved directive in test.adoc - include::../test/includes/chapter-a.adoc[]: open ../test/includes/chapter-a.adoc: file does not exist"))
+			})
+		})
+
 	})
 
 	Context("in final documents", func() {
@@ -1857,9 +1970,7 @@ ____`
 			})
 
 			It("with unclosed tag", func() {
-				// setup logger to write in a buffer so we can check the output
-				logs, reset := ConfigureLogger(log.WarnLevel)
-				defer reset()
+				diagnostics := types.NewDiagnostics()
 				source := `include::../../test/includes/tag-include-unclosed.adoc[tag=unclosed]`
 				expected := &types.Document{
 					Elements: []interface{}{
@@ -1879,9 +1990,14 @@ ____`
 						},
 					},
 				}
-				Expect(ParseDocument(source)).To(MatchDocument(expected))
-				// verify error in logs
-				Expect(logs).To(ContainJSONLog(log.WarnLevel, "detected unclosed tag 'unclosed' starting at line 6 of include file: ../../test/includes/tag-include-unclosed.adoc"))
+				Expect(ParseDocument(source, configuration.WithDiagnostics(diagnostics))).To(MatchDocument(expected))
+				// verify warning in diagnostics
+				Expect(diagnostics.All()).To(HaveLen(1))
+				Expect(diagnostics.All()[0].Severity).To(Equal(types.SeverityWarning))
+				Expect(diagnostics.All()[0].Message).To(And(
+					HavePrefix("detected unclosed tag 'unclosed' starting at line 6 of include file: "),
+					HaveSuffix("test/includes/tag-include-unclosed.adoc"),
+				))
 			})
 
 			It("with unknown tag", func() {
@@ -2224,7 +2340,7 @@ ____`
 				_, err := ParseDocument(source)
 				GinkgoT().Log(err.Error())
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Unresolved directive in test.adoc - include::{unknown}/unknown.adoc[leveloffset=+1]: chdir %s", filepath.Join(wd, "{unknown}"))))
+				Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Unresolved directive in test.adoc - include::{unknown}/unknown.adoc[leveloffset=+1]: open %s", filepath.Join(wd, "{unknown}/unknown.adoc"))))
 			})
 
 			It("should fail if file is missing in standalone block", func() {
@@ -2240,7 +2356,7 @@ ____`
 				_, err := ParseDocument(source)
 				GinkgoT().Log(err.Error())
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Unresolved directive in test.adoc - include::{includedir}/unknown.adoc[leveloffset=+1]: chdir %s", filepath.Join(wd, "{includedir}"))))
+				Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Unresolved directive in test.adoc - include::{includedir}/unknown.adoc[leveloffset=+1]: open %s", filepath.Join(wd, "{includedir}/unknown.adoc"))))
 			})
 
 			It("should fail if file is missing in delimited block", func() {
@@ -2260,7 +2376,7 @@ include::{includedir}/unknown.adoc[leveloffset=+1]
 				_, err := ParseDocument(source)
 				GinkgoT().Log(err.Error())
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Unresolved directive in test.adoc - include::{includedir}/unknown.adoc[leveloffset=+1]: chdir %s", filepath.Join(wd, "{includedir}"))))
+				Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Unresolved directive in test.adoc - include::{includedir}/unknown.adoc[leveloffset=+1]: open %s", filepath.Join(wd, "{includedir}/unknown.adoc"))))
 			})
 		})
 
@@ -16,16 +16,22 @@ const bufferSize = 10
 func ParseDocument(r io.Reader, config *configuration.Configuration, opts ...Option) (*types.Document, error) {
 	done := make(chan interface{})
 	defer close(done)
+	return processDocumentFragments(r, config, opts, done, ParseDocumentFragments(NewParseContext(config, opts...), r, done))
+}
 
+// processDocumentFragments applies all the substitutions and arrangements on the fragments of the document
+func processDocumentFragments(r io.Reader, config *configuration.Configuration, opts []Option, done <-chan interface{}, fragmentStream <-chan types.DocumentFragment) (*types.Document, error) {
 	footnotes := types.NewFootnotes()
+	footnotes.Diagnostics = config.Diagnostics
+	index := types.NewIndex()
 	doc, err := Aggregate(NewParseContext(config, opts...),
 		// SplitHeader(done,
 		FilterOut(done,
 			ArrangeLists(done,
-				CollectFootnotes(footnotes, done,
-					ApplySubstitutions(NewParseContext(config, opts...), done, // needs to be before 'ArrangeLists'
-						RefineFragments(NewParseContext(config, opts...), r, done,
-							ParseDocumentFragments(NewParseContext(config, opts...), r, done),
+				CollectIndexTerms(index, done,
+					CollectFootnotes(footnotes, done,
+						ApplySubstitutions(NewParseContext(config, opts...), done, // needs to be before 'ArrangeLists'
+							RefineFragments(NewParseContext(config, opts...), r, done, fragmentStream),
 						),
 					),
 				),
@@ -39,6 +45,18 @@ func ParseDocument(r io.Reader, config *configuration.Configuration, opts ...Opt
 	if len(footnotes.Notes) > 0 {
 		doc.Footnotes = footnotes.Notes
 	}
+	if len(index.Entries) > 0 {
+		doc.Index = index
+	}
+	if !config.SafeMode.AllowsPassthroughs() {
+		if err := DisablePassthroughs(doc); err != nil {
+			return nil, err
+		}
+	}
+	if err := ApplyExtensions(doc, config.Extensions); err != nil {
+		return nil, err
+	}
+	CheckCrossReferences(config.Diagnostics, doc)
 	if log.IsLevelEnabled(log.DebugLevel) {
 		log.Debugf("parsed document:\n%s", spew.Sdump(doc))
 	}
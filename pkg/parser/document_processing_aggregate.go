@@ -35,7 +35,12 @@ func aggregate(ctx *ParseContext, fragmentStream <-chan types.DocumentFragment)
 	}
 	for f := range fragmentStream {
 		if f.Error != nil {
-			log.WithField("start_offset", f.Position.Start).WithField("end_offset", f.Position.End).Error(f.Error)
+			log.WithField("start_offset", f.Position.Start).WithField("end_offset", f.Position.End).Debug(f.Error)
+			var r types.SourceRange
+			if f.Position != (types.Position{}) {
+				r = ctx.sourceRange(f.Position)
+			}
+			ctx.diagnostics.Errorf(types.DiagnosticParseError, r, "%v", f.Error)
 			continue
 		}
 		start := time.Now()
@@ -74,6 +79,12 @@ func aggregate(ctx *ParseContext, fragmentStream <-chan types.DocumentFragment)
 			case *types.BlankLine, *types.SinglelineComment:
 				// ignore
 			case *types.Section:
+				// custom IDs are made unique, but the duplicates are reported
+				if id, ok := e.Attributes[types.AttrID].(string); ok && id != "" {
+					if _, exists := refs[id]; exists {
+						ctx.diagnostics.Warnf(types.DiagnosticDuplicateID, e.SourceRange, "id assigned to section already in use: %s", id)
+					}
+				}
 				if err := e.ResolveID(attrs.allAttributes(), refs); err != nil {
 					return nil, err
 				}
@@ -215,6 +226,7 @@ func newPreamble(doc *types.Document) *types.Preamble {
 		case *types.Section:
 			return preamble
 		default:
+			preamble.ExtendSourceRange(e)
 			preamble.Elements = append(preamble.Elements, e)
 		}
 	}
@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// ApplyExtensions replaces the blocks and the macros handled by the block and macro processors registered in the configuration,
+// then calls the tree processors on the document
+func ApplyExtensions(doc *types.Document, extensions configuration.Extensions) error {
+	if len(extensions.BlockProcessors) > 0 || len(extensions.BlockMacroProcessors) > 0 || len(extensions.InlineMacroProcessors) > 0 {
+		if err := replaceElements(doc, processExtensions(extensions)); err != nil {
+			return err
+		}
+	}
+	for _, process := range extensions.TreeProcessors {
+		if err := process(doc); err != nil {
+			return errors.Wrap(err, "unable to process the document")
+		}
+	}
+	log.WithField("pipeline_task", "apply_extensions").Debug("done")
+	return nil
+}
+
+// processExtensions returns the replacer which replaces the blocks and the macros handled by the given processors
+func processExtensions(extensions configuration.Extensions) elementReplacer {
+	return func(element interface{}) (interface{}, bool, error) {
+		switch e := element.(type) {
+		case *types.UserMacro:
+			processors := extensions.InlineMacroProcessors
+			if e.Kind == types.BlockMacro {
+				processors = extensions.BlockMacroProcessors
+			}
+			if process, found := processors[e.Name]; found {
+				r, err := process(e)
+				if err != nil {
+					return nil, false, errors.Wrapf(err, "unable to process macro '%s'", e.Name)
+				}
+				return r, true, nil
+			}
+		case types.WithAttributes:
+			if style, ok := e.GetAttributes()[types.AttrStyle].(string); ok {
+				if process, found := extensions.BlockProcessors[style]; found {
+					r, err := process(e)
+					if err != nil {
+						return nil, false, errors.Wrapf(err, "unable to process block with style '%s'", style)
+					}
+					return r, true, nil
+				}
+			}
+		}
+		return nil, false, nil
+	}
+}
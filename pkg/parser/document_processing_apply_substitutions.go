@@ -462,7 +462,8 @@ func replaceAttributeRefsInElementsAndReparse(ctx *ParseContext, elements []inte
 			switch v := v.(type) {
 			case string:
 				elements[i] = &types.StringElement{
-					Content: v,
+					Positioned: e.Positioned, // keep the position of the reference
+					Content:    v,
 				}
 			default:
 				elements[i] = v
@@ -474,7 +475,8 @@ func replaceAttributeRefsInElementsAndReparse(ctx *ParseContext, elements []inte
 				return nil, err
 			}
 			elements[i] = &types.StringElement{
-				Content: v,
+				Positioned: e.Positioned, // keep the position of the counter
+				Content:    v,
 			}
 		case types.WithElements: // if `subs=macros,attributes`, then replace within inline macros
 			// in attributes
@@ -527,7 +529,8 @@ func replaceAttributeRefsInElements(ctx *ParseContext, elements []interface{}) (
 			switch v := v.(type) {
 			case string:
 				elements[i] = &types.StringElement{
-					Content: v,
+					Positioned: e.Positioned, // keep the position of the reference
+					Content:    v,
 				}
 			default:
 				elements[i] = v
@@ -538,7 +541,8 @@ func replaceAttributeRefsInElements(ctx *ParseContext, elements []interface{}) (
 				return nil, err
 			}
 			elements[i] = &types.StringElement{
-				Content: v,
+				Positioned: e.Positioned, // keep the position of the counter
+				Content:    v,
 			}
 		default:
 			// do nothing, keep as-is
@@ -550,7 +554,7 @@ func replaceAttributeRefsInElements(ctx *ParseContext, elements []interface{}) (
 func valueForAttributeRef(ctx *ParseContext, a *types.AttributeReference) (interface{}, bool, error) {
 	v, found := ctx.attributes.get(a.Name)
 	if !found {
-		log.Warnf("unable to find entry for attribute with key '%s' in context", a.Name)
+		ctx.diagnostics.Warnf(types.DiagnosticUnresolvedAttribute, a.SourceRange, "unable to find entry for attribute with key '%s' in context", a.Name)
 		return "{" + a.Name + "}", false, nil
 	}
 	switch v := v.(type) {
@@ -604,7 +608,7 @@ func valueForCounter(ctx *ParseContext, c *types.CounterSubstitution) (string, e
 // parseElementsWithSubstitutions parse the elements, using placeholders for existing "structured" elements (ie, not RawLine or StringElements)
 // Also, does not parse the content of the placeholders, but restores them at the end.
 func parseWithSubstitutions(content interface{}, subs *substitutions, opts ...Option) ([]interface{}, error) {
-	serialized, placeholders, err := serialize(content)
+	serialized, placeholders, sources, err := serialize(content)
 	if err != nil {
 		return nil, err
 	}
@@ -615,7 +619,7 @@ func parseWithSubstitutions(content interface{}, subs *substitutions, opts ...Op
 		log.Debugf("parsing '%s' with '%s' substitutions", serialized, subs.toString())
 	}
 	// stats := Stats{}
-	elements, err := parseContent(serialized, append(opts, GlobalStore(enabledSubstitutionsKey, subs))...) // , Statistics(&stats, "no match"), Debug(false)
+	elements, err := parseContent(serialized, append(opts, GlobalStore(enabledSubstitutionsKey, subs), WithSourceMap(sources))...) // , Statistics(&stats, "no match"), Debug(false)
 	if err != nil {
 		return nil, err
 	}
@@ -651,17 +655,21 @@ func parseWithSubstitutions(content interface{}, subs *substitutions, opts ...Op
 	return elements, nil
 }
 
-func serialize(content interface{}) ([]byte, *placeholders, error) {
+// serialize the given content, replacing the "structured" elements with placeholders,
+// and returns the map of the serialized content to its position in the source
+func serialize(content interface{}) ([]byte, *placeholders, *types.SourceMap, error) {
 	if log.IsLevelEnabled(log.DebugLevel) {
 		log.Debugf("serializing:\n%v", spew.Sdump(content))
 	}
 	placeholders := newPlaceholders()
 	switch content := content.(type) {
 	case string:
-		return []byte(content), placeholders, nil
+		return []byte(content), placeholders, nil, nil
 	case []interface{}:
 		result := bytes.NewBuffer(nil)
+		sources := newSourceMapBuilder()
 		for _, element := range content {
+			l := result.Len()
 			switch element := element.(type) {
 			case *types.RawLine:
 				result.WriteString(element.Content)
@@ -676,13 +684,14 @@ func serialize(content interface{}) ([]byte, *placeholders, error) {
 				p := placeholders.add(element)
 				result.WriteString(p.String())
 			}
+			sources.add(element, result.Len()-l)
 		}
 		// if log.IsLevelEnabled(log.DebugLevel) {
 		// 	log.Debugf("serialized lines: '%s'\nplaceholders: %v", result.Bytes(), spew.Sdump(placeholders.elements))
 		// }
-		return result.Bytes(), placeholders, nil
+		return result.Bytes(), placeholders, sources.build(result.Bytes()), nil
 	default:
-		return nil, nil, fmt.Errorf("unexpected type of content to serialize: %T", content)
+		return nil, nil, nil, fmt.Errorf("unexpected type of content to serialize: %T", content)
 	}
 }
 
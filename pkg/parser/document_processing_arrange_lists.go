@@ -58,6 +58,12 @@ func arrangeListElements(elements []interface{}) ([]interface{}, error) {
 				return nil, err
 			}
 			result = append(result, e)
+		case *types.Table:
+			log.Debug("checking elements in Table")
+			if err := arrangeListElementsInTable(e); err != nil {
+				return nil, err
+			}
+			result = append(result, e)
 		case *types.ListElements:
 			log.Debug("arranging list elements in ListElements")
 			l, err := doArrangeListElements(e.Elements)
@@ -75,6 +81,24 @@ func arrangeListElements(elements []interface{}) ([]interface{}, error) {
 	return result, nil
 }
 
+// arranges the list elements in the cells of the given table (eg: cells with the AsciiDoc style)
+func arrangeListElementsInTable(t *types.Table) error {
+	rows := append([]*types.TableRow{t.Header, t.Footer}, t.Rows...)
+	for _, r := range rows {
+		if r == nil {
+			continue
+		}
+		for _, c := range r.Cells {
+			elements, err := arrangeListElements(c.Elements)
+			if err != nil {
+				return err
+			}
+			c.Elements = elements
+		}
+	}
+	return nil
+}
+
 func doArrangeListElements(elements []interface{}) (interface{}, error) {
 	lists := newListStack() // so we can support delimited blocks in list elements, etc.
 
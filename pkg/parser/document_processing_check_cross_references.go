@@ -0,0 +1,30 @@
+package parser
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+)
+
+// CheckCrossReferences reports the internal cross references to IDs which are not defined in the document
+func CheckCrossReferences(diagnostics *types.Diagnostics, doc *types.Document) {
+	ids := map[string]bool{}
+	for id := range doc.ElementReferences {
+		ids[id] = true
+	}
+	xrefs := []*types.InternalCrossReference{}
+	types.Walk(doc, func(element interface{}) bool {
+		if e, ok := element.(types.WithAttributes); ok {
+			if id, ok := e.GetAttributes()[types.AttrID].(string); ok {
+				ids[id] = true
+			}
+		}
+		if xref, ok := element.(*types.InternalCrossReference); ok {
+			xrefs = append(xrefs, xref)
+		}
+		return true
+	})
+	for _, xref := range xrefs {
+		if id, ok := xref.ID.(string); ok && !ids[id] {
+			diagnostics.Warnf(types.DiagnosticUnresolvedCrossReference, xref.SourceRange, "possible invalid reference: %s", id)
+		}
+	}
+}
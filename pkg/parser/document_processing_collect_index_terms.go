@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// CollectIndexTerms pipeline task which assigns an ID to each index term (visible or concealed)
+// and records its occurrence in the given document index
+func CollectIndexTerms(index *types.Index, done <-chan interface{}, fragmentStream <-chan types.DocumentFragment) chan types.DocumentFragment {
+	processedFragmentStream := make(chan types.DocumentFragment, bufferSize)
+	go func() {
+		defer close(processedFragmentStream)
+		c := &indexTermsCollector{
+			index: index,
+		}
+		for f := range fragmentStream {
+			select {
+			case <-done:
+				log.WithField("pipeline_stage", "collect_index_terms").Debug("received 'done' signal")
+				return
+			case processedFragmentStream <- c.collect(f):
+			}
+		}
+		log.WithField("pipeline_stage", "collect_index_terms").Debug("done")
+	}()
+	return processedFragmentStream
+}
+
+// indexTermsCollector records the index terms, along with the title of the section
+// (or of the document) in which they occur
+type indexTermsCollector struct {
+	index *types.Index
+	title []interface{} // the title of the current section
+}
+
+func (c *indexTermsCollector) collect(f types.DocumentFragment) types.DocumentFragment {
+	if f.Error != nil {
+		log.Debugf("skipping index terms")
+		return f
+	}
+	for _, e := range f.Elements {
+		c.collectInElement(e)
+	}
+	return f
+}
+
+func (c *indexTermsCollector) collectInElement(element interface{}) {
+	switch e := element.(type) {
+	case *types.IndexTerm, *types.ConcealedIndexTerm:
+		c.index.Reference(e, c.title)
+	case []interface{}:
+		for _, elmt := range e {
+			c.collectInElement(elmt)
+		}
+	case *types.LabeledListElement:
+		c.collectInElement(e.Term)
+		c.collectInElement(e.Elements)
+	case *types.Table:
+		if e.Header != nil {
+			c.collectInElement(e.Header)
+		}
+		c.collectInElement(e.GetElements())
+		if e.Footer != nil {
+			c.collectInElement(e.Footer)
+		}
+	case types.WithElements:
+		switch e := e.(type) {
+		case *types.DocumentHeader:
+			c.title = e.Title
+		case *types.Section:
+			c.title = e.Title
+		}
+		if t, ok := e.(types.WithTitle); ok {
+			c.collectInElement(t.GetTitle())
+		}
+		c.collectInElement(e.GetElements())
+	}
+}
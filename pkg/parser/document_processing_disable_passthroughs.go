@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DisablePassthroughs replaces the passthrough macros, paragraphs and blocks (whose content is rendered as-is)
+// with their escaped content, so that no raw content (eg: HTML) ends up in the output.
+// Also, the content of the paragraphs and blocks with custom substitutions is escaped, in case the `specialchars` substitution was removed.
+// (used in the `server` and `secure` safe modes)
+func DisablePassthroughs(doc *types.Document) error {
+	err := replaceElements(doc, disablePassthrough)
+	log.WithField("pipeline_task", "disable_passthroughs").Debug("done")
+	return err
+}
+
+func disablePassthrough(element interface{}) (interface{}, bool, error) {
+	switch e := element.(type) {
+	case *types.InlinePassthrough:
+		return escapeSpecialCharacters(e.Elements), true, nil
+	case *types.Paragraph:
+		if e.Attributes.GetAsStringWithDefault(types.AttrStyle, "") == types.Passthrough {
+			delete(e.Attributes, types.AttrStyle)
+			e.Elements = escapeSpecialCharacters(e.Elements)
+			return e, true, nil
+		}
+		if _, found := e.Attributes[types.AttrSubstitutions]; found {
+			elements, err := escapeAllSpecialCharacters(e.Elements)
+			if err != nil {
+				return nil, false, err
+			}
+			e.Elements = elements
+			return e, true, nil
+		}
+	case *types.DelimitedBlock:
+		if e.Kind == types.Passthrough {
+			// content is rendered in a literal block
+			e.Kind = types.Literal
+			e.Elements = escapeSpecialCharacters(e.Elements)
+			return e, true, nil
+		}
+		if _, found := e.Attributes[types.AttrSubstitutions]; found {
+			elements, err := escapeAllSpecialCharacters(e.Elements)
+			if err != nil {
+				return nil, false, err
+			}
+			e.Elements = elements
+			return e, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// escapeAllSpecialCharacters escapes the special characters in the given elements, and in their children (recursively)
+func escapeAllSpecialCharacters(elements []interface{}) ([]interface{}, error) {
+	for _, element := range elements {
+		if e, ok := element.(types.WithElements); ok {
+			children, err := escapeAllSpecialCharacters(e.GetElements())
+			if err != nil {
+				return nil, err
+			}
+			if err := e.SetElements(children); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return escapeSpecialCharacters(elements), nil
+}
+
+// escapeSpecialCharacters splits the content of the given string elements on the special characters (`<`, `>` and `&`),
+// so that these latter are escaped during rendering
+func escapeSpecialCharacters(elements []interface{}) []interface{} {
+	result := make([]interface{}, 0, len(elements))
+	for _, element := range elements {
+		s, ok := element.(*types.StringElement)
+		if !ok || !strings.ContainsAny(s.Content, "<>&") {
+			result = append(result, element)
+			continue
+		}
+		content := s.Content
+		for {
+			i := strings.IndexAny(content, "<>&")
+			if i < 0 {
+				break
+			}
+			if i > 0 {
+				result = append(result, &types.StringElement{
+					Content: content[:i],
+				})
+			}
+			result = append(result, &types.SpecialCharacter{
+				Name: content[i : i+1],
+			})
+			content = content[i+1:]
+		}
+		if content != "" {
+			result = append(result, &types.StringElement{
+				Content: content,
+			})
+		}
+	}
+	return result
+}
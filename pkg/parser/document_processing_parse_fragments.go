@@ -18,60 +18,87 @@ func ParseDocumentFragments(ctx *ParseContext, source io.Reader, done <-chan int
 			resultStream <- types.NewErrorFragment(types.Position{}, err)
 			return
 		}
-		p := newParser(ctx.filename, b, ctx.opts...) // we want to parse block attributes to detect AttributeReferences
-		if err := p.setup(g); err != nil {
+		p, err := newFragmentParser(ctx, b)
+		if err != nil {
 			resultStream <- types.NewErrorFragment(types.Position{}, err)
 			return
 		}
 		log.WithField("pipeline_task", "document_parsing").Debug("start of document parsing")
 	parsing:
 		for {
-			// if log.IsLevelEnabled(log.DebugLevel) {
-			// 	log.Debugf("starting new fragment at line %d", p.pt.line)
-			// }
-			start := time.Now()
-			if log.IsLevelEnabled(log.DebugLevel) {
-				log.Debugf("parsing fragment starting at p.pt.line:%d / p.cur.pos.line:%d", p.pt.line, p.cur.pos.line)
-			}
-			startOffset := p.pt.offset
-			element, err := p.next()
-			endOffset := p.pt.offset
-			p := types.Position{
-				Start: startOffset,
-				End:   endOffset,
-			}
-			if err != nil {
-				log.WithError(err).Error("error while parsing")
-				resultStream <- types.NewErrorFragment(p, err)
+			f, found := p.nextFragment()
+			if !found {
 				break parsing
 			}
-			if element == nil {
-				break parsing
-			}
-			f := types.DocumentFragment{
-				Position: p,
-			}
-			if elements, ok := element.([]interface{}); ok {
-				f.Elements = elements
-			} else {
-				f.Elements = []interface{}{element}
-			}
-			if log.IsLevelEnabled(log.DebugLevel) {
-				log.Debugf("parsed fragment:\n%s", spew.Sdump(f))
-			}
-			log.Debugf("time to parse fragment at %d: %d microseconds", f.Position.Start, time.Since(start).Microseconds())
 			select {
 			case <-done:
 				log.Debug("exiting the document parsing routine")
 				break parsing // stops/exits the go routine
 			case resultStream <- f:
 			}
+			if f.Error != nil {
+				break parsing
+			}
 		}
 		log.WithField("pipeline_task", "document_parsing").Debug("end of document parsing")
 	}()
 	return resultStream
 }
 
+// newFragmentParser returns a parser of the fragments of the given content
+func newFragmentParser(ctx *ParseContext, b []byte) (*parser, error) {
+	p := newParser(ctx.filename, b, ctx.opts...) // we want to parse block attributes to detect AttributeReferences
+	if err := p.setup(g); err != nil {
+		return nil, err
+	}
+	// map the content to its position in the source, using the map provided by the caller if it matches the content
+	if m, ok := p.cur.globalStore[sourceMapKey].(*types.SourceMap); ok && m != nil && m.Matches(b) {
+		m.SetContent(b)
+	} else {
+		p.cur.globalStore[sourceMapKey] = types.NewIdentitySourceMap(ctx.filename, b)
+	}
+	return p, nil
+}
+
+// nextFragment parses the next fragment of the document, which may be an error fragment,
+// or returns `false` if the end of the document was reached
+func (p *parser) nextFragment() (types.DocumentFragment, bool) {
+	// if log.IsLevelEnabled(log.DebugLevel) {
+	// 	log.Debugf("starting new fragment at line %d", p.pt.line)
+	// }
+	start := time.Now()
+	if log.IsLevelEnabled(log.DebugLevel) {
+		log.Debugf("parsing fragment starting at p.pt.line:%d / p.cur.pos.line:%d", p.pt.line, p.cur.pos.line)
+	}
+	startOffset := p.pt.offset
+	element, err := p.next()
+	endOffset := p.pt.offset
+	pos := types.Position{
+		Start: startOffset,
+		End:   endOffset,
+	}
+	if err != nil {
+		log.WithError(err).Error("error while parsing")
+		return types.NewErrorFragment(pos, err), true
+	}
+	if element == nil {
+		return types.DocumentFragment{}, false
+	}
+	f := types.DocumentFragment{
+		Position: pos,
+	}
+	if elements, ok := element.([]interface{}); ok {
+		f.Elements = elements
+	} else {
+		f.Elements = []interface{}{element}
+	}
+	if log.IsLevelEnabled(log.DebugLevel) {
+		log.Debugf("parsed fragment:\n%s", spew.Sdump(f))
+	}
+	log.Debugf("time to parse fragment at %d: %d microseconds", f.Position.Start, time.Since(start).Microseconds())
+	return f, true
+}
+
 const documentHeaderKey = "document_header"
 const frontMatterKey = "front_matter"
 
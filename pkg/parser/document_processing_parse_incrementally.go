@@ -0,0 +1,400 @@
+package parser
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// TextEdit a change in the content of a document: the bytes between the `Start` (inclusive)
+// and `End` (exclusive) offsets are replaced with the `Text`
+type TextEdit struct {
+	Start int
+	End   int
+	Text  string
+}
+
+// IncrementalDocument a document which can be parsed again after a change in its content,
+// by parsing the fragments affected by the change only (see `Update`)
+type IncrementalDocument struct {
+	Document *types.Document
+	Reparsed types.Position         // the range of the preprocessed content which was parsed during the last update (or the initial parsing)
+	source   []byte                 // the content of the document
+	content  []byte                 // the content of the document, once preprocessed
+	lines    []types.SourcePosition // the position in the source files of each line of the preprocessed content
+	config   *configuration.Configuration
+	opts     []Option
+	// the fragments of the document, as they were parsed (ie, before the substitutions and arrangements,
+	// which is why they are copied before being processed)
+	fragments []incrementalFragment
+}
+
+// incrementalFragment a fragment of the document, along with the state of the parser at the start of the fragment
+type incrementalFragment struct {
+	types.DocumentFragment
+	start savepoint
+	store storeDict
+	state storeDict
+}
+
+// ParseDocumentIncrementally preprocesses and parses the content of the reader identitied by the filename and applies all the substitutions
+// and arrangements, as `PreprocessWithSourceMap` followed by `ParseDocument` do, but also retains the fragments of the document,
+// so that the document can be updated after a change in its content.
+// The positions of the elements are the positions in the source files, so the `WithSourceMap` option is ignored.
+func ParseDocumentIncrementally(r io.Reader, config *configuration.Configuration, opts ...Option) (*IncrementalDocument, error) {
+	source, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	d := &IncrementalDocument{
+		config: config,
+		opts:   opts,
+	}
+	return d.parse(source, nil)
+}
+
+// Content returns the content of the document
+func (d *IncrementalDocument) Content() string {
+	return string(d.source)
+}
+
+// Update returns the document resulting from the given change in the content of this document.
+// The content is preprocessed again (since the change may affect a preprocessor directive, or the lines which follow a directive),
+// but only the fragments affected by the change in the preprocessed content are parsed again: the parsing resumes at the fragment preceding the change,
+// and stops once it reaches the start of a fragment which follows the change and which is parsed with the same state
+// (eg: outside of a delimited block) as before the change. The other fragments are reused, then all the substitutions
+// and arrangements are applied on the whole document (since an attribute declaration may affect the fragments which follow).
+// This document is not modified, but the problems found in the whole document are reported again in the diagnostics of the configuration.
+func (d *IncrementalDocument) Update(edit TextEdit) (*IncrementalDocument, error) {
+	if edit.Start < 0 || edit.End < edit.Start || edit.End > len(d.source) {
+		return nil, errors.Errorf("invalid edit: [%d:%d] is out of the content of %d bytes", edit.Start, edit.End, len(d.source))
+	}
+	source := make([]byte, 0, len(d.source)-(edit.End-edit.Start)+len(edit.Text))
+	source = append(source, d.source[:edit.Start]...)
+	source = append(source, edit.Text...)
+	source = append(source, d.source[edit.End:]...)
+	u := &IncrementalDocument{
+		config: d.config,
+		opts:   d.opts,
+	}
+	return u.parse(source, d)
+}
+
+// parse preprocesses and parses the given source, reusing the fragments of the previous document (if any)
+// which precede or follow the change in the preprocessed content, if possible.
+func (d *IncrementalDocument) parse(source []byte, previous *IncrementalDocument) (*IncrementalDocument, error) {
+	content, sources, err := PreprocessWithSourceMap(bytes.NewReader(source), d.config, d.opts...)
+	if err != nil {
+		return nil, err
+	}
+	d.source = source
+	d.content = []byte(content)
+	d.lines = sources.Lines()
+	opts := append(append([]Option{}, d.opts...), WithSourceMap(sources))
+	p, err := newFragmentParser(NewParseContext(d.config, opts...), d.content)
+	if err != nil {
+		return nil, err
+	}
+	d.fragments = []incrementalFragment{}
+	// the change in the preprocessed content, which is not the change in the source if it affected
+	// a preprocessor directive (eg: a condition which is not satisfied anymore)
+	var edit TextEdit
+	previousFragments := []incrementalFragment{}
+	lines := 0
+	if previous != nil {
+		edit = changeOf(previous.content, d.content)
+		previousFragments = previous.fragments
+		lines = strings.Count(edit.Text, "\n") - bytes.Count(previous.content[edit.Start:edit.End], []byte("\n"))
+	}
+	// the end of a fragment may depend on the line which follows, so the parsing resumes at the fragment
+	// which precedes the first fragment that ends at or after the start of the change
+	restart := 0
+	for i, f := range previousFragments {
+		if f.Position.End >= edit.Start {
+			break
+		}
+		restart = i
+	}
+	if l := len(previousFragments); l > 0 && previousFragments[l-1].Error != nil {
+		// the parsing stopped at an error, whose message refers to its position: the whole content is parsed again
+		restart = 0
+	}
+	if restart > 0 {
+		// resume the parsing in the state at the start of the fragment, since the content before the change is unchanged
+		f := previousFragments[restart]
+		for _, g := range previousFragments[:restart] {
+			d.fragments = append(d.fragments, previous.move(g, 0, 0, d.lines))
+		}
+		p.pt = f.start
+		for k, v := range f.store {
+			if k != sourceMapKey {
+				p.cur.globalStore[k] = v
+			}
+		}
+		p.restoreState(copyStore(f.state))
+	}
+	d.Reparsed.Start = p.pt.offset
+	editEnd := edit.Start + len(edit.Text)
+	delta := len(edit.Text) - (edit.End - edit.Start)
+	// the fragments which follow the change, indexed by their start offset in the new content
+	following := map[int]int{}
+	for i, f := range previousFragments {
+		if f.Error == nil && f.Position.Start >= edit.End {
+			following[f.Position.Start+delta] = i
+		}
+	}
+	for {
+		start := p.pt.offset
+		current := incrementalFragment{
+			start: p.pt,
+			store: copyStore(p.cur.globalStore),
+			state: copyStore(p.cur.state),
+		}
+		// the remaining fragments can be reused if the parser is in the same state as when they were parsed,
+		// after the change, at the start of a line (so that the columns of the elements are unchanged)
+		if i, found := following[start]; found && start >= editEnd && (start == 0 || d.content[start-1] == '\n') && previousFragments[i].sameState(current) {
+			log.Debugf("reusing %d fragment(s) after offset %d", len(previousFragments)-i, start)
+			d.Reparsed.End = start
+			for _, f := range previousFragments[i:] {
+				d.fragments = append(d.fragments, previous.move(f, delta, lines, d.lines))
+			}
+			break
+		}
+		f, found := p.nextFragment()
+		if !found {
+			d.Reparsed.End = len(d.content)
+			break
+		}
+		current.DocumentFragment = f
+		d.fragments = append(d.fragments, current)
+		if f.Error != nil {
+			d.Reparsed.End = len(d.content)
+			break
+		}
+	}
+	log.Debugf("parsed content from offset %d to %d", d.Reparsed.Start, d.Reparsed.End)
+	done := make(chan interface{})
+	defer close(done)
+	fragmentStream := make(chan types.DocumentFragment, bufferSize)
+	go func() {
+		defer close(fragmentStream)
+		for _, f := range d.fragments {
+			select {
+			case fragmentStream <- f.copy():
+			case <-done:
+				return
+			}
+		}
+	}()
+	doc, err := processDocumentFragments(bytes.NewReader(d.content), d.config, opts, done, fragmentStream)
+	if err != nil {
+		return nil, err
+	}
+	d.Document = doc
+	return d, nil
+}
+
+// changeOf returns the change which turns the old content into the new content
+func changeOf(old, new []byte) TextEdit {
+	start := 0
+	for start < len(old) && start < len(new) && old[start] == new[start] {
+		start++
+	}
+	end := 0
+	for end < len(old)-start && end < len(new)-start && old[len(old)-1-end] == new[len(new)-1-end] {
+		end++
+	}
+	return TextEdit{
+		Start: start,
+		End:   len(old) - end,
+		Text:  string(new[start : len(new)-end]),
+	}
+}
+
+// sameState returns true if the parser was in the same state at the start of both fragments.
+// The source map and the block attributes are ignored, since they are set before being used in each fragment.
+func (f incrementalFragment) sameState(other incrementalFragment) bool {
+	if !reflect.DeepEqual(f.state, other.state) {
+		return false
+	}
+	return f.storeIncludes(other) && other.storeIncludes(f)
+}
+
+func (f incrementalFragment) storeIncludes(other incrementalFragment) bool {
+	for k, v := range f.store {
+		switch k {
+		case sourceMapKey, blockAttributesKey:
+			continue
+		}
+		if w, found := other.store[k]; !found || !reflect.DeepEqual(v, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// move returns a copy of the given fragment of this document, moved by the given number of bytes and lines in the preprocessed content,
+// and in which the positions in the source files are those of the same lines in the new preprocessed content, whose lines start at the given positions
+// (eg: the lines which follow a change in the document, but not the lines of an included file).
+// The fragment is returned as-is if it is not moved at all.
+func (d *IncrementalDocument) move(f incrementalFragment, delta, lines int, positions []types.SourcePosition) incrementalFragment {
+	moves := map[sourceLine]lineMove{}
+	first := f.start.line - 1
+	last := first + bytes.Count(d.content[f.Position.Start:f.Position.End], []byte("\n"))
+	for i := first; i <= last && i < len(d.lines) && i+lines < len(positions); i++ {
+		if m := (lineMove{from: d.lines[i], to: positions[i+lines]}); m.from != m.to && !m.from.IsZero() {
+			moves[sourceLineOf(m.from)] = m
+		}
+	}
+	if delta == 0 && lines == 0 && len(moves) == 0 {
+		return f
+	}
+	result := f
+	result.DocumentFragment = copyFragment(f.DocumentFragment, func(p types.SourcePosition) types.SourcePosition {
+		if m, found := moves[sourceLineOf(p)]; found {
+			column := p.Column - m.from.Column + m.to.Column
+			p = m.to
+			p.Column = column
+		}
+		return p
+	})
+	result.Position.Start += delta
+	result.Position.End += delta
+	result.start.offset += delta
+	result.start.line += lines
+	return result
+}
+
+// sourceLine a line in a source file
+type sourceLine struct {
+	file string
+	line int
+}
+
+func sourceLineOf(p types.SourcePosition) sourceLine {
+	return sourceLine{
+		file: p.File,
+		line: p.Line,
+	}
+}
+
+// lineMove the positions in the source files of the start of a line in the preprocessed content, before and after a change
+type lineMove struct {
+	from types.SourcePosition
+	to   types.SourcePosition
+}
+
+// copy returns a copy of the fragment, which can be processed without altering this fragment
+func (f incrementalFragment) copy() types.DocumentFragment {
+	return copyFragment(f.DocumentFragment, nil)
+}
+
+func copyStore(s storeDict) storeDict {
+	result := make(storeDict, len(s))
+	for k, v := range s {
+		result[k] = v
+	}
+	return result
+}
+
+// copyFragment returns a deep copy of the given fragment, in which the positions in the source
+// are moved with the given function (if any)
+func copyFragment(f types.DocumentFragment, move func(types.SourcePosition) types.SourcePosition) types.DocumentFragment {
+	c := &fragmentCopier{
+		move:   move,
+		copies: map[copiedPointer]reflect.Value{},
+	}
+	result := f
+	if f.Elements != nil {
+		result.Elements = c.copy(reflect.ValueOf(f.Elements)).Interface().([]interface{})
+	}
+	return result
+}
+
+type fragmentCopier struct {
+	move   func(types.SourcePosition) types.SourcePosition
+	copies map[copiedPointer]reflect.Value // the copies of the pointers, so that an element referenced twice is copied once
+}
+
+type copiedPointer struct {
+	address uintptr
+	t       reflect.Type
+}
+
+var sourcePositionType = reflect.TypeOf(types.SourcePosition{})
+
+func (c *fragmentCopier) copy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		key := copiedPointer{
+			address: v.Pointer(),
+			t:       v.Type(),
+		}
+		if result, found := c.copies[key]; found {
+			return result
+		}
+		result := reflect.New(v.Type().Elem())
+		c.copies[key] = result
+		result.Elem().Set(c.copy(v.Elem()))
+		return result
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.New(v.Type()).Elem()
+		result.Set(c.copy(v.Elem()))
+		return result
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			result.Index(i).Set(c.copy(v.Index(i)))
+		}
+		return result
+	case reflect.Array:
+		result := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			result.Index(i).Set(c.copy(v.Index(i)))
+		}
+		return result
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for i := v.MapRange(); i.Next(); {
+			result.SetMapIndex(i.Key(), c.copy(i.Value()))
+		}
+		return result
+	case reflect.Struct:
+		if v.Type() == sourcePositionType {
+			p := v.Interface().(types.SourcePosition)
+			if !p.IsZero() && c.move != nil {
+				p = c.move(p)
+			}
+			return reflect.ValueOf(p)
+		}
+		result := reflect.New(v.Type()).Elem()
+		result.Set(v) // also copies the unexported fields, as-is
+		for i := 0; i < v.NumField(); i++ {
+			if result.Field(i).CanSet() {
+				result.Field(i).Set(c.copy(v.Field(i)))
+			}
+		}
+		return result
+	default:
+		return v
+	}
+}
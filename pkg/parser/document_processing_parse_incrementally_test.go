@@ -0,0 +1,312 @@
+package parser_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc"
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/parser"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("incremental document parsing", func() {
+
+	const source = `= Title
+:name: value
+
+Preamble with {name} and *bold* text.
+
+== Section A
+
+[source,go]
+----
+func main() {
+}
+----
+
+* item 1
+* item 2
+
+:name: other
+
+[NOTE]
+====
+a note with {name}
+====
+
+=== Sub section
+
+a paragraph footnote:[a note] with ((index term)).
+
+== Section B
+
+last paragraph`
+
+	parseIncrementally := func(content string) *parser.IncrementalDocument {
+		d, err := parser.ParseDocumentIncrementally(strings.NewReader(content), configuration.NewConfiguration(configuration.WithFilename("test.adoc")))
+		Expect(err).NotTo(HaveOccurred())
+		return d
+	}
+
+	// preprocesses and parses the whole content, as `libasciidoc.Convert` does
+	parseFully := func(content string) *types.Document {
+		config := configuration.NewConfiguration(configuration.WithFilename("test.adoc"))
+		p, sources, err := parser.PreprocessWithSourceMap(strings.NewReader(content), config)
+		Expect(err).NotTo(HaveOccurred())
+		doc, err := parser.ParseDocument(strings.NewReader(p), config, parser.WithSourceMap(sources))
+		Expect(err).NotTo(HaveOccurred())
+		return doc
+	}
+
+	// returns the edit which replaces the first occurrence of `old` with `text`
+	replace := func(content, old, text string) parser.TextEdit {
+		start := strings.Index(content, old)
+		Expect(start).To(BeNumerically(">=", 0))
+		return parser.TextEdit{
+			Start: start,
+			End:   start + len(old),
+			Text:  text,
+		}
+	}
+
+	It("should parse the same document as a full parsing", func() {
+		d := parseIncrementally(source)
+		Expect(d.Document).To(Equal(parseFully(source)))
+		Expect(d.Reparsed).To(Equal(types.Position{Start: 0, End: len(source)}))
+		Expect(d.Content()).To(Equal(source))
+	})
+
+	DescribeTable("should parse the same document as a full parsing after a change",
+		func(old, text string) {
+			// given
+			d := parseIncrementally(source)
+			edit := replace(source, old, text)
+			// when
+			result, err := d.Update(edit)
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			expected := source[:edit.Start] + edit.Text + source[edit.End:]
+			Expect(result.Content()).To(Equal(expected))
+			Expect(result.Document).To(Equal(parseFully(expected)))
+		},
+		Entry("word in a paragraph", "*bold*", "_italic_"),
+		Entry("new line in a paragraph", "Preamble with", "Preamble\nwith"),
+		Entry("new paragraph", "last paragraph", "new paragraph\n\nlast paragraph"),
+		Entry("new section", "=== Sub section", "=== Other section\n\ncontent\n\n=== Sub section"),
+		Entry("section level", "=== Sub section", "== Sub section"),
+		Entry("document title", "= Title", "= Other title"),
+		Entry("removed document title", "= Title\n", ""),
+		Entry("opened delimited block", "* item 1", "....\n* item 1"),
+		Entry("removed closing delimiter", "}\n----\n", "}\n"),
+		Entry("content in a delimited block", "func main() {", "func main() { // comment"),
+		Entry("attribute declaration in the header", ":name: value", ":name: new value"),
+		Entry("attribute declaration in the body", ":name: other", ":name: changed"),
+		Entry("removed attribute declaration", ":name: other\n", ""),
+		Entry("new list item", "* item 2", "* item 2\n* item 3"),
+		Entry("attached paragraph", "* item 2\n", "* item 2\n+\n"),
+		Entry("block attributes", "[NOTE]", "[TIP]"),
+		Entry("footnote", "footnote:[a note]", "footnote:[another note]"),
+		Entry("start of the document", "= Title", "// comment\n= Title"),
+		Entry("end of the document", "last paragraph", "last paragraph\n\n----\ncode"),
+	)
+
+	It("should only parse the fragments affected by the change", func() {
+		// given
+		d := parseIncrementally(source)
+		// when
+		result, err := d.Update(replace(source, "a note with", "a warning with"))
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		// the parsing resumes at the fragment before the delimited block, and stops after the delimited block
+		start := strings.Index(source, ":name: other")
+		end := strings.Index(source, "====\n\n") + len("====\n")
+		Expect(result.Reparsed.Start).To(BeNumerically(">=", start))
+		Expect(result.Reparsed.End).To(BeNumerically("<=", end+len("warning")-len("note")+len("\n")))
+		Expect(result.Document).To(Equal(parseFully(result.Content())))
+	})
+
+	It("should parse the rest of the document when a delimited block is not closed anymore", func() {
+		// given
+		d := parseIncrementally(source)
+		// when
+		result, err := d.Update(replace(source, "====\n\n", "\n\n"))
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Reparsed.End).To(Equal(len(result.Content())))
+		Expect(result.Document).To(Equal(parseFully(result.Content())))
+	})
+
+	It("should apply successive changes", func() {
+		// given
+		d := parseIncrementally(source)
+		edits := []struct {
+			old  string
+			text string
+		}{
+			{old: "last paragraph", text: "last paragraph\n\n[NOTE]\n====\nnote"},
+			{old: "* item 1", text: "* item 0\n* item 1"},
+			{old: ":name: value", text: ":name: value\n:toc:"},
+			{old: "====\nnote", text: "====\nnote\n===="},
+			{old: "== Section B", text: "== Section C"},
+			{old: "Preamble", text: "A preamble"},
+		}
+		for _, e := range edits {
+			// when
+			var err error
+			d, err = d.Update(replace(d.Content(), e.old, e.text))
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d.Document).To(Equal(parseFully(d.Content())))
+		}
+	})
+
+	It("should not modify the previous document", func() {
+		// given
+		d := parseIncrementally(source)
+		// when
+		_, err := d.Update(replace(source, "== Section A", "== Section Z"))
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(d.Content()).To(Equal(source))
+		Expect(d.Document).To(Equal(parseFully(source)))
+		// also, the fragments of the previous document can be reused
+		result, err := d.Update(replace(source, "Section B", "Section Y"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Document).To(Equal(parseFully(result.Content())))
+	})
+
+	It("should reject a change out of the content", func() {
+		// given
+		d := parseIncrementally(source)
+		// when
+		_, err := d.Update(parser.TextEdit{
+			Start: len(source),
+			End:   len(source) + 1,
+		})
+		// then
+		Expect(err).To(MatchError(fmt.Sprintf("invalid edit: [%[1]d:%[2]d] is out of the content of %[1]d bytes", len(source), len(source)+1)))
+	})
+
+	Context("with preprocessor directives", func() {
+
+		const source = `= Title
+:flag:
+
+ifdef::flag[]
+conditional paragraph
+endif::[]
+
+include::part.adoc[]
+
+== Section
+
+ifndef::flag[]
+other paragraph
+endif::[]
+
+last paragraph with {name} and <<_included_section>>`
+
+		var filename string
+
+		BeforeEach(func() {
+			dir, err := os.MkdirTemp("", "libasciidoc-incremental")
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(os.RemoveAll, dir)
+			filename = filepath.Join(dir, "test.adoc")
+			for name, content := range map[string]string{
+				"part.adoc": `:name: value
+
+included paragraph
+
+== Included section
+
+content`,
+				"other.adoc": `== Other section
+
+other content`,
+			} {
+				Expect(os.WriteFile(filepath.Join(dir, name), []byte(content), 0600)).To(Succeed())
+			}
+		})
+
+		DescribeTable("should convert the same document as a full conversion after a change",
+			func(old, text string) {
+				// given
+				d, err := parser.ParseDocumentIncrementally(strings.NewReader(source), configuration.NewConfiguration(configuration.WithFilename(filename)))
+				Expect(err).NotTo(HaveOccurred())
+				edit := replace(source, old, text)
+				// when
+				result, err := d.Update(edit)
+				// then
+				Expect(err).NotTo(HaveOccurred())
+				expected := source[:edit.Start] + edit.Text + source[edit.End:]
+				Expect(result.Content()).To(Equal(expected))
+				config := configuration.NewConfiguration(configuration.WithFilename(filename))
+				p, sources, err := parser.PreprocessWithSourceMap(strings.NewReader(expected), config)
+				Expect(err).NotTo(HaveOccurred())
+				doc, err := parser.ParseDocument(strings.NewReader(p), config, parser.WithSourceMap(sources))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Document).To(Equal(doc))
+				// also, the rendered document is the same
+				expectedOutput := &strings.Builder{}
+				_, err = libasciidoc.Convert(strings.NewReader(expected), expectedOutput, configuration.NewConfiguration(configuration.WithFilename(filename)))
+				Expect(err).NotTo(HaveOccurred())
+				output := &strings.Builder{}
+				_, err = renderer.Render(result.Document, configuration.NewConfiguration(configuration.WithFilename(filename)), output)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output.String()).To(Equal(expectedOutput.String()))
+			},
+			Entry("removed attribute used in a condition", ":flag:\n", ""),
+			Entry("condition", "ifdef::flag[]", "ifndef::flag[]"),
+			Entry("content of a satisfied condition", "conditional paragraph", "conditional\n\nparagraphs"),
+			Entry("content of an unsatisfied condition", "other paragraph", "other\n\nparagraph"),
+			Entry("removed end of condition", "endif::[]\n\ninclude", "\ninclude"),
+			Entry("paragraph before an inclusion", "\ninclude::part.adoc[]", "\nnew paragraph\n\ninclude::part.adoc[]"),
+			Entry("included file", "include::part.adoc[]", "include::other.adoc[]"),
+			Entry("removed inclusion", "include::part.adoc[]\n", ""),
+			Entry("new inclusion", "last paragraph", "include::other.adoc[]\n\nlast paragraph"),
+			Entry("section after an inclusion", "== Section", "== Other section"),
+		)
+
+		It("should report the positions in the included file", func() {
+			// given
+			d, err := parser.ParseDocumentIncrementally(strings.NewReader(source), configuration.NewConfiguration(configuration.WithFilename(filename)))
+			Expect(err).NotTo(HaveOccurred())
+			// when
+			result, err := d.Update(replace(source, "== Section", "new paragraph\n\n== Section"))
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			var included, section types.SourceRange
+			types.Walk(result.Document, func(element interface{}) bool {
+				if s, ok := element.(*types.Section); ok {
+					switch s.Level {
+					case 1:
+						if included.IsZero() {
+							included = s.SourceRange
+						} else {
+							section = s.SourceRange
+						}
+					}
+				}
+				return true
+			})
+			Expect(included.Start).To(Equal(types.SourcePosition{
+				File:   filepath.Join(filepath.Dir(filename), "part.adoc"),
+				Line:   5,
+				Column: 1,
+			}))
+			Expect(section.Start).To(Equal(types.SourcePosition{
+				File:   filename,
+				Line:   12,
+				Column: 1,
+			}))
+		})
+	})
+})
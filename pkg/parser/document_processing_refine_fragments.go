@@ -2,6 +2,7 @@ package parser
 
 import (
 	"io"
+	"strings"
 	"time"
 
 	"github.com/bytesparadise/libasciidoc/pkg/types"
@@ -74,25 +75,32 @@ func refineElement(ctx *ParseContext, element interface{}) error {
 }
 
 func reparseTable(ctx *ParseContext, t *types.Table) error {
+	styles := tableColumnStyles(ctx, t)
+	// the header and footer cells only have their own style
 	if t.Header != nil {
 		for _, c := range t.Header.Cells {
-			if err := reparseTableCell(ctx, c); err != nil {
+			if err := reparseTableCell(ctx, c, c.Style()); err != nil {
 				return err
 			}
 		}
 	}
-	if t.Rows != nil {
-		for _, r := range t.Rows {
-			for _, c := range r.Cells {
-				if err := reparseTableCell(ctx, c); err != nil {
-					return err
-				}
+	positions := types.NewTableCellColumns()
+	for _, r := range t.Rows {
+		columns := positions.Next(r)
+		for i, c := range r.Cells {
+			// the style of the cell takes precedence over the style of its column
+			style := c.Style()
+			if style == "" && len(styles) > 0 {
+				style = styles[columns[i]%len(styles)]
+			}
+			if err := reparseTableCell(ctx, c, style); err != nil {
+				return err
 			}
 		}
 	}
 	if t.Footer != nil {
 		for _, c := range t.Footer.Cells {
-			if err := reparseTableCell(ctx, c); err != nil {
+			if err := reparseTableCell(ctx, c, c.Style()); err != nil {
 				return err
 			}
 		}
@@ -100,28 +108,112 @@ func reparseTable(ctx *ParseContext, t *types.Table) error {
 	return nil
 }
 
-func reparseTableCell(ctx *ParseContext, c *types.TableCell) error {
+// tableColumnStyles returns the style of each column defined in the `cols` attribute of the table, if any,
+// after organizing the cells in rows accordingly (so that the column of each cell is known).
+// Returns nil if the attribute contains an attribute reference, which is substituted later.
+func tableColumnStyles(ctx *ParseContext, t *types.Table) []types.ContentStyle {
+	v, found := t.Attributes[types.AttrCols]
+	if !found {
+		return nil
+	}
+	s, err := serializePlainText(v)
+	if err != nil || strings.Contains(s, "{") {
+		return nil
+	}
+	cols, err := Parse("", []byte(s), append(ctx.opts, Entrypoint("TableColumnsAttribute"))...)
+	if err != nil {
+		// will be reported when the attributes are processed
+		return nil
+	}
+	defs, ok := cols.([]interface{})
+	if !ok || len(defs) == 0 {
+		return nil
+	}
+	if len(t.Rows) > 0 {
+		t.SetColumnDefinitions(defs)
+	}
+	styles := []types.ContentStyle{}
+	for _, d := range defs {
+		if d, ok := d.(*types.TableColumn); ok {
+			for i := 0; i < d.Multiplier; i++ {
+				styles = append(styles, d.Style)
+			}
+		}
+	}
+	return styles
+}
+
+// reparseTableCell parses the content of the cell, according to the given style (the style of the cell or the style of its column)
+func reparseTableCell(ctx *ParseContext, c *types.TableCell, style types.ContentStyle) error {
 	log.Debugf("reparsing content of table cell")
-	switch c.Format {
-	case "a":
+	switch style {
+	case types.AsciidocStyle:
 		opts := append(ctx.opts, Entrypoint("DelimitedBlockElements"))
 		elements, err := reparseElements(c.Elements, opts...)
 		if err != nil {
 			return err
 		}
 		c.Elements = elements
-	default:
-		// wrap in a paragraph
-		c.Elements = []interface{}{
-			&types.Paragraph{
-				Elements: c.Elements,
-			},
+		for _, e := range c.Elements { // eg: nested tables
+			if err := refineElement(ctx, e); err != nil {
+				return err
+			}
 		}
+	case types.LiteralStyle:
+		// wrap in a literal block, so that only verbatim substitutions apply
+		b := &types.DelimitedBlock{
+			Kind:     types.Literal,
+			Elements: c.Elements,
+		}
+		types.SetSourceRangeOfElements(b, b.Elements)
+		c.Elements = []interface{}{b}
+	default:
+		// wrap in paragraphs, separated by the blank lines
+		c.Elements = splitTableCellParagraphs(c.Elements)
 	}
 
 	return nil
 }
 
+// splitTableCellParagraphs wraps the given lines in paragraphs,
+// starting a new paragraph after each blank line
+func splitTableCellParagraphs(lines []interface{}) []interface{} {
+	result := []interface{}{}
+	elements := []interface{}{}
+	flush := func() {
+		if len(elements) == 0 {
+			return
+		}
+		// remove the trailing `\n` of the last line of the paragraph
+		if l, ok := elements[len(elements)-1].(*types.RawLine); ok {
+			l.Content = strings.TrimSuffix(l.Content, "\n")
+		}
+		p := &types.Paragraph{
+			Elements: elements,
+		}
+		types.SetSourceRangeOfElements(p, elements)
+		result = append(result, p)
+		elements = []interface{}{}
+	}
+	for _, l := range lines {
+		if l, ok := l.(*types.RawLine); ok && strings.TrimSpace(l.Content) == "" {
+			flush()
+			continue
+		}
+		elements = append(elements, l)
+	}
+	flush()
+	if len(result) == 0 {
+		// keep an empty paragraph in empty cells
+		p := &types.Paragraph{
+			Elements: lines,
+		}
+		types.SetSourceRangeOfElements(p, lines)
+		result = append(result, p)
+	}
+	return result
+}
+
 func reparseDelimitedBlock(ctx *ParseContext, b *types.DelimitedBlock) error {
 	switch b.Kind {
 	case types.Example, types.Quote, types.Sidebar, types.Open:
@@ -142,11 +234,11 @@ func reparseDelimitedBlock(ctx *ParseContext, b *types.DelimitedBlock) error {
 }
 
 func reparseElements(elements []interface{}, opts ...Option) ([]interface{}, error) {
-	content, placeholders, err := serialize(elements)
+	content, placeholders, sources, err := serialize(elements)
 	if err != nil {
 		return nil, err
 	}
-	elmts, err := Parse("", content, opts...)
+	elmts, err := Parse("", content, append(opts, WithSourceMap(sources))...)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to parse elements") // ignore error (malformed content)
 	}
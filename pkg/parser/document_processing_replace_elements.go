@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+)
+
+// elementReplacer returns the replacement of the given element and `true` if the element is replaced,
+// or `false` if the element is kept (in which case its children are processed).
+// The replacement can be nil (to remove the element) or a slice of elements (to replace the element with multiple elements).
+type elementReplacer func(element interface{}) (interface{}, bool, error)
+
+// ReplaceElements replaces the elements of the given document (and their children, recursively) with the result of the `replace` func
+// when this latter returns `true`. The replacement can be nil (to remove the element) or a slice of elements (to replace the element with multiple elements).
+// Can be used in the tree processors (see `configuration.WithTreeProcessor()`).
+func ReplaceElements(doc *types.Document, replace func(element interface{}) (interface{}, bool, error)) error {
+	return replaceElements(doc, replace)
+}
+
+// replaceElements replaces the elements of the given document (and their children, recursively) using the given replacer
+func replaceElements(doc *types.Document, replace elementReplacer) error {
+	elements, _, err := replace.elements(doc.Elements)
+	if err != nil {
+		return err
+	}
+	doc.Elements = elements
+	for _, note := range doc.Footnotes {
+		if note.Elements, _, err = replace.elements(note.Elements); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// elements returns the given elements, in which the elements handled by the replacer were replaced,
+// and `true` if at least one element was replaced
+func (replace elementReplacer) elements(elements []interface{}) ([]interface{}, bool, error) {
+	result := make([]interface{}, 0, len(elements))
+	changed := false
+	for _, element := range elements {
+		r, replaced, err := replace(element)
+		if err != nil {
+			return nil, false, err
+		}
+		if !replaced {
+			r = element
+		}
+		changed = changed || replaced
+		switch r := r.(type) {
+		case nil:
+			// element is removed
+		case []interface{}:
+			for _, e := range r {
+				if err := replace.children(e); err != nil {
+					return nil, false, err
+				}
+			}
+			result = append(result, r...)
+		default:
+			// also process the children of the (new) element
+			if err := replace.children(r); err != nil {
+				return nil, false, err
+			}
+			result = append(result, r)
+		}
+	}
+	return result, changed, nil
+}
+
+func (replace elementReplacer) children(element interface{}) error {
+	switch e := element.(type) {
+	case *types.Preamble:
+		elements, _, err := replace.elements(e.Elements)
+		if err != nil {
+			return err
+		}
+		e.Elements = elements
+		return nil
+	case *types.List:
+		// list elements are not replaced, but their content is
+		for _, elmt := range e.Elements {
+			if err := replace.children(elmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *types.LabeledListElement:
+		term, changed, err := replace.elements(e.Term)
+		if err != nil {
+			return err
+		}
+		if changed {
+			e.Term = term
+		}
+	case *types.Table:
+		for _, row := range []*types.TableRow{e.Header, e.Footer} {
+			if row != nil {
+				if err := replace.children(row); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if e, ok := element.(types.WithTitle); ok {
+		title, changed, err := replace.elements(e.GetTitle())
+		if err != nil {
+			return err
+		}
+		if changed && len(title) > 0 {
+			e.SetTitle(title)
+		}
+	}
+	if e, ok := element.(types.WithElements); ok {
+		elements, changed, err := replace.elements(e.GetElements())
+		if err != nil {
+			return err
+		}
+		if changed {
+			return e.SetElements(elements)
+		}
+	}
+	return nil
+}
@@ -83,6 +83,14 @@ func verbatimSubstitutions() *substitutions {
 	}
 }
 
+func basicSubstitutions() *substitutions {
+	return &substitutions{
+		sequence: []string{
+			SpecialCharacters,
+		},
+	}
+}
+
 type substitutions struct {
 	sequence []string
 }
@@ -140,6 +148,8 @@ func defaultSubstitutions(b types.WithElements) *substitutions {
 			return normalSubstitutions()
 		case types.Comment, types.Passthrough:
 			return noneSubstitutions()
+		case types.Stem, types.LatexMath, types.AsciiMath:
+			return basicSubstitutions()
 		default: // includes `types.Listing`, `types.Fenced`, `types.Literal`
 			return verbatimSubstitutions()
 		}
@@ -150,6 +160,8 @@ func defaultSubstitutions(b types.WithElements) *substitutions {
 			return verbatimSubstitutions()
 		case types.Passthrough:
 			return noneSubstitutions()
+		case types.Stem, types.LatexMath, types.AsciiMath:
+			return basicSubstitutions()
 		default:
 			return normalSubstitutions()
 		}
@@ -245,6 +257,8 @@ func substitutionsFor(s string) (*substitutions, error) {
 		return noneSubstitutions(), nil
 	case "verbatim":
 		return verbatimSubstitutions(), nil
+	case "basic":
+		return basicSubstitutions(), nil
 	case "attributes", "macros", "quotes", "replacements", "post_replacements", "callouts", "specialchars":
 		return &substitutions{
 			sequence: []string{s},
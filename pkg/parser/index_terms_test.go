@@ -14,6 +14,11 @@ var _ = Describe("index terms", func() {
 
 		It("index term in existing paragraph line", func() {
 			source := `a paragraph with an ((index)) term.`
+			term := []interface{}{
+				&types.StringElement{
+					Content: "index",
+				},
+			}
 			expected := &types.Document{
 				Elements: []interface{}{
 					&types.Paragraph{
@@ -22,11 +27,8 @@ var _ = Describe("index terms", func() {
 								Content: "a paragraph with an ",
 							},
 							&types.IndexTerm{
-								Term: []interface{}{
-									&types.StringElement{
-										Content: "index",
-									},
-								},
+								ID:   "_indexterm_1",
+								Term: term,
 							},
 							&types.StringElement{
 								Content: " term.",
@@ -34,6 +36,18 @@ var _ = Describe("index terms", func() {
 						},
 					},
 				},
+				Index: &types.Index{
+					Entries: []*types.IndexEntry{
+						{
+							Term: term,
+							Occurrences: []*types.IndexOccurrence{
+								{
+									ID: "_indexterm_1",
+								},
+							},
+						},
+					},
+				},
 			}
 			Expect(ParseDocument(source)).To(MatchDocument(expected))
 		})
@@ -41,37 +55,170 @@ var _ = Describe("index terms", func() {
 		It("index term in single paragraph line", func() {
 			source := `((foo_bar_baz _italic_ normal))
 a paragraph with an index term.`
+			term := []interface{}{
+				&types.StringElement{
+					Content: "foo_bar_baz ",
+				},
+				&types.QuotedText{
+					Kind: types.SingleQuoteItalic,
+					Elements: []interface{}{
+						&types.StringElement{
+							Content: "italic",
+						},
+					},
+				},
+				&types.StringElement{
+					Content: " normal",
+				},
+			}
 			expected := &types.Document{
 				Elements: []interface{}{
 					&types.Paragraph{
 						Elements: []interface{}{
 							&types.IndexTerm{
-								Term: []interface{}{
-									&types.StringElement{
-										Content: "foo_bar_baz ",
-									},
-									&types.QuotedText{
-										Kind: types.SingleQuoteItalic,
-										Elements: []interface{}{
-											&types.StringElement{
-												Content: "italic",
-											},
+								ID:   "_indexterm_1",
+								Term: term,
+							},
+							&types.StringElement{
+								Content: "\na paragraph with an index term.",
+							},
+						},
+					},
+				},
+				Index: &types.Index{
+					Entries: []*types.IndexEntry{
+						{
+							Term: term,
+							Occurrences: []*types.IndexOccurrence{
+								{
+									ID: "_indexterm_1",
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+
+		It("same index terms in multiple paragraphs", func() {
+			source := `a ((foo)) paragraph.
+
+another ((foo)) paragraph with (((foo, bar))).`
+			term := func() []interface{} {
+				return []interface{}{
+					&types.StringElement{
+						Content: "foo",
+					},
+				}
+			}
+			expected := &types.Document{
+				Elements: []interface{}{
+					&types.Paragraph{
+						Elements: []interface{}{
+							&types.StringElement{
+								Content: "a ",
+							},
+							&types.IndexTerm{
+								ID:   "_indexterm_1",
+								Term: term(),
+							},
+							&types.StringElement{
+								Content: " paragraph.",
+							},
+						},
+					},
+					&types.Paragraph{
+						Elements: []interface{}{
+							&types.StringElement{
+								Content: "another ",
+							},
+							&types.IndexTerm{
+								ID:   "_indexterm_2",
+								Term: term(),
+							},
+							&types.StringElement{
+								Content: " paragraph with ",
+							},
+							&types.ConcealedIndexTerm{
+								ID:    "_indexterm_3",
+								Term1: "foo",
+								Term2: "bar",
+							},
+							&types.StringElement{
+								Content: ".",
+							},
+						},
+					},
+				},
+				Index: &types.Index{
+					Entries: []*types.IndexEntry{
+						{
+							Term: term(),
+							Occurrences: []*types.IndexOccurrence{
+								{
+									ID: "_indexterm_1",
+								},
+								{
+									ID: "_indexterm_2",
+								},
+							},
+							Entries: []*types.IndexEntry{
+								{
+									Term: []interface{}{
+										&types.StringElement{
+											Content: "bar",
 										},
 									},
-									&types.StringElement{
-										Content: " normal",
+									Occurrences: []*types.IndexOccurrence{
+										{
+											ID: "_indexterm_3",
+										},
 									},
 								},
 							},
-							&types.StringElement{
-								Content: "\na paragraph with an index term.",
-							},
 						},
 					},
 				},
 			}
 			Expect(ParseDocument(source)).To(MatchDocument(expected))
 		})
+
+		It("index terms in the preamble and in sections", func() {
+			source := `= The Title
+
+a ((preamble)).
+
+== First Section
+
+a ((term)) in the section.
+
+=== Second Section
+
+another (((Term))) in the sub-section.`
+			doc, err := ParseDocument(source)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(doc.Index.Entries).To(HaveLen(2))
+			Expect(doc.Index.Entries[0].Occurrences).To(Equal([]*types.IndexOccurrence{
+				{
+					ID:    "_indexterm_1",
+					Title: doc.Elements[0].(*types.DocumentHeader).Title,
+				},
+			}))
+			// terms which only differ by their case are grouped in the same entry
+			sections := doc.Elements[2].(*types.Section)
+			Expect(doc.Index.Entries[1].Key()).To(Equal("term"))
+			Expect(doc.Index.Entries[1].Occurrences).To(Equal([]*types.IndexOccurrence{
+				{
+					ID:    "_indexterm_2",
+					Title: sections.Title,
+				},
+				{
+					ID:    "_indexterm_3",
+					Title: sections.Elements[1].(*types.Section).Title,
+				},
+			}))
+		})
 	})
 })
 
@@ -89,6 +236,7 @@ var _ = Describe("concealed index terms", func() {
 								Content: "a paragraph with an index term ",
 							},
 							&types.ConcealedIndexTerm{
+								ID:    "_indexterm_1",
 								Term1: "index",
 								Term2: "term",
 								Term3: "here",
@@ -99,6 +247,40 @@ var _ = Describe("concealed index terms", func() {
 						},
 					},
 				},
+				Index: &types.Index{
+					Entries: []*types.IndexEntry{
+						{
+							Term: []interface{}{
+								&types.StringElement{
+									Content: "index",
+								},
+							},
+							Entries: []*types.IndexEntry{
+								{
+									Term: []interface{}{
+										&types.StringElement{
+											Content: "term",
+										},
+									},
+									Entries: []*types.IndexEntry{
+										{
+											Term: []interface{}{
+												&types.StringElement{
+													Content: "here",
+												},
+											},
+											Occurrences: []*types.IndexOccurrence{
+												{
+													ID: "_indexterm_1",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
 			}
 			Expect(ParseDocument(source)).To(MatchDocument(expected))
 		})
@@ -111,6 +293,7 @@ a paragraph with an index term.`
 					&types.Paragraph{
 						Elements: []interface{}{
 							&types.ConcealedIndexTerm{
+								ID:    "_indexterm_1",
 								Term1: "index",
 								Term2: "term",
 							},
@@ -120,6 +303,31 @@ a paragraph with an index term.`
 						},
 					},
 				},
+				Index: &types.Index{
+					Entries: []*types.IndexEntry{
+						{
+							Term: []interface{}{
+								&types.StringElement{
+									Content: "index",
+								},
+							},
+							Entries: []*types.IndexEntry{
+								{
+									Term: []interface{}{
+										&types.StringElement{
+											Content: "term",
+										},
+									},
+									Occurrences: []*types.IndexOccurrence{
+										{
+											ID: "_indexterm_1",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
 			}
 			Expect(ParseDocument(source)).To(MatchDocument(expected))
 		})
@@ -168,6 +168,16 @@ on 2 lines`
 		It("with a index term", func() {
 			source := "((`foo`))::\n" +
 				`This function is _untyped_.`
+			fooTerm := []interface{}{
+				&types.QuotedText{
+					Kind: types.SingleQuoteMonospace,
+					Elements: []interface{}{
+						&types.StringElement{
+							Content: "foo",
+						},
+					},
+				},
+			}
 			expected := &types.Document{
 				Elements: []interface{}{
 					&types.List{
@@ -177,16 +187,8 @@ on 2 lines`
 								Style: types.DoubleColons,
 								Term: []interface{}{
 									&types.IndexTerm{
-										Term: []interface{}{
-											&types.QuotedText{
-												Kind: types.SingleQuoteMonospace,
-												Elements: []interface{}{
-													&types.StringElement{
-														Content: "foo",
-													},
-												},
-											},
-										},
+										ID:   "_indexterm_1",
+										Term: fooTerm,
 									},
 								},
 								Elements: []interface{}{
@@ -213,6 +215,18 @@ on 2 lines`
 						},
 					},
 				},
+				Index: &types.Index{
+					Entries: []*types.IndexEntry{
+						{
+							Term: fooTerm,
+							Occurrences: []*types.IndexOccurrence{
+								{
+									ID: "_indexterm_1",
+								},
+							},
+						},
+					},
+				},
 			}
 			Expect(ParseDocument(source)).To(MatchDocument(expected))
 		})
@@ -229,6 +243,7 @@ on 2 lines`
 								Style: types.DoubleColons,
 								Term: []interface{}{
 									&types.ConcealedIndexTerm{
+										ID:    "_indexterm_1",
 										Term1: "foo",
 										Term2: "bar",
 									},
@@ -257,6 +272,27 @@ on 2 lines`
 						},
 					},
 				},
+				Index: &types.Index{
+					Entries: []*types.IndexEntry{
+						{
+							Term: []interface{}{
+								&types.StringElement{Content: "foo"},
+							},
+							Entries: []*types.IndexEntry{
+								{
+									Term: []interface{}{
+										&types.StringElement{Content: "bar"},
+									},
+									Occurrences: []*types.IndexOccurrence{
+										{
+											ID: "_indexterm_1",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
 			}
 			Expect(ParseDocument(source)).To(MatchDocument(expected))
 		})
@@ -7,7 +7,6 @@ import (
 	"strings"
 	"unicode"
 
-	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 	"github.com/davecgh/go-spew/spew"
 	log "github.com/sirupsen/logrus"
@@ -152,7 +151,7 @@ func (c *current) isInlineMacro(text string) bool {
 	default:
 		switch text {
 		// TODO: refine by checking is associated substitution is enabled?
-		case "http", "https", "mailto", "image", "link", "icon", "pass", "menu", "xref", "btn":
+		case "http", "https", "mailto", "image", "link", "icon", "pass", "menu", "xref", "btn", "stem", "latexmath", "asciimath":
 			log.Debugf("invalid inline text: '%s' (conflicts with inline macro)", text)
 			return true
 		}
@@ -248,13 +247,67 @@ func (c *current) matchBlockDelimiterLength(length int) (bool, error) {
 	return c.globalStore[blockDelimiterLengthKey] == length, nil
 }
 
+const tableDelimiterKey = "table_delimiter"
+const tableCellSeparatorKey = "table_cell_separator"
+
+// state info to indicate the delimiter of the current table, and the character that separates its cells
+// (the value of the `separator` attribute, or the first character of the delimiter by default)
+func (c *current) setTableDelimiter(delimiter string) (bool, error) {
+	c.globalStore[tableDelimiterKey] = delimiter
+	separator := delimiter
+	if s := c.tableSeparator(); s != "" {
+		separator = s
+	}
+	c.globalStore[tableCellSeparatorKey] = separator
+	return true, nil
+}
+
+// check if the delimiter matches the one that started the current table
+func (c *current) matchTableDelimiter(delimiter string) (bool, error) {
+	return c.globalStore[tableDelimiterKey] == delimiter, nil
+}
+
+// check if the given character separates the cells of the current table
+func (c *current) isTableCellSeparator(s string) bool {
+	return c.globalStore[tableCellSeparatorKey] == s
+}
+
+// check if the content of the table starting with the given delimiter is in the `csv`, `tsv` or `dsv` format
+func (c *current) isDataTable(delimiter string) bool {
+	return c.dataTableFormat(delimiter) != types.PSVFormat
+}
+
+// returns the format of the table content, based on its delimiter and its `format` attribute
+func (c *current) dataTableFormat(delimiter string) string {
+	switch delimiter {
+	case ",":
+		return types.CSVFormat
+	case ":":
+		return types.DSVFormat
+	}
+	if attrs, ok := c.globalStore[blockAttributesKey].(types.Attributes); ok {
+		switch f := attrs.GetAsStringWithDefault(types.AttrFormat, types.PSVFormat); f {
+		case types.CSVFormat, types.TSVFormat, types.DSVFormat:
+			return f
+		}
+	}
+	return types.PSVFormat
+}
+
+// returns the value of the `separator` attribute of the current table, or an empty string
+func (c *current) tableSeparator() string {
+	if attrs, ok := c.globalStore[blockAttributesKey].(types.Attributes); ok {
+		return attrs.GetAsStringWithDefault(types.AttrSeparator, "")
+	}
+	return ""
+}
+
 const usermacrosKey = "user_macros"
 
 func (c *current) hasUserMacro(name string) bool {
-	if macros, ok := c.globalStore[usermacrosKey].(map[string]configuration.MacroTemplate); ok {
-		_, found := macros[name]
-		// log.Debugf("user macro '%s' registered: %t", name, found)
-		return found
+	if macros, ok := c.globalStore[usermacrosKey].(map[string]bool); ok {
+		// log.Debugf("user macro '%s' registered: %t", name, macros[name])
+		return macros[name]
 	}
 	// log.Debugf("no user macro registered")
 	return false
@@ -20,7 +20,7 @@ var _ = Describe("block delimiter tracker", func() {
 		// given
 		t := newBlockDelimiterTracker()
 		// when
-		t.track(types.Listing, 4) // entered block
+		t.track(types.Listing, 4, types.SourceRange{}) // entered block
 		// then
 		Expect(t.stack).NotTo(BeEmpty())
 	})
@@ -29,8 +29,8 @@ var _ = Describe("block delimiter tracker", func() {
 		// given
 		t := newBlockDelimiterTracker()
 		// when
-		t.track(types.Listing, 4) // entered block
-		t.track(types.Comment, 4) // entered another block
+		t.track(types.Listing, 4, types.SourceRange{}) // entered block
+		t.track(types.Comment, 4, types.SourceRange{}) // entered another block
 		// then
 		Expect(t.stack).NotTo(BeEmpty())
 	})
@@ -39,8 +39,8 @@ var _ = Describe("block delimiter tracker", func() {
 		// given
 		t := newBlockDelimiterTracker()
 		// when
-		t.track(types.Listing, 5) // entered first block
-		t.track(types.Listing, 4) // entered second block
+		t.track(types.Listing, 5, types.SourceRange{}) // entered first block
+		t.track(types.Listing, 4, types.SourceRange{}) // entered second block
 		// then
 		Expect(t.stack).NotTo(BeEmpty())
 	})
@@ -49,8 +49,8 @@ var _ = Describe("block delimiter tracker", func() {
 		// given
 		t := newBlockDelimiterTracker()
 		// when
-		t.track(types.Listing, 4) // entered block
-		t.track(types.Listing, 4) // exited block
+		t.track(types.Listing, 4, types.SourceRange{}) // entered block
+		t.track(types.Listing, 4, types.SourceRange{}) // exited block
 		// then
 		Expect(t.stack).To(BeEmpty())
 	})
@@ -59,10 +59,10 @@ var _ = Describe("block delimiter tracker", func() {
 		// given
 		t := newBlockDelimiterTracker()
 		// when
-		t.track(types.Listing, 4) // entered first block
-		t.track(types.Comment, 4) // entered second block
-		t.track(types.Comment, 4) // existed second block
-		t.track(types.Listing, 4) // exited first block
+		t.track(types.Listing, 4, types.SourceRange{}) // entered first block
+		t.track(types.Comment, 4, types.SourceRange{}) // entered second block
+		t.track(types.Comment, 4, types.SourceRange{}) // existed second block
+		t.track(types.Listing, 4, types.SourceRange{}) // exited first block
 		// then
 		Expect(t.stack).To(BeEmpty())
 	})
@@ -71,10 +71,10 @@ var _ = Describe("block delimiter tracker", func() {
 		// given
 		t := newBlockDelimiterTracker()
 		// when
-		t.track(types.Listing, 5) // entered first block
-		t.track(types.Listing, 4) // entered second block
-		t.track(types.Listing, 4) // exited second block
-		t.track(types.Listing, 5) // exited first block
+		t.track(types.Listing, 5, types.SourceRange{}) // entered first block
+		t.track(types.Listing, 4, types.SourceRange{}) // entered second block
+		t.track(types.Listing, 4, types.SourceRange{}) // exited second block
+		t.track(types.Listing, 5, types.SourceRange{}) // exited first block
 		// then
 		Expect(t.stack).To(BeEmpty())
 	})
@@ -83,10 +83,21 @@ var _ = Describe("block delimiter tracker", func() {
 		// given
 		t := newBlockDelimiterTracker()
 		// when
-		t.track(types.Listing, 4) // entered first block
-		t.track(types.Listing, 5) // entered second block
-		t.track(types.Listing, 5) // exited second block
-		t.track(types.Listing, 4) // exited first block
+		t.track(types.Listing, 4, types.SourceRange{}) // entered first block
+		t.track(types.Listing, 5, types.SourceRange{}) // entered second block
+		t.track(types.Listing, 5, types.SourceRange{}) // exited second block
+		t.track(types.Listing, 4, types.SourceRange{}) // exited first block
+		// then
+		Expect(t.stack).To(BeEmpty())
+	})
+
+	It("should not be within delimited block anymore - case 5", func() {
+		// given
+		t := newBlockDelimiterTracker()
+		// when
+		t.track(types.Listing, 4, types.SourceRange{}) // entered verbatim block
+		t.track(types.Example, 4, types.SourceRange{}) // content of the verbatim block
+		t.track(types.Listing, 4, types.SourceRange{}) // exited verbatim block
 		// then
 		Expect(t.stack).To(BeEmpty())
 	})
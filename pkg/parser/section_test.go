@@ -1505,6 +1505,109 @@ a short preamble
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
 
+			It("book with preface, parts, chapters and appendix", func() {
+				source := `= Book
+:doctype: book
+
+[preface]
+== Preface
+
+= Part
+
+== Chapter
+
+[appendix]
+== Extra`
+				prefaceTitle := []interface{}{
+					&types.StringElement{Content: "Preface"},
+				}
+				partTitle := []interface{}{
+					&types.StringElement{Content: "Part"},
+				}
+				chapterTitle := []interface{}{
+					&types.StringElement{Content: "Chapter"},
+				}
+				appendixTitle := []interface{}{
+					&types.StringElement{Content: "Extra"},
+				}
+				expected := &types.Document{
+					Elements: []interface{}{
+						&types.DocumentHeader{
+							Title: []interface{}{
+								&types.StringElement{Content: "Book"},
+							},
+							Elements: []interface{}{
+								&types.AttributeDeclaration{
+									Name:  types.AttrDocType,
+									Value: "book",
+								},
+							},
+						},
+						&types.Section{
+							Level: 1,
+							Attributes: types.Attributes{
+								types.AttrID:    "_Preface",
+								types.AttrStyle: "preface",
+							},
+							Title: prefaceTitle,
+						},
+						&types.Section{
+							Level: 0,
+							Attributes: types.Attributes{
+								types.AttrID: "_Part",
+							},
+							Title: partTitle,
+							Elements: []interface{}{
+								&types.Section{
+									Level: 1,
+									Attributes: types.Attributes{
+										types.AttrID: "_Chapter",
+									},
+									Title: chapterTitle,
+								},
+								&types.Section{
+									Level: 1,
+									Attributes: types.Attributes{
+										types.AttrID:    "_Extra",
+										types.AttrStyle: "appendix",
+									},
+									Title: appendixTitle,
+								},
+							},
+						},
+					},
+					ElementReferences: types.ElementReferences{
+						"_Preface": prefaceTitle,
+						"_Part":    partTitle,
+						"_Chapter": chapterTitle,
+						"_Extra":   appendixTitle,
+					},
+					TableOfContents: &types.TableOfContents{
+						MaxDepth: 2,
+						Sections: []*types.ToCSection{
+							{
+								ID:    "_Preface",
+								Level: 1,
+							},
+							{
+								ID:    "_Part",
+								Level: 0,
+								Children: []*types.ToCSection{
+									{
+										ID:    "_Chapter",
+										Level: 1,
+									},
+									{
+										ID:    "_Extra",
+										Level: 1,
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
 		})
 
 		Context("invalid sections", func() {
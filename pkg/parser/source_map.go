@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+)
+
+// sourceMapKey the key in which the map of the content being parsed to its position in the source files is stored in the parser's GlobalStore
+const sourceMapKey = "source_map"
+
+// WithSourceMap parser option to map the content to parse to its position in the source file(s),
+// eg: using the SourceMap returned by `PreprocessWithSourceMap`
+func WithSourceMap(m *types.SourceMap) Option {
+	return GlobalStore(sourceMapKey, m)
+}
+
+// sourceMapOf returns the source map set with the `WithSourceMap` option in the given options, or nil if there is none
+func sourceMapOf(opts []Option) *types.SourceMap {
+	p := &parser{
+		cur: current{
+			globalStore: storeDict{},
+		},
+	}
+	for _, o := range opts {
+		o(p)
+	}
+	m, _ := p.cur.globalStore[sourceMapKey].(*types.SourceMap)
+	return m
+}
+
+// sourceRange returns the range in the source of the content between the given offsets,
+// or a zero range if the source map is unknown
+func (c *ParseContext) sourceRange(p types.Position) types.SourceRange {
+	if c.sources == nil {
+		return types.SourceRange{}
+	}
+	return c.sources.Range(p.Start, p.End)
+}
+
+func (c *current) sourceMap() (*types.SourceMap, bool) {
+	m, ok := c.globalStore[sourceMapKey].(*types.SourceMap)
+	return m, ok && m != nil
+}
+
+// withSourceRange sets the range in the source of the element which was just matched,
+// if a source map was provided for the content being parsed
+func (c *current) withSourceRange(element interface{}, err error) (interface{}, error) {
+	if err != nil {
+		return element, err
+	}
+	m, ok := c.sourceMap()
+	if !ok || !m.Covers(c.pos.offset, c.text) {
+		// no map for the content being parsed
+		return element, nil
+	}
+	r := m.Range(c.pos.offset, c.pos.offset+len(c.text))
+	switch e := element.(type) {
+	case *types.QuotedText:
+		e.SetSourceRange(r)
+		// the content is between the delimiters
+		n := len(e.Kind)
+		types.FillSourceRanges(types.SourceRange{
+			Start: types.SourcePosition{File: r.Start.File, Line: r.Start.Line, Column: r.Start.Column + n},
+			End:   types.SourcePosition{File: r.End.File, Line: r.End.Line, Column: r.End.Column - n},
+		}, e.Elements)
+	case types.WithSourceRange:
+		e.SetSourceRange(r)
+	case []*types.TableCell:
+		for _, cell := range e {
+			cell.SetSourceRange(r)
+		}
+	}
+	return element, nil
+}
+
+// withSourceRanges sets the range in the source of the inline elements which were just matched and merged,
+// if a source map was provided for the content being parsed
+func (c *current) withSourceRanges(elements []interface{}, err error) ([]interface{}, error) {
+	if err != nil {
+		return elements, err
+	}
+	m, ok := c.sourceMap()
+	if !ok || !m.Covers(c.pos.offset, c.text) {
+		// no map for the content being parsed
+		return elements, nil
+	}
+	types.FillSourceRanges(m.Range(c.pos.offset, c.pos.offset+len(c.text)), elements)
+	return elements, nil
+}
+
+// sourceMapBuilder records the position in the source of each element that is serialized
+// before being reparsed, so that the positions of the new elements can be mapped to the source
+type sourceMapBuilder struct {
+	offset int
+	m      *types.SourceMap
+}
+
+func newSourceMapBuilder() *sourceMapBuilder {
+	return &sourceMapBuilder{
+		m: types.NewSourceMap(nil),
+	}
+}
+
+// add records the position in the source of the element, which is serialized with the given length.
+// The content following the element has an unknown position in the source, unless the element is a `RawLine`
+// or a `StringElement` (whose content is serialized as-is), or until another element is added.
+func (b *sourceMapBuilder) add(element interface{}, length int) {
+	r, _ := types.SourceRangeOf(element)
+	b.m.Add(b.offset, r.Start)
+	b.offset += length
+	switch element.(type) {
+	case *types.RawLine, *types.StringElement:
+		// the content of the element was serialized as-is, so the positions within the element can be computed from its start
+	default:
+		// the next content starts at the end of the element
+		b.m.Add(b.offset, r.End)
+	}
+}
+
+func (b *sourceMapBuilder) build(content []byte) *types.SourceMap {
+	b.m.SetContent(content)
+	return b.m
+}
@@ -0,0 +1,90 @@
+package parser_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("source ranges", func() {
+
+	rangeOf := func(file string, startLine, startColumn, endLine, endColumn int) types.SourceRange {
+		return types.SourceRange{
+			Start: types.SourcePosition{
+				File:   file,
+				Line:   startLine,
+				Column: startColumn,
+			},
+			End: types.SourcePosition{
+				File:   file,
+				Line:   endLine,
+				Column: endColumn,
+			},
+		}
+	}
+
+	It("should record ranges of blocks and inline elements", func() {
+		source := `== Section A
+
+some *bold* content
+
+* item 1
+* item 2`
+		doc, err := ParseDocument(source)
+		Expect(err).NotTo(HaveOccurred())
+		section := doc.Elements[0].(*types.Section)
+		Expect(section.GetSourceRange()).To(Equal(rangeOf("test.adoc", 1, 1, 6, 9)))
+		Expect(section.Title[0].(*types.StringElement).GetSourceRange()).To(Equal(rangeOf("test.adoc", 1, 4, 1, 13)))
+		paragraph := section.Elements[0].(*types.Paragraph)
+		Expect(paragraph.GetSourceRange()).To(Equal(rangeOf("test.adoc", 3, 1, 3, 20)))
+		Expect(paragraph.Elements[0].(*types.StringElement).GetSourceRange()).To(Equal(rangeOf("test.adoc", 3, 1, 3, 6)))
+		bold := paragraph.Elements[1].(*types.QuotedText)
+		Expect(bold.GetSourceRange()).To(Equal(rangeOf("test.adoc", 3, 6, 3, 12)))
+		Expect(bold.Elements[0].(*types.StringElement).GetSourceRange()).To(Equal(rangeOf("test.adoc", 3, 7, 3, 11)))
+		Expect(paragraph.Elements[2].(*types.StringElement).GetSourceRange()).To(Equal(rangeOf("test.adoc", 3, 12, 3, 20)))
+		list := section.Elements[1].(*types.List)
+		Expect(list.GetSourceRange()).To(Equal(rangeOf("test.adoc", 5, 1, 6, 9)))
+		Expect(list.Elements[1].(*types.UnorderedListElement).GetSourceRange()).To(Equal(rangeOf("test.adoc", 6, 1, 6, 9)))
+	})
+
+	It("should record ranges of elements in delimited blocks and tables", func() {
+		source := `====
+an _example_
+====
+
+|===
+|a |b
+|===`
+		doc, err := ParseDocument(source)
+		Expect(err).NotTo(HaveOccurred())
+		example := doc.Elements[0].(*types.DelimitedBlock)
+		Expect(example.GetSourceRange()).To(Equal(rangeOf("test.adoc", 1, 1, 3, 5)))
+		paragraph := example.Elements[0].(*types.Paragraph)
+		Expect(paragraph.GetSourceRange()).To(Equal(rangeOf("test.adoc", 2, 1, 2, 13)))
+		Expect(paragraph.Elements[1].(*types.QuotedText).GetSourceRange()).To(Equal(rangeOf("test.adoc", 2, 4, 2, 13)))
+		table := doc.Elements[1].(*types.Table)
+		Expect(table.GetSourceRange()).To(Equal(rangeOf("test.adoc", 5, 1, 7, 5)))
+		cell := table.Rows[0].Cells[1]
+		Expect(cell.GetSourceRange()).To(Equal(rangeOf("test.adoc", 6, 3, 6, 6)))
+		Expect(cell.Elements[0].(*types.Paragraph).Elements[0].(*types.StringElement).GetSourceRange()).To(Equal(rangeOf("test.adoc", 6, 5, 6, 6)))
+	})
+
+	It("should record ranges in the included file", func() {
+		source := `first paragraph
+
+include::../../test/includes/tag-include.adoc[tag=section]
+
+last paragraph`
+		included := "../../test/includes/tag-include.adoc" // in the same form as the path of the root document
+		doc, err := ParseDocument(source)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(doc.Elements[0].(*types.Paragraph).GetSourceRange()).To(Equal(rangeOf("test.adoc", 1, 1, 1, 16)))
+		section := doc.Elements[1].(*types.Section)
+		Expect(section.Title[0].(*types.StringElement).GetSourceRange()).To(Equal(rangeOf(included, 3, 4, 3, 13)))
+		// last paragraph is in the section, but in another file
+		last := section.Elements[0].(*types.Paragraph)
+		Expect(last.GetSourceRange()).To(Equal(rangeOf("test.adoc", 5, 1, 5, 15)))
+	})
+})
@@ -0,0 +1,206 @@
+package parser_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("stem", func() {
+
+	Context("in final documents", func() {
+
+		Context("as delimited blocks", func() {
+
+			It("with stem style", func() {
+				source := `[stem]
+++++
+sqrt(4) < 2
+++++`
+				expected := &types.Document{
+					Elements: []interface{}{
+						&types.DelimitedBlock{
+							Kind: types.Stem,
+							Attributes: types.Attributes{
+								types.AttrStyle: types.Stem,
+							},
+							Elements: []interface{}{
+								&types.StringElement{
+									Content: "sqrt(4) ",
+								},
+								&types.SpecialCharacter{
+									Name: "<",
+								},
+								&types.StringElement{
+									Content: " 2",
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
+			It("with latexmath style, ID and title", func() {
+				source := `.an equation
+[latexmath#eq]
+++++
+C = \alpha + \beta Y^{\gamma} + \epsilon
+++++`
+				expected := &types.Document{
+					Elements: []interface{}{
+						&types.DelimitedBlock{
+							Kind: types.LatexMath,
+							Attributes: types.Attributes{
+								types.AttrStyle: types.LatexMath,
+								types.AttrID:    "eq",
+								types.AttrTitle: "an equation",
+							},
+							Elements: []interface{}{
+								&types.StringElement{
+									Content: `C = \alpha + \beta Y^{\gamma} + \epsilon`,
+								},
+							},
+						},
+					},
+					ElementReferences: types.ElementReferences{
+						"eq": "an equation",
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
+			It("with pass style", func() {
+				source := `[pass]
+++++
+<b>*bold*</b>
+++++`
+				expected := &types.Document{
+					Elements: []interface{}{
+						&types.DelimitedBlock{
+							Kind: types.Passthrough,
+							Attributes: types.Attributes{
+								types.AttrStyle: types.Passthrough,
+							},
+							Elements: []interface{}{
+								&types.StringElement{
+									Content: "<b>*bold*</b>",
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+		})
+
+		Context("as paragraphs", func() {
+
+			It("with asciimath style", func() {
+				source := `[asciimath]
+sum_(i=1)^n *i* = (n(n+1))/2`
+				expected := &types.Document{
+					Elements: []interface{}{
+						&types.Paragraph{
+							Attributes: types.Attributes{
+								types.AttrStyle: types.AsciiMath,
+							},
+							Elements: []interface{}{
+								&types.StringElement{
+									Content: "sum_(i=1)^n *i* = (n(n+1))/2",
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+		})
+
+		Context("as inline macros", func() {
+
+			It("with stem, latexmath and asciimath macros", func() {
+				source := `stem:[sqrt(4) = 2], latexmath:[C = \alpha + \beta] and asciimath:[a < b]`
+				expected := &types.Document{
+					Elements: []interface{}{
+						&types.Paragraph{
+							Elements: []interface{}{
+								&types.InlineStem{
+									Kind:    types.Stem,
+									Content: "sqrt(4) = 2",
+								},
+								&types.StringElement{
+									Content: ", ",
+								},
+								&types.InlineStem{
+									Kind:    types.LatexMath,
+									Content: `C = \alpha + \beta`,
+								},
+								&types.StringElement{
+									Content: " and ",
+								},
+								&types.InlineStem{
+									Kind:    types.AsciiMath,
+									Content: "a < b",
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
+			It("with escaped closing bracket and quoted text", func() {
+				source := `the interval stem:[[a, b\]] is *not* bold`
+				expected := &types.Document{
+					Elements: []interface{}{
+						&types.Paragraph{
+							Elements: []interface{}{
+								&types.StringElement{
+									Content: "the interval ",
+								},
+								&types.InlineStem{
+									Kind:    types.Stem,
+									Content: "[a, b]",
+								},
+								&types.StringElement{
+									Content: " is ",
+								},
+								&types.QuotedText{
+									Kind: types.SingleQuoteBold,
+									Elements: []interface{}{
+										&types.StringElement{
+											Content: "not",
+										},
+									},
+								},
+								&types.StringElement{
+									Content: " bold",
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
+			It("with unknown macro name", func() {
+				source := `math:[x]`
+				expected := &types.Document{
+					Elements: []interface{}{
+						&types.Paragraph{
+							Elements: []interface{}{
+								&types.StringElement{
+									Content: "math:[x]",
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+		})
+	})
+})
@@ -1554,6 +1554,9 @@ image::another-image.png[]
 								Cells: []*types.TableCell{
 									{
 										Format: "a",
+										Attributes: types.Attributes{
+											types.AttrStyle: types.AsciidocStyle,
+										},
 										Elements: []interface{}{
 											&types.ImageBlock{
 												Location: &types.Location{
@@ -1564,6 +1567,9 @@ image::another-image.png[]
 									},
 									{
 										Format: "a",
+										Attributes: types.Attributes{
+											types.AttrStyle: types.AsciidocStyle,
+										},
 										Elements: []interface{}{
 											&types.ImageBlock{
 												Location: &types.Location{
@@ -1611,6 +1617,9 @@ image::another-image.png[]
 								Cells: []*types.TableCell{
 									{
 										Format: "a",
+										Attributes: types.Attributes{
+											types.AttrStyle: types.AsciidocStyle,
+										},
 										Elements: []interface{}{
 											&types.ImageBlock{
 												Attributes: types.Attributes{
@@ -1625,6 +1634,9 @@ image::another-image.png[]
 									},
 									{
 										Format: "a",
+										Attributes: types.Attributes{
+											types.AttrStyle: types.AsciidocStyle,
+										},
 										Elements: []interface{}{
 											&types.ImageBlock{
 												Attributes: types.Attributes{
@@ -1650,6 +1662,635 @@ image::another-image.png[]
 			Expect(ParseDocument(source)).To(MatchDocument(expected))
 		})
 	})
+
+	Context("with cell specifiers", func() {
+
+		It("with spans, duplication, alignments and style", func() {
+			source := `|===
+|a 2+|b
+.2+^.>s|c |d |e
+|f |g
+3*|h
+|===`
+			expected := &types.Document{
+				Elements: []interface{}{
+					&types.Table{
+						Rows: []*types.TableRow{
+							{
+								Cells: []*types.TableCell{
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "a",
+													},
+												},
+											},
+										},
+									},
+									{
+										Attributes: types.Attributes{
+											types.AttrColspan: 2,
+										},
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "b",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+							{
+								Cells: []*types.TableCell{
+									{
+										Format: "s",
+										Attributes: types.Attributes{
+											types.AttrRowspan: 2,
+											types.AttrHAlign:  types.HAlignCenter,
+											types.AttrVAlign:  types.VAlignBottom,
+											types.AttrStyle:   types.StrongStyle,
+										},
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "c",
+													},
+												},
+											},
+										},
+									},
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "d",
+													},
+												},
+											},
+										},
+									},
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "e",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+							{
+								Cells: []*types.TableCell{
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "f",
+													},
+												},
+											},
+										},
+									},
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "g",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+							{
+								Cells: []*types.TableCell{
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "h",
+													},
+												},
+											},
+										},
+									},
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "h",
+													},
+												},
+											},
+										},
+									},
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "h",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+
+		It("with specifier preceded by content", func() {
+			source := `|===
+|a2+|b |c m|d
+|===`
+			expected := &types.Document{
+				Elements: []interface{}{
+					&types.Table{
+						Rows: []*types.TableRow{
+							{
+								Cells: []*types.TableCell{
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "a2+",
+													},
+												},
+											},
+										},
+									},
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "b",
+													},
+												},
+											},
+										},
+									},
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "c",
+													},
+												},
+											},
+										},
+									},
+									{
+										Format: "m",
+										Attributes: types.Attributes{
+											types.AttrStyle: types.MonospaceStyle,
+										},
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "d",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+
+		It("with literal style", func() {
+			source := `|===
+l|*not bold*
+|===`
+			expected := &types.Document{
+				Elements: []interface{}{
+					&types.Table{
+						Rows: []*types.TableRow{
+							{
+								Cells: []*types.TableCell{
+									{
+										Format: "l",
+										Attributes: types.Attributes{
+											types.AttrStyle: types.LiteralStyle,
+										},
+										Elements: []interface{}{
+											&types.DelimitedBlock{
+												Kind: types.Literal,
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "*not bold*",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+	})
+
+	Context("nested", func() {
+
+		It("in AsciiDoc cell", func() {
+			source := `|===
+a|
+!===
+!a !b
+!===
+|===`
+			expected := &types.Document{
+				Elements: []interface{}{
+					&types.Table{
+						Rows: []*types.TableRow{
+							{
+								Cells: []*types.TableCell{
+									{
+										Format: "a",
+										Attributes: types.Attributes{
+											types.AttrStyle: types.AsciidocStyle,
+										},
+										Elements: []interface{}{
+											&types.Table{
+												Rows: []*types.TableRow{
+													{
+														Cells: []*types.TableCell{
+															{
+																Elements: []interface{}{
+																	&types.Paragraph{
+																		Elements: []interface{}{
+																			&types.StringElement{
+																				Content: "a",
+																			},
+																		},
+																	},
+																},
+															},
+															{
+																Elements: []interface{}{
+																	&types.Paragraph{
+																		Elements: []interface{}{
+																			&types.StringElement{
+																				Content: "b",
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+	})
+
+	Context("with custom separator", func() {
+
+		It("with broken bar", func() {
+			source := `[separator=¦]
+|===
+¦a ¦b|c
+|===`
+			expected := &types.Document{
+				Elements: []interface{}{
+					&types.Table{
+						Attributes: types.Attributes{
+							types.AttrSeparator: "¦",
+						},
+						Rows: []*types.TableRow{
+							{
+								Cells: []*types.TableCell{
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "a",
+													},
+												},
+											},
+										},
+									},
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "b|c",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+	})
+
+	Context("with data formats", func() {
+
+		It("csv with shorthand delimiter, header and quoted fields", func() {
+			source := `,===
+Artist,Track
+
+Baauer, "Harlem Shake, the song"
+The Lumineers,"Ho
+Hey"
+,===`
+			expected := &types.Document{
+				Elements: []interface{}{
+					&types.Table{
+						Header: &types.TableRow{
+							Cells: []*types.TableCell{
+								{
+									Elements: []interface{}{
+										&types.Paragraph{
+											Elements: []interface{}{
+												&types.StringElement{
+													Content: "Artist",
+												},
+											},
+										},
+									},
+								},
+								{
+									Elements: []interface{}{
+										&types.Paragraph{
+											Elements: []interface{}{
+												&types.StringElement{
+													Content: "Track",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						Rows: []*types.TableRow{
+							{
+								Cells: []*types.TableCell{
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "Baauer",
+													},
+												},
+											},
+										},
+									},
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "Harlem Shake, the song",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+							{
+								Cells: []*types.TableCell{
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "The Lumineers",
+													},
+												},
+											},
+										},
+									},
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "Ho\nHey",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+
+		It("tsv with format attribute", func() {
+			source := "[format=tsv]\n|===\na\tb\nc\td\n|==="
+			expected := &types.Document{
+				Elements: []interface{}{
+					&types.Table{
+						Attributes: types.Attributes{
+							types.AttrFormat: "tsv",
+						},
+						Rows: []*types.TableRow{
+							{
+								Cells: []*types.TableCell{
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "a",
+													},
+												},
+											},
+										},
+									},
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "b",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+							{
+								Cells: []*types.TableCell{
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "c",
+													},
+												},
+											},
+										},
+									},
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "d",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+
+		It("dsv with custom separator and escaped separator", func() {
+			source := `[format=dsv,separator=;]
+|===
+a;b\;c
+|===`
+			expected := &types.Document{
+				Elements: []interface{}{
+					&types.Table{
+						Attributes: types.Attributes{
+							types.AttrFormat:    "dsv",
+							types.AttrSeparator: ";",
+						},
+						Rows: []*types.TableRow{
+							{
+								Cells: []*types.TableCell{
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "a",
+													},
+												},
+											},
+										},
+									},
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "b;c",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+
+		It("psv with format attribute", func() {
+			source := `[format=psv]
+|===
+|a |b
+|===`
+			expected := &types.Document{
+				Elements: []interface{}{
+					&types.Table{
+						Attributes: types.Attributes{
+							types.AttrFormat: "psv",
+						},
+						Rows: []*types.TableRow{
+							{
+								Cells: []*types.TableCell{
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "a",
+													},
+												},
+											},
+										},
+									},
+									{
+										Elements: []interface{}{
+											&types.Paragraph{
+												Elements: []interface{}{
+													&types.StringElement{
+														Content: "b",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+	})
 })
 
 var _ = Describe("table cols", func() {
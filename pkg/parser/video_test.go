@@ -0,0 +1,120 @@
+package parser_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("video and audio blocks", func() {
+
+	Context("in final documents", func() {
+
+		It("video without attributes", func() {
+			source := "video::videos/intro.mp4[]"
+			expected := &types.Document{
+				Elements: []interface{}{
+					&types.VideoBlock{
+						Location: &types.Location{
+							Path: "videos/intro.mp4",
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+
+		It("video with positional and named attributes", func() {
+			source := `.A video
+[#intro]
+video::intro.mp4[poster.png,640,480,start=60,end=140,opts="autoplay,loop"]`
+			expected := &types.Document{
+				Elements: []interface{}{
+					&types.VideoBlock{
+						Attributes: types.Attributes{
+							types.AttrTitle:  "A video",
+							types.AttrID:     "intro",
+							types.AttrPoster: "poster.png",
+							types.AttrWidth:  "640",
+							types.AttrHeight: "480",
+							types.AttrStart:  "60",
+							types.AttrEnd:    "140",
+							types.AttrOptions: types.Options{
+								"autoplay",
+								"loop",
+							},
+						},
+						Location: &types.Location{
+							Path: "intro.mp4",
+						},
+					},
+				},
+				ElementReferences: types.ElementReferences{
+					"intro": "A video",
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+
+		It("video on YouTube with attribute substitution", func() {
+			source := `:video-id: rPQoq7ThGAU
+
+video::{video-id}[youtube]`
+			expected := &types.Document{
+				Elements: []interface{}{
+					&types.AttributeDeclaration{
+						Name:  "video-id",
+						Value: "rPQoq7ThGAU",
+					},
+					&types.VideoBlock{
+						Attributes: types.Attributes{
+							types.AttrPoster: types.YouTube,
+						},
+						Location: &types.Location{
+							Path: "rPQoq7ThGAU",
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+
+		It("audio with options", func() {
+			source := `audio::sounds/ocean.wav[options="autoplay,nocontrols"]`
+			expected := &types.Document{
+				Elements: []interface{}{
+					&types.AudioBlock{
+						Attributes: types.Attributes{
+							types.AttrOptions: types.Options{
+								"autoplay",
+								"nocontrols",
+							},
+						},
+						Location: &types.Location{
+							Path: "sounds/ocean.wav",
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+
+		It("video macro within paragraph", func() {
+			source := `a video::intro.mp4[] in a paragraph`
+			expected := &types.Document{
+				Elements: []interface{}{
+					&types.Paragraph{
+						Elements: []interface{}{
+							&types.StringElement{
+								Content: "a video::intro.mp4[] in a paragraph",
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+	})
+})
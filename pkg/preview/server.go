@@ -0,0 +1,238 @@
+package preview
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bytesparadise/libasciidoc"
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// LiveReloadPath the path of the stream of server-sent events which notify the browsers that the page should be reloaded
+const LiveReloadPath = "/_livereload"
+
+const liveReloadScript = `<script>new EventSource("` + LiveReloadPath + `").onmessage = function() { location.reload(); };</script>`
+
+// Server an HTTP server which renders the Asciidoc documents on demand, and which notifies the browsers
+// when the documents (or the files they include) changed, so that the pages are reloaded.
+type Server struct {
+	root     string                  // the document to render, or the directory containing the documents to render
+	settings []configuration.Setting // the settings of the configuration used to render the documents
+	watcher  *Watcher
+	mutex    sync.Mutex
+	clients  map[chan string]bool // the channels of the browsers listening to the live-reload events
+}
+
+// NewServer returns a new server which renders the given document, or the documents in the given directory,
+// with the given settings
+func NewServer(root string, settings ...configuration.Setting) *Server {
+	return &Server{
+		root:     root,
+		settings: settings,
+		watcher:  NewWatcher(),
+		clients:  map[chan string]bool{},
+	}
+}
+
+// Watch checks the rendered documents and the files they include at the given interval,
+// and notifies the browsers when one of them changed, until the given context is done
+func (s *Server) Watch(ctx context.Context, interval time.Duration) {
+	s.watcher.Run(ctx, interval, func(targets []string) {
+		log.Infof("changes detected in %s", strings.Join(targets, ", "))
+		s.notify(strings.Join(targets, ","))
+	})
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	p := path.Clean("/" + r.URL.Path)
+	if p == LiveReloadPath {
+		s.liveReload(w, r)
+		return
+	}
+	// the hidden files and directories (eg: `.git`) are not listed, and not served either
+	for _, segment := range strings.Split(p, "/") {
+		if hidden(segment) {
+			http.NotFound(w, r)
+			return
+		}
+	}
+	info, err := os.Stat(s.root)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !info.IsDir() {
+		// single document, along with the files (eg: images) in its directory
+		if p == "/" || p == "/"+outputName(filepath.Base(s.root)) {
+			s.render(w, s.root)
+			return
+		}
+		s.serveFile(w, r, filepath.Join(filepath.Dir(s.root), filepath.FromSlash(p)))
+		return
+	}
+	s.serveFile(w, r, filepath.Join(s.root, filepath.FromSlash(p)))
+}
+
+// serveFile renders the document with the given name (or the document from which the HTML file with the given name is generated),
+// or lists the documents in the directory with the given name, or serves the file with the given name as-is
+func (s *Server) serveFile(w http.ResponseWriter, r *http.Request, name string) {
+	if strings.HasSuffix(name, ".html") {
+		if source := strings.TrimSuffix(name, ".html") + ".adoc"; isFile(source) {
+			s.render(w, source)
+			return
+		}
+	}
+	info, err := os.Stat(name)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	switch {
+	case info.IsDir():
+		if index := filepath.Join(name, "index.adoc"); isFile(index) {
+			s.render(w, index)
+			return
+		}
+		s.list(w, name)
+	case filepath.Ext(name) == ".adoc":
+		s.render(w, name)
+	default:
+		http.ServeFile(w, r, name)
+	}
+}
+
+// render converts the given document, and watches the files from which it was read
+func (s *Server) render(w http.ResponseWriter, filename string) {
+	config := configuration.NewConfiguration(append(append([]configuration.Setting{
+		configuration.WithHeaderFooter(true),
+	}, s.settings...),
+		configuration.WithFilename(filename),
+		configuration.WithPostprocessor(injectLiveReloadScript))...)
+	out := &bytes.Buffer{}
+	metadata, err := libasciidoc.ConvertFile(out, config)
+	// also watch the document if it could not be converted, so that the page is reloaded once the problem is fixed
+	s.watcher.Watch(filename, metadata.Files...)
+	if err != nil {
+		log.WithError(err).Errorf("unable to render '%s'", filename)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "<pre>%s</pre>\n%s\n", html.EscapeString(err.Error()), liveReloadScript)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := out.WriteTo(w); err != nil {
+		log.WithError(err).Errorf("unable to send '%s'", filename)
+	}
+}
+
+// list renders the links to the documents and the sub-directories in the given directory
+// hidden returns true if the file or directory with the given name is hidden (eg: `.git`)
+func hidden(name string) bool {
+	return strings.HasPrefix(name, ".")
+}
+
+func (s *Server) list(w http.ResponseWriter, dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	links := []string{}
+	for _, e := range entries {
+		switch {
+		case hidden(e.Name()):
+			continue
+		case e.IsDir():
+			links = append(links, e.Name()+"/")
+		case filepath.Ext(e.Name()) == ".adoc":
+			links = append(links, outputName(e.Name()))
+		}
+	}
+	sort.Strings(links)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintln(w, "<!DOCTYPE html>\n<html>\n<body>\n<ul>")
+	for _, l := range links {
+		fmt.Fprintf(w, "<li><a href=\"%[1]s\">%[1]s</a></li>\n", html.EscapeString(l))
+	}
+	fmt.Fprintln(w, "</ul>\n</body>\n</html>")
+}
+
+// liveReload streams the live-reload events to the browser, until the request is canceled
+func (s *Server) liveReload(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	events := make(chan string, 1)
+	s.mutex.Lock()
+	s.clients[events] = true
+	s.mutex.Unlock()
+	defer func() {
+		s.mutex.Lock()
+		delete(s.clients, events)
+		s.mutex.Unlock()
+	}()
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case data := <-events:
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// notify sends a live-reload event with the given data to all the browsers
+func (s *Server) notify(data string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for events := range s.clients {
+		select {
+		case events <- data:
+		default:
+			// an event is already pending for this browser
+		}
+	}
+}
+
+// injectLiveReloadScript inserts the script which listens to the live-reload events at the end of the page body
+func injectLiveReloadScript(output string) (string, error) {
+	if i := strings.LastIndex(output, "</body>"); i >= 0 {
+		return output[:i] + liveReloadScript + "\n" + output[i:], nil
+	}
+	return output + liveReloadScript + "\n", nil
+}
+
+// outputName returns the name of the HTML file generated from the document with the given name
+func outputName(name string) string {
+	return strings.TrimSuffix(name, filepath.Ext(name)) + ".html"
+}
+
+func isFile(name string) bool {
+	info, err := os.Stat(name)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,146 @@
+package preview_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/preview"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("server", func() {
+
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "libasciidoc-server")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+		Expect(os.Mkdir(filepath.Join(dir, "guide"), 0700)).To(Succeed())
+		for name, content := range map[string]string{
+			"doc.adoc":         "= Doc\n\ninclude::chapter.adoc[]",
+			"chapter.adoc":     "first version of the chapter",
+			"style.css":        "body {}",
+			"guide/index.adoc": "= Guide",
+		} {
+			Expect(os.WriteFile(filepath.Join(dir, name), []byte(content), 0600)).To(Succeed())
+		}
+	})
+
+	get := func(url string) (int, string) {
+		resp, err := http.Get(url) //nolint:gosec
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return resp.StatusCode, string(body)
+	}
+
+	Context("single document", func() {
+
+		It("should render the document with the live-reload script", func() {
+			s := httptest.NewServer(preview.NewServer(filepath.Join(dir, "doc.adoc"), configuration.WithAttribute("nofooter", "")))
+			defer s.Close()
+			status, body := get(s.URL + "/")
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(body).To(ContainSubstring("<title>Doc</title>"))
+			Expect(body).To(ContainSubstring("<p>first version of the chapter</p>"))
+			Expect(body).To(ContainSubstring(`<script>new EventSource("/_livereload").onmessage = function() { location.reload(); };</script>` + "\n</body>"))
+			// also available with the name of the generated file
+			status, _ = get(s.URL + "/doc.html")
+			Expect(status).To(Equal(http.StatusOK))
+		})
+
+		It("should serve the files in the directory of the document", func() {
+			s := httptest.NewServer(preview.NewServer(filepath.Join(dir, "doc.adoc")))
+			defer s.Close()
+			status, body := get(s.URL + "/style.css")
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(body).To(Equal("body {}"))
+			status, _ = get(s.URL + "/../../unknown.txt")
+			Expect(status).To(Equal(http.StatusNotFound))
+		})
+
+		It("should notify the browsers when an included file changed", func() {
+			server := preview.NewServer(filepath.Join(dir, "doc.adoc"))
+			s := httptest.NewServer(server)
+			defer s.Close()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go server.Watch(ctx, 10*time.Millisecond)
+			// render the document first, so that the included file is watched
+			get(s.URL + "/")
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL+preview.LiveReloadPath, nil)
+			Expect(err).NotTo(HaveOccurred())
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.Header.Get("Content-Type")).To(Equal("text/event-stream"))
+			Expect(os.WriteFile(filepath.Join(dir, "chapter.adoc"), []byte("second version of the chapter"), 0600)).To(Succeed())
+			line, err := bufio.NewReader(resp.Body).ReadString('\n')
+			Expect(err).NotTo(HaveOccurred())
+			Expect(line).To(Equal("data: " + filepath.Join(dir, "doc.adoc") + "\n"))
+			_, body := get(s.URL + "/")
+			Expect(body).To(ContainSubstring("<p>second version of the chapter</p>"))
+		})
+	})
+
+	Context("directory", func() {
+
+		It("should list the documents", func() {
+			s := httptest.NewServer(preview.NewServer(dir))
+			defer s.Close()
+			status, body := get(s.URL + "/")
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(body).To(ContainSubstring(`<li><a href="chapter.html">chapter.html</a></li>
+<li><a href="doc.html">doc.html</a></li>
+<li><a href="guide/">guide/</a></li>`))
+		})
+
+		It("should render the documents", func() {
+			s := httptest.NewServer(preview.NewServer(dir))
+			defer s.Close()
+			status, body := get(s.URL + "/doc.html")
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(body).To(ContainSubstring("<p>first version of the chapter</p>"))
+			// index document
+			status, body = get(s.URL + "/guide/")
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(body).To(ContainSubstring("<title>Guide</title>"))
+		})
+
+		It("should not find unknown documents", func() {
+			s := httptest.NewServer(preview.NewServer(dir))
+			defer s.Close()
+			status, _ := get(s.URL + "/unknown.html")
+			Expect(status).To(Equal(http.StatusNotFound))
+		})
+
+		It("should not serve the hidden files and directories", func() {
+			Expect(os.Mkdir(filepath.Join(dir, ".git"), 0700)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, ".git", "config"), []byte("[core]"), 0600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, ".env"), []byte("SECRET=secret"), 0600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "guide", ".hidden.adoc"), []byte("= Hidden"), 0600)).To(Succeed())
+			s := httptest.NewServer(preview.NewServer(dir))
+			defer s.Close()
+			for _, p := range []string{"/.git/config", "/.git/", "/.env", "/guide/.hidden.adoc", "/guide/.hidden.html"} {
+				status, body := get(s.URL + p)
+				Expect(status).To(Equal(http.StatusNotFound), p)
+				Expect(body).NotTo(ContainSubstring("ecret"), p)
+			}
+			// the other files are still served
+			status, _ := get(s.URL + "/style.css")
+			Expect(status).To(Equal(http.StatusOK))
+		})
+	})
+})
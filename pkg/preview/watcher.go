@@ -0,0 +1,95 @@
+package preview
+
+import (
+	"context"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Watcher watches the files on which some targets (eg: the documents to convert) depend,
+// by polling their modification time and size.
+type Watcher struct {
+	mutex   sync.Mutex
+	targets map[string][]string  // the files on which each target depends
+	states  map[string]fileState // the last known state of each file
+}
+
+// fileState the modification time and size of a file (zero if the file does not exist)
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewWatcher returns a new Watcher
+func NewWatcher() *Watcher {
+	return &Watcher{
+		targets: map[string][]string{},
+		states:  map[string]fileState{},
+	}
+}
+
+// Watch records that the given target depends on the given files (in place of the files previously recorded for this target)
+func (w *Watcher) Watch(target string, files ...string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.targets[target] = append([]string{target}, files...)
+	for _, f := range w.targets[target] {
+		if _, found := w.states[f]; !found {
+			w.states[f] = stateOf(f)
+		}
+	}
+}
+
+// Changes returns the targets whose files were modified, created or deleted since the previous call
+func (w *Watcher) Changes() []string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	changed := map[string]bool{}
+	for f, previous := range w.states {
+		if s := stateOf(f); !s.modTime.Equal(previous.modTime) || s.size != previous.size {
+			w.states[f] = s
+			changed[f] = true
+		}
+	}
+	result := []string{}
+	for target, files := range w.targets {
+		for _, f := range files {
+			if changed[f] {
+				result = append(result, target)
+				break
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Run checks the files at the given interval, and calls `onChange` with the targets whose files changed,
+// until the given context is done
+func (w *Watcher) Run(ctx context.Context, interval time.Duration, onChange func(targets []string)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if targets := w.Changes(); len(targets) > 0 {
+				onChange(targets)
+			}
+		}
+	}
+}
+
+func stateOf(filename string) fileState {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{
+		modTime: info.ModTime(),
+		size:    info.Size(),
+	}
+}
@@ -0,0 +1,81 @@
+package preview_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bytesparadise/libasciidoc/pkg/preview"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("watcher", func() {
+
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "libasciidoc-watcher")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+		for name, content := range map[string]string{
+			"doc.adoc":     "include::chapter.adoc[]",
+			"other.adoc":   "other",
+			"chapter.adoc": "chapter",
+		} {
+			Expect(os.WriteFile(filepath.Join(dir, name), []byte(content), 0600)).To(Succeed())
+		}
+	})
+
+	It("should not report unchanged targets", func() {
+		w := preview.NewWatcher()
+		w.Watch(filepath.Join(dir, "doc.adoc"), filepath.Join(dir, "chapter.adoc"))
+		Expect(w.Changes()).To(BeEmpty())
+	})
+
+	It("should report the targets whose included file changed", func() {
+		w := preview.NewWatcher()
+		w.Watch(filepath.Join(dir, "doc.adoc"), filepath.Join(dir, "chapter.adoc"))
+		w.Watch(filepath.Join(dir, "other.adoc"))
+		Expect(os.WriteFile(filepath.Join(dir, "chapter.adoc"), []byte("updated chapter"), 0600)).To(Succeed())
+		Expect(w.Changes()).To(Equal([]string{filepath.Join(dir, "doc.adoc")}))
+		// changes are only reported once
+		Expect(w.Changes()).To(BeEmpty())
+	})
+
+	It("should report the targets which were deleted", func() {
+		w := preview.NewWatcher()
+		w.Watch(filepath.Join(dir, "other.adoc"))
+		Expect(os.Remove(filepath.Join(dir, "other.adoc"))).To(Succeed())
+		Expect(w.Changes()).To(Equal([]string{filepath.Join(dir, "other.adoc")}))
+	})
+
+	It("should not report the files which are no longer included", func() {
+		w := preview.NewWatcher()
+		w.Watch(filepath.Join(dir, "doc.adoc"), filepath.Join(dir, "chapter.adoc"))
+		w.Watch(filepath.Join(dir, "doc.adoc"))
+		Expect(os.WriteFile(filepath.Join(dir, "chapter.adoc"), []byte("updated chapter"), 0600)).To(Succeed())
+		Expect(w.Changes()).To(BeEmpty())
+	})
+
+	It("should notify the changes until the context is done", func() {
+		w := preview.NewWatcher()
+		w.Watch(filepath.Join(dir, "doc.adoc"), filepath.Join(dir, "chapter.adoc"))
+		ctx, cancel := context.WithCancel(context.Background())
+		changes := make(chan []string, 10)
+		done := make(chan struct{})
+		go func() {
+			w.Run(ctx, 10*time.Millisecond, func(targets []string) {
+				changes <- targets
+			})
+			close(done)
+		}()
+		Expect(os.WriteFile(filepath.Join(dir, "chapter.adoc"), []byte("updated chapter"), 0600)).To(Succeed())
+		Eventually(changes).Should(Receive(Equal([]string{filepath.Join(dir, "doc.adoc")})))
+		cancel()
+		Eventually(done).Should(BeClosed())
+	})
+})
@@ -0,0 +1,242 @@
+// Package ast serializes the documents and all their elements in JSON or in YAML,
+// so the parsed documents can be processed by other tools (search indexes, link checkers, etc.)
+//
+// Each node of the tree is serialized with a `type` discriminator (eg: `paragraph`, `delimited_block`),
+// along with its fields (eg: `attributes`, `elements`). The root node also contains the `version`
+// of the serialization format, which is incremented if a change breaks the existing format.
+// Since the names of the nodes and of their fields are derived from the types of the `types` package,
+// the format is pinned by the golden files in `testdata`, so that renaming a type or a field cannot go unnoticed.
+package ast
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Version the version of the serialization format
+const Version = "1"
+
+// Render serializes the given document in JSON (with the `json` or `ast` backends)
+// or in YAML (with the `yaml` backend) in the given output writer
+func Render(doc *types.Document, config *configuration.Configuration, output io.Writer) (types.Metadata, error) {
+	metadata := types.Metadata{
+		LastUpdated:     config.LastUpdated.Format(configuration.LastUpdatedFormat),
+		TableOfContents: doc.TableOfContents,
+	}
+	if header, _ := doc.Header(); header != nil {
+		title, err := sgml.RenderPlainText(header.Title, sgml.WithoutEscape())
+		if err != nil {
+			return metadata, errors.Wrap(err, "unable to render document title")
+		}
+		metadata.Title = title
+	}
+	var result []byte
+	var err error
+	switch config.BackEnd {
+	case "json", "ast":
+		result, err = MarshalJSON(doc)
+	case "yaml":
+		result, err = MarshalYAML(doc)
+	default:
+		err = fmt.Errorf("backend '%s' not supported", config.BackEnd)
+	}
+	if err != nil {
+		return metadata, err
+	}
+	if _, err := output.Write(result); err != nil {
+		return metadata, errors.Wrap(err, "unable to write document")
+	}
+	return metadata, nil
+}
+
+// MarshalJSON returns the (indented) JSON serialization of the given document
+func MarshalJSON(doc *types.Document) ([]byte, error) {
+	result, err := json.MarshalIndent(NewTree(doc), "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to serialize document in JSON")
+	}
+	return append(result, '\n'), nil
+}
+
+// MarshalYAML returns the YAML serialization of the given document
+func MarshalYAML(doc *types.Document) ([]byte, error) {
+	result, err := yaml.Marshal(NewTree(doc))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to serialize document in YAML")
+	}
+	return result, nil
+}
+
+// NewTree converts the given document into a tree of maps, slices and primitive values,
+// ready to be serialized. The root node contains the version of the serialization format.
+func NewTree(doc *types.Document) map[string]interface{} {
+	root, _ := newNode(reflect.ValueOf(doc)).(map[string]interface{})
+	if root == nil {
+		root = map[string]interface{}{
+			"type": "document",
+		}
+	}
+	root["version"] = Version
+	return root
+}
+
+// newNode converts the given value into a map (for structs and maps), a slice or a primitive value
+func newNode(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return newNode(v.Elem())
+	case reflect.Struct:
+		if r, ok := v.Interface().(types.SourceRange); ok {
+			return newSourceRangeNode(r)
+		}
+		node := map[string]interface{}{
+			"type": TypeName(v.Type()),
+		}
+		addFields(node, v)
+		return node
+	case reflect.Map:
+		if v.Len() == 0 {
+			return nil
+		}
+		node := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			node[fmt.Sprintf("%v", iter.Key().Interface())] = newNode(iter.Value())
+		}
+		return node
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			return nil
+		}
+		nodes := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			nodes[i] = newNode(v.Index(i))
+		}
+		return nodes
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	default:
+		// eg: funcs, channels
+		return nil
+	}
+}
+
+// newSourceRangeNode converts the given range in the source into a compact node,
+// or returns `nil` if the range is unknown
+func newSourceRangeNode(r types.SourceRange) interface{} {
+	if r.IsZero() {
+		return nil
+	}
+	node := map[string]interface{}{
+		"start": map[string]interface{}{
+			"line":   int64(r.Start.Line),
+			"column": int64(r.Start.Column),
+		},
+		"end": map[string]interface{}{
+			"line":   int64(r.End.Line),
+			"column": int64(r.End.Column),
+		},
+	}
+	if r.Start.File != "" {
+		node["file"] = r.Start.File
+	}
+	return node
+}
+
+// addFields adds the exported fields of the given struct in the node,
+// including the fields of the exported embedded structs, but excluding the empty values
+func addFields(node map[string]interface{}, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" { // unexported field or embedded struct
+			continue
+		}
+		fv := v.Field(i)
+		if f.Anonymous {
+			for fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					break
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				addFields(node, fv)
+			}
+			continue
+		}
+		value := newNode(fv)
+		if value == nil || value == "" {
+			continue
+		}
+		node[FieldName(f.Name)] = value
+	}
+}
+
+// type names which would not be converted properly by splitting on the letter case
+var typeNames = map[string]string{
+	"ToCSection": "toc_section",
+}
+
+// TypeName returns the name of the given type in snake case (eg: `DelimitedBlock` becomes `delimited_block`)
+func TypeName(t reflect.Type) string {
+	if name, found := typeNames[t.Name()]; found {
+		return name
+	}
+	words := splitWords(t.Name())
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+	return strings.Join(words, "_")
+}
+
+// FieldName returns the name of the given struct field in camel case (eg: `TableOfContents` becomes `tableOfContents`, `ID` becomes `id`)
+func FieldName(name string) string {
+	words := splitWords(name)
+	if len(words) == 0 {
+		return name
+	}
+	words[0] = strings.ToLower(words[0])
+	return strings.Join(words, "")
+}
+
+// splitWords splits the given identifier in words, based on the case of its letters
+// (eg: `InlineLink` becomes `Inline` and `Link`, `URLPath` becomes `URL` and `Path`)
+func splitWords(name string) []string {
+	runes := []rune(name)
+	words := []string{}
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		if unicode.IsUpper(runes[i]) &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if len(runes) > 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
@@ -0,0 +1,367 @@
+package ast_test
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc"
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/ast"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AST serialization", func() {
+
+	source := `= Title
+
+some *bold* content`
+
+	It("should serialize in JSON", func() {
+		// given
+		config := configuration.NewConfiguration(configuration.WithBackEnd("json"))
+		output := &bytes.Buffer{}
+		// when
+		metadata, err := libasciidoc.Convert(strings.NewReader(source), output, config)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(metadata.Title).To(Equal("Title"))
+		Expect(output.String()).To(Equal(`{
+  "elements": [
+    {
+      "sourceRange": {
+        "end": {
+          "column": 8,
+          "line": 1
+        },
+        "start": {
+          "column": 1,
+          "line": 1
+        }
+      },
+      "title": [
+        {
+          "content": "Title",
+          "sourceRange": {
+            "end": {
+              "column": 8,
+              "line": 1
+            },
+            "start": {
+              "column": 3,
+              "line": 1
+            }
+          },
+          "type": "string_element"
+        }
+      ],
+      "type": "document_header"
+    },
+    {
+      "elements": [
+        {
+          "content": "some ",
+          "sourceRange": {
+            "end": {
+              "column": 6,
+              "line": 3
+            },
+            "start": {
+              "column": 1,
+              "line": 3
+            }
+          },
+          "type": "string_element"
+        },
+        {
+          "elements": [
+            {
+              "content": "bold",
+              "sourceRange": {
+                "end": {
+                  "column": 11,
+                  "line": 3
+                },
+                "start": {
+                  "column": 7,
+                  "line": 3
+                }
+              },
+              "type": "string_element"
+            }
+          ],
+          "kind": "*",
+          "sourceRange": {
+            "end": {
+              "column": 12,
+              "line": 3
+            },
+            "start": {
+              "column": 6,
+              "line": 3
+            }
+          },
+          "type": "quoted_text"
+        },
+        {
+          "content": " content",
+          "sourceRange": {
+            "end": {
+              "column": 20,
+              "line": 3
+            },
+            "start": {
+              "column": 12,
+              "line": 3
+            }
+          },
+          "type": "string_element"
+        }
+      ],
+      "sourceRange": {
+        "end": {
+          "column": 20,
+          "line": 3
+        },
+        "start": {
+          "column": 1,
+          "line": 3
+        }
+      },
+      "type": "paragraph"
+    }
+  ],
+  "type": "document",
+  "version": "1"
+}
+`))
+	})
+
+	It("should serialize in JSON with the ast backend", func() {
+		// given
+		config := configuration.NewConfiguration(configuration.WithBackEnd("ast"))
+		output := &bytes.Buffer{}
+		// when
+		_, err := libasciidoc.Convert(strings.NewReader(source), output, config)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output.String()).To(HavePrefix("{\n"))
+		Expect(output.String()).To(ContainSubstring(`"type": "quoted_text"`))
+	})
+
+	It("should serialize in YAML", func() {
+		// given
+		config := configuration.NewConfiguration(configuration.WithBackEnd("yaml"))
+		output := &bytes.Buffer{}
+		// when
+		_, err := libasciidoc.Convert(strings.NewReader(source), output, config)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output.String()).To(Equal(`elements:
+- sourceRange:
+    end:
+      column: 8
+      line: 1
+    start:
+      column: 1
+      line: 1
+  title:
+  - content: Title
+    sourceRange:
+      end:
+        column: 8
+        line: 1
+      start:
+        column: 3
+        line: 1
+    type: string_element
+  type: document_header
+- elements:
+  - content: 'some '
+    sourceRange:
+      end:
+        column: 6
+        line: 3
+      start:
+        column: 1
+        line: 3
+    type: string_element
+  - elements:
+    - content: bold
+      sourceRange:
+        end:
+          column: 11
+          line: 3
+        start:
+          column: 7
+          line: 3
+      type: string_element
+    kind: '*'
+    sourceRange:
+      end:
+        column: 12
+        line: 3
+      start:
+        column: 6
+        line: 3
+    type: quoted_text
+  - content: ' content'
+    sourceRange:
+      end:
+        column: 20
+        line: 3
+      start:
+        column: 12
+        line: 3
+    type: string_element
+  sourceRange:
+    end:
+      column: 20
+      line: 3
+    start:
+      column: 1
+      line: 3
+  type: paragraph
+type: document
+version: "1"
+`))
+	})
+
+	It("should serialize embedded structs and attributes", func() {
+		// given
+		doc := &types.Document{
+			Elements: []interface{}{
+				&types.DocumentHeader{
+					Elements: []interface{}{
+						&types.AttributeDeclaration{
+							Name: types.AttrAuthors,
+							Value: types.DocumentAuthors{
+								{
+									DocumentAuthorFullName: &types.DocumentAuthorFullName{
+										FirstName: "John",
+										LastName:  "Doe",
+									},
+									Email: "john@example.com",
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		// when
+		tree := ast.NewTree(doc)
+		// then
+		Expect(tree).To(Equal(map[string]interface{}{
+			"type":    "document",
+			"version": ast.Version,
+			"elements": []interface{}{
+				map[string]interface{}{
+					"type": "document_header",
+					"elements": []interface{}{
+						map[string]interface{}{
+							"type": "attribute_declaration",
+							"name": "authors",
+							"value": []interface{}{
+								map[string]interface{}{
+									"type":      "document_author",
+									"firstName": "John",
+									"lastName":  "Doe",
+									"email":     "john@example.com",
+								},
+							},
+						},
+					},
+				},
+			},
+		}))
+	})
+
+	It("should skip the unexported fields and embedded structs", func() {
+		// given
+		doc := &types.Document{
+			Elements: []interface{}{
+				&withUnexportedFields{
+					unexported: unexported{
+						Range: types.SourceRange{
+							Start: types.SourcePosition{Line: 1, Column: 1},
+						},
+					},
+					name:  "hidden",
+					Value: "visible",
+				},
+			},
+		}
+		// when
+		tree := ast.NewTree(doc)
+		// then
+		Expect(tree).To(Equal(map[string]interface{}{
+			"type":    "document",
+			"version": ast.Version,
+			"elements": []interface{}{
+				map[string]interface{}{
+					"type":  "with_unexported_fields",
+					"value": "visible",
+				},
+			},
+		}))
+	})
+
+	// the golden files pin the serialization format: any change in these files
+	// (eg: after a field was renamed in the `types` package) requires a new version of the format
+	DescribeTable("golden files",
+		func(backend, golden string) {
+			// given
+			source, err := os.ReadFile("testdata/document.adoc")
+			Expect(err).NotTo(HaveOccurred())
+			expected, err := os.ReadFile(golden)
+			Expect(err).NotTo(HaveOccurred())
+			config := configuration.NewConfiguration(
+				configuration.WithFilename("document.adoc"),
+				configuration.WithBackEnd(backend),
+			)
+			output := &bytes.Buffer{}
+			// when
+			_, err = libasciidoc.Convert(bytes.NewReader(source), output, config)
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ast.Version).To(Equal("1"))
+			Expect(output.String()).To(Equal(string(expected)))
+		},
+		Entry("json", "json", "testdata/document.json"),
+		Entry("yaml", "yaml", "testdata/document.yaml"),
+	)
+
+	DescribeTable("type names",
+		func(t interface{}, expected string) {
+			Expect(ast.TypeName(reflect.TypeOf(t))).To(Equal(expected))
+		},
+		Entry("paragraph", types.Paragraph{}, "paragraph"),
+		Entry("delimited block", types.DelimitedBlock{}, "delimited_block"),
+		Entry("toc section", types.ToCSection{}, "toc_section"),
+	)
+
+	DescribeTable("field names",
+		func(name, expected string) {
+			Expect(ast.FieldName(name)).To(Equal(expected))
+		},
+		Entry("single word", "Elements", "elements"),
+		Entry("multiple words", "TableOfContents", "tableOfContents"),
+		Entry("acronym", "ID", "id"),
+		Entry("acronym prefix", "URLPath", "urlPath"),
+	)
+})
+
+type unexported struct {
+	Range types.SourceRange
+}
+
+type withUnexportedFields struct {
+	unexported
+	name  string
+	Value string
+}
@@ -0,0 +1,36 @@
+package manpage
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+)
+
+// context carries the document which is being processed, along with the state of the rendering
+type context struct {
+	config            *configuration.Configuration
+	attributes        types.Attributes
+	authors           types.DocumentAuthors
+	elementReferences types.ElementReferences
+	footnotes         []*types.Footnote
+}
+
+// newContext returns a new rendering context for the given document.
+func newContext(doc *types.Document, config *configuration.Configuration) *context {
+	ctx := &context{
+		config:            config,
+		attributes:        config.Attributes,
+		elementReferences: doc.ElementReferences,
+		footnotes:         doc.Footnotes,
+	}
+	// also, expand authors and revision
+	if header, _ := doc.Header(); header != nil {
+		if authors := header.Authors(); authors != nil {
+			ctx.authors = authors
+			ctx.attributes.AddAll(authors.Expand())
+		}
+		if revision := header.Revision(); revision != nil {
+			ctx.attributes.AddAll(revision.Expand())
+		}
+	}
+	return ctx
+}
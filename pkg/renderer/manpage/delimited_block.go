@@ -0,0 +1,155 @@
+package manpage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+func (r *manpageRenderer) renderDelimitedBlock(ctx *context, b *types.DelimitedBlock) (string, error) {
+	switch b.Kind {
+	case types.Example:
+		content, err := r.renderElements(ctx, b.Elements)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render example block")
+		}
+		if b.Attributes.Has(types.AttrStyle) {
+			return r.renderAdmonition(ctx, b.Attributes, content)
+		}
+		return r.renderIndentedBlock(ctx, b.Attributes, content)
+	case types.Fenced, types.Listing, types.Literal, types.Source, types.Stem, types.LatexMath, types.AsciiMath:
+		return r.renderVerbatimBlock(ctx, b.Attributes, b.Elements)
+	case types.Quote, types.MarkdownQuote:
+		content, err := r.renderElements(ctx, b.Elements)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render quote block")
+		}
+		return r.renderQuote(ctx, b.Attributes, content, false)
+	case types.Verse:
+		content, err := r.renderInlineElements(ctx, b.Elements)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render verse block")
+		}
+		return r.renderQuote(ctx, b.Attributes, strings.Trim(content, "\n")+"\n", true)
+	case types.Sidebar:
+		content, err := r.renderElements(ctx, b.Elements)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render sidebar block")
+		}
+		return r.renderIndentedBlock(ctx, b.Attributes, content)
+	case types.Open:
+		title, err := r.renderElementTitle(ctx, b.Attributes)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render open block")
+		}
+		content, err := r.renderElements(ctx, b.Elements)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render open block")
+		}
+		return title + content, nil
+	case types.Passthrough:
+		return r.renderPassthrough(ctx, b.Elements)
+	default:
+		return "", fmt.Errorf("unsupported kind of delimited block: '%s'", b.Kind)
+	}
+}
+
+// renderIndentedBlock renders the optional title, followed by the (already rendered) content with an indentation
+func (r *manpageRenderer) renderIndentedBlock(ctx *context, attrs types.Attributes, content string) (string, error) {
+	title, err := r.renderElementTitle(ctx, attrs)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render block title")
+	}
+	return title + ".RS 4\n" + content + ".RE\n", nil
+}
+
+// renderVerbatimBlock renders the given elements in "no-fill" mode, using a monospace font
+func (r *manpageRenderer) renderVerbatimBlock(ctx *context, attrs types.Attributes, elements []interface{}) (string, error) {
+	title, err := r.renderElementTitle(ctx, attrs)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render verbatim block title")
+	}
+	content, err := r.renderInlineElements(ctx, elements)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render verbatim block content")
+	}
+	result := &strings.Builder{}
+	result.WriteString(title)
+	result.WriteString(".sp\n.RS 4\n.nf\n.fam C\n")
+	result.WriteString(strings.Trim(content, "\n"))
+	result.WriteString("\n.fam\n.fi\n.RE\n")
+	return result.String(), nil
+}
+
+// renderQuote renders the (already rendered) content of a quote or a verse, followed by its attribution
+func (r *manpageRenderer) renderQuote(ctx *context, attrs types.Attributes, content string, verse bool) (string, error) {
+	title, err := r.renderElementTitle(ctx, attrs)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render quote title")
+	}
+	result := &strings.Builder{}
+	result.WriteString(title)
+	result.WriteString(".RS 3\n")
+	if verse {
+		result.WriteString(".sp\n.nf\n")
+	}
+	result.WriteString(content)
+	if verse {
+		result.WriteString(".fi\n")
+	}
+	result.WriteString(".RE\n")
+	author := attrs.GetAsStringWithDefault(types.AttrQuoteAuthor, "")
+	citation := attrs.GetAsStringWithDefault(types.AttrQuoteTitle, "")
+	if author != "" || citation != "" {
+		result.WriteString(".RS 5\n.sp\n\\(em ")
+		result.WriteString(escape(author))
+		if author != "" && citation != "" {
+			result.WriteString(", ")
+		}
+		if citation != "" {
+			result.WriteString("\\fI" + escape(citation) + "\\fP")
+		}
+		result.WriteString("\n.RE\n")
+	}
+	return result.String(), nil
+}
+
+// renderPassthrough renders the given elements as-is
+func (r *manpageRenderer) renderPassthrough(_ *context, elements []interface{}) (string, error) {
+	content, err := sgml.RenderPlainText(elements, sgml.WithoutEscape())
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render passthrough content")
+	}
+	return strings.Trim(content, "\n") + "\n", nil
+}
+
+// renderImageBlock renders the alt text of the image, since images cannot be displayed in manpages
+func (r *manpageRenderer) renderImageBlock(ctx *context, i *types.ImageBlock) (string, error) {
+	title, err := r.renderElementTitle(ctx, i.Attributes)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render image block")
+	}
+	alt := i.Attributes.GetAsStringWithDefault(types.AttrImageAlt, i.Location.ToDisplayString())
+	return title + ".sp\n[" + escape(alt) + "]\n", nil
+}
+
+// renderMediaBlock renders the location of the video or audio, since they cannot be played in manpages
+func (r *manpageRenderer) renderMediaBlock(ctx *context, attrs types.Attributes, location string) (string, error) {
+	title, err := r.renderElementTitle(ctx, attrs)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render media block")
+	}
+	return title + ".sp\n[" + escape(location) + "]\n", nil
+}
+
+// renderUserMacro renders the raw text of the macro, since there is no template to render it in a manpage
+func (r *manpageRenderer) renderUserMacro(_ *context, m *types.UserMacro) (string, error) {
+	if m.Kind == types.BlockMacro {
+		return ".sp\n" + escapeLine(escape(m.RawText)) + "\n", nil
+	}
+	return escape(m.RawText), nil
+}
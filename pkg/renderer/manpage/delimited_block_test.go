@@ -0,0 +1,89 @@
+package manpage_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("delimited blocks", func() {
+
+	It("listing block", func() {
+		source := `----
+$ foo -v
+.hidden
+----`
+		expected := `.sp
+.RS 4
+.nf
+.fam C
+$ foo \-v
+\&.hidden
+.fam
+.fi
+.RE
+`
+		Expect(RenderManpage(source)).To(Equal(expected))
+	})
+
+	It("admonition paragraph", func() {
+		source := `WARNING: be careful`
+		expected := `.sp
+.RS 4
+.B Warning
+.br
+be careful
+.RE
+`
+		Expect(RenderManpage(source)).To(Equal(expected))
+	})
+
+	It("quote block", func() {
+		source := `[quote, John Doe, The Book]
+____
+a quote
+____`
+		expected := `.RS 3
+.sp
+a quote
+.RE
+.RS 5
+.sp
+\(em John Doe, \fIThe Book\fP
+.RE
+`
+		Expect(RenderManpage(source)).To(Equal(expected))
+	})
+
+	It("table with header", func() {
+		source := `.A table
+[cols="<,^,>",options="header"]
+|===
+|H1 |H2 |H3
+|a |b |c
+|===`
+		expected := `.sp
+\fBA table\fP
+.TS
+allbox tab(:);
+ltB ctB rtB
+lt ct rt.
+T{
+H1
+T}:T{
+H2
+T}:T{
+H3
+T}
+T{
+a
+T}:T{
+b
+T}:T{
+c
+T}
+.TE
+.sp
+`
+		Expect(RenderManpage(source)).To(Equal(expected))
+	})
+})
@@ -0,0 +1,91 @@
+package manpage
+
+import (
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+func (r *manpageRenderer) renderElements(ctx *context, elements []interface{}) (string, error) {
+	buff := &strings.Builder{}
+	for _, element := range elements {
+		renderedElement, err := r.renderElement(ctx, element)
+		if err != nil {
+			return "", err // no need to wrap the error here
+		}
+		buff.WriteString(renderedElement)
+	}
+	return buff.String(), nil
+}
+
+// renderElement renders the given block element
+//
+//nolint:gocyclo
+func (r *manpageRenderer) renderElement(ctx *context, element interface{}) (string, error) {
+	switch e := element.(type) {
+	case *types.Section:
+		return r.renderSection(ctx, e)
+	case *types.Preamble:
+		return r.renderElements(ctx, e.Elements)
+	case *types.List:
+		return r.renderList(ctx, e)
+	case *types.Paragraph:
+		return r.renderParagraph(ctx, e)
+	case *types.DelimitedBlock:
+		return r.renderDelimitedBlock(ctx, e)
+	case *types.Table:
+		return r.renderTable(ctx, e)
+	case *types.ImageBlock:
+		return r.renderImageBlock(ctx, e)
+	case *types.VideoBlock:
+		return r.renderMediaBlock(ctx, e.Attributes, e.URL())
+	case *types.AudioBlock:
+		return r.renderMediaBlock(ctx, e.Attributes, e.Location.ToDisplayString())
+	case *types.ThematicBreak:
+		return ".sp\n.ce\n\\l'\\n(.lu*25u/100u\\(ap'\n", nil
+	case *types.UserMacro:
+		return r.renderUserMacro(ctx, e)
+	case *types.TableOfContents, *types.BlankLine:
+		// not rendered in manpages
+		return "", nil
+	case *types.AttributeDeclaration:
+		ctx.attributes[e.Name] = e.Value
+		return "", nil
+	case *types.AttributeReset:
+		delete(ctx.attributes, e.Name)
+		return "", nil
+	case *types.FrontMatter:
+		ctx.attributes.AddAll(e.Attributes)
+		return "", nil
+	default:
+		// also support inline elements at the block level (eg: within table cells)
+		result, err := r.renderInlineElement(ctx, &strings.Builder{}, element)
+		if err != nil {
+			return "", errors.Errorf("unsupported type of element: %T", element)
+		}
+		return result, nil
+	}
+}
+
+// renderElementTitle renders the title of the element, in bold and on its own line (if the element has a title)
+func (r *manpageRenderer) renderElementTitle(ctx *context, attrs types.Attributes) (string, error) {
+	title, found := attrs[types.AttrTitle]
+	if !found {
+		return "", nil
+	}
+	var content string
+	switch title := title.(type) {
+	case string:
+		content = escape(title)
+	case []interface{}:
+		var err error
+		if content, err = r.renderInlineElements(ctx, title); err != nil {
+			return "", errors.Wrap(err, "unable to render element title")
+		}
+	default:
+		return "", errors.Errorf("unable to render title of type '%T'", title)
+	}
+	return ".sp\n\\fB" + content + "\\fP\n", nil
+}
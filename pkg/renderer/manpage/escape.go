@@ -0,0 +1,64 @@
+package manpage
+
+import (
+	"strings"
+	"unicode"
+)
+
+var escaper = strings.NewReplacer(
+	`\`, `\e`,
+	`-`, `\-`,
+)
+
+// escape escapes the backslashes and the hyphens in the given text
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// escapeLine prevents the lines of the given text starting with a dot or an apostrophe
+// to be interpreted as control lines
+func escapeLine(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		if strings.HasPrefix(l, ".") || strings.HasPrefix(l, "'") {
+			lines[i] = `\&` + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// escapeMacroArgument escapes the double quotes in the given text,
+// so it can be used as a quoted argument of a macro (eg: `.SH "..."`)
+func escapeMacroArgument(s string) string {
+	return strings.ReplaceAll(s, `"`, `\(dq`)
+}
+
+// uppercase converts the given (escaped) text in upper case, while
+// preserving the escape sequences (eg: `\fB`, `\(em`, etc.)
+func uppercase(s string) string {
+	result := &strings.Builder{}
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '\\' || i == len(runes)-1 {
+			result.WriteRune(unicode.ToUpper(runes[i]))
+			continue
+		}
+		// copy the escape sequence as-is
+		n := 2 // eg: `\-`, `\e`, `\&`
+		switch runes[i+1] {
+		case '(':
+			n = 4 // eg: `\(em`
+		case 'f':
+			n = 3 // eg: `\fB`
+			if i+2 < len(runes) && runes[i+2] == '(' {
+				n = 5 // eg: `\f(CR`
+			}
+		}
+		if i+n > len(runes) {
+			n = len(runes) - i
+		}
+		result.WriteString(string(runes[i : i+n]))
+		i += n - 1
+	}
+	return result.String()
+}
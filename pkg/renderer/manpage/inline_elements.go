@@ -0,0 +1,254 @@
+package manpage
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+func (r *manpageRenderer) renderInlineElements(ctx *context, elements []interface{}) (string, error) {
+	buff := &strings.Builder{}
+	for _, element := range elements {
+		renderedElement, err := r.renderInlineElement(ctx, buff, element)
+		if err != nil {
+			return "", err
+		}
+		buff.WriteString(renderedElement)
+	}
+	return buff.String(), nil
+}
+
+// renderInlineElement renders the given inline element. The content which was already rendered
+// on the current line is needed to determine if a string element starts a new line,
+// in which case a leading dot or apostrophe must be escaped.
+//
+//nolint:gocyclo
+func (r *manpageRenderer) renderInlineElement(ctx *context, before *strings.Builder, element interface{}) (string, error) {
+	switch e := element.(type) {
+	case *types.StringElement:
+		content := escape(e.Content)
+		if before.Len() == 0 || strings.HasSuffix(before.String(), "\n") {
+			return escapeLine(content), nil
+		}
+		if i := strings.Index(content, "\n"); i >= 0 {
+			return content[:i+1] + escapeLine(content[i+1:]), nil
+		}
+		return content, nil
+	case *types.SpecialCharacter:
+		return e.Name, nil
+	case *types.Symbol:
+		return r.renderSymbol(e), nil
+	case *types.PredefinedAttribute:
+		return r.renderPredefinedAttribute(e), nil
+	case *types.QuotedText:
+		return r.renderQuotedText(ctx, e)
+	case *types.InlinePassthrough:
+		return r.renderInlineElements(ctx, e.Elements)
+	case *types.InlineStem:
+		return "\\f(CR" + escape(e.Content) + "\\fP", nil
+	case *types.InlineLink:
+		return r.renderLink(ctx, e)
+	case *types.InternalCrossReference:
+		return r.renderInternalCrossReference(ctx, e)
+	case *types.ExternalCrossReference:
+		return r.renderExternalCrossReference(ctx, e)
+	case *types.InlineImage:
+		return "[" + escape(e.Attributes.GetAsStringWithDefault(types.AttrImageAlt, e.Location.ToDisplayString())) + "]", nil
+	case *types.Icon:
+		return "[" + escape(e.Attributes.GetAsStringWithDefault(types.AttrImageAlt, e.Class)) + "]", nil
+	case *types.InlineButton:
+		return "\\fB[" + escape(e.Attributes.GetAsStringWithDefault(types.AttrButtonLabel, "")) + "]\\fP", nil
+	case *types.InlineMenu:
+		path := make([]string, len(e.Path))
+		for i, p := range e.Path {
+			path[i] = "\\fI" + escape(p) + "\\fP"
+		}
+		return strings.Join(path, "\\ \\(fc\\ "), nil
+	case *types.FootnoteReference:
+		return r.renderFootnoteReference(e), nil
+	case *types.Callout:
+		return "\\fB(" + strconv.Itoa(e.Ref) + ")\\fP", nil
+	case *types.LineBreak:
+		return "\n.br\n", nil
+	case *types.IndexTerm:
+		return r.renderInlineElements(ctx, e.Term)
+	case *types.ConcealedIndexTerm:
+		return "", nil
+	case *types.UserMacro:
+		return r.renderUserMacro(ctx, e)
+	case *types.AttributeDeclaration:
+		ctx.attributes[e.Name] = e.Value
+		return "", nil
+	case *types.AttributeReset:
+		delete(ctx.attributes, e.Name)
+		return "", nil
+	default:
+		return "", errors.Errorf("unsupported type of element: %T", element)
+	}
+}
+
+func (r *manpageRenderer) renderQuotedText(ctx *context, t *types.QuotedText) (string, error) {
+	content, err := r.renderInlineElements(ctx, t.Elements)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render quoted text")
+	}
+	switch t.Kind {
+	case types.SingleQuoteBold, types.DoubleQuoteBold:
+		return "\\fB" + content + "\\fP", nil
+	case types.SingleQuoteItalic, types.DoubleQuoteItalic:
+		return "\\fI" + content + "\\fP", nil
+	case types.SingleQuoteMonospace, types.DoubleQuoteMonospace:
+		return "\\f(CR" + content + "\\fP", nil
+	case types.SingleQuoteSuperscript:
+		return "^(" + content + ")", nil
+	case types.SingleQuoteSubscript:
+		return "_(" + content + ")", nil
+	default: // marked text
+		return content, nil
+	}
+}
+
+// renderLink renders the text of the link followed by its URL within angle brackets,
+// or just the URL if the link has no text
+func (r *manpageRenderer) renderLink(ctx *context, l *types.InlineLink) (string, error) {
+	url := escape(l.Location.ToDisplayString())
+	var text string
+	switch t := l.Attributes[types.AttrInlineLinkText].(type) {
+	case string:
+		text = escape(t)
+	case []interface{}:
+		var err error
+		if text, err = r.renderInlineElements(ctx, t); err != nil {
+			return "", errors.Wrap(err, "unable to render link")
+		}
+	}
+	if text == "" || text == url {
+		return url, nil
+	}
+	return text + " <" + url + ">", nil
+}
+
+func (r *manpageRenderer) renderInternalCrossReference(ctx *context, xref *types.InternalCrossReference) (string, error) {
+	id, ok := xref.ID.(string)
+	if !ok {
+		return "", errors.Errorf("unable to process internal cross reference: invalid ID: '%v'", xref.ID)
+	}
+	if label, ok := xref.Label.(string); ok {
+		return escape(label), nil
+	}
+	switch target := ctx.elementReferences[id].(type) {
+	case string:
+		return escape(target), nil
+	case []interface{}:
+		return r.renderInlineElements(ctx, target)
+	default:
+		return "[" + escape(id) + "]", nil
+	}
+}
+
+func (r *manpageRenderer) renderExternalCrossReference(ctx *context, xref *types.ExternalCrossReference) (string, error) {
+	switch label := xref.Attributes[types.AttrXRefLabel].(type) {
+	case string:
+		return escape(label), nil
+	case []interface{}:
+		return r.renderInlineElements(ctx, label)
+	default:
+		return escape(xref.Location.ToDisplayString()), nil
+	}
+}
+
+var symbols = map[string]string{
+	"(C)":  "\\(co",
+	"(R)":  "\\(rg",
+	"(TM)": "\\(tm",
+	"...":  "\\&...",
+	"'":    "\\(cq",
+	"'`":   "\\(oq",
+	"`'":   "\\(cq",
+	"\"`":  "\\(lq",
+	"`\"":  "\\(rq",
+	"->":   "\\(->",
+	"<-":   "\\(<-",
+	"=>":   "\\(rA",
+	"<=":   "\\(lA",
+	"--":   "\\(em",
+	" -- ": "\\|\\(em\\|",
+}
+
+func (r *manpageRenderer) renderSymbol(s *types.Symbol) string {
+	if str, found := symbols[s.Name]; found {
+		return str
+	}
+	return escape(s.Name)
+}
+
+var predefinedAttributes = map[string]string{
+	"sp":             " ",
+	"blank":          "",
+	"empty":          "",
+	"nbsp":           "\\ ",
+	"zwsp":           "\\:",
+	"wj":             "",
+	"apos":           "'",
+	"quot":           "\\(dq",
+	"lsquo":          "\\(oq",
+	"rsquo":          "\\(cq",
+	"ldquo":          "\\(lq",
+	"rdquo":          "\\(rq",
+	"deg":            "\\(de",
+	"plus":           "+",
+	"brvbar":         "\\(bb",
+	"vbar":           "|",
+	"amp":            "&",
+	"lt":             "<",
+	"gt":             ">",
+	"startsb":        "[",
+	"endsb":          "]",
+	"caret":          "^",
+	"asterisk":       "*",
+	"tilde":          "~",
+	"backslash":      "\\e",
+	"backtick":       "`",
+	"two-colons":     "::",
+	"two-semicolons": ";;",
+	"cpp":            "C++",
+}
+
+func (r *manpageRenderer) renderPredefinedAttribute(a *types.PredefinedAttribute) string {
+	if value, found := predefinedAttributes[a.Name]; found {
+		return value
+	}
+	return "{" + a.Name + "}"
+}
+
+// ------------------------------------------------------------
+// Footnotes
+// ------------------------------------------------------------
+
+func (r *manpageRenderer) renderFootnoteReference(note *types.FootnoteReference) string {
+	if note.ID == types.InvalidFootnoteReference {
+		return "[" + escape(note.Ref) + "]"
+	}
+	return "[" + strconv.Itoa(note.ID) + "]"
+}
+
+// renderFootnotes renders the footnotes in a `NOTES` section at the end of the manpage
+func (r *manpageRenderer) renderFootnotes(ctx *context, notes []*types.Footnote) (string, error) {
+	if len(notes) == 0 {
+		return "", nil
+	}
+	result := &strings.Builder{}
+	result.WriteString(".SH \"NOTES\"\n")
+	for _, note := range notes {
+		content, err := r.renderInlineElements(ctx, note.Elements)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render footnote")
+		}
+		result.WriteString(".IP \"" + numbering(types.Arabic, note.ID) + "\" 4\n")
+		result.WriteString(strings.TrimSpace(content) + "\n")
+	}
+	return result.String(), nil
+}
@@ -0,0 +1,187 @@
+package manpage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+func (r *manpageRenderer) renderList(ctx *context, l *types.List) (string, error) {
+	title, err := r.renderElementTitle(ctx, l.Attributes)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render list title")
+	}
+	result := &strings.Builder{}
+	result.WriteString(title)
+	// wrap the list in a relative indent, so that the indentation of the list items
+	// does not apply on the subsequent elements
+	if l.Kind == types.LabeledListKind {
+		result.WriteString(".RS 0\n")
+	} else {
+		result.WriteString(".RS 4\n")
+	}
+	switch l.Kind {
+	case types.OrderedListKind:
+		err = r.renderOrderedList(ctx, result, l)
+	case types.UnorderedListKind:
+		err = r.renderUnorderedList(ctx, result, l)
+	case types.LabeledListKind:
+		err = r.renderLabeledList(ctx, result, l)
+	case types.CalloutListKind:
+		err = r.renderCalloutList(ctx, result, l)
+	default:
+		err = fmt.Errorf("unable to render list of kind '%s'", l.Kind)
+	}
+	if err != nil {
+		return "", err
+	}
+	result.WriteString(".RE\n")
+	return result.String(), nil
+}
+
+func (r *manpageRenderer) renderOrderedList(ctx *context, result *strings.Builder, l *types.List) error {
+	start := l.Attributes.GetAsIntWithDefault(types.AttrStart, 1)
+	for i, element := range l.Elements {
+		e, ok := element.(*types.OrderedListElement)
+		if !ok {
+			return errors.Errorf("unable to render ordered list element of type '%T'", element)
+		}
+		style := l.Attributes.GetAsStringWithDefault(types.AttrStyle, e.Style)
+		result.WriteString(".IP \"" + numbering(style, start+i) + "\" 4\n")
+		if err := r.renderListElementContent(ctx, result, e.Elements); err != nil {
+			return errors.Wrap(err, "unable to render ordered list element")
+		}
+	}
+	return nil
+}
+
+// numbering returns the number of an ordered list item, in the given style (eg: ` 1.`, ` a.`, `ii.`)
+func numbering(style string, n int) string {
+	var number string
+	switch style {
+	case types.LowerAlpha:
+		number = alpha(n)
+	case types.UpperAlpha:
+		number = strings.ToUpper(alpha(n))
+	case types.LowerRoman:
+		number = roman(n)
+	case types.UpperRoman:
+		number = strings.ToUpper(roman(n))
+	default:
+		number = strconv.Itoa(n)
+	}
+	return fmt.Sprintf("%2s.", number)
+}
+
+func alpha(n int) string {
+	result := ""
+	for ; n > 0; n = (n - 1) / 26 {
+		result = string(rune('a'+(n-1)%26)) + result
+	}
+	return result
+}
+
+func roman(n int) string {
+	values := []int{1000, 900, 500, 400, 100, 90, 50, 40, 10, 9, 5, 4, 1}
+	symbols := []string{"m", "cm", "d", "cd", "c", "xc", "l", "xl", "x", "ix", "v", "iv", "i"}
+	result := &strings.Builder{}
+	for i, v := range values {
+		for ; n >= v; n -= v {
+			result.WriteString(symbols[i])
+		}
+	}
+	return result.String()
+}
+
+func (r *manpageRenderer) renderUnorderedList(ctx *context, result *strings.Builder, l *types.List) error {
+	for _, element := range l.Elements {
+		e, ok := element.(*types.UnorderedListElement)
+		if !ok {
+			return errors.Errorf("unable to render unordered list element of type '%T'", element)
+		}
+		result.WriteString(".IP \\(bu 2\n")
+		if err := r.renderListElementContent(ctx, result, e.Elements); err != nil {
+			return errors.Wrap(err, "unable to render unordered list element")
+		}
+	}
+	return nil
+}
+
+func (r *manpageRenderer) renderLabeledList(ctx *context, result *strings.Builder, l *types.List) error {
+	for _, element := range l.Elements {
+		e, ok := element.(*types.LabeledListElement)
+		if !ok {
+			return errors.Errorf("unable to render labeled list element of type '%T'", element)
+		}
+		term, err := r.renderInlineElements(ctx, e.Term)
+		if err != nil {
+			return errors.Wrap(err, "unable to render labeled list term")
+		}
+		result.WriteString(".TP\n")
+		result.WriteString(strings.TrimSpace(term) + "\n")
+		if err := r.renderListElementContent(ctx, result, e.Elements); err != nil {
+			return errors.Wrap(err, "unable to render labeled list element")
+		}
+	}
+	return nil
+}
+
+func (r *manpageRenderer) renderCalloutList(ctx *context, result *strings.Builder, l *types.List) error {
+	for _, element := range l.Elements {
+		e, ok := element.(*types.CalloutListElement)
+		if !ok {
+			return errors.Errorf("unable to render callout list element of type '%T'", element)
+		}
+		result.WriteString(".IP \"(" + strconv.Itoa(e.Ref) + ")\" 4\n")
+		if err := r.renderListElementContent(ctx, result, e.Elements); err != nil {
+			return errors.Wrap(err, "unable to render callout list element")
+		}
+	}
+	return nil
+}
+
+// renderListElementContent renders the content of a list element: the first paragraph
+// is rendered directly after the list item macro
+func (r *manpageRenderer) renderListElementContent(ctx *context, result *strings.Builder, elements []interface{}) error {
+	for i, element := range elements {
+		switch e := element.(type) {
+		case *types.Paragraph:
+			if i > 0 || e.Attributes.Has(types.AttrStyle) {
+				content, err := r.renderParagraph(ctx, e)
+				if err != nil {
+					return err
+				}
+				result.WriteString(content)
+				continue
+			}
+			content, err := r.renderParagraphElements(ctx, e)
+			if err != nil {
+				return err
+			}
+			result.WriteString(checkStyle(e.Attributes[types.AttrCheckStyle]))
+			result.WriteString(content + "\n")
+		default:
+			content, err := r.renderElement(ctx, e)
+			if err != nil {
+				return err
+			}
+			result.WriteString(content)
+		}
+	}
+	return nil
+}
+
+func checkStyle(style interface{}) string {
+	switch style {
+	case types.Checked, types.CheckedInteractive:
+		return "[x] "
+	case types.Unchecked, types.UncheckedInteractive:
+		return "[ ] "
+	default:
+		return ""
+	}
+}
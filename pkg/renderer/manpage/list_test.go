@@ -0,0 +1,58 @@
+package manpage_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("lists", func() {
+
+	It("nested unordered lists", func() {
+		source := `* item one
+** nested
+* [x] checked`
+		expected := `.RS 4
+.IP \(bu 2
+item one
+.RS 4
+.IP \(bu 2
+nested
+.RE
+.IP \(bu 2
+[x] checked
+.RE
+`
+		Expect(RenderManpage(source)).To(Equal(expected))
+	})
+
+	It("ordered list with style", func() {
+		source := `[loweralpha]
+. alpha
+. beta`
+		expected := `.RS 4
+.IP " a." 4
+alpha
+.IP " b." 4
+beta
+.RE
+`
+		Expect(RenderManpage(source)).To(Equal(expected))
+	})
+
+	It("labeled list", func() {
+		source := `*-v*, *--verbose*::
+  Be verbose.
+*-o* _FILE_::
+  Write output to _FILE_.`
+		expected := `.RS 0
+.TP
+\fB\-v\fP, \fB\-\-verbose\fP
+Be verbose.
+.TP
+\fB\-o\fP \fIFILE\fP
+Write output to \fIFILE\fP.
+.RE
+`
+		Expect(RenderManpage(source)).To(Equal(expected))
+	})
+})
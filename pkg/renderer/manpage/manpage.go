@@ -0,0 +1,144 @@
+// Package manpage renders documents with the `manpage` doctype in the roff format,
+// so they can be displayed with the `man` command.
+package manpage
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Render renders the given document in the roff format, in the given output writer
+func Render(doc *types.Document, config *configuration.Configuration, output io.Writer) (types.Metadata, error) {
+	r := &manpageRenderer{}
+	ctx := newContext(doc, config)
+	metadata := types.Metadata{
+		LastUpdated:     config.LastUpdated.Format(configuration.LastUpdatedFormat),
+		TableOfContents: doc.TableOfContents,
+	}
+	header, _ := doc.Header()
+	if header != nil {
+		title, err := sgml.RenderPlainText(header.Title, sgml.WithoutEscape())
+		if err != nil {
+			return metadata, errors.Wrap(err, "unable to render manpage")
+		}
+		metadata.Title = title
+		// process attribute declarations in the header
+		for _, e := range header.Elements {
+			switch e := e.(type) {
+			case *types.AttributeDeclaration:
+				ctx.attributes[e.Name] = e.Value
+			case *types.AttributeReset:
+				delete(ctx.attributes, e.Name)
+			}
+		}
+	}
+	result := &strings.Builder{}
+	if config.WrapInHTMLBodyElement {
+		result.WriteString(r.renderPreamble(ctx, metadata.Title))
+	}
+	content, err := r.renderElements(ctx, doc.BodyElements())
+	if err != nil {
+		return metadata, errors.Wrap(err, "unable to render manpage")
+	}
+	result.WriteString(content)
+	notes, err := r.renderFootnotes(ctx, doc.Footnotes)
+	if err != nil {
+		return metadata, errors.Wrap(err, "unable to render manpage")
+	}
+	result.WriteString(notes)
+	if config.WrapInHTMLBodyElement {
+		authors, err := r.renderAuthors(ctx)
+		if err != nil {
+			return metadata, errors.Wrap(err, "unable to render manpage")
+		}
+		result.WriteString(authors)
+	}
+	if _, err := io.WriteString(output, result.String()); err != nil {
+		return metadata, errors.Wrap(err, "unable to render manpage")
+	}
+	return metadata, nil
+}
+
+type manpageRenderer struct{}
+
+var manpageTitleRegexp = regexp.MustCompile(`^(.+)\((.+)\)$`)
+
+// SplitTitle splits the title of the document (eg: `git-foo(1)`) in a name and a volume number.
+// The volume number defaults to `1` if it is not specified in the title.
+func SplitTitle(title string) (string, string) {
+	if m := manpageTitleRegexp.FindStringSubmatch(strings.TrimSpace(title)); m != nil {
+		return strings.TrimSpace(m[1]), strings.TrimSpace(m[2])
+	}
+	return strings.TrimSpace(title), "1"
+}
+
+// renderPreamble renders the comments and the `.TH` macro which start the manpage
+func (r *manpageRenderer) renderPreamble(ctx *context, title string) string {
+	name, volnum := SplitTitle(title)
+	name = ctx.attributes.GetAsStringWithDefault(types.AttrManName, name)
+	volnum = ctx.attributes.GetAsStringWithDefault(types.AttrManVolNum, volnum)
+	source := ctx.attributes.GetAsStringWithDefault(types.AttrManSource, "")
+	manual := ctx.attributes.GetAsStringWithDefault(types.AttrManManual, "")
+	date := ctx.attributes.GetAsStringWithDefault("revdate", ctx.config.LastUpdated.Format("2006-01-02"))
+	result := &strings.Builder{}
+	result.WriteString("'\\\" t\n")
+	result.WriteString(fmt.Sprintf(".\\\"     Title: %s\n", name))
+	if author := ctx.attributes.GetAsStringWithDefault(types.AttrAuthor, ""); author != "" {
+		result.WriteString(fmt.Sprintf(".\\\"    Author: %s\n", author))
+	}
+	result.WriteString(".\\\" Generator: libasciidoc\n")
+	result.WriteString(fmt.Sprintf(".\\\"      Date: %s\n", date))
+	if manual != "" {
+		result.WriteString(fmt.Sprintf(".\\\"    Manual: %s\n", manual))
+	}
+	if source != "" {
+		result.WriteString(fmt.Sprintf(".\\\"    Source: %s\n", source))
+	}
+	result.WriteString(".\\\"\n")
+	result.WriteString(fmt.Sprintf(".TH \"%s\" \"%s\" \"%s\" \"%s\" \"%s\"\n",
+		escapeMacroArgument(uppercase(escape(name))),
+		escapeMacroArgument(escape(volnum)),
+		escapeMacroArgument(escape(date)),
+		escapeMacroArgument(escape(source)),
+		escapeMacroArgument(escape(manual))))
+	// disable hyphenation and justification
+	result.WriteString(".nh\n")
+	result.WriteString(".ad l\n")
+	return result.String()
+}
+
+// renderAuthors renders the `AUTHOR(S)` section at the end of the manpage
+func (r *manpageRenderer) renderAuthors(ctx *context) (string, error) {
+	authors := ctx.authors
+	if len(authors) == 0 {
+		return "", nil
+	}
+	result := &strings.Builder{}
+	if len(authors) == 1 {
+		result.WriteString(".SH \"AUTHOR\"\n")
+	} else {
+		result.WriteString(".SH \"AUTHORS\"\n")
+	}
+	for _, author := range authors {
+		if author.DocumentAuthorFullName == nil {
+			continue
+		}
+		log.Debugf("rendering author '%v'", author.FullName())
+		result.WriteString(".sp\n")
+		result.WriteString(escapeLine(escape(author.FullName())))
+		if author.Email != "" {
+			result.WriteString(" <" + escape(author.Email) + ">")
+		}
+		result.WriteString("\n")
+	}
+	return result.String(), nil
+}
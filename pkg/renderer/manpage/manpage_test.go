@@ -0,0 +1,131 @@
+package manpage_test
+
+import (
+	"time"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("manpages", func() {
+
+	It("full manpage with header", func() {
+		source := `= git-foo(1)
+John Doe <john@example.com>
+v1.0, 2020-01-01
+:mansource: Git 1.0
+:manmanual: Git Manual
+
+== Name
+
+git-foo - does foo things
+
+== Synopsis
+
+*git foo* [_OPTIONS_]
+
+== Options
+
+*-v*::
+  Be verbose.`
+		expected := `'\" t
+.\"     Title: git-foo
+.\"    Author: John Doe
+.\" Generator: libasciidoc
+.\"      Date: 2020-01-01
+.\"    Manual: Git Manual
+.\"    Source: Git 1.0
+.\"
+.TH "GIT\-FOO" "1" "2020\-01\-01" "Git 1.0" "Git Manual"
+.nh
+.ad l
+.SH "NAME"
+git\-foo \- does foo things
+.SH "SYNOPSIS"
+.sp
+\fBgit foo\fP [\fIOPTIONS\fP]
+.SH "OPTIONS"
+.RS 0
+.TP
+\fB\-v\fP
+Be verbose.
+.RE
+.SH "AUTHOR"
+.sp
+John Doe <john@example.com>
+`
+		Expect(RenderManpage(source, configuration.WithHeaderFooter(true))).To(Equal(expected))
+	})
+
+	It("manpage with volume number and date from attributes", func() {
+		source := `= foo
+:manvolnum: 8
+
+== Name
+
+foo - does foo things
+
+== Synopsis
+
+foo`
+		expected := `'\" t
+.\"     Title: foo
+.\" Generator: libasciidoc
+.\"      Date: 2021-06-01
+.\"
+.TH "FOO" "8" "2021\-06\-01" "" ""
+.nh
+.ad l
+.SH "NAME"
+foo \- does foo things
+.SH "SYNOPSIS"
+.sp
+foo
+`
+		Expect(RenderManpage(source,
+			configuration.WithHeaderFooter(true),
+			configuration.WithLastUpdated(time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)),
+		)).To(Equal(expected))
+	})
+
+	It("manpage without header", func() {
+		source := `= foo(1)
+
+== Name
+
+foo - does foo things
+
+== Synopsis
+
+foo
+
+=== Sub-section
+
+some content
+'quoted' content with (C) and -> symbols.`
+		expected := `.SH "NAME"
+foo \- does foo things
+.SH "SYNOPSIS"
+.sp
+foo
+.SS "Sub\-section"
+.sp
+some content
+\&'quoted' content with \(co and \(-> symbols.
+`
+		Expect(RenderManpage(source)).To(Equal(expected))
+	})
+
+	It("links and footnotes", func() {
+		source := `a link to https://example.com[Example] and a footnote.footnote:[the *note*]`
+		expected := `.sp
+a link to Example <https://example.com> and a footnote.[1]
+.SH "NOTES"
+.IP " 1." 4
+the \fBnote\fP
+`
+		Expect(RenderManpage(source)).To(Equal(expected))
+	})
+})
@@ -0,0 +1,90 @@
+package manpage
+
+import (
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+func (r *manpageRenderer) renderParagraph(ctx *context, p *types.Paragraph) (string, error) {
+	switch p.Attributes[types.AttrStyle] {
+	case types.Listing, types.Source, types.LiteralParagraph, types.Literal, types.Stem, types.LatexMath, types.AsciiMath:
+		return r.renderVerbatimBlock(ctx, p.Attributes, p.Elements)
+	case types.Verse, types.Quote:
+		content, err := r.renderParagraphElements(ctx, p)
+		if err != nil {
+			return "", err
+		}
+		return r.renderQuote(ctx, p.Attributes, content+"\n", p.Attributes[types.AttrStyle] == types.Verse)
+	case types.Passthrough:
+		return r.renderPassthrough(ctx, p.Elements)
+	case types.Tip, types.Note, types.Important, types.Warning, types.Caution:
+		content, err := r.renderParagraphElements(ctx, p)
+		if err != nil {
+			return "", err
+		}
+		return r.renderAdmonition(ctx, p.Attributes, content)
+	default:
+		title, err := r.renderElementTitle(ctx, p.Attributes)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render paragraph")
+		}
+		content, err := r.renderParagraphElements(ctx, p)
+		if err != nil {
+			return "", err
+		}
+		return title + ".sp\n" + content + "\n", nil
+	}
+}
+
+// renderParagraphElements renders the content of the paragraph,
+// without the leading `.sp` macro and without the trailing newline
+func (r *manpageRenderer) renderParagraphElements(ctx *context, p *types.Paragraph) (string, error) {
+	content, err := r.renderInlineElements(ctx, p.Elements)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render paragraph content")
+	}
+	content = strings.Trim(content, "\n")
+	// leading spaces would cause a line break
+	lines := strings.Split(content, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimLeft(l, " \t")
+	}
+	content = strings.Join(lines, "\n")
+	if p.Attributes.HasOption(types.AttrHardBreaks) || ctx.attributes.HasOption(types.AttrHardBreaks) {
+		content = strings.ReplaceAll(content, "\n", "\n.br\n")
+	}
+	return content, nil
+}
+
+var defaultAdmonitionCaptions = map[string]string{
+	types.Caution:   "Caution",
+	types.Important: "Important",
+	types.Note:      "Note",
+	types.Tip:       "Tip",
+	types.Warning:   "Warning",
+}
+
+// renderAdmonition renders the admonition caption in bold, followed by the (already rendered) content
+func (r *manpageRenderer) renderAdmonition(ctx *context, attrs types.Attributes, content string) (string, error) {
+	kind, _ := attrs.GetAsString(types.AttrStyle)
+	caption := ctx.attributes.GetAsStringWithDefault(strings.ToLower(kind)+"-caption", defaultAdmonitionCaptions[kind])
+	caption = attrs.GetAsStringWithDefault(types.AttrCaption, caption)
+	title, err := r.renderElementTitle(ctx, attrs)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render admonition")
+	}
+	result := &strings.Builder{}
+	result.WriteString(".sp\n.RS 4\n")
+	result.WriteString(".B " + escape(caption) + "\n")
+	result.WriteString(".br\n")
+	result.WriteString(strings.TrimPrefix(title, ".sp\n"))
+	result.WriteString(strings.TrimPrefix(content, ".sp\n"))
+	if !strings.HasSuffix(content, "\n") {
+		result.WriteString("\n")
+	}
+	result.WriteString(".RE\n")
+	return result.String(), nil
+}
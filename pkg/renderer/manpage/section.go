@@ -0,0 +1,63 @@
+package manpage
+
+import (
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+func (r *manpageRenderer) renderSection(ctx *context, s *types.Section) (string, error) {
+	title, err := r.renderInlineElements(ctx, s.Title)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render section title")
+	}
+	title = strings.ReplaceAll(title, "\n", " ")
+	result := &strings.Builder{}
+	switch s.Level {
+	case 0, 1:
+		result.WriteString(".SH \"" + escapeMacroArgument(uppercase(title)) + "\"\n")
+	case 2:
+		result.WriteString(".SS \"" + escapeMacroArgument(title) + "\"\n")
+	default:
+		result.WriteString(".sp\n\\fB" + title + "\\fP\n")
+	}
+	if s.Level == 1 && isNameSection(s) {
+		// the `NAME` section only contains the name and purpose of the command, as plain text
+		content, err := r.renderNameSection(ctx, s)
+		if err != nil {
+			return "", err
+		}
+		result.WriteString(content)
+		return result.String(), nil
+	}
+	content, err := r.renderElements(ctx, s.Elements)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render section content")
+	}
+	result.WriteString(content)
+	return result.String(), nil
+}
+
+func isNameSection(s *types.Section) bool {
+	if len(s.Title) != 1 {
+		return false
+	}
+	title, ok := s.Title[0].(*types.StringElement)
+	return ok && strings.EqualFold(strings.TrimSpace(title.Content), "name")
+}
+
+func (r *manpageRenderer) renderNameSection(ctx *context, s *types.Section) (string, error) {
+	result := &strings.Builder{}
+	for _, e := range s.Elements {
+		if p, ok := e.(*types.Paragraph); ok {
+			content, err := r.renderInlineElements(ctx, p.Elements)
+			if err != nil {
+				return "", errors.Wrap(err, "unable to render manpage 'NAME' section")
+			}
+			result.WriteString(strings.TrimSpace(content) + "\n")
+		}
+	}
+	return result.String(), nil
+}
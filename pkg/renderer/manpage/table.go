@@ -0,0 +1,177 @@
+package manpage
+
+import (
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+// renderTable renders the table with the `tbl` preprocessor macros
+func (r *manpageRenderer) renderTable(ctx *context, t *types.Table) (string, error) {
+	columns, err := t.Columns()
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render table")
+	}
+	rows := make([]*types.TableRow, 0, len(t.Rows)+2)
+	if t.Header != nil {
+		rows = append(rows, t.Header)
+	}
+	rows = append(rows, t.Rows...)
+	if t.Footer != nil {
+		rows = append(rows, t.Footer)
+	}
+	// the position of each cell, given the cells which span over several columns or rows
+	// (the header and the footer are not affected by the spans of the body)
+	positions := make([][]int, len(rows))
+	body := types.NewTableCellColumns()
+	for i, row := range rows {
+		if (i == 0 && t.Header != nil) || (i == len(rows)-1 && t.Footer != nil) {
+			positions[i] = types.NewTableCellColumns().Next(row)
+		} else {
+			positions[i] = body.Next(row)
+		}
+		// no explicit column definitions: use the number of columns covered by the cells of the rows
+		for c, cell := range row.Cells {
+			for len(columns) < positions[i][c]+cell.Colspan() {
+				columns = append(columns, &types.TableColumn{
+					HAlign: types.HAlignLeft,
+					VAlign: types.VAlignTop,
+				})
+			}
+		}
+	}
+	if len(columns) == 0 {
+		return "", nil
+	}
+	title, err := r.renderElementTitle(ctx, t.Attributes)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render table title")
+	}
+	result := &strings.Builder{}
+	result.WriteString(title)
+	result.WriteString(".TS\n")
+	switch {
+	case t.Attributes.GetAsStringWithDefault(types.AttrGrid, "all") == "all":
+		result.WriteString("allbox ")
+	case t.Attributes.GetAsStringWithDefault(types.AttrFrame, "all") == "all":
+		result.WriteString("box ")
+	}
+	result.WriteString("tab(:);\n")
+	// format lines: one per row (the header in bold), with the `s` and `^` markers of the spanned columns and rows,
+	// up to the last row which differs from the default format, which then applies to the subsequent rows
+	formats := make([][]string, len(rows))
+	data := make([][]string, len(rows))
+	covered := make([]int, len(columns)) // the number of rows still covered by the cells which span over several rows
+	for i, row := range rows {
+		modifier := ""
+		if i == 0 && t.Header != nil {
+			modifier = "B"
+		}
+		formats[i] = make([]string, len(columns))
+		data[i] = make([]string, len(columns))
+		for c := range columns {
+			if covered[c] > 0 {
+				formats[i][c] = "^"
+				covered[c]--
+				continue
+			}
+			formats[i][c] = columnFormat(columns[c], modifier)
+		}
+		for j, cell := range row.Cells {
+			content, err := r.renderTableCell(ctx, cell)
+			if err != nil {
+				return "", errors.Wrap(err, "unable to render table cell")
+			}
+			column := positions[i][j]
+			data[i][column] = "T{\n" + content + "\nT}"
+			for c := column + 1; c < column+cell.Colspan(); c++ {
+				formats[i][c] = "s"
+			}
+			for c := column; c < column+cell.Colspan(); c++ {
+				covered[c] = cell.Rowspan() - 1
+			}
+		}
+	}
+	defaultFormat := tableFormat(columns, "")
+	last := -1
+	for i, f := range formats {
+		if strings.Join(f, " ") != defaultFormat {
+			last = i
+		}
+	}
+	for i := 0; i <= last; i++ {
+		result.WriteString(strings.Join(formats[i], " "))
+		if i == len(rows)-1 {
+			result.WriteString(".")
+		}
+		result.WriteString("\n")
+	}
+	if last < len(rows)-1 {
+		result.WriteString(defaultFormat + ".\n")
+	}
+	for _, cells := range data {
+		// the data of the spanned columns and rows is left empty
+		result.WriteString(strings.TrimRight(strings.Join(cells, ":"), ":") + "\n")
+	}
+	result.WriteString(".TE\n.sp\n")
+	return result.String(), nil
+}
+
+// tableFormat returns the `tbl` format line for the given columns (eg: `lt ct rt`)
+func tableFormat(columns []*types.TableColumn, modifier string) string {
+	formats := make([]string, len(columns))
+	for i, c := range columns {
+		formats[i] = columnFormat(c, modifier)
+	}
+	return strings.Join(formats, " ")
+}
+
+// columnFormat returns the `tbl` format of the given column (eg: `lt`)
+func columnFormat(c *types.TableColumn, modifier string) string {
+	var f string
+	switch c.HAlign {
+	case types.HAlignCenter:
+		f = "c"
+	case types.HAlignRight:
+		f = "r"
+	default:
+		f = "l"
+	}
+	switch c.VAlign {
+	case types.VAlignBottom, types.VAlignMiddle:
+		// `tbl` only supports vertical alignment on top, otherwise centered
+	default:
+		f += "t"
+	}
+	if c.Style == types.HeaderStyle || c.Style == types.StrongStyle {
+		f += "B"
+	} else {
+		f += modifier
+	}
+	return f
+}
+
+func (r *manpageRenderer) renderTableCell(ctx *context, cell *types.TableCell) (string, error) {
+	result := &strings.Builder{}
+	for i, element := range cell.Elements {
+		if p, ok := element.(*types.Paragraph); ok {
+			if i > 0 {
+				result.WriteString("\n.sp\n")
+			}
+			content, err := r.renderParagraphElements(ctx, p)
+			if err != nil {
+				return "", err
+			}
+			result.WriteString(content)
+			continue
+		}
+		content, err := r.renderElement(ctx, element)
+		if err != nil {
+			return "", err
+		}
+		result.WriteString(strings.TrimSuffix(content, "\n"))
+	}
+	return result.String(), nil
+}
@@ -0,0 +1,113 @@
+package manpage_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("tables", func() {
+
+	It("table with header and aligned columns", func() {
+		source := `[cols="<,^,>"]
+|===
+|Name |Status |Count
+
+|foo |ok |1
+|===`
+		expected := `.TS
+allbox tab(:);
+ltB ctB rtB
+lt ct rt.
+T{
+Name
+T}:T{
+Status
+T}:T{
+Count
+T}
+T{
+foo
+T}:T{
+ok
+T}:T{
+1
+T}
+.TE
+.sp
+`
+		Expect(RenderManpage(source)).To(Equal(expected))
+	})
+
+	It("table with spans", func() {
+		source := `|===
+|a |b |c
+2+|span |d
+.2+|tall |e |f
+|g |h
+|===`
+		expected := `.TS
+allbox tab(:);
+lt lt lt
+lt s lt
+lt lt lt
+^ lt lt.
+T{
+a
+T}:T{
+b
+T}:T{
+c
+T}
+T{
+span
+T}::T{
+d
+T}
+T{
+tall
+T}:T{
+e
+T}:T{
+f
+T}
+:T{
+g
+T}:T{
+h
+T}
+.TE
+.sp
+`
+		Expect(RenderManpage(source)).To(Equal(expected))
+	})
+
+	It("table with spans before regular rows", func() {
+		source := `[cols="2*"]
+|===
+2+|span
+|a |b
+|c |d
+|===`
+		expected := `.TS
+allbox tab(:);
+lt s
+lt lt.
+T{
+span
+T}
+T{
+a
+T}:T{
+b
+T}
+T{
+c
+T}:T{
+d
+T}
+.TE
+.sp
+`
+		Expect(RenderManpage(source)).To(Equal(expected))
+	})
+})
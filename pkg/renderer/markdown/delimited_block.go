@@ -0,0 +1,166 @@
+package markdown
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+func (r *markdownRenderer) renderDelimitedBlock(ctx *context, b *types.DelimitedBlock) (string, error) {
+	switch b.Kind {
+	case types.Example:
+		if b.Attributes.Has(types.AttrStyle) {
+			content, err := r.renderElements(ctx, b.Elements)
+			if err != nil {
+				return "", errors.Wrap(err, "unable to render admonition block")
+			}
+			return r.renderAdmonition(ctx, b.Attributes, content)
+		}
+		return r.renderHTML(ctx, b, "example block")
+	case types.Sidebar:
+		return r.renderHTML(ctx, b, "sidebar block")
+	case types.Fenced, types.Listing, types.Literal, types.Source:
+		return r.renderCodeBlock(ctx, b.Attributes, b.Elements)
+	case types.Quote, types.MarkdownQuote:
+		content, err := r.renderElements(ctx, b.Elements)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render quote block")
+		}
+		return r.renderQuote(ctx, b.Attributes, content)
+	case types.Verse:
+		content, err := r.renderInlineElements(ctx, b.Elements)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render verse block")
+		}
+		return r.renderQuote(ctx, b.Attributes, hardBreaks(escapeLines(strings.Trim(content, "\n")))+"\n")
+	case types.Open:
+		title, err := r.renderElementTitle(ctx, b.Attributes)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render open block")
+		}
+		content, err := r.renderElements(ctx, b.Elements)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render open block")
+		}
+		return title + content, nil
+	case types.Passthrough:
+		return r.renderPassthrough(ctx, b.Elements)
+	case types.Stem, types.LatexMath, types.AsciiMath:
+		return r.renderStemBlock(ctx, b.Kind, b.Attributes, b.Elements)
+	default:
+		return "", fmt.Errorf("unsupported kind of delimited block: '%s'", b.Kind)
+	}
+}
+
+// renderCodeBlock renders the given elements in a fenced code block, with the language of the source (if specified)
+func (r *markdownRenderer) renderCodeBlock(ctx *context, attrs types.Attributes, elements []interface{}) (string, error) {
+	title, err := r.renderElementTitle(ctx, attrs)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render code block title")
+	}
+	content, err := r.renderVerbatimElements(elements)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render code block content")
+	}
+	content = strings.Trim(content, "\n")
+	// the fence must be longer than any sequence of backticks in the content
+	fence := "```"
+	for strings.Contains(content, fence) {
+		fence += "`"
+	}
+	language, _ := attrs.GetAsString(types.AttrLanguage)
+	return title + fence + language + "\n" + content + "\n" + fence + "\n", nil
+}
+
+// renderVerbatimElements renders the given elements as-is, without any escaping
+func (r *markdownRenderer) renderVerbatimElements(elements []interface{}) (string, error) {
+	result := &strings.Builder{}
+	for _, element := range elements {
+		switch e := element.(type) {
+		case *types.Callout:
+			result.WriteString("<" + strconv.Itoa(e.Ref) + ">")
+		default:
+			content, err := sgml.RenderPlainText(element, sgml.WithoutEscape())
+			if err != nil {
+				return "", err
+			}
+			result.WriteString(content)
+		}
+	}
+	return result.String(), nil
+}
+
+// renderQuote renders the (already rendered) content of a quote or a verse in a blockquote, followed by its attribution
+func (r *markdownRenderer) renderQuote(ctx *context, attrs types.Attributes, content string) (string, error) {
+	title, err := r.renderElementTitle(ctx, attrs)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render quote title")
+	}
+	result := &strings.Builder{}
+	result.WriteString(title)
+	result.WriteString(content)
+	author := attrs.GetAsStringWithDefault(types.AttrQuoteAuthor, "")
+	citation := attrs.GetAsStringWithDefault(types.AttrQuoteTitle, "")
+	if author != "" || citation != "" {
+		result.WriteString("\n— ")
+		result.WriteString(escape(author))
+		if author != "" && citation != "" {
+			result.WriteString(", ")
+		}
+		if citation != "" {
+			result.WriteString("_" + escape(citation) + "_")
+		}
+		result.WriteString("\n")
+	}
+	return blockquote(result.String()), nil
+}
+
+// renderPassthrough renders the given elements as-is
+func (r *markdownRenderer) renderPassthrough(_ *context, elements []interface{}) (string, error) {
+	content, err := sgml.RenderPlainText(elements, sgml.WithoutEscape())
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render passthrough content")
+	}
+	return strings.Trim(content, "\n") + "\n", nil
+}
+
+// renderImageBlock renders the image, or an HTML `<img>` element if the image has some dimensions
+func (r *markdownRenderer) renderImageBlock(ctx *context, i *types.ImageBlock) (string, error) {
+	if i.Attributes.Has(types.AttrWidth) || i.Attributes.Has(types.AttrHeight) {
+		return r.renderHTML(ctx, i, "image with dimensions")
+	}
+	title, err := r.renderElementTitle(ctx, i.Attributes)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render image block")
+	}
+	return title + image(ctx, i.Attributes, i.Location) + "\n", nil
+}
+
+// image renders an image with its alt text and its location
+func image(ctx *context, attrs types.Attributes, location *types.Location) string {
+	if imagesdir, found := ctx.attributes.GetAsString(types.AttrImagesDir); found {
+		location.SetPathPrefix(imagesdir)
+	}
+	src := location.ToString()
+	alt, found := attrs.GetAsString(types.AttrImageAlt)
+	if !found {
+		// base path without its extension, and with the separators replaced by spaces
+		alt = strings.TrimSuffix(filepath.Base(src), filepath.Ext(src))
+		alt = strings.NewReplacer("-", " ", "_", " ").Replace(alt)
+	}
+	return "![" + escape(alt) + "](" + destination(src) + ")"
+}
+
+// destination returns the destination of a link or an image, within angle brackets if it contains some spaces
+func destination(d string) string {
+	if strings.ContainsAny(d, " ()") {
+		return "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(d) + ">"
+	}
+	return d
+}
@@ -0,0 +1,108 @@
+package markdown_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("delimited blocks", func() {
+
+	It("source block with language and callouts", func() {
+		source := `.main.go
+[source,go]
+----
+func main() { // <1>
+	fmt.Println("a < b")
+}
+----
+<1> the entrypoint`
+		expected := "**main.go**\n\n" +
+			"```go\n" +
+			"func main() { // <1>\n" +
+			"\tfmt.Println(\"a < b\")\n" +
+			"}\n" +
+			"```\n" +
+			"\n" +
+			"1. the entrypoint\n"
+		Expect(RenderMarkdown(source)).To(Equal(expected))
+	})
+
+	It("listing block with backticks", func() {
+		source := "----\n```\ncode\n```\n----"
+		expected := "````\n```\ncode\n```\n````\n"
+		Expect(RenderMarkdown(source)).To(Equal(expected))
+	})
+
+	It("admonitions", func() {
+		source := `NOTE: a note
+
+[WARNING]
+.Careful
+====
+a warning
+
+with 2 paragraphs
+====`
+		expected := `> [!NOTE]
+> a note
+
+> [!WARNING]
+> **Careful**
+>
+> a warning
+>
+> with 2 paragraphs
+`
+		Expect(RenderMarkdown(source)).To(Equal(expected))
+	})
+
+	It("quote and verse", func() {
+		source := `[quote, John Doe, The Book]
+____
+some *quote*
+____
+
+[verse]
+____
+first line
+second line
+____`
+		expected := `> some **quote**
+>
+> — John Doe, _The Book_
+
+> first line\
+> second line
+`
+		Expect(RenderMarkdown(source)).To(Equal(expected))
+	})
+
+	It("passthrough block", func() {
+		source := `++++
+<video src="foo.mp4"></video>
+++++`
+		expected := `<video src="foo.mp4"></video>
+`
+		Expect(RenderMarkdown(source)).To(Equal(expected))
+	})
+
+	It("sidebar block in raw HTML", func() {
+		diagnostics := types.NewDiagnostics()
+		source := `****
+content
+****`
+		expected := `<div class="sidebarblock">
+<div class="content">
+<div class="paragraph">
+<p>content</p>
+</div>
+</div>
+</div>
+`
+		Expect(RenderMarkdown(source, configuration.WithDiagnostics(diagnostics))).To(Equal(expected))
+		Expect(diagnostics.All()).To(ContainElement(HaveField("Message", "sidebar block cannot be rendered in Markdown, using raw HTML instead")))
+	})
+})
@@ -0,0 +1,111 @@
+package markdown
+
+import (
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+// renderElements renders the given block elements, separated by a blank line
+func (r *markdownRenderer) renderElements(ctx *context, elements []interface{}) (string, error) {
+	blocks := make([]string, 0, len(elements))
+	for _, element := range elements {
+		renderedElement, err := r.renderElement(ctx, element)
+		if err != nil {
+			return "", err // no need to wrap the error here
+		}
+		if renderedElement != "" {
+			blocks = append(blocks, renderedElement)
+		}
+	}
+	return strings.Join(blocks, "\n"), nil
+}
+
+// renderElement renders the given block element, with a trailing newline
+//
+//nolint:gocyclo
+func (r *markdownRenderer) renderElement(ctx *context, element interface{}) (string, error) {
+	switch e := element.(type) {
+	case *types.Section:
+		return r.renderSection(ctx, e)
+	case *types.Preamble:
+		return r.renderElements(ctx, e.Elements)
+	case *types.List:
+		return r.renderList(ctx, e)
+	case *types.Paragraph:
+		return r.renderParagraph(ctx, e)
+	case *types.DelimitedBlock:
+		return r.renderDelimitedBlock(ctx, e)
+	case *types.Table:
+		return r.renderTable(ctx, e)
+	case *types.ImageBlock:
+		return r.renderImageBlock(ctx, e)
+	case *types.VideoBlock:
+		return r.renderHTML(ctx, e, "video")
+	case *types.AudioBlock:
+		return r.renderHTML(ctx, e, "audio")
+	case *types.ThematicBreak:
+		return "---\n", nil
+	case *types.UserMacro:
+		return r.renderHTML(ctx, e, "user macro '"+e.Name+"'")
+	case *types.TableOfContents, *types.BlankLine:
+		// not rendered in Markdown (see warning in `Render`)
+		return "", nil
+	case *types.AttributeDeclaration:
+		ctx.attributes[e.Name] = e.Value
+		return "", nil
+	case *types.AttributeReset:
+		delete(ctx.attributes, e.Name)
+		return "", nil
+	case *types.FrontMatter:
+		ctx.attributes.AddAll(e.Attributes)
+		return "", nil
+	default:
+		// also support inline elements at the block level (eg: within table cells)
+		result, err := r.renderInlineElement(ctx, element)
+		if err != nil {
+			return "", errors.Errorf("unsupported type of element: %T", element)
+		}
+		return result + "\n", nil
+	}
+}
+
+// renderElementTitle renders the title of the element in bold, followed by a blank line
+// (if the element has a title)
+func (r *markdownRenderer) renderElementTitle(ctx *context, attrs types.Attributes) (string, error) {
+	title, err := r.renderAttribute(ctx, attrs[types.AttrTitle])
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render element title")
+	}
+	if title == "" {
+		return "", nil
+	}
+	return "**" + title + "**\n\n", nil
+}
+
+// renderAttribute renders the value of an attribute, which is either a string or a slice of inline elements
+func (r *markdownRenderer) renderAttribute(ctx *context, value interface{}) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return escape(value), nil
+	case []interface{}:
+		return r.renderInlineElements(ctx, value)
+	default:
+		return "", errors.Errorf("unable to render attribute of type '%T'", value)
+	}
+}
+
+// indent indents all non-empty lines of the given content with the given prefix
+func indent(content, prefix string) string {
+	lines := strings.Split(content, "\n")
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = prefix + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
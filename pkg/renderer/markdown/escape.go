@@ -0,0 +1,40 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// escaper escapes the characters which would otherwise be interpreted as inline Markdown syntax
+var escaper = strings.NewReplacer(
+	"\\", "\\\\",
+	"`", "\\`",
+	"*", "\\*",
+	"_", "\\_",
+	"[", "\\[",
+	"]", "\\]",
+	"<", "\\<",
+	"~", "\\~",
+)
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// blockMarker matches the start of a line which would otherwise be interpreted as a block in Markdown
+// (heading, blockquote, list item, setext heading underline, etc.)
+var blockMarker = regexp.MustCompile(`^(?:[#>+=-]|\d+[.)])`)
+
+// escapeLines removes the leading spaces (which could start an indented code block) on each line,
+// and escapes the characters which would start a new block
+func escapeLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		l = strings.TrimLeft(l, " \t")
+		if loc := blockMarker.FindStringIndex(l); loc != nil {
+			l = l[:loc[1]-1] + "\\" + l[loc[1]-1:]
+		}
+		lines[i] = l
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,30 @@
+package markdown
+
+import (
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml/html5"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+// renderHTML renders the given element in raw HTML, for the constructs which cannot be expressed in Markdown
+func (r *markdownRenderer) renderHTML(ctx *context, element interface{}, description string) (string, error) {
+	sourceRange, _ := types.SourceRangeOf(element)
+	ctx.config.Diagnostics.Warnf(types.DiagnosticUnsupportedElement, sourceRange, "%s cannot be rendered in Markdown, using raw HTML instead", description)
+	doc := &types.Document{
+		Elements:          []interface{}{element},
+		ElementReferences: ctx.elementReferences,
+	}
+	config := configuration.NewConfiguration(
+		configuration.WithAttributes(ctx.attributes.Clone()),
+		configuration.WithBackEnd("html5"),
+	)
+	result := &strings.Builder{}
+	if _, err := html5.Render(doc, config, result); err != nil {
+		return "", errors.Wrapf(err, "unable to render %s in HTML", description)
+	}
+	return result.String(), nil
+}
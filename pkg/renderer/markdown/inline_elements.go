@@ -0,0 +1,310 @@
+package markdown
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+func (r *markdownRenderer) renderInlineElements(ctx *context, elements []interface{}) (string, error) {
+	buff := &strings.Builder{}
+	for _, element := range elements {
+		renderedElement, err := r.renderInlineElement(ctx, element)
+		if err != nil {
+			return "", err
+		}
+		buff.WriteString(renderedElement)
+	}
+	return buff.String(), nil
+}
+
+// renderInlineElement renders the given inline element
+//
+//nolint:gocyclo
+func (r *markdownRenderer) renderInlineElement(ctx *context, element interface{}) (string, error) {
+	switch e := element.(type) {
+	case *types.StringElement:
+		return escape(e.Content), nil
+	case *types.SpecialCharacter:
+		return escape(e.Name), nil
+	case *types.Symbol:
+		return r.renderSymbol(e), nil
+	case *types.PredefinedAttribute:
+		return r.renderPredefinedAttribute(e), nil
+	case *types.QuotedText:
+		return r.renderQuotedText(ctx, e)
+	case *types.InlinePassthrough:
+		return r.renderVerbatimElements(e.Elements)
+	case *types.InlineStem:
+		return r.renderInlineStem(ctx, e), nil
+	case *types.InlineLink:
+		return r.renderLink(ctx, e)
+	case *types.InternalCrossReference:
+		return r.renderInternalCrossReference(ctx, e)
+	case *types.ExternalCrossReference:
+		return r.renderExternalCrossReference(ctx, e)
+	case *types.InlineImage:
+		if e.Attributes.Has(types.AttrWidth) || e.Attributes.Has(types.AttrHeight) {
+			content, err := r.renderHTML(ctx, &types.Paragraph{Elements: []interface{}{e}}, "image with dimensions")
+			if err != nil {
+				return "", err
+			}
+			return htmlParagraphContent(content), nil
+		}
+		return image(ctx, e.Attributes, e.Location), nil
+	case *types.Icon:
+		return "\\[" + escape(e.Attributes.GetAsStringWithDefault(types.AttrImageAlt, e.Class)) + "\\]", nil
+	case *types.InlineButton:
+		return "**\\[" + escape(e.Attributes.GetAsStringWithDefault(types.AttrButtonLabel, "")) + "\\]**", nil
+	case *types.InlineMenu:
+		path := make([]string, len(e.Path))
+		for i, p := range e.Path {
+			path[i] = "**" + escape(p) + "**"
+		}
+		return strings.Join(path, " › "), nil
+	case *types.FootnoteReference:
+		return r.renderFootnoteReference(e), nil
+	case *types.Callout:
+		return "<" + strconv.Itoa(e.Ref) + ">", nil
+	case *types.LineBreak:
+		return "\\", nil
+	case *types.IndexTerm:
+		return r.renderInlineElements(ctx, e.Term)
+	case *types.ConcealedIndexTerm:
+		return "", nil
+	case *types.UserMacro:
+		content, err := r.renderHTML(ctx, &types.Paragraph{Elements: []interface{}{e}}, "user macro '"+e.Name+"'")
+		if err != nil {
+			return "", err
+		}
+		return htmlParagraphContent(content), nil
+	case *types.AttributeDeclaration:
+		ctx.attributes[e.Name] = e.Value
+		return "", nil
+	case *types.AttributeReset:
+		delete(ctx.attributes, e.Name)
+		return "", nil
+	default:
+		return "", errors.Errorf("unsupported type of element: %T", element)
+	}
+}
+
+func (r *markdownRenderer) renderQuotedText(ctx *context, t *types.QuotedText) (string, error) {
+	if t.Kind == types.SingleQuoteMonospace || t.Kind == types.DoubleQuoteMonospace {
+		return r.renderMonospaceText(ctx, t)
+	}
+	content, err := r.renderInlineElements(ctx, t.Elements)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render quoted text")
+	}
+	switch t.Kind {
+	case types.SingleQuoteBold, types.DoubleQuoteBold:
+		return "**" + content + "**", nil
+	case types.SingleQuoteItalic, types.DoubleQuoteItalic:
+		return "_" + content + "_", nil
+	case types.SingleQuoteSuperscript:
+		return "<sup>" + content + "</sup>", nil
+	case types.SingleQuoteSubscript:
+		return "<sub>" + content + "</sub>", nil
+	default: // marked text
+		return "<mark>" + content + "</mark>", nil
+	}
+}
+
+// renderMonospaceText renders the text in a code span if it only contains some characters,
+// or within an HTML `<code>` element if it also contains some formatted text
+func (r *markdownRenderer) renderMonospaceText(ctx *context, t *types.QuotedText) (string, error) {
+	raw := &strings.Builder{}
+	for _, e := range t.Elements {
+		switch e := e.(type) {
+		case *types.StringElement:
+			raw.WriteString(e.Content)
+		case *types.SpecialCharacter:
+			raw.WriteString(e.Name)
+		case *types.InlinePassthrough:
+			content, err := r.renderVerbatimElements(e.Elements)
+			if err != nil {
+				return "", errors.Wrap(err, "unable to render monospace text")
+			}
+			raw.WriteString(content)
+		default:
+			content, err := r.renderInlineElements(ctx, t.Elements)
+			if err != nil {
+				return "", errors.Wrap(err, "unable to render monospace text")
+			}
+			return "<code>" + content + "</code>", nil
+		}
+	}
+	return codeSpan(raw.String()), nil
+}
+
+// codeSpan returns the given content within backticks. The delimiter is longer than
+// any sequence of backticks in the content, and padded with spaces if the content
+// starts or ends with a backtick.
+func codeSpan(content string) string {
+	delimiter := "`"
+	for strings.Contains(content, delimiter) {
+		delimiter += "`"
+	}
+	if strings.HasPrefix(content, "`") || strings.HasSuffix(content, "`") {
+		content = " " + content + " "
+	}
+	return delimiter + content + delimiter
+}
+
+// renderLink renders the link with its text, or as an autolink if the link has no text
+func (r *markdownRenderer) renderLink(ctx *context, l *types.InlineLink) (string, error) {
+	url := l.Location.ToString()
+	text, err := r.renderAttribute(ctx, l.Attributes[types.AttrInlineLinkText])
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render link")
+	}
+	if text == "" {
+		if l.Location.Scheme != "" && !strings.ContainsAny(url, " <>") {
+			return "<" + url + ">", nil
+		}
+		text = escape(l.Location.ToDisplayString())
+	}
+	return "[" + text + "](" + destination(url) + ")", nil
+}
+
+func (r *markdownRenderer) renderInternalCrossReference(ctx *context, xref *types.InternalCrossReference) (string, error) {
+	id, ok := xref.ID.(string)
+	if !ok {
+		return "", errors.Errorf("unable to process internal cross reference: invalid ID: '%v'", xref.ID)
+	}
+	label, err := r.renderAttribute(ctx, xref.Label)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render internal cross reference")
+	}
+	if label == "" {
+		if label, err = r.renderAttribute(ctx, ctx.elementReferences[id]); err != nil {
+			return "", errors.Wrap(err, "unable to render internal cross reference")
+		}
+	}
+	if label == "" {
+		label = "\\[" + escape(id) + "\\]"
+	}
+	anchor, found := ctx.anchors[id]
+	if !found {
+		anchor = id
+	}
+	return "[" + label + "](#" + anchor + ")", nil
+}
+
+func (r *markdownRenderer) renderExternalCrossReference(ctx *context, xref *types.ExternalCrossReference) (string, error) {
+	loc := xref.Location.ToDisplayString()
+	// link to the Markdown document which is generated from the target document
+	loc = strings.TrimSuffix(loc, filepath.Ext(loc)) + ".md"
+	label, err := r.renderAttribute(ctx, xref.Attributes[types.AttrXRefLabel])
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render external cross reference")
+	}
+	if label == "" {
+		label = escape(loc)
+	}
+	return "[" + label + "](" + destination(loc) + ")", nil
+}
+
+// htmlParagraphContent returns the content of the HTML paragraph rendered for an inline element
+func htmlParagraphContent(content string) string {
+	content = strings.TrimSpace(content)
+	content = strings.TrimPrefix(content, "<div class=\"paragraph\">\n<p>")
+	content = strings.TrimSuffix(content, "</p>\n</div>")
+	return content
+}
+
+var symbols = map[string]string{
+	"(C)":  "©",
+	"(R)":  "®",
+	"(TM)": "™",
+	"...":  "…",
+	"'":    "’",
+	"'`":   "‘",
+	"`'":   "’",
+	"\"`":  "“",
+	"`\"":  "”",
+	"->":   "→",
+	"<-":   "←",
+	"=>":   "⇒",
+	"<=":   "⇐",
+	"--":   "—",
+	" -- ": " — ",
+}
+
+func (r *markdownRenderer) renderSymbol(s *types.Symbol) string {
+	if str, found := symbols[s.Name]; found {
+		return str
+	}
+	return escape(s.Name)
+}
+
+var predefinedAttributes = map[string]string{
+	"sp":             " ",
+	"blank":          "",
+	"empty":          "",
+	"nbsp":           "&nbsp;",
+	"zwsp":           "&#8203;",
+	"wj":             "&#8288;",
+	"apos":           "'",
+	"quot":           "\"",
+	"lsquo":          "‘",
+	"rsquo":          "’",
+	"ldquo":          "“",
+	"rdquo":          "”",
+	"deg":            "°",
+	"plus":           "+",
+	"brvbar":         "¦",
+	"vbar":           "|",
+	"amp":            "&",
+	"lt":             "\\<",
+	"gt":             ">",
+	"startsb":        "\\[",
+	"endsb":          "\\]",
+	"caret":          "^",
+	"asterisk":       "\\*",
+	"tilde":          "\\~",
+	"backslash":      "\\\\",
+	"backtick":       "\\`",
+	"two-colons":     "::",
+	"two-semicolons": ";;",
+	"cpp":            "C++",
+}
+
+func (r *markdownRenderer) renderPredefinedAttribute(a *types.PredefinedAttribute) string {
+	if value, found := predefinedAttributes[a.Name]; found {
+		return value
+	}
+	return "{" + a.Name + "}"
+}
+
+// ------------------------------------------------------------
+// Footnotes
+// ------------------------------------------------------------
+
+func (r *markdownRenderer) renderFootnoteReference(note *types.FootnoteReference) string {
+	if note.ID == types.InvalidFootnoteReference {
+		// already reported while parsing the document
+		log.Debugf("invalid footnote reference: '%s'", note.Ref)
+		return "\\[" + escape(note.Ref) + "\\]"
+	}
+	return "[^" + strconv.Itoa(note.ID) + "]"
+}
+
+// renderFootnotes renders the footnote definitions at the end of the document
+func (r *markdownRenderer) renderFootnotes(ctx *context, notes []*types.Footnote) (string, error) {
+	result := &strings.Builder{}
+	for _, note := range notes {
+		content, err := r.renderInlineElements(ctx, note.Elements)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render footnote")
+		}
+		result.WriteString("[^" + strconv.Itoa(note.ID) + "]: " + strings.TrimSpace(content) + "\n")
+	}
+	return result.String(), nil
+}
@@ -0,0 +1,129 @@
+package markdown
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+func (r *markdownRenderer) renderList(ctx *context, l *types.List) (string, error) {
+	if l.Kind == types.LabeledListKind {
+		return r.renderHTML(ctx, l, "labeled list")
+	}
+	title, err := r.renderElementTitle(ctx, l.Attributes)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render list title")
+	}
+	result := &strings.Builder{}
+	result.WriteString(title)
+	switch l.Kind {
+	case types.OrderedListKind:
+		err = r.renderOrderedList(ctx, result, l)
+	case types.UnorderedListKind:
+		err = r.renderUnorderedList(ctx, result, l)
+	case types.CalloutListKind:
+		err = r.renderCalloutList(ctx, result, l)
+	default:
+		err = fmt.Errorf("unable to render list of kind '%s'", l.Kind)
+	}
+	if err != nil {
+		return "", err
+	}
+	return result.String(), nil
+}
+
+func (r *markdownRenderer) renderOrderedList(ctx *context, result *strings.Builder, l *types.List) error {
+	start := l.Attributes.GetAsIntWithDefault(types.AttrStart, 1)
+	for i, element := range l.Elements {
+		e, ok := element.(*types.OrderedListElement)
+		if !ok {
+			return errors.Errorf("unable to render ordered list element of type '%T'", element)
+		}
+		if i == 0 {
+			if style := l.Attributes.GetAsStringWithDefault(types.AttrStyle, e.Style); style != types.Arabic {
+				ctx.config.Diagnostics.Warnf(types.DiagnosticUnsupportedElement, l.SourceRange, "'%s' numbering style of ordered list is not rendered in Markdown", style)
+			}
+		}
+		if err := r.renderListElement(ctx, result, strconv.Itoa(start+i)+". ", e.Elements); err != nil {
+			return errors.Wrap(err, "unable to render ordered list element")
+		}
+	}
+	return nil
+}
+
+func (r *markdownRenderer) renderUnorderedList(ctx *context, result *strings.Builder, l *types.List) error {
+	for _, element := range l.Elements {
+		e, ok := element.(*types.UnorderedListElement)
+		if !ok {
+			return errors.Errorf("unable to render unordered list element of type '%T'", element)
+		}
+		if err := r.renderListElement(ctx, result, "- ", e.Elements); err != nil {
+			return errors.Wrap(err, "unable to render unordered list element")
+		}
+	}
+	return nil
+}
+
+func (r *markdownRenderer) renderCalloutList(ctx *context, result *strings.Builder, l *types.List) error {
+	for _, element := range l.Elements {
+		e, ok := element.(*types.CalloutListElement)
+		if !ok {
+			return errors.Errorf("unable to render callout list element of type '%T'", element)
+		}
+		if err := r.renderListElement(ctx, result, strconv.Itoa(e.Ref)+". ", e.Elements); err != nil {
+			return errors.Wrap(err, "unable to render callout list element")
+		}
+	}
+	return nil
+}
+
+// renderListElement renders the content of a list element after the given marker: the first paragraph
+// is rendered on the same line as the marker, and the other elements are indented to the width of the marker
+func (r *markdownRenderer) renderListElement(ctx *context, result *strings.Builder, marker string, elements []interface{}) error {
+	content := &strings.Builder{}
+	for i, element := range elements {
+		if p, ok := element.(*types.Paragraph); ok && i == 0 && !p.Attributes.Has(types.AttrStyle) {
+			c, err := r.renderParagraphElements(ctx, p)
+			if err != nil {
+				return err
+			}
+			content.WriteString(checkStyle(p.Attributes[types.AttrCheckStyle]))
+			content.WriteString(c + "\n")
+			continue
+		}
+		c, err := r.renderElement(ctx, element)
+		if err != nil {
+			return err
+		}
+		if c == "" {
+			continue
+		}
+		// nested lists are rendered without a blank line, so that the list remains "tight"
+		if _, ok := element.(*types.List); !ok && i > 0 {
+			content.WriteString("\n")
+		}
+		content.WriteString(c)
+	}
+	padding := strings.Repeat(" ", len(marker))
+	result.WriteString(marker)
+	result.WriteString(strings.TrimPrefix(indent(content.String(), padding), padding))
+	if content.Len() == 0 {
+		result.WriteString("\n")
+	}
+	return nil
+}
+
+func checkStyle(style interface{}) string {
+	switch style {
+	case types.Checked, types.CheckedInteractive:
+		return "[x] "
+	case types.Unchecked, types.UncheckedInteractive:
+		return "[ ] "
+	default:
+		return ""
+	}
+}
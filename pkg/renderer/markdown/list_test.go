@@ -0,0 +1,76 @@
+package markdown_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("lists", func() {
+
+	It("nested unordered lists", func() {
+		source := `* item one
+** nested
+* item two`
+		expected := `- item one
+  - nested
+- item two
+`
+		Expect(RenderMarkdown(source)).To(Equal(expected))
+	})
+
+	It("checklist", func() {
+		source := `* [x] done
+* [ ] todo
+* regular`
+		expected := `- [x] done
+- [ ] todo
+- regular
+`
+		Expect(RenderMarkdown(source)).To(Equal(expected))
+	})
+
+	It("ordered list with start and continuation", func() {
+		source := `[start=9]
+. first
++
+more about the first
+. second`
+		expected := `9. first
+
+   more about the first
+10. second
+`
+		Expect(RenderMarkdown(source)).To(Equal(expected))
+	})
+
+	It("ordered list with style", func() {
+		diagnostics := types.NewDiagnostics()
+		source := `[loweralpha]
+. alpha
+. beta`
+		expected := `1. alpha
+2. beta
+`
+		Expect(RenderMarkdown(source, configuration.WithDiagnostics(diagnostics))).To(Equal(expected))
+		Expect(diagnostics.All()).To(ContainElement(HaveField("Message", "'loweralpha' numbering style of ordered list is not rendered in Markdown")))
+	})
+
+	It("labeled list in raw HTML", func() {
+		diagnostics := types.NewDiagnostics()
+		source := `term:: description`
+		expected := `<div class="dlist">
+<dl>
+<dt class="hdlist1">term</dt>
+<dd>
+<p>description</p>
+</dd>
+</dl>
+</div>
+`
+		Expect(RenderMarkdown(source, configuration.WithDiagnostics(diagnostics))).To(Equal(expected))
+		Expect(diagnostics.All()).To(ContainElement(HaveField("Message", "labeled list cannot be rendered in Markdown, using raw HTML instead")))
+	})
+})
@@ -0,0 +1,73 @@
+// Package markdown renders documents in GitHub-flavored Markdown.
+//
+// The constructs which cannot be expressed in Markdown are rendered in raw HTML,
+// and a warning is logged when some content cannot be rendered without loss.
+package markdown
+
+import (
+	"io"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+// Render renders the given document in GitHub-flavored Markdown, in the given output writer
+func Render(doc *types.Document, config *configuration.Configuration, output io.Writer) (types.Metadata, error) {
+	r := &markdownRenderer{}
+	ctx := newContext(doc, config)
+	metadata := types.Metadata{
+		LastUpdated:     config.LastUpdated.Format(configuration.LastUpdatedFormat),
+		TableOfContents: doc.TableOfContents,
+	}
+	blocks := []string{}
+	if header, _ := doc.Header(); header != nil {
+		title, err := sgml.RenderPlainText(header.Title, sgml.WithoutEscape())
+		if err != nil {
+			return metadata, errors.Wrap(err, "unable to render document title")
+		}
+		metadata.Title = title
+		for _, e := range header.Elements {
+			switch e := e.(type) {
+			case *types.AttributeDeclaration:
+				ctx.attributes[e.Name] = e.Value
+			case *types.AttributeReset:
+				delete(ctx.attributes, e.Name)
+			}
+		}
+		if config.WrapInHTMLBodyElement && len(header.Title) > 0 {
+			renderedTitle, err := r.renderInlineElements(ctx, header.Title)
+			if err != nil {
+				return metadata, errors.Wrap(err, "unable to render document title")
+			}
+			blocks = append(blocks, "# "+renderedTitle+"\n")
+		}
+	}
+	// the table of contents is only lost if it was requested
+	if _, found := ctx.attributes[types.AttrTableOfContents]; found && doc.TableOfContents != nil {
+		config.Diagnostics.Warnf(types.DiagnosticUnsupportedElement, types.SourceRange{}, "table of contents is not rendered in Markdown")
+	}
+	content, err := r.renderElements(ctx, doc.BodyElements())
+	if err != nil {
+		return metadata, errors.Wrap(err, "unable to render document")
+	}
+	if content != "" {
+		blocks = append(blocks, content)
+	}
+	footnotes, err := r.renderFootnotes(ctx, doc.Footnotes)
+	if err != nil {
+		return metadata, errors.Wrap(err, "unable to render footnotes")
+	}
+	if footnotes != "" {
+		blocks = append(blocks, footnotes)
+	}
+	if _, err := io.WriteString(output, strings.Join(blocks, "\n")); err != nil {
+		return metadata, errors.Wrap(err, "unable to render document")
+	}
+	return metadata, nil
+}
+
+type markdownRenderer struct{}
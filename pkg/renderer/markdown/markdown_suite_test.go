@@ -0,0 +1,34 @@
+package markdown_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bytesparadise/libasciidoc"
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	log "github.com/sirupsen/logrus"
+
+	_ "github.com/bytesparadise/libasciidoc/testsupport"
+)
+
+func RenderMarkdown(actual string, settings ...configuration.Setting) (string, error) {
+	allSettings := append([]configuration.Setting{configuration.WithFilename("test.adoc"), configuration.WithBackEnd("markdown")}, settings...)
+	config := configuration.NewConfiguration(allSettings...)
+	resultWriter := bytes.NewBuffer(nil)
+	if _, err := libasciidoc.Convert(strings.NewReader(actual), resultWriter, config); err != nil {
+		return "", err
+	}
+	if log.IsLevelEnabled(log.DebugLevel) {
+		log.Debug(resultWriter.String())
+	}
+	return resultWriter.String(), nil
+}
+
+func TestMarkdown(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Markdown Suite")
+}
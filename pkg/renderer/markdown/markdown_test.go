@@ -0,0 +1,183 @@
+package markdown_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("markdown", func() {
+
+	It("document with title and sections", func() {
+		source := `= Document Title
+
+preamble
+
+== Section A
+
+content
+
+=== Section A.1
+
+more content`
+		expected := `# Document Title
+
+preamble
+
+## Section A
+
+content
+
+### Section A.1
+
+more content
+`
+		Expect(RenderMarkdown(source, configuration.WithHeaderFooter(true))).To(Equal(expected))
+	})
+
+	It("document without title in body", func() {
+		source := `= Document Title
+
+content`
+		expected := `content
+`
+		Expect(RenderMarkdown(source)).To(Equal(expected))
+	})
+
+	It("quoted text", func() {
+		source := "*bold*, _italic_, `mono` and `+a*b+`, H~2~O, x^2^ and #marked#"
+		expected := "**bold**, _italic_, `mono` and `a*b`, H<sub>2</sub>O, x<sup>2</sup> and <mark>marked</mark>\n"
+		Expect(RenderMarkdown(source)).To(Equal(expected))
+	})
+
+	It("escaped characters", func() {
+		source := `a*b [c] and <d>
+# not a heading
+- not a list
+1. not a list either`
+		expected := `a\*b \[c\] and \<d>
+\# not a heading
+\- not a list
+1\. not a list either
+`
+		Expect(RenderMarkdown(source)).To(Equal(expected))
+	})
+
+	It("links", func() {
+		source := `https://example.com[Example], https://example.com[] and link:docs/index.html[the docs]`
+		expected := `[Example](https://example.com), <https://example.com> and [the docs](docs/index.html)
+`
+		Expect(RenderMarkdown(source)).To(Equal(expected))
+	})
+
+	It("cross references", func() {
+		source := `See <<_Section_B,the section>> and xref:other.adoc[the other doc].
+
+== Section B
+
+content`
+		expected := `See [the section](#section-b) and [the other doc](other.md).
+
+## Section B
+
+content
+`
+		Expect(RenderMarkdown(source)).To(Equal(expected))
+	})
+
+	It("footnotes", func() {
+		source := `A statement.footnote:[a note]
+Another statement.footnote:disclaimer[a disclaimer] And again.footnote:disclaimer[]`
+		expected := `A statement.[^1]
+Another statement.[^2] And again.[^2]
+
+[^1]: a note
+[^2]: a disclaimer
+`
+		Expect(RenderMarkdown(source)).To(Equal(expected))
+	})
+
+	It("images", func() {
+		source := `image::images/foo-bar.png[]
+
+.A title
+image::foo.png[Foo]
+
+An inline image:bar.png[Bar].`
+		expected := `![foo bar](images/foo-bar.png)
+
+**A title**
+
+![Foo](foo.png)
+
+An inline ![Bar](bar.png).
+`
+		Expect(RenderMarkdown(source)).To(Equal(expected))
+	})
+
+	It("image with dimensions in raw HTML", func() {
+		diagnostics := types.NewDiagnostics()
+		source := `image::foo.png[Foo,100,50]`
+		expected := `<div class="imageblock">
+<div class="content">
+<img src="foo.png" alt="Foo" width="100" height="50">
+</div>
+</div>
+`
+		Expect(RenderMarkdown(source, configuration.WithDiagnostics(diagnostics))).To(Equal(expected))
+		Expect(diagnostics.All()).To(ContainElement(HaveField("Message", "image with dimensions cannot be rendered in Markdown, using raw HTML instead")))
+	})
+
+	It("thematic break and hard line breaks", func() {
+		source := `first +
+second
+
+'''
+
+[%hardbreaks]
+third
+fourth`
+		expected := `first\
+second
+
+---
+
+third\
+fourth
+`
+		Expect(RenderMarkdown(source)).To(Equal(expected))
+	})
+
+	It("table of contents", func() {
+		diagnostics := types.NewDiagnostics()
+		source := `= Title
+:toc:
+
+== Section
+
+content`
+		expected := `## Section
+
+content
+`
+		Expect(RenderMarkdown(source, configuration.WithDiagnostics(diagnostics))).To(Equal(expected))
+		Expect(diagnostics.All()).To(ContainElement(HaveField("Message", "table of contents is not rendered in Markdown")))
+	})
+
+	It("sections without table of contents", func() {
+		diagnostics := types.NewDiagnostics()
+		source := `= Title
+
+== Section
+
+content`
+		expected := `## Section
+
+content
+`
+		Expect(RenderMarkdown(source, configuration.WithDiagnostics(diagnostics))).To(Equal(expected))
+		Expect(diagnostics.All()).To(BeEmpty())
+	})
+})
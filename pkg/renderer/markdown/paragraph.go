@@ -0,0 +1,101 @@
+package markdown
+
+import (
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+func (r *markdownRenderer) renderParagraph(ctx *context, p *types.Paragraph) (string, error) {
+	switch p.Attributes[types.AttrStyle] {
+	case types.Listing, types.Source, types.LiteralParagraph, types.Literal:
+		return r.renderCodeBlock(ctx, p.Attributes, p.Elements)
+	case types.Verse:
+		content, err := r.renderInlineElements(ctx, p.Elements)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render verse paragraph")
+		}
+		return r.renderQuote(ctx, p.Attributes, hardBreaks(escapeLines(strings.Trim(content, "\n")))+"\n")
+	case types.Quote:
+		content, err := r.renderParagraphElements(ctx, p)
+		if err != nil {
+			return "", err
+		}
+		return r.renderQuote(ctx, p.Attributes, content+"\n")
+	case types.Passthrough:
+		return r.renderPassthrough(ctx, p.Elements)
+	case types.Stem, types.LatexMath, types.AsciiMath:
+		return r.renderStemBlock(ctx, p.Attributes.GetAsStringWithDefault(types.AttrStyle, ""), p.Attributes, p.Elements)
+	case types.Tip, types.Note, types.Important, types.Warning, types.Caution:
+		content, err := r.renderParagraphElements(ctx, p)
+		if err != nil {
+			return "", err
+		}
+		return r.renderAdmonition(ctx, p.Attributes, content+"\n")
+	default:
+		title, err := r.renderElementTitle(ctx, p.Attributes)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render paragraph")
+		}
+		content, err := r.renderParagraphElements(ctx, p)
+		if err != nil {
+			return "", err
+		}
+		return title + content + "\n", nil
+	}
+}
+
+// renderParagraphElements renders the content of the paragraph, without the trailing newline
+func (r *markdownRenderer) renderParagraphElements(ctx *context, p *types.Paragraph) (string, error) {
+	content, err := r.renderInlineElements(ctx, p.Elements)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render paragraph content")
+	}
+	content = escapeLines(strings.Trim(content, "\n"))
+	if p.Attributes.HasOption(types.AttrHardBreaks) || ctx.attributes.HasOption(types.AttrHardBreaks) {
+		content = hardBreaks(content)
+	}
+	return content, nil
+}
+
+// hardBreaks appends a backslash at the end of each line (but the last one), so that the line breaks are retained
+func hardBreaks(content string) string {
+	return strings.ReplaceAll(content, "\n", "\\\n")
+}
+
+// admonitionAlerts the GitHub alerts corresponding to the kinds of admonitions
+var admonitionAlerts = map[string]string{
+	types.Caution:   "[!CAUTION]",
+	types.Important: "[!IMPORTANT]",
+	types.Note:      "[!NOTE]",
+	types.Tip:       "[!TIP]",
+	types.Warning:   "[!WARNING]",
+}
+
+// renderAdmonition renders the (already rendered) content in a GitHub alert
+func (r *markdownRenderer) renderAdmonition(ctx *context, attrs types.Attributes, content string) (string, error) {
+	kind, _ := attrs.GetAsString(types.AttrStyle)
+	if attrs.Has(types.AttrCaption) {
+		ctx.config.Diagnostics.Warnf(types.DiagnosticUnsupportedElement, types.SourceRange{}, "custom caption of '%s' admonition is not rendered in Markdown", strings.ToLower(kind))
+	}
+	title, err := r.renderElementTitle(ctx, attrs)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render admonition")
+	}
+	return blockquote(admonitionAlerts[kind] + "\n" + title + content), nil
+}
+
+// blockquote prefixes each line of the (already rendered) content with `> `
+func blockquote(content string) string {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	for i, l := range lines {
+		if l == "" {
+			lines[i] = ">"
+		} else {
+			lines[i] = "> " + l
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,80 @@
+package markdown
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+// renderSection renders the section title as an ATX heading, followed by the section elements
+func (r *markdownRenderer) renderSection(ctx *context, s *types.Section) (string, error) {
+	title, err := r.renderInlineElements(ctx, s.Title)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render section title")
+	}
+	level := s.Level + 1
+	if level > 6 {
+		level = 6
+	}
+	result := &strings.Builder{}
+	result.WriteString(strings.Repeat("#", level) + " " + strings.TrimSpace(title) + "\n")
+	content, err := r.renderElements(ctx, s.Elements)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render section content")
+	}
+	if content != "" {
+		result.WriteString("\n")
+		result.WriteString(content)
+	}
+	return result.String(), nil
+}
+
+// headingAnchors returns the anchors generated by GitHub for the headings of the given sections
+// (and their subsections), indexed by section ID
+func headingAnchors(elements []interface{}) map[string]string {
+	anchors := map[string]string{}
+	occurrences := map[string]int{}
+	var collect func([]interface{})
+	collect = func(elements []interface{}) {
+		for _, e := range elements {
+			s, ok := e.(*types.Section)
+			if !ok {
+				continue
+			}
+			title, err := sgml.RenderPlainText(s.Title, sgml.WithoutEscape())
+			if err == nil {
+				anchor := slug(title)
+				if n := occurrences[anchor]; n > 0 {
+					occurrences[anchor] = n + 1
+					anchor = anchor + "-" + strconv.Itoa(n)
+				} else {
+					occurrences[anchor] = 1
+				}
+				anchors[s.GetID()] = anchor
+			}
+			collect(s.Elements)
+		}
+	}
+	collect(elements)
+	return anchors
+}
+
+// slug returns the anchor of a heading, using the same rules as GitHub:
+// lowercase letters, digits, hyphens and underscores are retained, and spaces become hyphens
+func slug(title string) string {
+	result := &strings.Builder{}
+	for _, c := range strings.ToLower(strings.TrimSpace(title)) {
+		switch {
+		case c == ' ':
+			result.WriteRune('-')
+		case c == '-' || c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c):
+			result.WriteRune(c)
+		}
+	}
+	return result.String()
+}
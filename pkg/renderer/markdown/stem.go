@@ -0,0 +1,28 @@
+package markdown
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+)
+
+// renderStemBlock renders the LaTeX math equations in a `math` code block, which GitHub displays as a formula,
+// and the AsciiMath equations in an `asciimath` code block, since this notation is not supported in Markdown
+func (r *markdownRenderer) renderStemBlock(ctx *context, kind string, attrs types.Attributes, elements []interface{}) (string, error) {
+	attrs = attrs.Clone()
+	switch types.StemNotation(kind, ctx.attributes) {
+	case types.LatexMath:
+		attrs[types.AttrLanguage] = "math"
+	default:
+		attrs[types.AttrLanguage] = types.AsciiMath
+	}
+	return r.renderCodeBlock(ctx, attrs, elements)
+}
+
+// renderInlineStem renders the LaTeX math equations with the "$`...`$" delimiters, and the AsciiMath equations in a code span
+func (r *markdownRenderer) renderInlineStem(ctx *context, s *types.InlineStem) string {
+	switch types.StemNotation(s.Kind, ctx.attributes) {
+	case types.LatexMath:
+		return "$`" + s.Content + "`$"
+	default:
+		return codeSpan(s.Content)
+	}
+}
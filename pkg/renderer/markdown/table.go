@@ -0,0 +1,147 @@
+package markdown
+
+import (
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+// renderTable renders the table in the GFM syntax, or in HTML if some cells contain blocks
+// which cannot be rendered on a single line
+func (r *markdownRenderer) renderTable(ctx *context, t *types.Table) (string, error) {
+	columns, err := t.Columns()
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render table")
+	}
+	if len(columns) == 0 {
+		// no explicit column definitions: use the number of cells in the rows
+		for _, row := range append([]*types.TableRow{t.Header, t.Footer}, t.Rows...) {
+			for row != nil && len(columns) < len(row.Cells) {
+				columns = append(columns, &types.TableColumn{
+					HAlign: types.HAlignLeft,
+					VAlign: types.VAlignTop,
+				})
+			}
+		}
+	}
+	if len(columns) == 0 {
+		return "", nil
+	}
+	if reason, ok := inlineTable(t, columns); !ok {
+		return r.renderHTML(ctx, t, reason)
+	}
+	title, err := r.renderElementTitle(ctx, t.Attributes)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render table title")
+	}
+	result := &strings.Builder{}
+	result.WriteString(title)
+	// GFM tables always have a header row
+	header := make([]string, len(columns))
+	if t.Header != nil {
+		if header, err = r.renderTableRow(ctx, t.Header, nil); err != nil {
+			return "", errors.Wrap(err, "unable to render table header")
+		}
+	} else {
+		ctx.config.Diagnostics.Warnf(types.DiagnosticUnsupportedElement, t.SourceRange, "table without header is rendered with an empty header row in Markdown")
+	}
+	writeTableRow(result, header, len(columns))
+	delimiters := make([]string, len(columns))
+	for i, c := range columns {
+		switch c.HAlign {
+		case types.HAlignCenter:
+			delimiters[i] = ":-:"
+		case types.HAlignRight:
+			delimiters[i] = "--:"
+		default:
+			delimiters[i] = "---"
+		}
+	}
+	writeTableRow(result, delimiters, len(columns))
+	rows := append([]*types.TableRow{}, t.Rows...)
+	if t.Footer != nil {
+		rows = append(rows, t.Footer)
+	}
+	for _, row := range rows {
+		cells, err := r.renderTableRow(ctx, row, columns)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render table row")
+		}
+		writeTableRow(result, cells, len(columns))
+	}
+	return result.String(), nil
+}
+
+// inlineTable returns true if all the cells of the table only contain paragraphs, without spans or custom styles,
+// otherwise it returns the description of the table which cannot be rendered in GFM
+func inlineTable(t *types.Table, columns []*types.TableColumn) (string, bool) {
+	for _, c := range columns {
+		if c.Style == types.AsciidocStyle {
+			return "table with block content", false
+		}
+	}
+	for _, row := range append([]*types.TableRow{t.Header, t.Footer}, t.Rows...) {
+		if row == nil {
+			continue
+		}
+		for _, cell := range row.Cells {
+			// cells with spans or custom styles cannot be rendered in GFM either
+			if cell.Colspan() > 1 || cell.Rowspan() > 1 {
+				return "table with spanned cells", false
+			}
+			if cell.Style() != "" {
+				return "table with styled cells", false
+			}
+			for _, e := range cell.Elements {
+				if p, ok := e.(*types.Paragraph); !ok || p.Attributes.Has(types.AttrStyle) {
+					return "table with block content", false
+				}
+			}
+		}
+	}
+	return "", true
+}
+
+func writeTableRow(result *strings.Builder, cells []string, width int) {
+	result.WriteString("|")
+	for i := 0; i < width; i++ {
+		if i < len(cells) && cells[i] != "" {
+			result.WriteString(" " + cells[i] + " ")
+		} else {
+			result.WriteString(" ")
+		}
+		result.WriteString("|")
+	}
+	result.WriteString("\n")
+}
+
+// renderTableRow renders the cells of the given row, applying the style of the corresponding columns (if provided)
+func (r *markdownRenderer) renderTableRow(ctx *context, row *types.TableRow, columns []*types.TableColumn) ([]string, error) {
+	cells := make([]string, len(row.Cells))
+	for i, cell := range row.Cells {
+		paragraphs := make([]string, 0, len(cell.Elements))
+		for _, e := range cell.Elements {
+			p := e.(*types.Paragraph) // see `inlineTable`
+			content, err := r.renderInlineElements(ctx, p.Elements)
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to render table cell")
+			}
+			// cell content must fit on a single line
+			content = strings.Join(strings.Fields(content), " ")
+			paragraphs = append(paragraphs, strings.ReplaceAll(content, "|", "\\|"))
+		}
+		content := strings.Join(paragraphs, "<br><br>")
+		if content != "" && i < len(columns) {
+			switch columns[i].Style {
+			case types.HeaderStyle, types.StrongStyle:
+				content = "**" + content + "**"
+			case types.EmphasisStyle:
+				content = "_" + content + "_"
+			}
+		}
+		cells[i] = content
+	}
+	return cells, nil
+}
@@ -0,0 +1,73 @@
+package markdown_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("tables", func() {
+
+	It("table with header and alignments", func() {
+		source := `.Results
+[cols="<,^,>"]
+|===
+|Name |Status |Count
+
+|foo |*ok* |1
+|bar |_ko_ |2
+|===`
+		expected := `**Results**
+
+| Name | Status | Count |
+| --- | :-: | --: |
+| foo | **ok** | 1 |
+| bar | _ko_ | 2 |
+`
+		Expect(RenderMarkdown(source)).To(Equal(expected))
+	})
+
+	It("table without header", func() {
+		diagnostics := types.NewDiagnostics()
+		source := `|===
+|a |b
+|===`
+		expected := `| | |
+| --- | --- |
+| a | b |
+`
+		Expect(RenderMarkdown(source, configuration.WithDiagnostics(diagnostics))).To(Equal(expected))
+		Expect(diagnostics.All()).To(ContainElement(HaveField("Message", "table without header is rendered with an empty header row in Markdown")))
+	})
+
+	It("table with block content in raw HTML", func() {
+		diagnostics := types.NewDiagnostics()
+		source := `[cols="1,1a"]
+|===
+|a |* item
+|===`
+		Expect(RenderMarkdown(source, configuration.WithDiagnostics(diagnostics))).To(HavePrefix(`<table class="tableblock frame-all grid-all stretch">`))
+		Expect(diagnostics.All()).To(ContainElement(HaveField("Message", "table with block content cannot be rendered in Markdown, using raw HTML instead")))
+	})
+
+	It("table with spanned cells in raw HTML", func() {
+		diagnostics := types.NewDiagnostics()
+		source := `|===
+|a |b
+2+|c
+|===`
+		Expect(RenderMarkdown(source, configuration.WithDiagnostics(diagnostics))).To(ContainSubstring(`<td class="tableblock halign-left valign-top" colspan="2"><p class="tableblock">c</p></td>`))
+		Expect(diagnostics.All()).To(ContainElement(HaveField("Message", "table with spanned cells cannot be rendered in Markdown, using raw HTML instead")))
+	})
+
+	It("table with styled cells in raw HTML", func() {
+		diagnostics := types.NewDiagnostics()
+		source := `|===
+|a e|b
+|===`
+		Expect(RenderMarkdown(source, configuration.WithDiagnostics(diagnostics))).To(HavePrefix(`<table class="tableblock frame-all grid-all stretch">`))
+		Expect(diagnostics.All()).To(ContainElement(HaveField("Message", "table with styled cells cannot be rendered in Markdown, using raw HTML instead")))
+	})
+})
@@ -5,8 +5,14 @@ import (
 	"io"
 
 	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/ast"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/manpage"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/markdown"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml/docbook5"
 	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml/html5"
 	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml/xhtml5"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/text"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 )
 
@@ -16,7 +22,30 @@ func Render(doc *types.Document, config *configuration.Configuration, output io.
 		return html5.Render(doc, config, output)
 	case "xhtml", "xhtml5":
 		return xhtml5.Render(doc, config, output)
+	case "docbook", "docbook5":
+		return docbook5.Render(doc, config, output)
+	case "manpage":
+		return manpage.Render(doc, config, output)
+	case "markdown", "md":
+		return markdown.Render(doc, config, output)
+	case "text", "txt":
+		return text.Render(doc, config, output)
+	case "json", "ast", "yaml":
+		return ast.Render(doc, config, output)
 	default:
 		return types.Metadata{}, fmt.Errorf("backend '%s' not supported", config.BackEnd)
 	}
 }
+
+// RenderChunks renders the document in multiple pages, split at the sections of the given level (see `sgml.RenderChunks()`).
+// Only the HTML5 and XHTML5 backends support this mode.
+func RenderChunks(doc *types.Document, config *configuration.Configuration, level int) ([]sgml.Chunk, types.Metadata, error) {
+	switch config.BackEnd {
+	case "html", "html5":
+		return html5.RenderChunks(doc, config, level)
+	case "xhtml", "xhtml5":
+		return xhtml5.RenderChunks(doc, config, level)
+	default:
+		return nil, types.Metadata{}, fmt.Errorf("backend '%s' does not support the chunked output", config.BackEnd)
+	}
+}
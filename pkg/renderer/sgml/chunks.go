@@ -0,0 +1,286 @@
+package sgml
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Chunk a page of a document rendered in chunks (see `RenderChunks`)
+type Chunk struct {
+	Name    string // the name of the page (eg: `installation.html`)
+	Title   string // the title of the page, in plain text
+	Content string
+}
+
+// chunk a page of the document, along with the elements it contains
+type chunk struct {
+	name     string
+	section  *types.Section // the section rendered in the page (nil for the first page)
+	elements []interface{}
+	parent   *chunk
+	title    string // the title of the page, rendered in HTML
+}
+
+// RenderChunks renders the document in multiple pages: the first page contains the header and the preamble of the document,
+// and each section whose level is lower than or equal to the given level is rendered in a page of its own,
+// without its subsections which are also rendered in pages of their own.
+// Each page has its own table of contents (if the `toc` attribute is set) and footnotes, along with the links to the previous,
+// next and parent pages. The cross references and the links to the elements in another page link to this page.
+// The first page is named after the document (or `index.html` if the name of the document is unknown),
+// and the other pages are named after the ID of their section.
+func RenderChunks(doc *types.Document, config *configuration.Configuration, tmpls Templates, level int) ([]Chunk, types.Metadata, error) {
+	if level < 0 {
+		return nil, types.Metadata{}, errors.Errorf("invalid chunk level: %d", level)
+	}
+	r := newSGMLRenderer(tmpls)
+	ctx := newContext(doc, config)
+	metadata, renderedTitle, err := r.prepare(ctx, doc)
+	if err != nil {
+		return nil, metadata, errors.Wrap(err, "unable to render chunks")
+	}
+	if ctx.doctype() == "manpage" {
+		return nil, metadata, errors.New("unable to render a manpage in chunks")
+	}
+	chunks := splitInChunks(doc, config.Filename, level)
+	ctx.chunks = map[string]string{}
+	for _, c := range chunks {
+		for _, id := range c.ids() {
+			// the IDs are rendered in lower case, hence the references to them are resolved regardless of the case
+			id = strings.ToLower(id)
+			if _, found := ctx.chunks[id]; !found {
+				ctx.chunks[id] = c.name
+			}
+		}
+	}
+	header, _ := doc.Header()
+	renderedHeader, err := r.renderDocumentHeader(ctx, header)
+	if err != nil {
+		return nil, metadata, errors.Wrap(err, "unable to render chunks")
+	}
+	titles := make([]string, len(chunks)) // in plain text
+	for i, c := range chunks {
+		if c.section == nil {
+			titles[i] = renderedTitle
+			if c.title, err = r.renderDocumentHeaderTitle(ctx, header); err != nil {
+				return nil, metadata, errors.Wrap(err, "unable to render chunks")
+			}
+			if c.title == "" {
+				c.title = DefaultTitle
+			}
+			continue
+		}
+		if titles[i], err = RenderPlainText(c.section.Title); err != nil {
+			return nil, metadata, errors.Wrap(err, "unable to render chunks")
+		}
+		if c.title, err = r.renderInlineElements(ctx, c.section.Title); err != nil {
+			return nil, metadata, errors.Wrap(err, "unable to render chunks")
+		}
+		c.title = strings.TrimSpace(c.title)
+	}
+	result := make([]Chunk, len(chunks))
+	for i, c := range chunks {
+		log.Debugf("rendering chunk '%s'", c.name)
+		ctx.chunk = c.name
+		content, err := r.renderChunk(ctx, doc, chunks, i)
+		if err != nil {
+			return nil, metadata, errors.Wrapf(err, "unable to render chunk '%s'", c.name)
+		}
+		output := &strings.Builder{}
+		if ctx.config.WrapInHTMLBodyElement {
+			h := ""
+			if c.section == nil {
+				// the header of the document is only rendered in the first page
+				h = renderedHeader
+			}
+			if err := r.renderArticle(ctx, doc, output, titles[i], h, content); err != nil {
+				return nil, metadata, err
+			}
+		} else {
+			output.WriteString(content)
+		}
+		result[i] = Chunk{
+			Name:    c.name,
+			Title:   titles[i],
+			Content: output.String(),
+		}
+	}
+	return result, metadata, nil
+}
+
+// splitInChunks splits the body of the document in pages
+func splitInChunks(doc *types.Document, filename string, level int) []*chunk {
+	first := &chunk{
+		name: "index.html",
+	}
+	if filename != "" {
+		name := filepath.Base(filename)
+		first.name = strings.TrimSuffix(name, filepath.Ext(name)) + ".html"
+	}
+	chunks := []*chunk{first}
+	names := map[string]bool{
+		first.name: true,
+	}
+	var split func(elements []interface{}, parent *chunk) []interface{}
+	split = func(elements []interface{}, parent *chunk) []interface{} {
+		result := make([]interface{}, 0, len(elements))
+		for _, e := range elements {
+			if s, ok := e.(*types.Section); ok && s.Level <= level {
+				c := &chunk{
+					name:   chunkName(s.GetID(), names),
+					parent: parent,
+				}
+				chunks = append(chunks, c)
+				// the subsections which are rendered in pages of their own are excluded from the copy of the section
+				section := *s
+				section.Elements = split(s.Elements, c)
+				c.section = &section
+				c.elements = []interface{}{&section}
+				continue
+			}
+			result = append(result, e)
+		}
+		return result
+	}
+	first.elements = split(doc.BodyElements(), first)
+	return chunks
+}
+
+var chunkNameReplacer = strings.NewReplacer("/", "_", "\\", "_")
+
+// chunkName returns a name based on the given ID, which is not in the given names
+func chunkName(id string, names map[string]bool) string {
+	base := strings.TrimPrefix(chunkNameReplacer.Replace(strings.ToLower(id)), "_")
+	if base == "" {
+		base = "chunk"
+	}
+	name := base + ".html"
+	for i := 2; names[name]; i++ {
+		name = fmt.Sprintf("%s-%d.html", base, i)
+	}
+	names[name] = true
+	return name
+}
+
+// ids returns the IDs of the elements in the page
+func (c *chunk) ids() []string {
+	result := []string{}
+	types.Walk(c.elements, func(element interface{}) bool {
+		if e, ok := element.(types.WithAttributes); ok {
+			if id, ok := e.GetAttributes()[types.AttrID].(string); ok {
+				result = append(result, id)
+			}
+		}
+		return true
+	})
+	return result
+}
+
+// footnotes returns the footnotes which are referenced in the page
+func (c *chunk) footnotes(doc *types.Document) []*types.Footnote {
+	refs := map[int]bool{}
+	types.Walk(c.elements, func(element interface{}) bool {
+		if e, ok := element.(*types.FootnoteReference); ok {
+			refs[e.ID] = true
+		}
+		return true
+	})
+	result := []*types.Footnote{}
+	for _, note := range doc.Footnotes {
+		if refs[note.ID] {
+			result = append(result, note)
+		}
+	}
+	return result
+}
+
+// renderChunk renders the elements of the page at the given index, along with its table of contents, its footnotes
+// and the links to the other pages
+func (r *sgmlRenderer) renderChunk(ctx *context, doc *types.Document, chunks []*chunk, index int) (string, error) {
+	c := chunks[index]
+	var content string
+	var err error
+	if c.section == nil {
+		content, err = r.renderDocumentBody(ctx, c.elements, doc.TableOfContents, c.footnotes(doc))
+	} else {
+		elements := c.elements
+		if _, found := ctx.attributes[types.AttrTableOfContents]; found {
+			if toc := chunkTableOfContents(doc.TableOfContents, c.section.GetID()); toc != nil {
+				elements = append([]interface{}{toc}, elements...)
+			}
+		}
+		content, err = r.renderDocumentBody(ctx, elements, nil, c.footnotes(doc))
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(chunks) == 1 {
+		return content, nil
+	}
+	link := func(c *chunk) *chunkLink {
+		if c == nil {
+			return nil
+		}
+		return &chunkLink{
+			Name:  c.name,
+			Title: c.title,
+		}
+	}
+	data := struct {
+		Prev *chunkLink
+		Up   *chunkLink
+		Next *chunkLink
+	}{
+		Up: link(c.parent),
+	}
+	if index > 0 {
+		data.Prev = link(chunks[index-1])
+	}
+	if index < len(chunks)-1 {
+		data.Next = link(chunks[index+1])
+	}
+	navigation, err := r.execute(r.chunkNavigation, data)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render chunk navigation")
+	}
+	return content + navigation, nil
+}
+
+// chunkLink a link to another page
+type chunkLink struct {
+	Name  string
+	Title string
+}
+
+// chunkTableOfContents returns the table of contents of the subsections of the section with the given ID,
+// or nil if the section has no subsection in the table of contents
+func chunkTableOfContents(toc *types.TableOfContents, id string) *types.TableOfContents {
+	if toc == nil {
+		return nil
+	}
+	var lookup func(sections []*types.ToCSection) *types.ToCSection
+	lookup = func(sections []*types.ToCSection) *types.ToCSection {
+		for _, s := range sections {
+			if s.ID == id {
+				return s
+			}
+			if result := lookup(s.Children); result != nil {
+				return result
+			}
+		}
+		return nil
+	}
+	if s := lookup(toc.Sections); s != nil && len(s.Children) > 0 {
+		return &types.TableOfContents{
+			MaxDepth: toc.MaxDepth,
+			Sections: s.Children,
+		}
+	}
+	return nil
+}
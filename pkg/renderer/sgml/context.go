@@ -1,6 +1,8 @@
 package sgml
 
 import (
+	"strings"
+
 	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 )
@@ -14,8 +16,13 @@ type context struct {
 	counters             map[string]int
 	attributes           types.Attributes
 	elementReferences    types.ElementReferences
+	footnotes            []*types.Footnote
 	hasHeader            bool
 	sectionNumbering     types.SectionNumbers
+	sectionCaptions      map[string]string
+	index                *types.Index      // only set if the document has an `[index]` section
+	chunks               map[string]string // the name of the page containing each element, indexed by ID (only set when the document is rendered in chunks)
+	chunk                string            // the name of the page being rendered (only set when the document is rendered in chunks)
 }
 
 // newContext returns a new rendering context for the given document.
@@ -26,6 +33,7 @@ func newContext(doc *types.Document, config *configuration.Configuration) *conte
 		counters:          make(map[string]int),
 		attributes:        config.Attributes,
 		elementReferences: doc.ElementReferences,
+		footnotes:         doc.Footnotes,
 		hasHeader:         header != nil,
 	}
 	// TODO: add other attributes from https://docs.asciidoctor.org/asciidoc/latest/attributes/document-attributes-ref/#builtin-attributes-i18n
@@ -33,6 +41,7 @@ func newContext(doc *types.Document, config *configuration.Configuration) *conte
 	ctx.attributes[types.AttrExampleCaption] = "Example"
 	ctx.attributes[types.AttrTableCaption] = "Table"
 	ctx.attributes[types.AttrVersionLabel] = "version"
+	ctx.attributes[types.AttrAppendixCaption] = "Appendix"
 	// also, expand authors and revision
 	if header != nil {
 		if authors := header.Authors(); authors != nil {
@@ -47,6 +56,31 @@ func newContext(doc *types.Document, config *configuration.Configuration) *conte
 	return ctx
 }
 
+// doctype returns the type of the document being rendered (`article` by default)
+func (ctx *context) doctype() string {
+	return ctx.attributes.GetAsStringWithDefault(types.AttrDocType, "article")
+}
+
+// chunkOf returns the name of the page containing the element with the given ID (regardless of the case, as in the rendered IDs),
+// or `false` if the element is in the page being rendered (or if the document is not rendered in chunks)
+func (ctx *context) chunkOf(id string) (string, bool) {
+	if chunk, found := ctx.chunks[strings.ToLower(id)]; found && chunk != ctx.chunk {
+		return chunk, true
+	}
+	return "", false
+}
+
+// chunkHref returns the given link to an element of the document (eg: `#id`), prefixed with the name
+// of the page containing the element if it is in another page. Other links are returned as-is.
+func (ctx *context) chunkHref(href string) string {
+	if strings.HasPrefix(href, "#") {
+		if chunk, found := ctx.chunkOf(href[1:]); found {
+			return chunk + href
+		}
+	}
+	return href
+}
+
 func (ctx *context) UseUnicode() bool {
 	return ctx.attributes.GetAsBoolWithDefault(types.AttrUnicode, true)
 }
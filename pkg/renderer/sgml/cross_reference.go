@@ -52,6 +52,16 @@ func (r *sgmlRenderer) renderInternalCrossReference(ctx *context, xref *types.In
 	} else {
 		label = "[" + xrefID + "]"
 	}
+	if chunk, found := ctx.chunkOf(xrefID); found {
+		// the target is in another page
+		return r.execute(r.externalCrossReference, struct {
+			Href  string
+			Label string
+		}{
+			Href:  chunk + "#" + strings.ToLower(xrefID),
+			Label: label,
+		})
+	}
 	return r.execute(r.internalCrossReference, struct {
 		Href  string
 		Label string
@@ -79,25 +89,29 @@ func (r *sgmlRenderer) renderExternalCrossReference(ctx *context, xref *types.Ex
 		Href  string
 		Label string
 	}{
-		Href:  getCrossReferenceLocation(xref),
+		Href:  ctx.chunkHref(getCrossReferenceLocation(xref)),
 		Label: label,
 	})
 }
 
 func defaultXrefLabel(xref *types.ExternalCrossReference) string {
 	loc := xref.Location.ToDisplayString()
-	ext := filepath.Ext(loc)
-	if ext == "" {
+	if ext := filepath.Ext(strings.SplitN(loc, "#", 2)[0]); ext == "" {
 		return "[" + loc + "]" // internal references are within brackets
 	}
-	return loc[:len(loc)-len(ext)] + ".html"
+	return getCrossReferenceLocation(xref)
 }
 
 func getCrossReferenceLocation(xref *types.ExternalCrossReference) string {
 	loc := xref.Location.ToDisplayString()
-	ext := filepath.Ext(loc)
+	// the fragment (if any) is the ID of an element in the other document
+	path, fragment := loc, ""
+	if i := strings.Index(loc, "#"); i >= 0 {
+		path, fragment = loc[:i], loc[i:]
+	}
+	ext := filepath.Ext(path)
 	if ext == "" { // internal reference
 		return "#" + loc
 	}
-	return loc[:len(loc)-len(ext)] + ".html" // TODO output extension
+	return path[:len(path)-len(ext)] + ".html" + fragment // TODO output extension
 }
@@ -23,6 +23,8 @@ func (r *sgmlRenderer) renderDelimitedBlock(ctx *context, b *types.DelimitedBloc
 		return r.renderMarkdownQuoteBlock(ctx, b)
 	case types.Passthrough:
 		return r.renderPassthroughBlock(ctx, b)
+	case types.Stem, types.LatexMath, types.AsciiMath:
+		return r.renderStemBlock(ctx, b.Kind, b.Attributes, b.Elements)
 	case types.Quote:
 		return r.renderQuoteBlock(ctx, b)
 	case types.Verse:
@@ -7,6 +7,9 @@ import (
 
 func (r *sgmlRenderer) renderOpenBlock(ctx *context, b *types.DelimitedBlock) (string, error) {
 	blocks := discardBlankLines(b.Elements)
+	if b.Attributes.GetAsStringWithDefault(types.AttrStyle, "") == types.PartIntro {
+		return r.renderPartIntro(ctx, b.Attributes, blocks)
+	}
 	content, err := r.renderElements(ctx, blocks)
 	if err != nil {
 		return "", errors.Wrap(err, "unable to render open block content")
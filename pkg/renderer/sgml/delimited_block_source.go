@@ -74,8 +74,8 @@ func (r *sgmlRenderer) renderSourceBlockElements(ctx *context, b *types.Delimite
 	highlighter := ctx.attributes.GetAsStringWithDefault(types.AttrSyntaxHighlighter, "")
 	language := b.Attributes.GetAsStringWithDefault(types.AttrLanguage, "")
 
-	// render without syntax highlight
-	if language == "" || (highlighter != "chroma" && highlighter != "pygments") {
+	// render without syntax highlight (also, highlighting produces HTML content, which is not suitable for DocBook)
+	if language == "" || (highlighter != "chroma" && highlighter != "pygments") || ctx.attributes.Has("basebackend-docbook") {
 		log.Debug("rendering souce block without syntax highlighting")
 		content, err := r.renderElements(ctx, b.Elements)
 		return content, highlighter, language, err
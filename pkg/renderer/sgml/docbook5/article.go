@@ -0,0 +1,14 @@
+package docbook5
+
+const (
+	articleTmpl = `<?xml version="1.0" encoding="UTF-8"?>
+{{ $root := "article" }}{{ if eq .Doctype "book" }}{{ $root = "book" }}{{ end }}` +
+		`<{{ $root }} xmlns="http://docbook.org/ns/docbook" xmlns:xl="http://www.w3.org/1999/xlink" version="5.0" xml:lang="en"{{ if .ID }} xml:id="{{ .ID }}"{{ end }}{{ if .Roles }} role="{{ .Roles }}"{{ end }}>
+{{ if .IncludeHTMLBodyHeader }}{{ .Header }}{{ end }}{{ .Content }}</{{ $root }}>
+`
+
+	articleHeaderTmpl = `<info>
+<title>{{ .Header }}</title>
+{{ if .Details }}{{ .Details }}{{ end }}</info>
+`
+)
@@ -0,0 +1,6 @@
+package docbook5
+
+const (
+	// DocBook has no line break element, so we use the same processing instruction as Asciidoctor
+	lineBreakTmpl = "<?asciidoc-br?>"
+)
@@ -0,0 +1,51 @@
+package docbook5_test
+
+import (
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("books", func() {
+
+	It("parts, chapters and special sections", func() {
+		source := `= Book Title
+:doctype: book
+
+[preface]
+== Preface
+
+= Part One
+
+[partintro]
+Part intro.
+
+== First Chapter
+
+=== A Section
+
+[appendix]
+== Extra Info`
+		expected := `<preface xml:id="_preface">
+<title>Preface</title>
+</preface>
+<part xml:id="_part_one">
+<title>Part One</title>
+<partintro>
+<simpara>Part intro.</simpara>
+</partintro>
+<chapter xml:id="_first_chapter">
+<title>First Chapter</title>
+<section xml:id="_a_section">
+<title>A Section</title>
+</section>
+</chapter>
+<appendix xml:id="_extra_info">
+<title>Extra Info</title>
+</appendix>
+</part>
+`
+		Expect(RenderDocBook(source)).To(MatchHTML(expected))
+	})
+})
@@ -0,0 +1,11 @@
+package docbook5
+
+const (
+	calloutListTmpl = "<calloutlist{{ if .ID }} xml:id=\"{{ .ID }}\"{{ end }}{{ if .Roles }} role=\"{{ .Roles }}\"{{ end }}>\n" +
+		"{{ if .Title }}<title>{{ .Title }}</title>\n{{ end }}" +
+		"{{ .Content }}</calloutlist>\n"
+
+	calloutListElementTmpl = "<callout arearefs=\"CO1-{{ .Ref }}\">\n{{ .Content }}</callout>\n"
+
+	calloutRefTmpl = "<co xml:id=\"CO1-{{ .Ref }}\"/>"
+)
@@ -0,0 +1,6 @@
+package docbook5
+
+const (
+	internalCrossReferenceTmpl = `<link linkend="{{ toLower .Href }}">{{ .Label }}</link>`
+	externalCrossReferenceTmpl = `<link xl:href="{{ .Href }}">{{ .Label }}</link>`
+)
@@ -0,0 +1,31 @@
+package docbook5_test
+
+import (
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("cross references", func() {
+
+	It("internal and external cross references", func() {
+		source := `[[ref]]
+== Target
+
+see <<ref>> and <<ref,the target>> and xref:other.adoc[other doc].`
+		expected := `<section xml:id="ref">
+<title>Target</title>
+<simpara>see <link linkend="ref">Target</link> and <link linkend="ref">the target</link> and <link xl:href="other.html">other doc</link>.</simpara>
+</section>
+`
+		Expect(RenderDocBook(source)).To(MatchHTML(expected))
+	})
+
+	It("link", func() {
+		source := `a link to https://example.com[Example].`
+		expected := `<simpara>a link to <link xl:href="https://example.com">Example</link>.</simpara>
+`
+		Expect(RenderDocBook(source)).To(MatchHTML(expected))
+	})
+})
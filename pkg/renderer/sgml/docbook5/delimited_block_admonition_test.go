@@ -0,0 +1,34 @@
+package docbook5_test
+
+import (
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("admonitions", func() {
+
+	It("admonition paragraph", func() {
+		source := `TIP: a tip`
+		expected := `<tip>
+<simpara>a tip</simpara>
+</tip>
+`
+		Expect(RenderDocBook(source)).To(MatchHTML(expected))
+	})
+
+	It("admonition block with title", func() {
+		source := `[WARNING]
+.Caution
+====
+some content
+====`
+		expected := `<warning>
+<title>Caution</title>
+<simpara>some content</simpara>
+</warning>
+`
+		Expect(RenderDocBook(source)).To(MatchHTML(expected))
+	})
+})
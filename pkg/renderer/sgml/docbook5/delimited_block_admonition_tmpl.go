@@ -0,0 +1,9 @@
+package docbook5
+
+const (
+	// the kind of admonition (`note`, `tip`, `important`, `warning`, `caution`) matches the DocBook element name
+	admonitionBlockTmpl = "<{{ .Kind }}{{ if .ID }} xml:id=\"{{ .ID }}\"{{ end }}{{ if .Roles }} role=\"{{ .Roles }}\"{{ end }}>\n" +
+		"{{ if .Title }}<title>{{ .Title }}</title>\n{{ end }}" +
+		"{{ .Content }}" +
+		"</{{ .Kind }}>\n"
+)
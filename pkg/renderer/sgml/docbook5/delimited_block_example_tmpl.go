@@ -0,0 +1,11 @@
+package docbook5
+
+const (
+	exampleBlockTmpl = "{{ if .Title }}<example{{ if .ID }} xml:id=\"{{ .ID }}\"{{ end }}{{ if .Roles }} role=\"{{ .Roles }}\"{{ end }}>\n" +
+		"<title>{{ .Title }}</title>\n" +
+		"{{ .Content }}" +
+		"</example>\n" +
+		"{{ else }}<informalexample{{ if .ID }} xml:id=\"{{ .ID }}\"{{ end }}{{ if .Roles }} role=\"{{ .Roles }}\"{{ end }}>\n" +
+		"{{ .Content }}" +
+		"</informalexample>\n{{ end }}"
+)
@@ -0,0 +1,14 @@
+package docbook5
+
+const (
+	listingBlockTmpl = "{{ if .Title }}<formalpara{{ if .ID }} xml:id=\"{{ .ID }}\"{{ end }}>\n" +
+		"<title>{{ .Title }}</title>\n" +
+		"<para>\n" +
+		"<screen{{ if .Roles }} role=\"{{ .Roles }}\"{{ end }}>{{ .Content }}</screen>\n" +
+		"</para>\n" +
+		"</formalpara>\n" +
+		"{{ else }}<screen{{ if .ID }} xml:id=\"{{ .ID }}\"{{ end }}{{ if .Roles }} role=\"{{ .Roles }}\"{{ end }}>{{ .Content }}</screen>\n{{ end }}"
+
+	// fenced blocks are rendered as listing blocks
+	fencedBlockTmpl = listingBlockTmpl
+)
@@ -0,0 +1,11 @@
+package docbook5
+
+const (
+	literalBlockTmpl = "{{ if .Title }}<formalpara{{ if .ID }} xml:id=\"{{ .ID }}\"{{ end }}>\n" +
+		"<title>{{ .Title }}</title>\n" +
+		"<para>\n" +
+		"<literallayout class=\"monospaced\"{{ if .Roles }} role=\"{{ .Roles }}\"{{ end }}>{{ .Content }}</literallayout>\n" +
+		"</para>\n" +
+		"</formalpara>\n" +
+		"{{ else }}<literallayout{{ if .ID }} xml:id=\"{{ .ID }}\"{{ end }} class=\"monospaced\"{{ if .Roles }} role=\"{{ .Roles }}\"{{ end }}>{{ .Content }}</literallayout>\n{{ end }}"
+)
@@ -0,0 +1,11 @@
+package docbook5
+
+const (
+	openBlockTmpl = "{{ if .Title }}<formalpara{{ if .ID }} xml:id=\"{{ .ID }}\"{{ end }}{{ if .Roles }} role=\"{{ .Roles }}\"{{ end }}>\n" +
+		"<title>{{ .Title }}</title>\n" +
+		"<para>\n" +
+		"{{ .Content }}" +
+		"</para>\n" +
+		"</formalpara>\n" +
+		"{{ else }}{{ .Content }}{{ end }}"
+)
@@ -0,0 +1,12 @@
+package docbook5
+
+const (
+	sourceBlockTmpl = "{{ if .Title }}<formalpara{{ if .ID }} xml:id=\"{{ .ID }}\"{{ end }}>\n" +
+		"<title>{{ .Title }}</title>\n" +
+		"<para>\n" +
+		"<programlisting{{ if .Roles }} role=\"{{ .Roles }}\"{{ end }}{{ if .Language }} language=\"{{ .Language }}\"{{ end }} linenumbering=\"unnumbered\">{{ .Content }}</programlisting>\n" +
+		"</para>\n" +
+		"</formalpara>\n" +
+		"{{ else }}<programlisting{{ if .ID }} xml:id=\"{{ .ID }}\"{{ end }}{{ if .Roles }} role=\"{{ .Roles }}\"{{ end }}" +
+		"{{ if .Language }} language=\"{{ .Language }}\"{{ end }} linenumbering=\"unnumbered\">{{ .Content }}</programlisting>\n{{ end }}"
+)
@@ -0,0 +1,56 @@
+package docbook5_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("document header", func() {
+
+	It("header with title only", func() {
+		source := `= Document Title
+
+content`
+		expected := `<?xml version="1.0" encoding="UTF-8"?>
+<article xmlns="http://docbook.org/ns/docbook" xmlns:xl="http://www.w3.org/1999/xlink" version="5.0" xml:lang="en">
+<info>
+<title>Document Title</title>
+</info>
+<simpara>content</simpara>
+</article>
+`
+		Expect(RenderDocBook(source, configuration.WithHeaderFooter(true))).To(MatchHTML(expected))
+	})
+
+	It("header with author and revision", func() {
+		source := `= Document Title
+John Doe <john@example.com>
+v1.0, 2020-01-01: first
+
+content`
+		expected := `<?xml version="1.0" encoding="UTF-8"?>
+<article xmlns="http://docbook.org/ns/docbook" xmlns:xl="http://www.w3.org/1999/xlink" version="5.0" xml:lang="en">
+<info>
+<title>Document Title</title>
+<date>2020-01-01</date>
+<author>
+<personname>John Doe</personname>
+<email>john@example.com</email>
+</author>
+<revhistory>
+<revision>
+<revnumber>1.0</revnumber>
+<date>2020-01-01</date>
+<revremark>first</revremark>
+</revision>
+</revhistory>
+</info>
+<simpara>content</simpara>
+</article>
+`
+		Expect(RenderDocBook(source, configuration.WithHeaderFooter(true))).To(MatchHTML(expected))
+	})
+})
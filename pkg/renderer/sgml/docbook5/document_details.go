@@ -0,0 +1,19 @@
+package docbook5
+
+const (
+	documentDetailsTmpl = `{{ if .RevDate }}<date>{{ .RevDate }}</date>
+{{ end }}{{ if .Authors }}{{ .Authors }}
+{{ end }}{{ if .RevNumber }}<revhistory>
+<revision>
+<revnumber>{{ .RevNumber }}</revnumber>
+{{ if .RevDate }}<date>{{ .RevDate }}</date>
+{{ end }}{{ if .RevRemark }}<revremark>{{ .RevRemark }}</revremark>
+{{ end }}</revision>
+</revhistory>
+{{ end }}`
+
+	documentAuthorDetailsTmpl = `{{ if .Name }}<author>
+<personname>{{ .Name }}</personname>{{ if .Email }}
+<email>{{ .Email }}</email>{{ end }}
+</author>{{ end }}`
+)
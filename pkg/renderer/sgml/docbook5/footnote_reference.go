@@ -0,0 +1,10 @@
+package docbook5
+
+const (
+	// footnotes are rendered inline, where they are referenced
+	footnoteTmpl        = `<footnote xml:id="_footnotedef_{{ .ID }}"><simpara>{{ .Content }}</simpara></footnote>`
+	footnoteRefTmpl     = `<footnoteref linkend="_footnotedef_{{ .ID }}"/>`
+	invalidFootnoteTmpl = `[{{ .Ref }}]`
+	footnotesTmpl       = `{{/* not rendered in DocBook */}}`
+	footnoteElementTmpl = `{{/* not rendered in DocBook */}}`
+)
@@ -0,0 +1,18 @@
+package docbook5_test
+
+import (
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("footnotes", func() {
+
+	It("footnotes and footnote reference", func() {
+		source := `a note.footnote:[a *footnote*] and again.footnote:fn[another] and ref.footnote:fn[]`
+		expected := `<simpara>a note.<footnote xml:id="_footnotedef_1"><simpara>a <emphasis role="strong">footnote</emphasis></simpara></footnote> and again.<footnote xml:id="_footnotedef_2"><simpara>another</simpara></footnote> and ref.<footnoteref linkend="_footnotedef_2"/></simpara>
+`
+		Expect(RenderDocBook(source)).To(MatchHTML(expected))
+	})
+})
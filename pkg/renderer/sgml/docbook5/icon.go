@@ -0,0 +1,17 @@
+package docbook5
+
+const (
+	// DocBook admonitions have their own icons, so icons are only rendered inline
+	inlineIconTmpl = `{{ if .Link }}<link xl:href="{{ .Link }}">{{ end }}{{ .Icon }}{{ if .Link }}</link>{{ end }}`
+
+	iconImageTmpl = `<inlinemediaobject>
+<imageobject>
+<imagedata fileref="{{ .Src }}"{{ if .Width }} contentwidth="{{ .Width }}"{{ end }}{{ if .Height }} contentdepth="{{ .Height }}"{{ end }}/>
+</imageobject>
+<textobject><phrase>{{ .Alt }}</phrase></textobject>
+</inlinemediaobject>`
+
+	iconFontTmpl = `{{ if not .Admonition }}[{{ .Class }}]{{ end }}`
+
+	iconTextTmpl = `{{ if not .Admonition }}[{{ .Alt }}]{{ end }}`
+)
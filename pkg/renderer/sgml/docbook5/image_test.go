@@ -0,0 +1,40 @@
+package docbook5_test
+
+import (
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("images", func() {
+
+	It("block image with title", func() {
+		source := `.A figure
+[#img-id]
+image::foo.png[Foo,200,100]`
+		expected := `<figure xml:id="img-id">
+<title>A figure</title>
+<mediaobject>
+<imageobject>
+<imagedata fileref="foo.png" contentwidth="200" contentdepth="100"/>
+</imageobject>
+<textobject><phrase>Foo</phrase></textobject>
+</mediaobject>
+</figure>
+`
+		Expect(RenderDocBook(source)).To(MatchHTML(expected))
+	})
+
+	It("inline image", func() {
+		source := `an inline image:bar.png[Bar] here.`
+		expected := `<simpara>an inline <inlinemediaobject>
+<imageobject>
+<imagedata fileref="bar.png"/>
+</imageobject>
+<textobject><phrase>Bar</phrase></textobject>
+</inlinemediaobject> here.</simpara>
+`
+		Expect(RenderDocBook(source)).To(MatchHTML(expected))
+	})
+})
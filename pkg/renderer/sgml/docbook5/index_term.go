@@ -0,0 +1,12 @@
+package docbook5
+
+const (
+	indexTermTmpl = `<indexterm><primary>{{ .Primary }}</primary>` +
+		`{{ if .Secondary }}<secondary>{{ .Secondary }}</secondary>{{ end }}` +
+		`{{ if .Tertiary }}<tertiary>{{ .Tertiary }}</tertiary>{{ end }}</indexterm>{{ .Term }}`
+
+	// the index is generated by the DocBook processors
+	indexTmpl         = `{{/* not rendered in DocBook */}}`
+	indexCategoryTmpl = `{{/* not rendered in DocBook */}}`
+	indexEntryTmpl    = `{{/* not rendered in DocBook */}}`
+)
@@ -0,0 +1,29 @@
+package docbook5_test
+
+import (
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("index terms", func() {
+
+	It("index terms and index section", func() {
+		source := `== Fruits
+
+An ((apple)) (((apple, green, granny smith))).
+
+[index]
+== Index`
+		expected := `<section xml:id="_fruits">
+<title>Fruits</title>
+<simpara>An <indexterm><primary>apple</primary></indexterm>apple <indexterm><primary>apple</primary><secondary>green</secondary><tertiary>granny smith</tertiary></indexterm>.</simpara>
+</section>
+<index xml:id="_index">
+<title>Index</title>
+</index>
+`
+		Expect(RenderDocBook(source)).To(MatchHTML(expected))
+	})
+})
@@ -0,0 +1,33 @@
+package docbook5
+
+const (
+	labeledListTmpl = "<variablelist{{ if .ID }} xml:id=\"{{ .ID }}\"{{ end }}{{ if .Roles }} role=\"{{ .Roles }}\"{{ end }}>\n" +
+		"{{ if .Title }}<title>{{ .Title }}</title>\n{{ end }}" +
+		"{{ .Content }}</variablelist>\n"
+
+	// Continuation items (multiple terms sharing a single definition) are grouped in the same entry
+	labeledListElementTmpl = "{{ if not .Continuation }}<varlistentry>\n{{ end }}" +
+		"<term>{{ .Term }}</term>\n" +
+		"{{ if .Content }}<listitem>\n{{ .Content }}</listitem>\n</varlistentry>\n{{ end }}"
+
+	labeledListGlossaryTmpl = "<glosslist{{ if .ID }} xml:id=\"{{ .ID }}\"{{ end }}{{ if .Roles }} role=\"{{ .Roles }}\"{{ end }}>\n" +
+		"{{ if .Title }}<title>{{ .Title }}</title>\n{{ end }}" +
+		"{{ .Content }}</glosslist>\n"
+
+	labeledListGlossaryElementTmpl = "{{ if not .Continuation }}<glossentry>\n{{ end }}" +
+		"<glossterm>{{ .Term }}</glossterm>\n" +
+		"{{ if .Content }}<glossdef>\n{{ .Content }}</glossdef>\n</glossentry>\n{{ end }}"
+
+	// DocBook has no horizontal layout for labeled lists, so we use the regular variable list
+	labeledListHorizontalTmpl        = labeledListTmpl
+	labeledListHorizontalElementTmpl = labeledListElementTmpl
+
+	qAndAListTmpl = "<qandaset{{ if .ID }} xml:id=\"{{ .ID }}\"{{ end }}{{ if .Roles }} role=\"{{ .Roles }}\"{{ end }}>\n" +
+		"{{ if .Title }}<title>{{ .Title }}</title>\n{{ end }}" +
+		"{{ .Content }}</qandaset>\n"
+
+	qAndAListElementTmpl = "<qandaentry>\n" +
+		"<question>\n<simpara>{{ .Term }}</simpara>\n</question>\n" +
+		"<answer>\n{{ .Content }}</answer>\n" +
+		"</qandaentry>\n"
+)
@@ -0,0 +1,7 @@
+package docbook5
+
+const (
+	// links without URL are anchors
+	linkTmpl = `{{ if .URL }}<link xl:href="{{ .URL }}"{{ if .Class }} role="{{ .Class }}"{{ end }}>{{ .Text }}</link>` +
+		`{{ else }}<anchor xml:id="{{ .ID }}" xreflabel="[{{ .ID }}]"/>{{ end }}`
+)
@@ -0,0 +1,61 @@
+package docbook5_test
+
+import (
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("lists", func() {
+
+	It("nested ordered lists", func() {
+		source := `. first
+. second
+.. nested`
+		expected := `<orderedlist numeration="arabic">
+<listitem>
+<simpara>first</simpara>
+</listitem>
+<listitem>
+<simpara>second</simpara>
+<orderedlist numeration="loweralpha">
+<listitem>
+<simpara>nested</simpara>
+</listitem>
+</orderedlist>
+</listitem>
+</orderedlist>
+`
+		Expect(RenderDocBook(source)).To(MatchHTML(expected))
+	})
+
+	It("checklist", func() {
+		source := `* [x] done
+* [ ] todo`
+		expected := `<itemizedlist role="checklist">
+<listitem>
+<simpara>&#10003; done</simpara>
+</listitem>
+<listitem>
+<simpara>&#10063; todo</simpara>
+</listitem>
+</itemizedlist>
+`
+		Expect(RenderDocBook(source)).To(MatchHTML(expected))
+	})
+
+	It("labeled list", func() {
+		source := `term:: definition`
+		expected := `<variablelist>
+<varlistentry>
+<term>term</term>
+<listitem>
+<simpara>definition</simpara>
+</listitem>
+</varlistentry>
+</variablelist>
+`
+		Expect(RenderDocBook(source)).To(MatchHTML(expected))
+	})
+})
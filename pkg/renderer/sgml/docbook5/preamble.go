@@ -0,0 +1,6 @@
+package docbook5
+
+const (
+	// DocBook has no preamble element in articles, the content is rendered as-is
+	preambleTmpl = `{{ .Content }}`
+)
@@ -0,0 +1,10 @@
+package docbook5
+
+const (
+	boldTextTmpl        = `<emphasis{{ if .ID }} xml:id="{{ .ID }}"{{ end }} role="strong{{ if .Roles }} {{ .Roles }}{{ end }}">{{ .Content }}</emphasis>`
+	italicTextTmpl      = `<emphasis{{ if .ID }} xml:id="{{ .ID }}"{{ end }}{{ if .Roles }} role="{{ .Roles }}"{{ end }}>{{ .Content }}</emphasis>`
+	monospaceTextTmpl   = `<literal{{ if .ID }} xml:id="{{ .ID }}"{{ end }}{{ if .Roles }} role="{{ .Roles }}"{{ end }}>{{ .Content }}</literal>`
+	subscriptTextTmpl   = `<subscript{{ if .ID }} xml:id="{{ .ID }}"{{ end }}{{ if .Roles }} role="{{ .Roles }}"{{ end }}>{{ .Content }}</subscript>`
+	superscriptTextTmpl = `<superscript{{ if .ID }} xml:id="{{ .ID }}"{{ end }}{{ if .Roles }} role="{{ .Roles }}"{{ end }}>{{ .Content }}</superscript>`
+	markedTextTmpl      = `{{ if .Roles }}<phrase{{ else }}<emphasis{{ end }}{{ if .ID }} xml:id="{{ .ID }}"{{ end }} role="{{ if .Roles }}{{ .Roles }}{{ else }}marked{{ end }}">{{ .Content }}{{ if .Roles }}</phrase>{{ else }}</emphasis>{{ end }}`
+)
@@ -0,0 +1,18 @@
+package docbook5
+
+const (
+	// in a book, parts, chapters and special sections use their own element
+	sectionContentTmpl = `{{ $tag := .Kind }}{{ if eq .Kind "abstract" }}{{ $tag = "section" }}{{ else if eq .Kind "acknowledgments" }}{{ $tag = "acknowledgements" }}{{ end }}` +
+		`<{{ $tag }} xml:id="{{ toLower .ID }}"{{ if .Roles }} role="{{ .Roles }}"{{ end }}>
+{{ .Header }}{{ .Content }}</{{ $tag }}>
+`
+
+	// the section number is not part of the title, DocBook processors take care of it
+	sectionTitleTmpl = `<title>{{ .Content }}</title>
+`
+
+	partIntroTmpl = `<partintro{{ if .ID }} xml:id="{{ .ID }}"{{ end }}{{ if .Roles }} role="{{ .Roles }}"{{ end }}>
+{{ if .Title }}<title>{{ .Title }}</title>
+{{ end }}{{ .Content }}</partintro>
+`
+)
@@ -0,0 +1,41 @@
+package docbook5_test
+
+import (
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("sections", func() {
+
+	It("nested sections", func() {
+		source := `== Section A
+
+content
+
+=== Section B`
+		expected := `<section xml:id="_section_a">
+<title>Section A</title>
+<simpara>content</simpara>
+<section xml:id="_section_b">
+<title>Section B</title>
+</section>
+</section>
+`
+		Expect(RenderDocBook(source)).To(MatchHTML(expected))
+	})
+
+	It("section with custom ID and quoted text", func() {
+		source := `[[custom]]
+== Section _A_
+
+some *bold* and _italic_ and ` + "`mono`" + ` content.`
+		expected := `<section xml:id="custom">
+<title>Section <emphasis>A</emphasis></title>
+<simpara>some <emphasis role="strong">bold</emphasis> and <emphasis>italic</emphasis> and <literal>mono</literal> content.</simpara>
+</section>
+`
+		Expect(RenderDocBook(source)).To(MatchHTML(expected))
+	})
+})
@@ -0,0 +1,17 @@
+package docbook5
+
+const (
+	// eg: `<informalequation><mathphrase><![CDATA[sqrt(4) = 2]]></mathphrase></informalequation>`
+	// LaTeX math equations are also passed in an `alt` element, for processing by dblatex
+	stemBlockTmpl = "{{ if .Title }}<equation{{ else }}<informalequation{{ end }}" +
+		"{{ if .ID }} xml:id=\"{{ .ID }}\"{{ end }}{{ if .Roles }} role=\"{{ .Roles }}\"{{ end }}>\n" +
+		"{{ if .Title }}<title>{{ .Title }}</title>\n{{ end }}" +
+		"{{ if eq .Notation \"latexmath\" }}<alt><![CDATA[{{ .Source }}]]></alt>\n{{ end }}" +
+		"<mathphrase><![CDATA[{{ .Source }}]]></mathphrase>\n" +
+		"{{ if .Title }}</equation>{{ else }}</informalequation>{{ end }}\n"
+
+	inlineStemTmpl = "<inlineequation>" +
+		"{{ if eq .Notation \"latexmath\" }}<alt><![CDATA[{{ .Source }}]]></alt>{{ end }}" +
+		"<mathphrase><![CDATA[{{ .Source }}]]></mathphrase>" +
+		"</inlineequation>"
+)
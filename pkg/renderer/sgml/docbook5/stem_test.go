@@ -0,0 +1,47 @@
+package docbook5_test
+
+import (
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("stem", func() {
+
+	It("asciimath block", func() {
+		source := `[stem]
+++++
+sqrt(4) < 2
+++++`
+		expected := `<informalequation>
+<mathphrase><![CDATA[sqrt(4) < 2]]></mathphrase>
+</informalequation>
+`
+		Expect(RenderDocBook(source)).To(MatchHTML(expected))
+	})
+
+	It("latexmath block with title", func() {
+		source := `.An equation
+[latexmath#eq]
+++++
+C = \alpha + \beta
+++++`
+		expected := `<equation xml:id="eq">
+<title>An equation</title>
+<alt><![CDATA[C = \alpha + \beta]]></alt>
+<mathphrase><![CDATA[C = \alpha + \beta]]></mathphrase>
+</equation>
+`
+		Expect(RenderDocBook(source)).To(MatchHTML(expected))
+	})
+
+	It("inline macros", func() {
+		source := `:stem: latexmath
+
+stem:[x < y] and asciimath:[a]`
+		expected := `<simpara><inlineequation><alt><![CDATA[x < y]]></alt><mathphrase><![CDATA[x < y]]></mathphrase></inlineequation> and <inlineequation><mathphrase><![CDATA[a]]></mathphrase></inlineequation></simpara>
+`
+		Expect(RenderDocBook(source)).To(MatchHTML(expected))
+	})
+})
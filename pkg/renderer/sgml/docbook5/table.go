@@ -0,0 +1,52 @@
+package docbook5
+
+const (
+	// CALS table model. Tables with a title are "formal" tables, others are "informal" tables.
+	tableTmpl = "{{ if .Title }}<table{{ else }}<informaltable{{ end }}" +
+		"{{ if .ID }} xml:id=\"{{ .ID }}\"{{ end }}" +
+		" frame=\"{{ if eq .Frame \"ends\" }}topbot{{ else }}{{ .Frame }}{{ end }}\"" +
+		" rowsep=\"{{ if or (eq .Grid \"all\") (eq .Grid \"rows\") }}1{{ else }}0{{ end }}\"" +
+		" colsep=\"{{ if or (eq .Grid \"all\") (eq .Grid \"cols\") }}1{{ else }}0{{ end }}\"" +
+		"{{ if .Roles }} role=\"{{ .Roles }}\"{{ end }}" +
+		"{{ if .Float }} floatstyle=\"{{ .Float }}\"{{ end }}" +
+		"{{ if .Width }} width=\"{{ .Width }}%\"{{ end }}" +
+		">\n" +
+		"{{ if .Title }}<title>{{ .Title }}</title>\n{{ end }}" +
+		"{{ if .Body }}" +
+		"<tgroup cols=\"{{ len .Columns }}\">\n" +
+		"{{ range $i, $c := .Columns }}<colspec colname=\"col_{{ inc $i }}\"" +
+		"{{ if $c.Width }} colwidth=\"{{ $c.Width }}*\"{{ end }}" +
+		"/>\n{{ end }}" +
+		"{{ .Header }}" +
+		"{{ .Footer }}" +
+		"{{ .Body }}" +
+		"</tgroup>\n" +
+		"{{ end }}" +
+		"{{ if .Title }}</table>{{ else }}</informaltable>{{ end }}\n"
+
+	tableBodyTmpl = "{{ if .Content }}<tbody>\n{{ .Content }}</tbody>\n{{ end }}"
+
+	tableHeaderTmpl = "{{ if .Content }}<thead>\n<row>\n{{ .Content }}</row>\n</thead>\n{{ end }}"
+
+	tableHeaderCellTmpl = "<entry{{ with halignValue .HAlign }} align=\"{{ . }}\"{{ end }}{{ with valignValue .VAlign }} valign=\"{{ . }}\"{{ end }}" +
+		"{{ if gt .Span.Colspan 1 }} namest=\"col_{{ .Span.FirstColumn }}\" nameend=\"col_{{ .Span.LastColumn }}\"{{ end }}{{ if gt .Span.Rowspan 1 }} morerows=\"{{ .Span.MoreRows }}\"{{ end }}" +
+		">{{ .Content }}</entry>\n"
+
+	tableFooterTmpl = "{{ if .Content }}<tfoot>\n<row>\n{{ .Content }}</row>\n</tfoot>\n{{ end }}"
+
+	tableFooterCellTmpl = "<entry{{ with halignValue .HAlign }} align=\"{{ . }}\"{{ end }}{{ with valignValue .VAlign }} valign=\"{{ . }}\"{{ end }}" +
+		"{{ if gt .Span.Colspan 1 }} namest=\"col_{{ .Span.FirstColumn }}\" nameend=\"col_{{ .Span.LastColumn }}\"{{ end }}{{ if gt .Span.Rowspan 1 }} morerows=\"{{ .Span.MoreRows }}\"{{ end }}" +
+		"><simpara>{{ .Content }}</simpara></entry>\n"
+
+	tableRowTmpl = "<row>\n{{ .Content }}</row>\n"
+
+	tableCellTmpl = "<entry{{ with halignValue .HAlign }} align=\"{{ . }}\"{{ end }}{{ with valignValue .VAlign }} valign=\"{{ . }}\"{{ end }}" +
+		"{{ if gt .Span.Colspan 1 }} namest=\"col_{{ .Span.FirstColumn }}\" nameend=\"col_{{ .Span.LastColumn }}\"{{ end }}{{ if gt .Span.Rowspan 1 }} morerows=\"{{ .Span.MoreRows }}\"{{ end }}" +
+		">{{ .Content }}</entry>\n"
+
+	tableCellBlockTmpl = "{{ .Content }}"
+
+	tableCellLiteralTmpl = "<literallayout class=\"monospaced\">{{ .Content }}</literallayout>"
+
+	tableCellVerseTmpl = "<literallayout>{{ .Content }}</literallayout>"
+)
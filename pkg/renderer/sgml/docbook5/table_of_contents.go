@@ -0,0 +1,8 @@
+package docbook5
+
+const (
+	// the table of contents is generated by the DocBook processors
+	tocRootTmpl    = `{{/* not rendered in DocBook */}}`
+	tocSectionTmpl = `{{/* not rendered in DocBook */}}`
+	tocEntryTmpl   = `{{/* not rendered in DocBook */}}`
+)
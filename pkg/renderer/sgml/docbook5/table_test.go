@@ -0,0 +1,148 @@
+package docbook5_test
+
+import (
+	"bytes"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml/docbook5"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("tables", func() {
+
+	It("informal table", func() {
+		source := `[cols="1,2"]
+|===
+|A |B
+|===`
+		expected := `<informaltable frame="all" rowsep="1" colsep="1">
+<tgroup cols="2">
+<colspec colname="col_1" colwidth="33.3333*"/>
+<colspec colname="col_2" colwidth="66.6667*"/>
+<tbody>
+<row>
+<entry align="left" valign="top"><simpara>A</simpara></entry>
+<entry align="left" valign="top"><simpara>B</simpara></entry>
+</row>
+</tbody>
+</tgroup>
+</informaltable>
+`
+		Expect(RenderDocBook(source)).To(MatchHTML(expected))
+	})
+
+	It("formal table with header and alignments", func() {
+		source := `.Table title
+[cols="<,^,>",options="header"]
+|===
+|H1 |H2 |H3
+|a |b |c
+|===`
+		expected := `<table frame="all" rowsep="1" colsep="1">
+<title>Table title</title>
+<tgroup cols="3">
+<colspec colname="col_1" colwidth="33.3333*"/>
+<colspec colname="col_2" colwidth="33.3333*"/>
+<colspec colname="col_3" colwidth="33.3334*"/>
+<thead>
+<row>
+<entry align="left" valign="top">H1</entry>
+<entry align="center" valign="top">H2</entry>
+<entry align="right" valign="top">H3</entry>
+</row>
+</thead>
+<tbody>
+<row>
+<entry align="left" valign="top"><simpara>a</simpara></entry>
+<entry align="center" valign="top"><simpara>b</simpara></entry>
+<entry align="right" valign="top"><simpara>c</simpara></entry>
+</row>
+</tbody>
+</tgroup>
+</table>
+`
+		Expect(RenderDocBook(source)).To(MatchHTML(expected))
+	})
+
+	It("informal table with spans", func() {
+		source := `|===
+|a 2+|b
+.2+|c |d |e
+|f |g
+|===`
+		expected := `<informaltable frame="all" rowsep="1" colsep="1">
+<tgroup cols="3">
+<colspec colname="col_1" colwidth="33.3333*"/>
+<colspec colname="col_2" colwidth="33.3333*"/>
+<colspec colname="col_3" colwidth="33.3334*"/>
+<tbody>
+<row>
+<entry align="left" valign="top"><simpara>a</simpara></entry>
+<entry align="left" valign="top" namest="col_2" nameend="col_3"><simpara>b</simpara></entry>
+</row>
+<row>
+<entry align="left" valign="top" morerows="1"><simpara>c</simpara></entry>
+<entry align="left" valign="top"><simpara>d</simpara></entry>
+<entry align="left" valign="top"><simpara>e</simpara></entry>
+</row>
+<row>
+<entry align="left" valign="top"><simpara>f</simpara></entry>
+<entry align="left" valign="top"><simpara>g</simpara></entry>
+</row>
+</tbody>
+</tgroup>
+</informaltable>
+`
+		Expect(RenderDocBook(source)).To(MatchHTML(expected))
+	})
+
+	It("informal table without alignments", func() {
+		// columns defined programmatically, without horizontal or vertical alignment
+		doc := &types.Document{
+			Elements: []interface{}{
+				&types.Table{
+					Attributes: types.Attributes{
+						types.AttrCols: []interface{}{
+							&types.TableColumn{
+								Multiplier: 1,
+								Weight:     1,
+							},
+						},
+					},
+					Rows: []*types.TableRow{
+						{
+							Cells: []*types.TableCell{
+								{
+									Elements: []interface{}{
+										&types.StringElement{
+											Content: "A",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		expected := `<informaltable frame="all" rowsep="1" colsep="1">
+<tgroup cols="1">
+<colspec colname="col_1" colwidth="100*"/>
+<tbody>
+<row>
+<entry>A</entry>
+</row>
+</tbody>
+</tgroup>
+</informaltable>
+`
+		output := &bytes.Buffer{}
+		_, err := docbook5.Render(doc, configuration.NewConfiguration(configuration.WithBackEnd("docbook5")), output)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output.String()).To(MatchHTML(expected))
+	})
+})
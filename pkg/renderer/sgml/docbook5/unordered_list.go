@@ -0,0 +1,11 @@
+package docbook5
+
+const (
+	unorderedListTmpl = "<itemizedlist{{ if .ID }} xml:id=\"{{ .ID }}\"{{ end }}" +
+		"{{ if or .Checklist .Roles }} role=\"{{ if .Checklist }}checklist{{ end }}{{ if and .Checklist .Roles }} {{ end }}{{ .Roles }}\"{{ end }}" +
+		"{{ if .Style }} mark=\"{{ .Style }}\"{{ end }}>\n" +
+		"{{ if .Title }}<title>{{ .Title }}</title>\n{{ end }}" +
+		"{{ .Content }}</itemizedlist>\n"
+
+	unorderedListElementTmpl = "<listitem>\n{{ .Content }}</listitem>\n"
+)
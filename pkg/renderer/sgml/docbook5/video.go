@@ -0,0 +1,24 @@
+package docbook5
+
+const (
+	// videos and audios with a title are "formal" figures, others are "informal" figures.
+	videoBlockTmpl = `{{ if .Title }}<figure{{ else }}<informalfigure{{ end }}{{ if .ID }} xml:id="{{ .ID }}"{{ end }}{{ if .Roles }} role="{{ .Roles }}"{{ end }}>
+{{ if .Title }}<title>{{ .Title }}</title>
+{{ end }}<mediaobject>
+<videoobject>
+<videodata fileref="{{ .Src }}"{{ if .Width }} contentwidth="{{ .Width }}"{{ end }}{{ if .Height }} contentdepth="{{ .Height }}"{{ end }}/>
+</videoobject>
+</mediaobject>
+{{ if .Title }}</figure>{{ else }}</informalfigure>{{ end }}
+`
+
+	audioBlockTmpl = `{{ if .Title }}<figure{{ else }}<informalfigure{{ end }}{{ if .ID }} xml:id="{{ .ID }}"{{ end }}{{ if .Roles }} role="{{ .Roles }}"{{ end }}>
+{{ if .Title }}<title>{{ .Title }}</title>
+{{ end }}<mediaobject>
+<audioobject>
+<audiodata fileref="{{ .Src }}"/>
+</audioobject>
+</mediaobject>
+{{ if .Title }}</figure>{{ else }}</informalfigure>{{ end }}
+`
+)
@@ -66,8 +66,14 @@ func (r *sgmlRenderer) renderElement(ctx *context, element interface{}) (string,
 		return r.renderQuotedText(ctx, e)
 	case *types.InlinePassthrough:
 		return r.renderInlinePassthrough(ctx, e)
+	case *types.InlineStem:
+		return r.renderInlineStem(ctx, e)
 	case *types.ImageBlock:
 		return r.renderImageBlock(ctx, e)
+	case *types.VideoBlock:
+		return r.renderVideoBlock(ctx, e)
+	case *types.AudioBlock:
+		return r.renderAudioBlock(ctx, e)
 	case *types.InlineButton:
 		return r.renderInlineButton(e)
 	case *types.InlineImage:
@@ -85,7 +91,7 @@ func (r *sgmlRenderer) renderElement(ctx *context, element interface{}) (string,
 	case *types.StringElement:
 		return r.renderStringElement(ctx, e)
 	case *types.FootnoteReference:
-		return r.renderFootnoteReference(e)
+		return r.renderFootnoteReference(ctx, e)
 	case *types.LineBreak:
 		return r.renderLineBreak()
 	case *types.UserMacro:
@@ -93,7 +99,7 @@ func (r *sgmlRenderer) renderElement(ctx *context, element interface{}) (string,
 	case *types.IndexTerm:
 		return r.renderIndexTerm(ctx, e)
 	case *types.ConcealedIndexTerm:
-		return r.renderConcealedIndexTerm(e)
+		return r.renderConcealedIndexTerm(ctx, e)
 	case *types.ThematicBreak:
 		return r.renderThematicBreak()
 	case *types.SpecialCharacter:
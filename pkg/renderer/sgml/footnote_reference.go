@@ -7,7 +7,7 @@ import (
 	"github.com/pkg/errors"
 )
 
-func (r *sgmlRenderer) renderFootnoteReference(note *types.FootnoteReference) (string, error) {
+func (r *sgmlRenderer) renderFootnoteReference(ctx *context, note *types.FootnoteReference) (string, error) {
 	result := &strings.Builder{}
 	if note.ID != types.InvalidFootnoteReference && !note.Duplicate {
 		// valid case for a footnote with content, with our without an explicit reference
@@ -15,10 +15,9 @@ func (r *sgmlRenderer) renderFootnoteReference(note *types.FootnoteReference) (s
 		if err != nil {
 			return "", errors.Wrap(err, "unable to load footnote template")
 		}
-		if err := tmpl.Execute(result, struct {
-			ID  int
-			Ref string
-		}{
+		if err := tmpl.Execute(result, &footnoteReference{
+			r:   r,
+			ctx: ctx,
 			ID:  note.ID,
 			Ref: note.Ref,
 		}); err != nil {
@@ -56,6 +55,25 @@ func (r *sgmlRenderer) renderFootnoteReference(note *types.FootnoteReference) (s
 	return result.String(), nil
 }
 
+// footnoteReference the data of the footnote template, which provides the footnote content
+// for the backends which render it inline (eg: DocBook). The content is only rendered
+// when the template uses it.
+type footnoteReference struct {
+	r   *sgmlRenderer
+	ctx *context
+	ID  int
+	Ref string
+}
+
+// Content returns the rendered content of the footnote
+func (n *footnoteReference) Content() (string, error) {
+	content, err := n.r.renderFootnoteContent(n.ctx, n.ID)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render footnote")
+	}
+	return content, nil
+}
+
 func (r *sgmlRenderer) renderFootnotes(ctx *context, notes []*types.Footnote) (string, error) {
 	// skip if there's no foot note in the doc
 	if len(notes) == 0 {
@@ -81,13 +99,10 @@ func (r *sgmlRenderer) renderFootnotes(ctx *context, notes []*types.Footnote) (s
 }
 
 func (r *sgmlRenderer) renderFootnoteElement(ctx *context, note *types.Footnote) (string, error) {
-	content, err := r.renderInlineElements(ctx, note.Elements)
+	content, err := r.renderFootnoteElements(ctx, note)
 	if err != nil {
-		return "", errors.Wrapf(err, "unable to render foot note content")
+		return "", err
 	}
-	content = strings.TrimSpace(content)
-	// Note: Asciidoctor will render the footnote content on a single line
-	content = strings.ReplaceAll(content, "\n", " ")
 	return r.execute(r.footnoteElement, struct {
 		Context *context
 		ID      int
@@ -100,3 +115,23 @@ func (r *sgmlRenderer) renderFootnoteElement(ctx *context, note *types.Footnote)
 		Content: string(content),
 	})
 }
+
+// renderFootnoteContent renders the content of the footnote with the given ID
+func (r *sgmlRenderer) renderFootnoteContent(ctx *context, id int) (string, error) {
+	for _, note := range ctx.footnotes {
+		if note.ID == id {
+			return r.renderFootnoteElements(ctx, note)
+		}
+	}
+	return "", nil
+}
+
+func (r *sgmlRenderer) renderFootnoteElements(ctx *context, note *types.Footnote) (string, error) {
+	content, err := r.renderInlineElements(ctx, note.Elements)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to render foot note content")
+	}
+	content = strings.TrimSpace(content)
+	// Note: Asciidoctor will render the footnote content on a single line
+	return strings.ReplaceAll(content, "\n", " "), nil
+}
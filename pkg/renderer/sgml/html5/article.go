@@ -22,7 +22,7 @@ const (
 {{ end }}Last updated {{ .LastUpdated }}
 </div>
 </div>
-{{ end }}</body>
+{{ end }}{{ if .IncludeMathJax }}` + mathJaxTmpl + `{{ end }}</body>
 </html>
 `
 
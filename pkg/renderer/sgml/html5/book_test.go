@@ -0,0 +1,202 @@
+package html5_test
+
+import (
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("books", func() {
+
+	It("parts with implicit part intro and chapters", func() {
+		source := `= Book Title
+:doctype: book
+:sectnums:
+
+= Part One
+
+Part intro.
+
+== First Chapter
+
+=== A Section
+
+= Part Two
+
+== Second Chapter`
+		expected := `<h1 id="_part_one" class="sect0">Part One</h1>
+<div class="openblock partintro">
+<div class="content">
+<div class="paragraph">
+<p>Part intro.</p>
+</div>
+</div>
+</div>
+<div class="sect1">
+<h2 id="_first_chapter">1. First Chapter</h2>
+<div class="sectionbody">
+<div class="sect2">
+<h3 id="_a_section">1.1. A Section</h3>
+</div>
+</div>
+</div>
+<h1 id="_part_two" class="sect0">Part Two</h1>
+<div class="sect1">
+<h2 id="_second_chapter">2. Second Chapter</h2>
+<div class="sectionbody">
+</div>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("part with explicit part intro block", func() {
+		source := `= Book Title
+:doctype: book
+
+= Part One
+
+[partintro]
+.Intro
+--
+Part intro.
+--
+
+== First Chapter`
+		expected := `<h1 id="_part_one" class="sect0">Part One</h1>
+<div class="openblock partintro">
+<div class="title">Intro</div>
+<div class="content">
+<div class="paragraph">
+<p>Part intro.</p>
+</div>
+</div>
+</div>
+<div class="sect1">
+<h2 id="_first_chapter">First Chapter</h2>
+<div class="sectionbody">
+</div>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("special sections with appendix caption and chapter signifier", func() {
+		source := `= Book Title
+:doctype: book
+:sectnums:
+:chapter-signifier: Chapter
+
+[preface]
+== Preface
+
+== First Chapter
+
+[appendix]
+== Extra Info
+
+=== Details
+
+[glossary]
+== Glossary
+
+[glossary]
+term:: definition`
+		expected := `<div class="sect1">
+<h2 id="_preface">Preface</h2>
+<div class="sectionbody">
+</div>
+</div>
+<div class="sect1">
+<h2 id="_first_chapter">Chapter 1. First Chapter</h2>
+<div class="sectionbody">
+</div>
+</div>
+<div class="sect1">
+<h2 id="_extra_info">Appendix A: Extra Info</h2>
+<div class="sectionbody">
+<div class="sect2">
+<h3 id="_details">A.1. Details</h3>
+</div>
+</div>
+</div>
+<div class="sect1">
+<h2 id="_glossary">Glossary</h2>
+<div class="sectionbody">
+<div class="dlist glossary">
+<dl>
+<dt>term</dt>
+<dd>
+<p>definition</p>
+</dd>
+</dl>
+</div>
+</div>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("appendix with custom caption and without section numbering", func() {
+		source := `= Book Title
+:doctype: book
+:appendix-caption: Annex
+
+== First Chapter
+
+[appendix]
+== Extra Info`
+		expected := `<div class="sect1">
+<h2 id="_first_chapter">First Chapter</h2>
+<div class="sectionbody">
+</div>
+</div>
+<div class="sect1">
+<h2 id="_extra_info">Annex A: Extra Info</h2>
+<div class="sectionbody">
+</div>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("table of contents with numbered parts", func() {
+		source := `= Book Title
+:doctype: book
+:toc:
+:partnums:
+:sectnums:
+
+[preface]
+== Preface
+
+= Part One
+
+== First Chapter`
+		expected := `<div id="toc" class="toc">
+<div id="toctitle">Table of Contents</div>
+<ul class="sectlevel1">
+<li><a href="#_preface">Preface</a></li>
+<li><a href="#_part_one">I: Part One</a>
+<ul class="sectlevel1">
+<li><a href="#_first_chapter">1. First Chapter</a></li>
+</ul>
+</li>
+</ul>
+</div>
+<div class="sect1">
+<h2 id="_preface">Preface</h2>
+<div class="sectionbody">
+</div>
+</div>
+<h1 id="_part_one" class="sect0">I: Part One</h1>
+<div class="sect1">
+<h2 id="_first_chapter">1. First Chapter</h2>
+<div class="sectionbody">
+</div>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+})
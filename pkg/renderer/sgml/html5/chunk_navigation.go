@@ -0,0 +1,9 @@
+package html5
+
+const (
+	chunkNavigationTmpl = "<nav class=\"chunk-nav\">\n" +
+		"{{ if .Prev }}<a class=\"prev\" rel=\"prev\" href=\"{{ .Prev.Name }}\">{{ .Prev.Title }}</a>\n{{ end }}" +
+		"{{ if .Up }}<a class=\"up\" rel=\"up\" href=\"{{ .Up.Name }}\">{{ .Up.Title }}</a>\n{{ end }}" +
+		"{{ if .Next }}<a class=\"next\" rel=\"next\" href=\"{{ .Next.Name }}\">{{ .Next.Title }}</a>\n{{ end }}" +
+		"</nav>\n"
+)
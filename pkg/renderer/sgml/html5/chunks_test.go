@@ -0,0 +1,247 @@
+package html5_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml"
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("chunks", func() {
+
+	source := `= Title
+
+preamble
+
+== Section A
+
+content A
+
+=== Section A.1
+
+content A.1
+
+== Section B
+
+content B`
+
+	It("should split at level 1", func() {
+		expected := []sgml.Chunk{
+			{
+				Name:  "test.html",
+				Title: "Title",
+				Content: `<div id="preamble">
+<div class="sectionbody">
+<div class="paragraph">
+<p>preamble</p>
+</div>
+</div>
+</div>
+<nav class="chunk-nav">
+<a class="next" rel="next" href="section_a.html">Section A</a>
+</nav>
+`,
+			},
+			{
+				Name:  "section_a.html",
+				Title: "Section A",
+				Content: `<div class="sect1">
+<h2 id="_section_a">Section A</h2>
+<div class="sectionbody">
+<div class="paragraph">
+<p>content A</p>
+</div>
+<div class="sect2">
+<h3 id="_section_a_1">Section A.1</h3>
+<div class="paragraph">
+<p>content A.1</p>
+</div>
+</div>
+</div>
+</div>
+<nav class="chunk-nav">
+<a class="prev" rel="prev" href="test.html">Title</a>
+<a class="up" rel="up" href="test.html">Title</a>
+<a class="next" rel="next" href="section_b.html">Section B</a>
+</nav>
+`,
+			},
+			{
+				Name:  "section_b.html",
+				Title: "Section B",
+				Content: `<div class="sect1">
+<h2 id="_section_b">Section B</h2>
+<div class="sectionbody">
+<div class="paragraph">
+<p>content B</p>
+</div>
+</div>
+</div>
+<nav class="chunk-nav">
+<a class="prev" rel="prev" href="section_a.html">Section A</a>
+<a class="up" rel="up" href="test.html">Title</a>
+</nav>
+`,
+			},
+		}
+		Expect(RenderHTMLChunks(source, 1)).To(Equal(expected))
+	})
+
+	It("should split at level 2", func() {
+		chunks, err := RenderHTMLChunks(source, 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(chunks).To(HaveLen(4))
+		Expect(chunks[1].Name).To(Equal("section_a.html"))
+		Expect(chunks[1].Content).NotTo(ContainSubstring("content A.1"))
+		Expect(chunks[2].Name).To(Equal("section_a_1.html"))
+		Expect(chunks[2].Content).To(Equal(`<div class="sect2">
+<h3 id="_section_a_1">Section A.1</h3>
+<div class="paragraph">
+<p>content A.1</p>
+</div>
+</div>
+<nav class="chunk-nav">
+<a class="prev" rel="prev" href="section_a.html">Section A</a>
+<a class="up" rel="up" href="section_a.html">Section A</a>
+<a class="next" rel="next" href="section_b.html">Section B</a>
+</nav>
+`))
+	})
+
+	It("should not split a document without section", func() {
+		Expect(RenderHTMLChunks("content", 1)).To(Equal([]sgml.Chunk{
+			{
+				Name:  "test.html",
+				Title: "Untitled",
+				Content: `<div class="paragraph">
+<p>content</p>
+</div>
+`,
+			},
+		}))
+	})
+
+	It("should rewrite the cross references to the other pages", func() {
+		source := `== Section A
+
+see <<_Section_B>>, <<anchor,the anchor>> and <<_Section_A>>
+
+== Section B
+
+[[anchor]]
+content B`
+		chunks, err := RenderHTMLChunks(source, 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(chunks).To(HaveLen(3))
+		Expect(chunks[1].Content).To(ContainSubstring(`<p>see <a href="section_b.html#_section_b">Section B</a>, <a href="section_b.html#anchor">the anchor</a> and <a href="#_section_a">Section A</a></p>`))
+	})
+
+	It("should rewrite the links to the auto-generated IDs in the other pages", func() {
+		source := `== Chapter One
+
+=== Sub One
+
+content
+
+== B Sec
+
+see <<_sub_one>>, xref:_sub_one[the xref], link:#_sub_one[the link] and <<_b_sec>>`
+		chunks, err := RenderHTMLChunks(source, 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(chunks).To(HaveLen(3))
+		Expect(chunks[2].Name).To(Equal("b_sec.html"))
+		Expect(chunks[2].Content).To(ContainSubstring(`<a href="chapter_one.html#_sub_one">[_sub_one]</a>`))
+		Expect(chunks[2].Content).To(ContainSubstring(`<a href="chapter_one.html#_sub_one">the xref</a>`))
+		Expect(chunks[2].Content).To(ContainSubstring(`<a href="chapter_one.html#_sub_one">the link</a>`))
+		Expect(chunks[2].Content).To(ContainSubstring(`<a href="#_b_sec">[_b_sec]</a>`))
+	})
+
+	It("should render the table of contents of each page", func() {
+		chunks, err := RenderHTMLChunks(":toc:\n\n"+source, 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(chunks).To(HaveLen(3))
+		// the table of contents of the whole document, in the first page
+		Expect(chunks[0].Content).To(ContainSubstring(`<div id="toc" class="toc">
+<div id="toctitle">Table of Contents</div>
+<ul class="sectlevel1">
+<li><a href="section_a.html#_section_a">Section A</a>
+<ul class="sectlevel2">
+<li><a href="section_a.html#_section_a_1">Section A.1</a></li>
+</ul>
+</li>
+<li><a href="section_b.html#_section_b">Section B</a></li>
+</ul>
+</div>
+`))
+		// the table of contents of the subsections, in the other pages
+		Expect(chunks[1].Content).To(HavePrefix(`<div id="toc" class="toc">
+<div id="toctitle">Table of Contents</div>
+<ul class="sectlevel2">
+<li><a href="#_section_a_1">Section A.1</a></li>
+</ul>
+</div>
+`))
+		Expect(chunks[2].Content).NotTo(ContainSubstring(`<div id="toc"`))
+	})
+
+	It("should keep the footnotes on the page where they are referenced", func() {
+		source := `== Section A
+
+content A.footnote:[note A]
+
+== Section B
+
+content B.footnote:[note B]`
+		chunks, err := RenderHTMLChunks(source, 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(chunks).To(HaveLen(3))
+		Expect(chunks[0].Content).NotTo(ContainSubstring(`<div id="footnotes">`))
+		Expect(chunks[1].Content).To(ContainSubstring(`<div id="footnotes">
+<hr>
+<div class="footnote" id="_footnotedef_1">
+<a href="#_footnoteref_1">1</a>. note A
+</div>
+</div>
+`))
+		Expect(chunks[1].Content).NotTo(ContainSubstring("note B"))
+		Expect(chunks[2].Content).To(ContainSubstring(`<div class="footnote" id="_footnotedef_2">`))
+		Expect(chunks[2].Content).NotTo(ContainSubstring("note A"))
+	})
+
+	It("should use distinct names", func() {
+		source := `[#test]
+== Test
+
+[#_Test]
+== Another Test`
+		chunks, err := RenderHTMLChunks(source, 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(chunks).To(HaveLen(3))
+		Expect(chunks[0].Name).To(Equal("test.html"))
+		Expect(chunks[1].Name).To(Equal("test-2.html"))
+		Expect(chunks[2].Name).To(Equal("test-3.html"))
+	})
+
+	It("should render the pages with header and footer", func() {
+		chunks, err := RenderHTMLChunks(source, 1, configuration.WithHeaderFooter(true))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(chunks).To(HaveLen(3))
+		Expect(chunks[0].Content).To(ContainSubstring("<title>Title</title>"))
+		Expect(chunks[0].Content).To(ContainSubstring("<h1>Title</h1>"))
+		Expect(chunks[1].Content).To(ContainSubstring("<title>Section A</title>"))
+		// the header of the document is only rendered in the first page
+		Expect(chunks[1].Content).NotTo(ContainSubstring("<h1>"))
+	})
+
+	It("should fail with an invalid level", func() {
+		_, err := RenderHTMLChunks(source, -1)
+		Expect(err).To(MatchError("invalid chunk level: -1"))
+	})
+
+	It("should fail with an unsupported backend", func() {
+		_, err := RenderHTMLChunks(source, 1, configuration.WithBackEnd("docbook5"))
+		Expect(err).To(MatchError("backend 'docbook5' does not support the chunked output"))
+	})
+})
@@ -300,6 +300,24 @@ some content linked to xref:{foo}.adoc[another_doc()]!`
 			expected := `<div class="paragraph">
 <p>some content linked to <a href="foo.html">foo.html</a>!</p>
 </div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("to section in other doc with empty label", func() {
+			source := `some content linked to xref:foo.adoc#section_a[]!`
+			expected := `<div class="paragraph">
+<p>some content linked to <a href="foo.html#section_a">foo.html#section_a</a>!</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("to section in other doc in parent directory with label", func() {
+			source := `some content linked to xref:../foo.adoc#section_a[Section A]!`
+			expected := `<div class="paragraph">
+<p>some content linked to <a href="../foo.html#section_a">Section A</a>!</p>
+</div>
 `
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
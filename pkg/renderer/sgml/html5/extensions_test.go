@@ -0,0 +1,193 @@
+package html5_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("extensions", func() {
+
+	It("should apply preprocessor", func() {
+		source := `first paragraph
+
+// TODO: remove this line
+second paragraph`
+		expected := `<div class="paragraph">
+<p>first paragraph</p>
+</div>
+<div class="paragraph">
+<p>second paragraph</p>
+</div>
+`
+		Expect(RenderHTML(source, configuration.WithPreprocessor(func(lines []string) ([]string, error) {
+			result := []string{}
+			for _, l := range lines {
+				if !strings.HasPrefix(l, "// TODO") {
+					result = append(result, l)
+				}
+			}
+			return result, nil
+		}))).To(MatchHTML(expected))
+	})
+
+	It("should apply include processor", func() {
+		source := `include::virtual.adoc[]
+
+include::../../../../test/includes/grandchild-include.adoc[]`
+		expected := `<div class="paragraph">
+<p>some <strong>virtual</strong> content</p>
+</div>
+<div class="sect1">
+<h2 id="_grandchild_title">grandchild title</h2>
+<div class="sectionbody">
+<div class="paragraph">
+<p>first line of grandchild</p>
+</div>
+<div class="paragraph">
+<p>last line of grandchild</p>
+</div>
+</div>
+</div>
+`
+		Expect(RenderHTML(source, configuration.WithIncludeProcessor(func(target string, _ types.Attributes) (string, bool, error) {
+			if target == "virtual.adoc" {
+				return "some *virtual* content", true, nil
+			}
+			return "", false, nil
+		}))).To(MatchHTML(expected))
+	})
+
+	It("should apply block processor", func() {
+		source := `[shout]
+hello, world
+
+[shout]
+====
+hello, *world*
+====`
+		expected := `<div class="paragraph">
+<p>HELLO, WORLD</p>
+</div>
+<div class="exampleblock">
+<div class="content">
+<div class="paragraph">
+<p>hello, <strong>world</strong></p>
+</div>
+</div>
+</div>
+`
+		Expect(RenderHTML(source, configuration.WithBlockProcessor("shout", func(block types.WithAttributes) (interface{}, error) {
+			p, ok := block.(*types.Paragraph)
+			if !ok {
+				// remove the style to render the block as-is
+				delete(block.GetAttributes(), types.AttrStyle)
+				return block, nil
+			}
+			for _, e := range p.Elements {
+				if s, ok := e.(*types.StringElement); ok {
+					s.Content = strings.ToUpper(s.Content)
+				}
+			}
+			delete(p.Attributes, types.AttrStyle)
+			return p, nil
+		}))).To(MatchHTML(expected))
+	})
+
+	It("should apply block and inline macro processors", func() {
+		source := `see issue:123[] and issue:456[title=Crash]
+
+toc::[]
+
+hello::world[]`
+		expected := `<div class="paragraph">
+<p>see <a href="https://example.com/issues/123">#123</a> and <a href="https://example.com/issues/456">Crash</a></p>
+</div>
+<div class="paragraph">
+<p>Hello, world!</p>
+</div>
+`
+		Expect(RenderHTML(source,
+			configuration.WithInlineMacroProcessor("issue", func(m *types.UserMacro) (interface{}, error) {
+				text := m.Attributes.GetAsStringWithDefault("title", "#"+m.Value)
+				return types.NewInlineLink(
+					&types.Location{
+						Scheme: "https://",
+						Path:   "example.com/issues/" + m.Value,
+					},
+					types.Attributes{
+						types.AttrInlineLinkText: text,
+					})
+			}),
+			configuration.WithBlockMacroProcessor("toc", func(m *types.UserMacro) (interface{}, error) {
+				// remove the macro
+				return nil, nil
+			}),
+			configuration.WithBlockMacroProcessor("hello", func(m *types.UserMacro) (interface{}, error) {
+				return types.NewParagraph(nil, &types.StringElement{
+					Content: fmt.Sprintf("Hello, %s!", m.Value),
+				})
+			}),
+		)).To(MatchHTML(expected))
+	})
+
+	It("should apply tree processor", func() {
+		source := `== Section 1
+
+== Section 2`
+		expected := `<div class="sect1">
+<h2 id="_section_1">1. Section 1</h2>
+<div class="sectionbody">
+</div>
+</div>
+<div class="sect1">
+<h2 id="_section_2">2. Section 2</h2>
+<div class="sectionbody">
+</div>
+</div>
+`
+		Expect(RenderHTML(source, configuration.WithTreeProcessor(func(doc *types.Document) error {
+			n := 0
+			for _, e := range doc.Elements {
+				if s, ok := e.(*types.Section); ok {
+					n++
+					s.Title = append([]interface{}{
+						&types.StringElement{
+							Content: fmt.Sprintf("%d. ", n),
+						},
+					}, s.Title...)
+				}
+			}
+			return nil
+		}))).To(MatchHTML(expected))
+	})
+
+	It("should apply postprocessors", func() {
+		source := `hello, world`
+		expected := `<div class="paragraph">
+<p>hello, world</p>
+</div>
+<!-- generated -->
+`
+		Expect(RenderHTML(source,
+			configuration.WithPostprocessor(func(output string) (string, error) {
+				return output + "<!-- generated -->\n", nil
+			}),
+		)).To(MatchHTML(expected))
+	})
+
+	It("should fail when a processor fails", func() {
+		source := `[fail]
+hello, world`
+		_, err := RenderHTML(source, configuration.WithBlockProcessor("fail", func(_ types.WithAttributes) (interface{}, error) {
+			return nil, fmt.Errorf("failure")
+		}))
+		Expect(err).To(MatchError("unable to process block with style 'fail': failure"))
+	})
+})
@@ -19,6 +19,7 @@ var _ = Describe("file inclusions", func() {
 		defer reset()
 		lastUpdated := time.Now()
 		source := "include::../../../../test/includes/grandchild-include.adoc[]"
+		included := "../../../../test/includes/grandchild-include.adoc" // in the same form as the path of the root document
 		expected := `<div class="sect1">
 <h2 id="_grandchild_title">grandchild title</h2>
 <div class="sectionbody">
@@ -52,6 +53,7 @@ var _ = Describe("file inclusions", func() {
 					},
 				},
 			},
+			Files: []string{included},
 		}))
 		// verify no error/warning in logs
 		Expect(logs).ToNot(ContainAnyMessageWithLevels(log.ErrorLevel, log.WarnLevel))
@@ -630,6 +632,37 @@ include::../../../../test/includes/hello_world.go.txt[]
 </tr>
 </tbody>
 </table>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("with csv data", func() {
+			source := `,===
+include::../../../../test/includes/table_data.csv[]
+,===`
+			expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 50%;">
+<col style="width: 50%;">
+</colgroup>
+<thead>
+<tr>
+<th class="tableblock halign-left valign-top">Artist</th>
+<th class="tableblock halign-left valign-top">Track</th>
+</tr>
+</thead>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">Baauer</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">Harlem Shake, the song</p></td>
+</tr>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">The Lumineers</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">Ho
+Hey</p></td>
+</tr>
+</tbody>
+</table>
 `
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
@@ -751,8 +784,7 @@ func helloworld() {
 		})
 
 		It("file inclusion with unclosed tag", func() {
-			logs, reset := ConfigureLogger(log.WarnLevel)
-			defer reset()
+			diagnostics := types.NewDiagnostics()
 			source := `include::../../../../test/includes/tag-include-unclosed.adoc[tag=unclosed]`
 			expected := `<div class="paragraph">
 <p>content</p>
@@ -761,9 +793,14 @@ func helloworld() {
 <p>end</p>
 </div>
 `
-			Expect(RenderHTML(source)).To(MatchHTML(expected))
-			// verify error in logs
-			Expect(logs).To(ContainJSONLog(log.WarnLevel, "detected unclosed tag 'unclosed' starting at line 6 of include file: ../../../../test/includes/tag-include-unclosed.adoc"))
+			Expect(RenderHTML(source, configuration.WithDiagnostics(diagnostics))).To(MatchHTML(expected))
+			// verify warning in diagnostics
+			Expect(diagnostics.All()).To(HaveLen(1))
+			Expect(diagnostics.All()[0].Severity).To(Equal(types.SeverityWarning))
+			Expect(diagnostics.All()[0].Message).To(And(
+				HavePrefix("detected unclosed tag 'unclosed' starting at line 6 of include file: "),
+				HaveSuffix("test/includes/tag-include-unclosed.adoc"),
+			))
 		})
 
 		It("file inclusion with no tag", func() {
@@ -12,3 +12,8 @@ import (
 func Render(doc *types.Document, config *configuration.Configuration, output io.Writer) (types.Metadata, error) {
 	return sgml.Render(doc, config, output, templates)
 }
+
+// RenderChunks renders the document in multiple pages (see `sgml.RenderChunks()`), using the SGML renderer configured with the HTML5 templates
+func RenderChunks(doc *types.Document, config *configuration.Configuration, level int) ([]sgml.Chunk, types.Metadata, error) {
+	return sgml.RenderChunks(doc, config, templates, level)
+}
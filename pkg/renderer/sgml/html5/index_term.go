@@ -0,0 +1,24 @@
+package html5
+
+const (
+	// the anchor is only rendered when the document has an `[index]` section
+	indexTermTmpl = `{{ if .Anchor }}<a id="{{ .ID }}"></a>{{ end }}{{ .Term }}`
+
+	indexTmpl = `<div class="index">
+{{ .Content }}</div>
+`
+
+	indexCategoryTmpl = `<div class="indexcategory">
+<div class="title">{{ .Letter }}</div>
+<ul>
+{{ .Content }}</ul>
+</div>
+`
+
+	indexEntryTmpl = `<li>{{ .Term }}{{ range .Occurrences }}, <a href="#{{ .ID }}">{{ .Title }}</a>{{ end }}` +
+		`{{ if .Content }}
+<ul>
+{{ .Content }}</ul>
+{{ end }}</li>
+`
+)
@@ -79,3 +79,109 @@ discarded.</p>
 		Expect(RenderHTML(source)).To(MatchHTML(expected))
 	})
 })
+
+var _ = Describe("index", func() {
+
+	It("index section with anchors and back-links", func() {
+		source := `== Fruits
+
+An ((apple)) and a ((banana)) (((apple, green))).
+
+== Vegetables
+
+A ((*carrot*)) and another ((Apple)) (((42))).
+
+[index]
+== Index`
+		expected := `<div class="sect1">
+<h2 id="_fruits">Fruits</h2>
+<div class="sectionbody">
+<div class="paragraph">
+<p>An <a id="_indexterm_1"></a>apple and a <a id="_indexterm_2"></a>banana <a id="_indexterm_3"></a>.</p>
+</div>
+</div>
+</div>
+<div class="sect1">
+<h2 id="_vegetables">Vegetables</h2>
+<div class="sectionbody">
+<div class="paragraph">
+<p>A <a id="_indexterm_4"></a><strong>carrot</strong> and another <a id="_indexterm_5"></a>Apple <a id="_indexterm_6"></a>.</p>
+</div>
+</div>
+</div>
+<div class="sect1">
+<h2 id="_index">Index</h2>
+<div class="sectionbody">
+<div class="index">
+<div class="indexcategory">
+<div class="title">#</div>
+<ul>
+<li>42, <a href="#_indexterm_6">Vegetables</a></li>
+</ul>
+</div>
+<div class="indexcategory">
+<div class="title">A</div>
+<ul>
+<li>apple, <a href="#_indexterm_1">Fruits</a>, <a href="#_indexterm_5">Vegetables</a>
+<ul>
+<li>green, <a href="#_indexterm_3">Fruits</a></li>
+</ul>
+</li>
+</ul>
+</div>
+<div class="indexcategory">
+<div class="title">B</div>
+<ul>
+<li>banana, <a href="#_indexterm_2">Fruits</a></li>
+</ul>
+</div>
+<div class="indexcategory">
+<div class="title">C</div>
+<ul>
+<li><strong>carrot</strong>, <a href="#_indexterm_4">Vegetables</a></li>
+</ul>
+</div>
+</div>
+</div>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("index back-links with and without section", func() {
+		source := `a ((term)) in the preamble
+
+== The _Terms_
+
+another ((Term)) in the section
+
+[index]
+== Index`
+		expected := `<div class="paragraph">
+<p>a <a id="_indexterm_1"></a>term in the preamble</p>
+</div>
+<div class="sect1">
+<h2 id="_the_terms">The <em>Terms</em></h2>
+<div class="sectionbody">
+<div class="paragraph">
+<p>another <a id="_indexterm_2"></a>Term in the section</p>
+</div>
+</div>
+</div>
+<div class="sect1">
+<h2 id="_index">Index</h2>
+<div class="sectionbody">
+<div class="index">
+<div class="indexcategory">
+<div class="title">T</div>
+<ul>
+<li>term, <a href="#_indexterm_1">1</a>, <a href="#_indexterm_2">The <em>Terms</em></a></li>
+</ul>
+</div>
+</div>
+</div>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+})
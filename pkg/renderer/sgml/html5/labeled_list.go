@@ -10,6 +10,15 @@ const (
 	labeledListElementTmpl = "<dt class=\"hdlist1\">{{ .Term }}</dt>\n" +
 		"{{ if .Content }}<dd>\n{{ .Content }}</dd>\n{{ end }}"
 
+	labeledListGlossaryTmpl = `<div` +
+		`{{ if .ID }} id="{{ .ID }}"{{ end }}` +
+		" class=\"dlist glossary{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
+		"{{ if .Title }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
+		"<dl>\n{{ .Content }}</dl>\n</div>\n"
+
+	labeledListGlossaryElementTmpl = "<dt>{{ .Term }}</dt>\n" +
+		"{{ if .Content }}<dd>\n{{ .Content }}</dd>\n{{ end }}"
+
 	labeledListHorizontalTmpl = `<div` +
 		`{{ if .ID }} id="{{ .ID }}"{{ end }} ` +
 		"class=\"hdlist{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
@@ -104,6 +104,18 @@ some content`
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("paragraph with safe mode attributes", func() {
+			source := `{safe-mode-name} ({safe-mode-level})
+ifdef::safe-mode-server[server mode]
+ifdef::safe-mode-unsafe[unsafe mode]`
+			expected := `<div class="paragraph">
+<p>server (10)
+server mode</p>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithSafeMode(configuration.SafeModeServer))).To(MatchHTML(expected))
+		})
+
 		It("with custom title attribute - explicit and unquoted", func() {
 			source := `:title: cookies
 			
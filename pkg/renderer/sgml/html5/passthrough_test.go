@@ -1,6 +1,7 @@
 package html5_test
 
 import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	. "github.com/bytesparadise/libasciidoc/testsupport"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -174,4 +175,106 @@ world</p>
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 	})
+
+	Context("in server safe mode", func() {
+
+		It("should escape content of passthrough block", func() {
+			source := `++++
+<script>alert('hello')</script>
+++++`
+			expected := `<div class="literalblock">
+<div class="content">
+<pre>&lt;script&gt;alert('hello')&lt;/script&gt;</pre>
+</div>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithSafeMode(configuration.SafeModeServer))).To(MatchHTML(expected))
+		})
+
+		It("should escape content of passthrough paragraph", func() {
+			source := `[pass]
+<u>hello</u> & goodbye`
+			expected := `<div class="paragraph">
+<p>&lt;u&gt;hello&lt;/u&gt; &amp; goodbye</p>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithSafeMode(configuration.SafeModeServer))).To(MatchHTML(expected))
+		})
+
+		It("should escape content of passthrough macros", func() {
+			source := `a pass:[<u>hello</u>], +++<s>world</s>+++ and pass:q[<b>*!*</b>]`
+			expected := `<div class="paragraph">
+<p>a &lt;u&gt;hello&lt;/u&gt;, &lt;s&gt;world&lt;/s&gt; and &lt;b&gt;<strong>!</strong>&lt;/b&gt;</p>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithSafeMode(configuration.SafeModeServer))).To(MatchHTML(expected))
+		})
+
+		It("should escape content of paragraph without substitutions", func() {
+			source := `[subs=none]
+<script>b()</script>`
+			expected := `<div class="paragraph">
+<p>&lt;script&gt;b()&lt;/script&gt;</p>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithSafeMode(configuration.SafeModeServer))).To(MatchHTML(expected))
+		})
+
+		It("should escape content of paragraph without special characters substitution", func() {
+			source := `[subs="-specialchars"]
+<img src=x onerror=c()> and *<i>bold</i>*`
+			expected := `<div class="paragraph">
+<p>&lt;img src=x onerror=c()&gt; and <strong>&lt;i&gt;bold&lt;/i&gt;</strong></p>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithSafeMode(configuration.SafeModeSecure))).To(MatchHTML(expected))
+		})
+
+		It("should escape content of listing block without substitutions", func() {
+			source := `[subs=none]
+----
+<u>raw</u>
+----`
+			expected := `<div class="listingblock">
+<div class="content">
+<pre>&lt;u&gt;raw&lt;/u&gt;</pre>
+</div>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithSafeMode(configuration.SafeModeServer))).To(MatchHTML(expected))
+		})
+
+		It("should escape content of literal block without substitutions", func() {
+			source := `[subs=none]
+....
+<u>raw</u> & more
+....`
+			expected := `<div class="literalblock">
+<div class="content">
+<pre>&lt;u&gt;raw&lt;/u&gt; &amp; more</pre>
+</div>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithSafeMode(configuration.SafeModeServer))).To(MatchHTML(expected))
+		})
+
+		It("should not escape content of paragraph without substitutions in safe mode", func() {
+			source := `[subs=none]
+<u>hello</u>`
+			expected := `<div class="paragraph">
+<p><u>hello</u></p>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithSafeMode(configuration.SafeModeSafe))).To(MatchHTML(expected))
+		})
+
+		It("should not escape content of passthrough macro in safe mode", func() {
+			source := `a pass:[<u>hello</u>]`
+			expected := `<div class="paragraph">
+<p>a <u>hello</u></p>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithSafeMode(configuration.SafeModeSafe))).To(MatchHTML(expected))
+		})
+	})
 })
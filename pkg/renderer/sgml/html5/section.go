@@ -2,11 +2,18 @@ package html5
 
 // initializes the sgml
 const (
-	sectionContentTmpl = `<div class="sect{{ .Level }}{{ if .Roles }} {{ .Roles }}{{ end }}">
+	sectionContentTmpl = `{{ if eq .Level 0 }}{{ .Header }}{{ .Content }}{{ else }}<div class="sect{{ .Level }}{{ if .Roles }} {{ .Roles }}{{ end }}">
 {{ .Header }}{{ if eq .Level 1 }}<div class="sectionbody">
 {{ end }}{{ .Content }}{{ if eq .Level 1 }}</div>
 {{ end }}</div>
+{{ end }}`
+	sectionTitleTmpl = `<h{{ .LevelPlusOne }} id="{{ toLower .ID }}"{{ if eq .Level 0 }} class="sect0{{ if .Roles }} {{ .Roles }}{{ end }}"{{ end }}>{{ .Caption }}{{ .Content }}</h{{ .LevelPlusOne }}>
 `
-	sectionTitleTmpl = `<h{{ .LevelPlusOne }} id="{{ toLower .ID }}">{{ if .Number }}{{ .Number }}. {{ end }}{{ .Content }}</h{{ .LevelPlusOne }}>
+
+	partIntroTmpl = `<div{{ if .ID }} id="{{ .ID }}"{{ end }} class="openblock partintro{{ if .Roles }} {{ .Roles }}{{ end }}">
+{{ if .Title }}<div class="title">{{ .Title }}</div>
+{{ end }}<div class="content">
+{{ .Content }}</div>
+</div>
 `
 )
@@ -0,0 +1,42 @@
+package html5
+
+const (
+	// eg: `<div class="stemblock"><div class="content">\$sqrt(4) = 2\$</div></div>`
+	stemBlockTmpl = "<div {{ if .ID }}id=\"{{ .ID }}\" {{ end }}" +
+		"class=\"stemblock{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
+		"{{ if .Title }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
+		"<div class=\"content\">\n" +
+		`{{ if eq .Notation "latexmath" }}\[{{ .Content }}\]{{ else }}\${{ .Content }}\${{ end }}` + "\n" +
+		"</div>\n" +
+		"</div>\n"
+
+	// eg: `\$sqrt(4) = 2\$` or `\(C = \alpha + \beta Y^{\gamma}\)`
+	inlineStemTmpl = `{{ if eq .Notation "latexmath" }}\({{ .Content }}\){{ else }}\${{ .Content }}\${{ end }}`
+
+	// the MathJax configuration and script, included at the end of the document body when the `stem` attribute is set
+	mathJaxTmpl = `<script type="text/x-mathjax-config">
+MathJax.Hub.Config({
+  messageStyle: "none",
+  tex2jax: {
+    inlineMath: [["\\(", "\\)"]],
+    displayMath: [["\\[", "\\]"]],
+    ignoreClass: "nostem|nolatexmath"
+  },
+  asciimath2jax: {
+    delimiters: [["\\$", "\\$"]],
+    ignoreClass: "nostem|noasciimath"
+  },
+  TeX: { equationNumbers: { autoNumber: "{{ .EquationNumbers }}" } }
+})
+MathJax.Hub.Register.StartupHook("AsciiMath Jax Ready", function () {
+  MathJax.InputJax.AsciiMath.postfilterHooks.Add(function (data, node) {
+    if ((node = data.script.parentNode) && (node = node.parentNode) && node.classList.contains("stemblock")) {
+      data.math.root.display = "block"
+    }
+    return data
+  })
+})
+</script>
+<script src="https://cdnjs.cloudflare.com/ajax/libs/mathjax/2.7.9/MathJax.js?config=TeX-MML-AM_HTMLorMML"></script>
+`
+)
@@ -0,0 +1,169 @@
+package html5_test
+
+import (
+	"time"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("stem", func() {
+
+	Context("as delimited blocks", func() {
+
+		It("with default notation", func() {
+			source := `[stem]
+++++
+sqrt(4) < 2
+++++`
+			expected := `<div class="stemblock">
+<div class="content">
+\$sqrt(4) &lt; 2\$
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("with notation set in document attribute", func() {
+			source := `:stem: latexmath
+
+[stem]
+++++
+C = \alpha + \beta Y^{\gamma} + \epsilon
+++++`
+			expected := `<div class="stemblock">
+<div class="content">
+\[C = \alpha + \beta Y^{\gamma} + \epsilon\]
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("with latexmath style, ID, title and role", func() {
+			source := `.an equation
+[latexmath#eq.role1]
+++++
+\[C = \alpha + \beta\]
+++++`
+			expected := `<div id="eq" class="stemblock role1">
+<div class="title">an equation</div>
+<div class="content">
+\[C = \alpha + \beta\]
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("as paragraph with asciimath style", func() {
+			source := `:stem: latexmath
+
+[asciimath]
+sum_(i=1)^n i = (n(n+1))/2`
+			expected := `<div class="stemblock">
+<div class="content">
+\$sum_(i=1)^n i = (n(n+1))/2\$
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+	})
+
+	Context("as inline macros", func() {
+
+		It("with default notation", func() {
+			source := `the equation stem:[sqrt(4) < 2] is true`
+			expected := `<div class="paragraph">
+<p>the equation \$sqrt(4) &lt; 2\$ is true</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("with latexmath and asciimath notations", func() {
+			source := `latexmath:[[a, b\]] and asciimath:[a < b]`
+			expected := `<div class="paragraph">
+<p>\([a, b]\) and \$a &lt; b\$</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+	})
+
+	Context("in documents", func() {
+
+		It("with MathJax script", func() {
+			source := `= Equations
+:stem: latexmath
+:eqnums:
+
+latexmath:[x]`
+			expectedTmpl := `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<meta http-equiv="X-UA-Compatible" content="IE=edge">
+<meta name="viewport" content="width=device-width, initial-scale=1.0">
+<meta name="generator" content="libasciidoc">
+<title>Equations</title>
+</head>
+<body class="article">
+<div id="header">
+<h1>Equations</h1>
+</div>
+<div id="content">
+<div class="paragraph">
+<p>\(x\)</p>
+</div>
+</div>
+<div id="footer">
+<div id="footer-text">
+Last updated {{ .LastUpdated }}
+</div>
+</div>
+<script type="text/x-mathjax-config">
+MathJax.Hub.Config({
+  messageStyle: "none",
+  tex2jax: {
+    inlineMath: [["\\(", "\\)"]],
+    displayMath: [["\\[", "\\]"]],
+    ignoreClass: "nostem|nolatexmath"
+  },
+  asciimath2jax: {
+    delimiters: [["\\$", "\\$"]],
+    ignoreClass: "nostem|noasciimath"
+  },
+  TeX: { equationNumbers: { autoNumber: "AMS" } }
+})
+MathJax.Hub.Register.StartupHook("AsciiMath Jax Ready", function () {
+  MathJax.InputJax.AsciiMath.postfilterHooks.Add(function (data, node) {
+    if ((node = data.script.parentNode) && (node = node.parentNode) && node.classList.contains("stemblock")) {
+      data.math.root.display = "block"
+    }
+    return data
+  })
+})
+</script>
+<script src="https://cdnjs.cloudflare.com/ajax/libs/mathjax/2.7.9/MathJax.js?config=TeX-MML-AM_HTMLorMML"></script>
+</body>
+</html>
+`
+			now := time.Now()
+			Expect(RenderHTML(source,
+				configuration.WithHeaderFooter(true),
+				configuration.WithLastUpdated(now),
+			)).To(MatchHTMLTemplate(expectedTmpl,
+				struct {
+					LastUpdated string
+				}{
+					LastUpdated: now.Format(configuration.LastUpdatedFormat),
+				}))
+		})
+	})
+})
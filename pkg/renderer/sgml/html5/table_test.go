@@ -755,4 +755,398 @@ image::another-image.png[]
 `
 		Expect(RenderHTML(source)).To(MatchHTML(expected))
 	})
+
+	Context("with cell specifiers", func() {
+
+		It("with spans and duplication", func() {
+			source := `|===
+|a 2+|b
+.2+|c |d |e
+|f |g
+3*|h
+|===`
+			expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 33.3333%;">
+<col style="width: 33.3333%;">
+<col style="width: 33.3334%;">
+</colgroup>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">a</p></td>
+<td class="tableblock halign-left valign-top" colspan="2"><p class="tableblock">b</p></td>
+</tr>
+<tr>
+<td class="tableblock halign-left valign-top" rowspan="2"><p class="tableblock">c</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">d</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">e</p></td>
+</tr>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">f</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">g</p></td>
+</tr>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">h</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">h</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">h</p></td>
+</tr>
+</tbody>
+</table>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("with alignments and styles", func() {
+			source := `[cols="3*"]
+|===
+^.>m|mono e|emph s|strong
+l|literal *text*
+  indented v|verse
+line h|head
+a|
+* item
+2+|_default_
+|===`
+			expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 33.3333%;">
+<col style="width: 33.3333%;">
+<col style="width: 33.3334%;">
+</colgroup>
+<tbody>
+<tr>
+<td class="tableblock halign-center valign-bottom"><p class="tableblock"><code>mono</code></p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock"><em>emph</em></p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock"><strong>strong</strong></p></td>
+</tr>
+<tr>
+<td class="tableblock halign-left valign-top"><div class="literal"><pre>literal *text*
+  indented</pre></div></td>
+<td class="tableblock halign-left valign-top"><div class="verse">verse
+line</div></td>
+<th class="tableblock halign-left valign-top"><p class="tableblock">head</p></th>
+</tr>
+<tr>
+<td class="tableblock halign-left valign-top"><div class="content"><div class="ulist">
+<ul>
+<li>
+<p>item</p>
+</li>
+</ul>
+</div></div></td>
+<td class="tableblock halign-left valign-top" colspan="2"><p class="tableblock"><em>default</em></p></td>
+</tr>
+</tbody>
+</table>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("with blank lines in multiline cell", func() {
+			source := `|===
+2+|first paragraph
+
+second paragraph
+|===`
+			expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 50%;">
+<col style="width: 50%;">
+</colgroup>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top" colspan="2"><p class="tableblock">first paragraph</p><p class="tableblock">second paragraph</p></td>
+</tr>
+</tbody>
+</table>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("with paragraphs and list in AsciiDoc cell", func() {
+			source := `|===
+a|first paragraph
+
+second paragraph
+
+* item
+|===`
+			expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 100%;">
+</colgroup>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><div class="content"><div class="paragraph">
+<p>first paragraph</p>
+</div>
+<div class="paragraph">
+<p>second paragraph</p>
+</div>
+<div class="ulist">
+<ul>
+<li>
+<p>item</p>
+</li>
+</ul>
+</div></div></td>
+</tr>
+</tbody>
+</table>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+	})
+
+	It("nested in AsciiDoc cell", func() {
+		source := `|===
+|Endpoint |Parameters
+
+|GET /users
+a|
+!===
+!Name !Type
+
+!limit !int
+!===
+|===`
+		expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 50%;">
+<col style="width: 50%;">
+</colgroup>
+<thead>
+<tr>
+<th class="tableblock halign-left valign-top">Endpoint</th>
+<th class="tableblock halign-left valign-top">Parameters</th>
+</tr>
+</thead>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">GET /users</p></td>
+<td class="tableblock halign-left valign-top"><div class="content"><table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 50%;">
+<col style="width: 50%;">
+</colgroup>
+<thead>
+<tr>
+<th class="tableblock halign-left valign-top">Name</th>
+<th class="tableblock halign-left valign-top">Type</th>
+</tr>
+</thead>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">limit</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">int</p></td>
+</tr>
+</tbody>
+</table></div></td>
+</tr>
+</tbody>
+</table>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("nested in AsciiDoc column after a blank line", func() {
+		source := `[cols="1,2a"]
+|===
+|Col 1 |Col 2
+
+|Cell in column 1, row 1
+|Cell with nested table
+
+[cols="2,1"]
+!===
+!Nested table cell 1 !Nested table cell 2
+!===
+|===`
+		expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 33.3333%;">
+<col style="width: 66.6667%;">
+</colgroup>
+<thead>
+<tr>
+<th class="tableblock halign-left valign-top">Col 1</th>
+<th class="tableblock halign-left valign-top">Col 2</th>
+</tr>
+</thead>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">Cell in column 1, row 1</p></td>
+<td class="tableblock halign-left valign-top"><div class="content"><div class="paragraph">
+<p>Cell with nested table</p>
+</div>
+<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 66.6667%;">
+<col style="width: 33.3334%;">
+</colgroup>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">Nested table cell 1</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">Nested table cell 2</p></td>
+</tr>
+</tbody>
+</table></div></td>
+</tr>
+</tbody>
+</table>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("nested in AsciiDoc column", func() {
+		source := `[cols="1,2a"]
+|===
+|Col 1 |Col 2
+
+|Cell in column 1, row 1
+|Cell with nested table
+[cols="2,1"]
+!===
+!Nested table cell 1 !Nested table cell 2
+!===
+|===`
+		expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 33.3333%;">
+<col style="width: 66.6667%;">
+</colgroup>
+<thead>
+<tr>
+<th class="tableblock halign-left valign-top">Col 1</th>
+<th class="tableblock halign-left valign-top">Col 2</th>
+</tr>
+</thead>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">Cell in column 1, row 1</p></td>
+<td class="tableblock halign-left valign-top"><div class="content"><div class="paragraph">
+<p>Cell with nested table</p>
+</div>
+<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 66.6667%;">
+<col style="width: 33.3334%;">
+</colgroup>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">Nested table cell 1</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">Nested table cell 2</p></td>
+</tr>
+</tbody>
+</table></div></td>
+</tr>
+</tbody>
+</table>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("with blank lines in AsciiDoc column", func() {
+		source := `[cols="1,1a"]
+|===
+|Step |Details
+
+|Install
+|Run the installer.
+
+* on Linux
+* on macOS
+|===`
+		expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 50%;">
+<col style="width: 50%;">
+</colgroup>
+<thead>
+<tr>
+<th class="tableblock halign-left valign-top">Step</th>
+<th class="tableblock halign-left valign-top">Details</th>
+</tr>
+</thead>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">Install</p></td>
+<td class="tableblock halign-left valign-top"><div class="content"><div class="paragraph">
+<p>Run the installer.</p>
+</div>
+<div class="ulist">
+<ul>
+<li>
+<p>on Linux</p>
+</li>
+<li>
+<p>on macOS</p>
+</li>
+</ul>
+</div></div></td>
+</tr>
+</tbody>
+</table>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	Context("with data formats", func() {
+
+		It("csv with header and quoted fields", func() {
+			source := `[format=csv]
+|===
+Artist,Track
+
+Baauer,"Harlem Shake, ""the"" song"
+The Lumineers,"Ho
+Hey"
+|===`
+			expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 50%;">
+<col style="width: 50%;">
+</colgroup>
+<thead>
+<tr>
+<th class="tableblock halign-left valign-top">Artist</th>
+<th class="tableblock halign-left valign-top">Track</th>
+</tr>
+</thead>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">Baauer</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">Harlem Shake, "the" song</p></td>
+</tr>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">The Lumineers</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">Ho
+Hey</p></td>
+</tr>
+</tbody>
+</table>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("dsv with shorthand delimiter", func() {
+			source := `:===
+a:b\:c
+:===`
+			expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 50%;">
+<col style="width: 50%;">
+</colgroup>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">a</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">b:c</p></td>
+</tr>
+</tbody>
+</table>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+	})
 })
@@ -15,11 +15,13 @@ var templates = sgml.Templates{
 	AdmonitionParagraph:          admonitionParagraphTmpl,
 	Article:                      articleTmpl,
 	ArticleHeader:                articleHeaderTmpl,
+	AudioBlock:                   audioBlockTmpl,
 	BlockImage:                   blockImageTmpl,
 	BoldText:                     boldTextTmpl,
 	CalloutList:                  calloutListTmpl,
 	CalloutListElement:           calloutListElementTmpl,
 	CalloutRef:                   calloutRefTmpl,
+	ChunkNavigation:              chunkNavigationTmpl,
 	DocumentDetails:              documentDetailsTmpl,
 	DocumentAuthorDetails:        documentAuthorDetailsTmpl,
 	EmbeddedParagraph:            embeddedParagraphTmpl,
@@ -33,15 +35,22 @@ var templates = sgml.Templates{
 	IconFont:                     iconFontTmpl,
 	IconImage:                    iconImageTmpl,
 	IconText:                     iconTextTmpl,
+	Index:                        indexTmpl,
+	IndexCategory:                indexCategoryTmpl,
+	IndexEntry:                   indexEntryTmpl,
+	IndexTerm:                    indexTermTmpl,
 	InlineButton:                 inlineButtonTmpl,
 	InlineIcon:                   inlineIconTmpl,
 	InlineImage:                  inlineImageTmpl,
 	InlineMenu:                   inlineMenuTmpl,
+	InlineStem:                   inlineStemTmpl,
 	InternalCrossReference:       internalCrossReferenceTmpl,
 	InvalidFootnote:              invalidFootnoteTmpl,
 	ItalicText:                   italicTextTmpl,
 	LabeledList:                  labeledListTmpl,
 	LabeledListElement:           labeledListElementTmpl,
+	LabeledListGlossary:          labeledListGlossaryTmpl,
+	LabeledListGlossaryElement:   labeledListGlossaryElementTmpl,
 	LabeledListHorizontal:        labeledListHorizontalTmpl,
 	LabeledListHorizontalElement: labeledListHorizontalElementTmpl,
 	LineBreak:                    lineBreakTmpl,
@@ -58,6 +67,7 @@ var templates = sgml.Templates{
 	OrderedListElement:           orderedListElementTmpl,
 	PassthroughBlock:             passthroughBlock,
 	Paragraph:                    paragraphTmpl,
+	PartIntro:                    partIntroTmpl,
 	Preamble:                     preambleTmpl,
 	QAndAList:                    qAndAListTmpl,
 	QAndAListElement:             qAndAListElementTmpl,
@@ -67,12 +77,15 @@ var templates = sgml.Templates{
 	SectionTitle:                 sectionTitleTmpl,
 	SidebarBlock:                 sidebarBlockTmpl,
 	SourceBlock:                  sourceBlockTmpl,
+	StemBlock:                    stemBlockTmpl,
 	SubscriptText:                subscriptTextTmpl,
 	SuperscriptText:              superscriptTextTmpl,
 	Table:                        tableTmpl,
 	TableBody:                    tableBodyTmpl,
 	TableCell:                    tableCellTmpl,
 	TableCellBlock:               tableCellBlockTmpl,
+	TableCellLiteral:             tableCellLiteralTmpl,
+	TableCellVerse:               tableCellVerseTmpl,
 	TableHeader:                  tableHeaderTmpl,
 	TableHeaderCell:              tableHeaderCellTmpl,
 	TableFooter:                  tableFooterTmpl,
@@ -86,4 +99,5 @@ var templates = sgml.Templates{
 	UnorderedListElement:         unorderedListElementTmpl,
 	VerseBlock:                   verseBlockTmpl,
 	VerseParagraph:               verseParagraphTmpl,
+	VideoBlock:                   videoBlockTmpl,
 }
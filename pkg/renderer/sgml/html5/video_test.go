@@ -0,0 +1,117 @@
+package html5_test
+
+import (
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("videos", func() {
+
+	It("video file alone", func() {
+		source := "video::video_file.mp4[]"
+		expected := `<div class="videoblock">
+<div class="content">
+<video src="video_file.mp4" controls>
+Your browser does not support the video tag.
+</video>
+</div>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("video file with title, ID, role, poster, dimensions, time range and options", func() {
+		source := `:imagesdir: media
+
+.A video
+[#intro.featured,float=left]
+video::intro.mp4[poster.png,640,480,start=60,end=140,opts="autoplay,loop,muted,nocontrols"]`
+		expected := `<div id="intro" class="videoblock float-left featured">
+<div class="title">A video</div>
+<div class="content">
+<video src="media/intro.mp4#t=60,140" width="640" height="480" poster="media/poster.png" autoplay loop muted>
+Your browser does not support the video tag.
+</video>
+</div>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("video on YouTube", func() {
+		source := `video::rPQoq7ThGAU[youtube]`
+		expected := `<div class="videoblock">
+<div class="content">
+<iframe src="https://www.youtube.com/embed/rPQoq7ThGAU?rel=0" frameborder="0" allowfullscreen></iframe>
+</div>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("video on YouTube with dimensions, time range and options", func() {
+		source := `video::rPQoq7ThGAU[youtube,640,360,start=10,end=20,options="autoplay,loop,modest,nofullscreen",theme=light,lang=fr]`
+		expected := `<div class="videoblock">
+<div class="content">
+<iframe width="640" height="360" src="https://www.youtube.com/embed/rPQoq7ThGAU?rel=0&amp;start=10&amp;end=20&amp;autoplay=1&amp;loop=1&amp;playlist=rPQoq7ThGAU&amp;fs=0&amp;modestbranding=1&amp;theme=light&amp;hl=fr" frameborder="0"></iframe>
+</div>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("video on YouTube with playlist", func() {
+		source := `video::rPQoq7ThGAU/PLxyz[youtube]`
+		expected := `<div class="videoblock">
+<div class="content">
+<iframe src="https://www.youtube.com/embed/rPQoq7ThGAU?rel=0&amp;list=PLxyz" frameborder="0" allowfullscreen></iframe>
+</div>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("video on Vimeo", func() {
+		source := `video::67480300[vimeo,options=autoplay,start=30]`
+		expected := `<div class="videoblock">
+<div class="content">
+<iframe src="https://player.vimeo.com/video/67480300?autoplay=1#at=30" frameborder="0" allowfullscreen></iframe>
+</div>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+})
+
+var _ = Describe("audios", func() {
+
+	It("audio file alone", func() {
+		source := "audio::ocean_waves.mp3[]"
+		expected := `<div class="audioblock">
+<div class="content">
+<audio src="ocean_waves.mp3" controls>
+Your browser does not support the audio tag.
+</audio>
+</div>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("audio file with title, time range and options", func() {
+		source := `.Ocean waves
+audio::ocean_waves.mp3[start=5,end=60,options="autoplay,loop"]`
+		expected := `<div class="audioblock">
+<div class="title">Ocean waves</div>
+<div class="content">
+<audio src="ocean_waves.mp3#t=5,60" autoplay controls loop>
+Your browser does not support the audio tag.
+</audio>
+</div>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+})
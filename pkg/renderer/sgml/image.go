@@ -3,14 +3,13 @@ package sgml
 import (
 	"encoding/base64"
 	"net/url"
-	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 	"github.com/pkg/errors"
-	log "github.com/sirupsen/logrus"
 )
 
 func (r *sgmlRenderer) renderImageBlock(ctx *context, img *types.ImageBlock) (string, error) {
@@ -45,7 +44,7 @@ func (r *sgmlRenderer) renderImageBlock(ctx *context, img *types.ImageBlock) (st
 	if err != nil {
 		return "", errors.Wrap(err, "unable to render image")
 	}
-	src := r.getImageSrc(ctx, img.Location)
+	src := r.getImageSrc(ctx, img.Location, img.SourceRange)
 	alt, err := r.renderImageAlt(img.Attributes, src)
 	if err != nil {
 		return "", errors.Wrap(err, "unable to render image")
@@ -68,7 +67,7 @@ func (r *sgmlRenderer) renderImageBlock(ctx *context, img *types.ImageBlock) (st
 		ImageNumber: number,
 		Caption:     caption.String(),
 		Roles:       roles,
-		Href:        img.Attributes.GetAsStringWithDefault(types.AttrInlineLink, ""),
+		Href:        ctx.chunkHref(img.Attributes.GetAsStringWithDefault(types.AttrInlineLink, "")),
 		Alt:         alt,
 		Width:       img.Attributes.GetAsStringWithDefault(types.AttrWidth, ""),
 		Height:      img.Attributes.GetAsStringWithDefault(types.AttrHeight, ""),
@@ -80,8 +79,8 @@ func (r *sgmlRenderer) renderInlineImage(ctx *context, img *types.InlineImage) (
 	if err != nil {
 		return "", errors.Wrap(err, "unable to render inline image")
 	}
-	href := img.Attributes.GetAsStringWithDefault(types.AttrInlineLink, "")
-	src := r.getImageSrc(ctx, img.Location)
+	href := ctx.chunkHref(img.Attributes.GetAsStringWithDefault(types.AttrInlineLink, ""))
+	src := r.getImageSrc(ctx, img.Location, img.SourceRange)
 	alt, err := r.renderImageAlt(img.Attributes, src)
 	if err != nil {
 		return "", errors.Wrap(err, "unable to render inline image")
@@ -109,7 +108,7 @@ func (r *sgmlRenderer) renderInlineImage(ctx *context, img *types.InlineImage) (
 	})
 }
 
-func (r *sgmlRenderer) getImageSrc(ctx *context, location *types.Location) string {
+func (r *sgmlRenderer) getImageSrc(ctx *context, location *types.Location, sourceRange types.SourceRange) string {
 	if imagesdir, found := ctx.attributes.GetAsString(types.AttrImagesDir); found {
 		location.SetPathPrefix(imagesdir)
 	}
@@ -122,9 +121,15 @@ func (r *sgmlRenderer) getImageSrc(ctx *context, location *types.Location) strin
 	dir := filepath.Dir(ctx.config.Filename)
 	src = filepath.Join(dir, src)
 	result := "data:image/" + strings.TrimPrefix(filepath.Ext(src), ".") + ";base64,"
-	data, err := os.ReadFile(src)
+	if ctx.config.FS == nil {
+		if err := ctx.config.SafeMode.CheckPath(ctx.config.GetBaseDir(), src); err != nil {
+			ctx.config.Diagnostics.Warnf(types.DiagnosticImageNotFound, sourceRange, "image to embed not readable: %v", err)
+			return result
+		}
+	}
+	data, err := configuration.ReadFile(ctx.config.FS, src)
 	if err != nil {
-		log.Warnf("image to embed not found or not readable: %s", src)
+		ctx.config.Diagnostics.Warnf(types.DiagnosticImageNotFound, sourceRange, "image to embed not found or not readable: %s", src)
 		return result
 	}
 	result += base64.StdEncoding.EncodeToString(data)
@@ -1,13 +1,152 @@
 package sgml
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
 )
 
 func (r *sgmlRenderer) renderIndexTerm(ctx *context, t *types.IndexTerm) (string, error) {
-	return r.renderInlineElements(ctx, t.Term)
+	term, err := r.renderInlineElements(ctx, t.Term)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render index term")
+	}
+	return r.execute(r.indexTerm, struct {
+		Context   *context
+		ID        string
+		Anchor    bool
+		Term      string
+		Primary   string
+		Secondary string
+		Tertiary  string
+	}{
+		Context: ctx,
+		ID:      t.ID,
+		Anchor:  ctx.index != nil && t.ID != "",
+		Term:    term,
+		Primary: term,
+	})
+}
+
+func (r *sgmlRenderer) renderConcealedIndexTerm(ctx *context, t *types.ConcealedIndexTerm) (string, error) {
+	terms := make([]string, 3)
+	for i, term := range t.Terms() {
+		rendered, err := r.renderInlineElements(ctx, term)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render concealed index term")
+		}
+		terms[i] = rendered
+	}
+	return r.execute(r.indexTerm, struct {
+		Context   *context
+		ID        string
+		Anchor    bool
+		Term      string
+		Primary   string
+		Secondary string
+		Tertiary  string
+	}{
+		Context:   ctx,
+		ID:        t.ID,
+		Anchor:    ctx.index != nil && t.ID != "",
+		Primary:   terms[0],
+		Secondary: terms[1],
+		Tertiary:  terms[2],
+	})
+}
+
+// renderIndex renders the entries of the document index, grouped by letter
+func (r *sgmlRenderer) renderIndex(ctx *context, index *types.Index) (string, error) {
+	// no anchor on the index terms rendered in the entries or in the titles of their sections
+	previousIndex := ctx.index
+	defer func() {
+		ctx.index = previousIndex
+	}()
+	ctx.index = nil
+	content := &strings.Builder{}
+	for _, c := range index.Categories() {
+		entries, err := r.renderIndexEntries(ctx, c.Entries)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render index")
+		}
+		category, err := r.execute(r.indexCategory, struct {
+			Letter  string
+			Content string
+		}{
+			Letter:  c.Letter,
+			Content: entries,
+		})
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render index")
+		}
+		content.WriteString(category)
+	}
+	return r.execute(r.index, struct {
+		Content string
+	}{
+		Content: content.String(),
+	})
+}
+
+type indexOccurrence struct {
+	ID    string
+	Title string
+}
+
+func (r *sgmlRenderer) renderIndexEntries(ctx *context, entries []*types.IndexEntry) (string, error) {
+	result := &strings.Builder{}
+	for _, e := range entries {
+		term, err := r.renderInlineElements(ctx, e.Term)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render index entry")
+		}
+		content, err := r.renderIndexEntries(ctx, e.Entries)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render index entry")
+		}
+		occurrences := make([]indexOccurrence, len(e.Occurrences))
+		for i, o := range e.Occurrences {
+			title, err := r.renderInlineElements(ctx, o.Title)
+			if err != nil {
+				return "", errors.Wrap(err, "unable to render index entry")
+			}
+			if title = strings.TrimSpace(title); title == "" {
+				// occurrence before the first section of a document without title
+				title = strconv.Itoa(i + 1)
+			}
+			occurrences[i] = indexOccurrence{
+				ID:    o.ID,
+				Title: title,
+			}
+		}
+		entry, err := r.execute(r.indexEntry, struct {
+			Term        string
+			Occurrences []indexOccurrence
+			Content     string
+		}{
+			Term:        term,
+			Occurrences: occurrences,
+			Content:     content,
+		})
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render index entry")
+		}
+		result.WriteString(entry)
+	}
+	return result.String(), nil
 }
 
-func (r *sgmlRenderer) renderConcealedIndexTerm(_ *types.ConcealedIndexTerm) (string, error) {
-	return "", nil // do not render in SGML
+// hasIndexSection returns true if one of the given elements (or their sub-sections) is a section with the `index` style
+func hasIndexSection(elements []interface{}) bool {
+	for _, e := range elements {
+		if s, ok := e.(*types.Section); ok {
+			if s.Attributes.GetAsStringWithDefault(types.AttrStyle, "") == types.IndexKind || hasIndexSection(s.Elements) {
+				return true
+			}
+		}
+	}
+	return false
 }
@@ -47,7 +47,7 @@ func (r *sgmlRenderer) renderLink(ctx *context, l *types.InlineLink) (string, er
 		NoOpener bool
 	}{
 		ID:       id,
-		URL:      htmlEscaper.Replace(l.Location.ToString()),
+		URL:      htmlEscaper.Replace(ctx.chunkHref(l.Location.ToString())),
 		Text:     text,
 		Class:    class,
 		Target:   target,
@@ -255,6 +255,16 @@ func (r *sgmlRenderer) getLabeledListTmpl(l *types.List) (*texttemplate.Template
 				return nil, nil, errors.Wrap(err, "unable to load q&A list element template")
 			}
 			return listTmpl, listElementTmpl, nil
+		case "glossary":
+			listTmpl, err := r.labeledListGlossary()
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "unable to load glossary list template")
+			}
+			listElementTmpl, err := r.labeledListGlossaryElement()
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "unable to load glossary list element template")
+			}
+			return listTmpl, listElementTmpl, nil
 		case "horizontal":
 			listTmpl, err := r.labeledListHorizontal()
 			if err != nil {
@@ -26,6 +26,15 @@ func (r *sgmlRenderer) renderParagraph(ctx *context, p *types.Paragraph) (string
 		return r.renderQuoteParagraph(ctx, p)
 	case types.Passthrough:
 		return r.renderPassthroughParagraph(ctx, p)
+	case types.Stem, types.LatexMath, types.AsciiMath:
+		return r.renderStemBlock(ctx, p.Attributes.GetAsStringWithDefault(types.AttrStyle, ""), p.Attributes, p.Elements)
+	case types.PartIntro:
+		// the paragraph is rendered without its style, within the part intro
+		return r.renderPartIntro(ctx, p.Attributes, []interface{}{
+			&types.Paragraph{
+				Elements: p.Elements,
+			},
+		})
 	case "manpage":
 		return r.renderManpageNameParagraph(ctx, p)
 	case types.Tip, types.Note, types.Important, types.Warning, types.Caution:
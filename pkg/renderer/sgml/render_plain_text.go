@@ -13,7 +13,9 @@ import (
 
 func RenderPlainText(element interface{}, opts ...Option) (string, error) {
 	r := &plaintextRenderer{
-		escape: escapeString,
+		escape:               escapeString,
+		symbols:              symbols,
+		predefinedAttributes: predefinedAttributes,
 	}
 	for _, apply := range opts {
 		apply(r)
@@ -29,8 +31,26 @@ func WithoutEscape() Option {
 	}
 }
 
+// WithUnicode renders the symbols and predefined attributes with Unicode characters instead of HTML entities
+func WithUnicode() Option {
+	return func(r *plaintextRenderer) {
+		r.symbols = unicodeSymbols
+		r.predefinedAttributes = unicodePredefinedAttributes
+	}
+}
+
+// WithLinkURLs renders the URL of the links within brackets, after their text (eg: `the docs [https://example.com/docs]`)
+func WithLinkURLs() Option {
+	return func(r *plaintextRenderer) {
+		r.linkURLs = true
+	}
+}
+
 func RenderParagraphElements(p *types.Paragraph) (string, error) {
-	r := &plaintextRenderer{}
+	r := &plaintextRenderer{
+		symbols:              symbols,
+		predefinedAttributes: predefinedAttributes,
+	}
 	buf := &strings.Builder{}
 	for _, e := range p.Elements {
 		renderedElement, err := r.render(e)
@@ -46,7 +66,10 @@ func RenderParagraphElements(p *types.Paragraph) (string, error) {
 }
 
 type plaintextRenderer struct {
-	escape func(string) string
+	escape               func(string) string
+	symbols              map[string]string
+	predefinedAttributes map[string]string
+	linkURLs             bool
 }
 
 func (r *plaintextRenderer) render(element interface{}) (string, error) {
@@ -70,12 +93,16 @@ func (r *plaintextRenderer) render(element interface{}) (string, error) {
 		return r.escape(e.Name), nil
 	case *types.Symbol:
 		return r.renderSymbol(e)
+	case *types.PredefinedAttribute:
+		return r.predefinedAttributes[e.Name], nil
 	case *types.StringElement:
 		return e.Content, nil
 	case *types.FootnoteReference:
 		return r.renderFootnoteReference(e)
 	case *types.InlinePassthrough:
 		return r.renderInlinePassthrough(e)
+	case *types.InlineStem:
+		return r.escape(e.Content), nil
 	default:
 		return "", errors.Errorf("unable to render plain string for element of type '%T'", e)
 	}
@@ -111,21 +138,30 @@ func (r *plaintextRenderer) renderInlinePassthrough(p *types.InlinePassthrough)
 }
 
 func (r *plaintextRenderer) renderSymbol(s *types.Symbol) (string, error) {
-	if v, found := symbols[s.Name]; found {
+	if v, found := r.symbols[s.Name]; found {
 		return v, nil
 	}
 	return s.Name, nil
 }
 
 func (r *plaintextRenderer) renderInlineLink(l *types.InlineLink) (string, error) {
+	url := l.Location.ToDisplayString()
+	var text string
 	switch alt := l.Attributes[types.AttrInlineLinkText].(type) {
 	case []interface{}:
-		return r.render(alt)
+		var err error
+		if text, err = r.render(alt); err != nil {
+			return "", err
+		}
 	case string:
-		return alt, nil
+		text = alt
 	default:
-		return l.Location.ToDisplayString(), nil
+		return url, nil
+	}
+	if r.linkURLs && text != url {
+		return text + " [" + url + "]", nil
 	}
+	return text, nil
 }
 
 func (r *plaintextRenderer) renderFootnoteReference(note *types.FootnoteReference) (string, error) {
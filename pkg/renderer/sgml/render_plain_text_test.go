@@ -0,0 +1,41 @@
+package sgml_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("render plain text", func() {
+
+	elements := []interface{}{
+		&types.StringElement{
+			Content: "Copyright ",
+		},
+		&types.Symbol{
+			Name: "(C)",
+		},
+		&types.PredefinedAttribute{
+			Name: "nbsp",
+		},
+		&types.InlineLink{
+			Location: &types.Location{
+				Scheme: "https://",
+				Path:   "example.com",
+			},
+			Attributes: types.Attributes{
+				types.AttrInlineLinkText: "Example",
+			},
+		},
+	}
+
+	It("with HTML entities", func() {
+		Expect(sgml.RenderPlainText(elements)).To(Equal("Copyright &#169;&#160;Example"))
+	})
+
+	It("with Unicode characters and link URLs", func() {
+		Expect(sgml.RenderPlainText(elements, sgml.WithUnicode(), sgml.WithLinkURLs())).To(Equal("Copyright © Example [https://example.com]"))
+	})
+})
@@ -15,11 +15,22 @@ func (r *sgmlRenderer) renderSection(ctx *context, s *types.Section) (string, er
 	if err != nil {
 		return "", errors.Wrap(err, "error while rendering section title")
 	}
-
-	content, err := r.renderElements(ctx, s.Elements)
+	var content string
+	if s.Level == 0 && ctx.doctype() == types.Book {
+		content, err = r.renderPartContent(ctx, s.Elements)
+	} else {
+		content, err = r.renderElements(ctx, s.Elements)
+	}
 	if err != nil {
 		return "", errors.Wrap(err, "error while rendering section content")
 	}
+	if s.Attributes.GetAsStringWithDefault(types.AttrStyle, "") == types.IndexKind && ctx.index != nil {
+		index, err := r.renderIndex(ctx, ctx.index)
+		if err != nil {
+			return "", errors.Wrap(err, "error while rendering section content")
+		}
+		content += index
+	}
 	roles, err := r.renderElementRoles(ctx, s.Attributes)
 	if err != nil {
 		return "", errors.Wrap(err, "unable to render section roles")
@@ -32,10 +43,12 @@ func (r *sgmlRenderer) renderSection(ctx *context, s *types.Section) (string, er
 		ID       string
 		Roles    string
 		Level    int
+		Kind     string
 	}{
 		Context:  ctx,
 		Header:   title,
 		Level:    s.Level,
+		Kind:     s.Kind(ctx.doctype()),
 		Elements: s.Elements,
 		ID:       r.renderElementID(s.Attributes),
 		Roles:    roles,
@@ -55,17 +68,125 @@ func (r *sgmlRenderer) renderSectionTitle(ctx *context, s *types.Section) (strin
 		log.Debugf("number for section '%s': '%s'", id, number)
 		number = ctx.sectionNumbering[id]
 	}
+	roles, err := r.renderElementRoles(ctx, s.Attributes)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render section roles")
+	}
 	return r.execute(r.sectionTitle, struct {
 		Level        int
 		LevelPlusOne int
 		ID           string
+		Roles        string
 		Number       string
+		Caption      string
 		Content      string
 	}{
 		Level:        s.Level,
 		LevelPlusOne: s.Level + 1, // Level 1 is <h2>.
 		ID:           r.renderElementID(s.Attributes),
+		Roles:        roles,
 		Number:       number,
+		Caption:      ctx.sectionCaptions[s.GetID()],
 		Content:      renderedContentStr,
 	})
 }
+
+// renderPartContent renders the content of a part in a book.
+// The blocks before the first chapter are wrapped in a `partintro` block,
+// unless they consist of a single block with the `partintro` style.
+func (r *sgmlRenderer) renderPartContent(ctx *context, elements []interface{}) (string, error) {
+	i := 0
+	for ; i < len(elements); i++ {
+		if _, ok := elements[i].(*types.Section); ok {
+			break
+		}
+	}
+	intro := discardBlankLines(elements[:i])
+	if len(intro) == 0 || (len(intro) == 1 && isPartIntro(intro[0])) {
+		return r.renderElements(ctx, elements)
+	}
+	result := &strings.Builder{}
+	renderedIntro, err := r.renderPartIntro(ctx, nil, intro)
+	if err != nil {
+		return "", err
+	}
+	result.WriteString(renderedIntro)
+	renderedChapters, err := r.renderElements(ctx, elements[i:])
+	if err != nil {
+		return "", err
+	}
+	result.WriteString(renderedChapters)
+	return result.String(), nil
+}
+
+func isPartIntro(element interface{}) bool {
+	if e, ok := element.(types.WithAttributes); ok {
+		return e.GetAttributes().GetAsStringWithDefault(types.AttrStyle, "") == types.PartIntro
+	}
+	return false
+}
+
+// renderPartIntro renders the given elements as the introduction of a part in a book
+func (r *sgmlRenderer) renderPartIntro(ctx *context, attrs types.Attributes, elements []interface{}) (string, error) {
+	content, err := r.renderElements(ctx, elements)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render part intro content")
+	}
+	roles, err := r.renderElementRoles(ctx, attrs)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render part intro roles")
+	}
+	title, err := r.renderElementTitle(ctx, attrs)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render part intro title")
+	}
+	return r.execute(r.partIntro, struct {
+		Context *context
+		ID      string
+		Title   string
+		Roles   string
+		Content string
+	}{
+		Context: ctx,
+		ID:      r.renderElementID(attrs),
+		Title:   title,
+		Roles:   roles,
+		Content: content,
+	})
+}
+
+// collectSectionCaptions computes the caption of each section (e.g., `1.2. `, `Appendix A: ` or `Chapter 3. `),
+// given its kind, its number and the `appendix-caption`, `chapter-signifier` and `part-signifier` attributes.
+func (r *sgmlRenderer) collectSectionCaptions(ctx *context, elements []interface{}) {
+	for _, e := range elements {
+		if s, ok := e.(*types.Section); ok {
+			if caption := sectionCaption(ctx, s); caption != "" {
+				ctx.sectionCaptions[s.GetID()] = caption
+			}
+			r.collectSectionCaptions(ctx, s.Elements)
+		}
+	}
+}
+
+func sectionCaption(ctx *context, s *types.Section) string {
+	number := ctx.sectionNumbering[s.GetID()]
+	if number == "" {
+		return ""
+	}
+	switch s.Kind(ctx.doctype()) {
+	case types.AppendixKind:
+		if caption := ctx.attributes.GetAsStringWithDefault(types.AttrAppendixCaption, ""); caption != "" {
+			return caption + " " + number + ": "
+		}
+	case types.ChapterKind:
+		if signifier := ctx.attributes.GetAsStringWithDefault(types.AttrChapterSignifier, ""); signifier != "" {
+			return signifier + " " + number + ". "
+		}
+	case types.PartKind:
+		if signifier := ctx.attributes.GetAsStringWithDefault(types.AttrPartSignifier, ""); signifier != "" {
+			return signifier + " " + number + ": "
+		}
+		return number + ": "
+	}
+	return number + ". "
+}
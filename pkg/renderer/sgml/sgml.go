@@ -16,21 +16,55 @@ import (
 )
 
 func Render(doc *types.Document, config *configuration.Configuration, output io.Writer, tmpls Templates) (types.Metadata, error) {
-	r := &sgmlRenderer{
+	r := newSGMLRenderer(tmpls)
+	ctx := newContext(doc, config)
+	metadata, renderedTitle, err := r.prepare(ctx, doc)
+	if err != nil {
+		return metadata, errors.Wrapf(err, "unable to render full document")
+	}
+	renderedHeader, renderedContent, err := r.splitAndRender(ctx, doc)
+	if err != nil {
+		return metadata, errors.Wrapf(err, "unable to render full document")
+	}
+	if ctx.config.WrapInHTMLBodyElement {
+		log.Debugf("Rendering full document...")
+		if err := r.renderArticle(ctx, doc, output, renderedTitle, renderedHeader, renderedContent); err != nil {
+			return metadata, err
+		}
+	} else {
+		log.Debugf("Rendering document body...")
+		_, err = output.Write([]byte(renderedContent))
+		if err != nil {
+			return metadata, errors.Wrapf(err, "unable to render full document")
+		}
+	}
+	return metadata, err
+
+}
+
+func newSGMLRenderer(tmpls Templates) *sgmlRenderer {
+	return &sgmlRenderer{
 		templates: tmpls,
 		// Establish some default function handlers.
 		functions: texttemplate.FuncMap{
 			"basename":           filepath.Base,
 			"escape":             escapeString,
 			"halign":             halign,
+			"halignValue":        halignValue,
+			"inc":                inc,
 			"lastInStrings":      lastInStrings,
 			"toLower":            strings.ToLower,
 			"trimLineFeedSuffix": trimLineFeedSuffix,
 			"unescape":           unescapeString,
 			"valign":             valign,
+			"valignValue":        valignValue,
 		},
 	}
-	ctx := newContext(doc, config)
+}
+
+// prepare processes the attributes, the section numbers and captions and the table of contents of the document before its elements are rendered,
+// and returns the metadata and the rendered title of the document (or the default title if the document has none)
+func (r *sgmlRenderer) prepare(ctx *context, doc *types.Document) (types.Metadata, string, error) {
 
 	// if log.IsLevelEnabled(log.DebugLevel) {
 	// 	log.Debugf("rendering document of type '%s'\n%s", ctx.Attributes.GetAsStringWithDefault(types.AttrDocType, "article"), spew.Sdump(ctx.Attributes))
@@ -42,7 +76,7 @@ func Render(doc *types.Document, config *configuration.Configuration, output io.
 	metadata.LastUpdated = ctx.config.LastUpdated.Format(configuration.LastUpdatedFormat)
 	renderedTitle, exists, err := r.renderDocumentTitle(ctx, doc)
 	if err != nil {
-		return metadata, errors.Wrapf(err, "unable to render full document")
+		return metadata, "", err
 	}
 	metadata.Title = string(renderedTitle) // retain an empty value if no title was defined in the document
 	if !exists {
@@ -72,71 +106,71 @@ elements:
 		}
 	}
 	if ctx.sectionNumbering, err = doc.SectionNumbers(); err != nil {
-		return metadata, errors.Wrapf(err, "unable to render full document")
+		return metadata, "", err
+	}
+	ctx.sectionCaptions = map[string]string{}
+	r.collectSectionCaptions(ctx, doc.Elements)
+	if doc.Index != nil && hasIndexSection(doc.Elements) {
+		ctx.index = doc.Index
 	}
 
 	// needs to be set before rendering the content elements
 	if err := r.prerenderTableOfContents(ctx, doc.TableOfContents); err != nil {
-		return metadata, errors.Wrapf(err, "unable to render full document")
+		return metadata, "", err
 	}
 	metadata.TableOfContents = doc.TableOfContents
-	renderedHeader, renderedContent, err := r.splitAndRender(ctx, doc)
-	if err != nil {
-		return metadata, errors.Wrapf(err, "unable to render full document")
-	}
+	return metadata, renderedTitle, nil
+}
+
+// renderArticle renders the given header and content of the document within the HEAD and BODY containers
+func (r *sgmlRenderer) renderArticle(ctx *context, doc *types.Document, output io.Writer, renderedTitle, renderedHeader, renderedContent string) error {
 	roles, err := r.renderDocumentRoles(ctx, doc)
 	if err != nil {
-		return metadata, errors.Wrap(err, "unable to render fenced block content")
+		return errors.Wrap(err, "unable to render fenced block content")
 	}
-	if ctx.config.WrapInHTMLBodyElement {
-		log.Debugf("Rendering full document...")
-		tmpl, err := r.article()
-		if err != nil {
-			return metadata, errors.Wrapf(err, "unable to render full document")
-		}
-		err = tmpl.Execute(output, struct {
-			Doctype               string
-			Generator             string
-			Description           string
-			Title                 string
-			Authors               string
-			Header                string
-			ID                    string
-			Roles                 string
-			Content               string
-			RevNumber             string
-			LastUpdated           string
-			CSS                   []string
-			IncludeHTMLBodyHeader bool
-			IncludeHTMLBodyFooter bool
-		}{
-			Doctype:               ctx.attributes.GetAsStringWithDefault(types.AttrDocType, "article"),
-			Generator:             "libasciidoc", // TODO: externalize this value and include the lib version ?
-			Description:           ctx.attributes.GetAsStringWithDefault(types.AttrDescription, ""),
-			Title:                 renderedTitle,
-			Authors:               r.renderAuthors(ctx, doc),
-			Header:                renderedHeader,
-			Roles:                 roles,
-			ID:                    r.renderDocumentID(doc),
-			Content:               string(renderedContent),
-			RevNumber:             ctx.attributes.GetAsStringWithDefault("revnumber", ""),
-			LastUpdated:           ctx.config.LastUpdated.Format(configuration.LastUpdatedFormat),
-			CSS:                   ctx.config.CSS,
-			IncludeHTMLBodyHeader: !ctx.attributes.Has(types.AttrNoHeader),
-			IncludeHTMLBodyFooter: !ctx.attributes.Has(types.AttrNoFooter),
-		})
-		if err != nil {
-			return metadata, errors.Wrapf(err, "unable to render full document")
-		}
-	} else {
-		log.Debugf("Rendering document body...")
-		_, err = output.Write([]byte(renderedContent))
-		if err != nil {
-			return metadata, errors.Wrapf(err, "unable to render full document")
-		}
+	tmpl, err := r.article()
+	if err != nil {
+		return errors.Wrapf(err, "unable to render full document")
+	}
+	err = tmpl.Execute(output, struct {
+		Doctype               string
+		Generator             string
+		Description           string
+		Title                 string
+		Authors               string
+		Header                string
+		ID                    string
+		Roles                 string
+		Content               string
+		RevNumber             string
+		LastUpdated           string
+		CSS                   []string
+		IncludeHTMLBodyHeader bool
+		IncludeHTMLBodyFooter bool
+		IncludeMathJax        bool
+		EquationNumbers       string
+	}{
+		Doctype:               ctx.doctype(),
+		Generator:             "libasciidoc", // TODO: externalize this value and include the lib version ?
+		Description:           ctx.attributes.GetAsStringWithDefault(types.AttrDescription, ""),
+		Title:                 renderedTitle,
+		Authors:               r.renderAuthors(ctx, doc),
+		Header:                renderedHeader,
+		Roles:                 roles,
+		ID:                    r.renderDocumentID(doc),
+		Content:               string(renderedContent),
+		RevNumber:             ctx.attributes.GetAsStringWithDefault("revnumber", ""),
+		LastUpdated:           ctx.config.LastUpdated.Format(configuration.LastUpdatedFormat),
+		CSS:                   ctx.config.CSS,
+		IncludeHTMLBodyHeader: !ctx.attributes.Has(types.AttrNoHeader),
+		IncludeHTMLBodyFooter: !ctx.attributes.Has(types.AttrNoFooter),
+		IncludeMathJax:        ctx.attributes.Has(types.AttrStem),
+		EquationNumbers:       r.renderEquationNumbers(ctx),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to render full document")
 	}
-	return metadata, err
-
+	return nil
 }
 
 var predefinedAttributes = map[string]string{
@@ -171,34 +205,88 @@ var predefinedAttributes = map[string]string{
 	"cpp":            "C++",
 }
 
+// unicodePredefinedAttributes the predefined attributes rendered in plain text (see `WithUnicode`)
+var unicodePredefinedAttributes = map[string]string{
+	"sp":             " ",
+	"blank":          "",
+	"empty":          "",
+	"nbsp":           "\u00a0",
+	"zwsp":           "\u200b",
+	"wj":             "\u2060",
+	"apos":           "'",
+	"quot":           "\"",
+	"lsquo":          "‘",
+	"rsquo":          "’",
+	"ldquo":          "“",
+	"rdquo":          "”",
+	"deg":            "°",
+	"plus":           "+",
+	"brvbar":         "¦",
+	"vbar":           "|",
+	"amp":            "&",
+	"lt":             "<",
+	"gt":             ">",
+	"startsb":        "[",
+	"endsb":          "]",
+	"caret":          "^",
+	"asterisk":       "*",
+	"tilde":          "~",
+	"backslash":      `\`,
+	"backtick":       "`",
+	"two-colons":     "::",
+	"two-semicolons": ";;",
+	"cpp":            "C++",
+}
+
 func predefinedAttribute(a string) string {
 	// log.Debugf("predefined attribute '%s': '%s", a, predefinedAttributes[a])
 	return predefinedAttributes[a]
 }
 
+// inc returns the given value incremented by 1 (eg: to convert a 0-based index into a 1-based one)
+func inc(i int) int {
+	return i + 1
+}
+
 func halign(v types.HAlign) string {
+	if value := halignValue(v); value != "" {
+		return "halign-" + value
+	}
+	return string(v)
+}
+
+// halignValue returns the name of the horizontal alignment (`left`, `center` or `right`)
+func halignValue(v types.HAlign) string {
 	switch v {
 	case types.HAlignLeft:
-		return "halign-left"
+		return "left"
 	case types.HAlignCenter:
-		return "halign-center"
+		return "center"
 	case types.HAlignRight:
-		return "halign-right"
+		return "right"
 	default:
-		return string(v)
+		return ""
 	}
 }
 
 func valign(v types.VAlign) string {
+	if value := valignValue(v); value != "" {
+		return "valign-" + value
+	}
+	return string(v)
+}
+
+// valignValue returns the name of the vertical alignment (`top`, `middle` or `bottom`)
+func valignValue(v types.VAlign) string {
 	switch v {
 	case types.VAlignTop:
-		return "valign-top"
+		return "top"
 	case types.VAlignMiddle:
-		return "valign-middle"
+		return "middle"
 	case types.VAlignBottom:
-		return "valign-bottom"
+		return "bottom"
 	default:
-		return string(v)
+		return ""
 	}
 }
 
@@ -214,7 +302,7 @@ func trimLineFeedSuffix(content string) string {
 // and all other elements (table of contents, with preamble, content) on the other side,
 // then renders the header and other elements
 func (r *sgmlRenderer) splitAndRender(ctx *context, doc *types.Document) (string, string, error) {
-	switch ctx.attributes.GetAsStringWithDefault(types.AttrDocType, "article") {
+	switch ctx.doctype() {
 	case "manpage":
 		return r.splitAndRenderForManpage(ctx, doc)
 	default:
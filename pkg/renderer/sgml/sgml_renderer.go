@@ -25,6 +25,9 @@ type sgmlRenderer struct {
 	articleHeaderOnce sync.Once
 	articleHeaderTmpl *texttemplate.Template
 
+	audioBlockOnce sync.Once
+	audioBlockTmpl *texttemplate.Template
+
 	blockImageOnce sync.Once
 	blockImageTmpl *texttemplate.Template
 
@@ -40,6 +43,9 @@ type sgmlRenderer struct {
 	calloutRefOnce sync.Once
 	calloutRefTmpl *texttemplate.Template
 
+	chunkNavigationOnce sync.Once
+	chunkNavigationTmpl *texttemplate.Template
+
 	embeddedParagraphOnce sync.Once
 	embeddedParagraphTmpl *texttemplate.Template
 
@@ -79,6 +85,18 @@ type sgmlRenderer struct {
 	iconTextOnce sync.Once
 	iconTextTmpl *texttemplate.Template
 
+	indexOnce sync.Once
+	indexTmpl *texttemplate.Template
+
+	indexCategoryOnce sync.Once
+	indexCategoryTmpl *texttemplate.Template
+
+	indexEntryOnce sync.Once
+	indexEntryTmpl *texttemplate.Template
+
+	indexTermOnce sync.Once
+	indexTermTmpl *texttemplate.Template
+
 	inlineButtonOnce sync.Once
 	inlineButtonTmpl *texttemplate.Template
 
@@ -91,6 +109,9 @@ type sgmlRenderer struct {
 	inlineMenuOnce sync.Once
 	inlineMenuTmpl *texttemplate.Template
 
+	inlineStemOnce sync.Once
+	inlineStemTmpl *texttemplate.Template
+
 	internalCrossReferenceOnce sync.Once
 	internalCrossReferenceTmpl *texttemplate.Template
 
@@ -106,6 +127,12 @@ type sgmlRenderer struct {
 	labeledListElementOnce sync.Once
 	labeledListElementTmpl *texttemplate.Template
 
+	labeledListGlossaryOnce sync.Once
+	labeledListGlossaryTmpl *texttemplate.Template
+
+	labeledListGlossaryElementOnce sync.Once
+	labeledListGlossaryElementTmpl *texttemplate.Template
+
 	labeledListHorizontalOnce sync.Once
 	labeledListHorizontalTmpl *texttemplate.Template
 
@@ -151,6 +178,9 @@ type sgmlRenderer struct {
 	paragraphOnce sync.Once
 	paragraphTmpl *texttemplate.Template
 
+	partIntroOnce sync.Once
+	partIntroTmpl *texttemplate.Template
+
 	passthroughBlockOnce sync.Once
 	passthroughBlockTmpl *texttemplate.Template
 
@@ -181,6 +211,9 @@ type sgmlRenderer struct {
 	sourceBlockOnce sync.Once
 	sourceBlockTmpl *texttemplate.Template
 
+	stemBlockOnce sync.Once
+	stemBlockTmpl *texttemplate.Template
+
 	subscriptTextOnce sync.Once
 	subscriptTextTmpl *texttemplate.Template
 
@@ -199,6 +232,12 @@ type sgmlRenderer struct {
 	tableCellBlockOnce sync.Once
 	tableCellBlockTmpl *texttemplate.Template
 
+	tableCellLiteralOnce sync.Once
+	tableCellLiteralTmpl *texttemplate.Template
+
+	tableCellVerseOnce sync.Once
+	tableCellVerseTmpl *texttemplate.Template
+
 	tableHeaderOnce sync.Once
 	tableHeaderTmpl *texttemplate.Template
 
@@ -237,6 +276,9 @@ type sgmlRenderer struct {
 
 	verseParagraphOnce sync.Once
 	verseParagraphTmpl *texttemplate.Template
+
+	videoBlockOnce sync.Once
+	videoBlockTmpl *texttemplate.Template
 }
 
 type template func() (*texttemplate.Template, error)
@@ -302,6 +344,14 @@ func (r *sgmlRenderer) articleHeader() (*texttemplate.Template, error) {
 	return r.articleHeaderTmpl, err
 }
 
+func (r *sgmlRenderer) audioBlock() (*texttemplate.Template, error) {
+	var err error
+	r.audioBlockOnce.Do(func() {
+		r.audioBlockTmpl, err = r.newTemplate("AudioBlock", r.templates.AudioBlock, err)
+	})
+	return r.audioBlockTmpl, err
+}
+
 func (r *sgmlRenderer) blockImage() (*texttemplate.Template, error) {
 	var err error
 	r.blockImageOnce.Do(func() {
@@ -342,6 +392,14 @@ func (r *sgmlRenderer) calloutRef() (*texttemplate.Template, error) {
 	return r.calloutRefTmpl, err
 }
 
+func (r *sgmlRenderer) chunkNavigation() (*texttemplate.Template, error) {
+	var err error
+	r.chunkNavigationOnce.Do(func() {
+		r.chunkNavigationTmpl, err = r.newTemplate("ChunkNavigation", r.templates.ChunkNavigation, err)
+	})
+	return r.chunkNavigationTmpl, err
+}
+
 func (r *sgmlRenderer) embeddedParagraph() (*texttemplate.Template, error) {
 	var err error
 	r.embeddedParagraphOnce.Do(func() {
@@ -446,6 +504,38 @@ func (r *sgmlRenderer) iconText() (*texttemplate.Template, error) {
 	return r.iconTextTmpl, err
 }
 
+func (r *sgmlRenderer) index() (*texttemplate.Template, error) {
+	var err error
+	r.indexOnce.Do(func() {
+		r.indexTmpl, err = r.newTemplate("Index", r.templates.Index, err)
+	})
+	return r.indexTmpl, err
+}
+
+func (r *sgmlRenderer) indexCategory() (*texttemplate.Template, error) {
+	var err error
+	r.indexCategoryOnce.Do(func() {
+		r.indexCategoryTmpl, err = r.newTemplate("IndexCategory", r.templates.IndexCategory, err)
+	})
+	return r.indexCategoryTmpl, err
+}
+
+func (r *sgmlRenderer) indexEntry() (*texttemplate.Template, error) {
+	var err error
+	r.indexEntryOnce.Do(func() {
+		r.indexEntryTmpl, err = r.newTemplate("IndexEntry", r.templates.IndexEntry, err)
+	})
+	return r.indexEntryTmpl, err
+}
+
+func (r *sgmlRenderer) indexTerm() (*texttemplate.Template, error) {
+	var err error
+	r.indexTermOnce.Do(func() {
+		r.indexTermTmpl, err = r.newTemplate("IndexTerm", r.templates.IndexTerm, err)
+	})
+	return r.indexTermTmpl, err
+}
+
 func (r *sgmlRenderer) inlineButton() (*texttemplate.Template, error) {
 	var err error
 	r.inlineButtonOnce.Do(func() {
@@ -478,6 +568,14 @@ func (r *sgmlRenderer) inlineMenu() (*texttemplate.Template, error) {
 	return r.inlineMenuTmpl, err
 }
 
+func (r *sgmlRenderer) inlineStem() (*texttemplate.Template, error) {
+	var err error
+	r.inlineStemOnce.Do(func() {
+		r.inlineStemTmpl, err = r.newTemplate("InlineStem", r.templates.InlineStem, err)
+	})
+	return r.inlineStemTmpl, err
+}
+
 func (r *sgmlRenderer) internalCrossReference() (*texttemplate.Template, error) {
 	var err error
 	r.internalCrossReferenceOnce.Do(func() {
@@ -518,6 +616,22 @@ func (r *sgmlRenderer) labeledListElement() (*texttemplate.Template, error) {
 	return r.labeledListElementTmpl, err
 }
 
+func (r *sgmlRenderer) labeledListGlossary() (*texttemplate.Template, error) {
+	var err error
+	r.labeledListGlossaryOnce.Do(func() {
+		r.labeledListGlossaryTmpl, err = r.newTemplate("LabeledListGlossary", r.templates.LabeledListGlossary, err)
+	})
+	return r.labeledListGlossaryTmpl, err
+}
+
+func (r *sgmlRenderer) labeledListGlossaryElement() (*texttemplate.Template, error) {
+	var err error
+	r.labeledListGlossaryElementOnce.Do(func() {
+		r.labeledListGlossaryElementTmpl, err = r.newTemplate("LabeledListGlossaryElement", r.templates.LabeledListGlossaryElement, err)
+	})
+	return r.labeledListGlossaryElementTmpl, err
+}
+
 func (r *sgmlRenderer) labeledListHorizontal() (*texttemplate.Template, error) {
 	var err error
 	r.labeledListHorizontalOnce.Do(func() {
@@ -638,6 +752,14 @@ func (r *sgmlRenderer) paragraph() (*texttemplate.Template, error) {
 	return r.paragraphTmpl, err
 }
 
+func (r *sgmlRenderer) partIntro() (*texttemplate.Template, error) {
+	var err error
+	r.partIntroOnce.Do(func() {
+		r.partIntroTmpl, err = r.newTemplate("PartIntro", r.templates.PartIntro, err)
+	})
+	return r.partIntroTmpl, err
+}
+
 func (r *sgmlRenderer) passthroughBlock() (*texttemplate.Template, error) {
 	var err error
 	r.passthroughBlockOnce.Do(func() {
@@ -718,6 +840,14 @@ func (r *sgmlRenderer) sourceBlock() (*texttemplate.Template, error) {
 	return r.sourceBlockTmpl, err
 }
 
+func (r *sgmlRenderer) stemBlock() (*texttemplate.Template, error) {
+	var err error
+	r.stemBlockOnce.Do(func() {
+		r.stemBlockTmpl, err = r.newTemplate("StemBlock", r.templates.StemBlock, err)
+	})
+	return r.stemBlockTmpl, err
+}
+
 func (r *sgmlRenderer) subscriptText() (*texttemplate.Template, error) {
 	var err error
 	r.subscriptTextOnce.Do(func() {
@@ -766,6 +896,22 @@ func (r *sgmlRenderer) tableCellBlock() (*texttemplate.Template, error) {
 	return r.tableCellBlockTmpl, err
 }
 
+func (r *sgmlRenderer) tableCellLiteral() (*texttemplate.Template, error) {
+	var err error
+	r.tableCellLiteralOnce.Do(func() {
+		r.tableCellLiteralTmpl, err = r.newTemplate("TableCellLiteral", r.templates.TableCellLiteral, err)
+	})
+	return r.tableCellLiteralTmpl, err
+}
+
+func (r *sgmlRenderer) tableCellVerse() (*texttemplate.Template, error) {
+	var err error
+	r.tableCellVerseOnce.Do(func() {
+		r.tableCellVerseTmpl, err = r.newTemplate("TableCellVerse", r.templates.TableCellVerse, err)
+	})
+	return r.tableCellVerseTmpl, err
+}
+
 func (r *sgmlRenderer) tableHeader() (*texttemplate.Template, error) {
 	var err error
 	r.tableHeaderOnce.Do(func() {
@@ -869,3 +1015,11 @@ func (r *sgmlRenderer) verseParagraph() (*texttemplate.Template, error) {
 	})
 	return r.verseParagraphTmpl, err
 }
+
+func (r *sgmlRenderer) videoBlock() (*texttemplate.Template, error) {
+	var err error
+	r.videoBlockOnce.Do(func() {
+		r.videoBlockTmpl, err = r.newTemplate("VideoBlock", r.templates.VideoBlock, err)
+	})
+	return r.videoBlockTmpl, err
+}
@@ -0,0 +1,87 @@
+package sgml
+
+import (
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// the delimiters of the equations in the stem blocks, which are expected by MathJax
+var stemBlockDelimiters = map[string][2]string{
+	types.AsciiMath: {`\$`, `\$`},
+	types.LatexMath: {`\[`, `\]`},
+}
+
+func (r *sgmlRenderer) renderStemBlock(ctx *context, kind string, attrs types.Attributes, elements []interface{}) (string, error) {
+	notation := types.StemNotation(kind, ctx.attributes)
+	content, err := r.renderElements(ctx, elements)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render stem block content")
+	}
+	source, err := RenderPlainText(elements, WithoutEscape())
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render stem block content")
+	}
+	roles, err := r.renderElementRoles(ctx, attrs)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render stem block roles")
+	}
+	title, err := r.renderElementTitle(ctx, attrs)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render stem block title")
+	}
+	return r.execute(r.stemBlock, struct {
+		Context  *context
+		ID       string
+		Title    string
+		Roles    string
+		Notation string
+		Content  string
+		Source   string
+	}{
+		Context:  ctx,
+		ID:       r.renderElementID(attrs),
+		Title:    title,
+		Roles:    roles,
+		Notation: notation,
+		Content:  trimStemDelimiters(notation, strings.Trim(content, "\n")),
+		Source:   trimStemDelimiters(notation, strings.Trim(source, "\n")),
+	})
+}
+
+// trimStemDelimiters removes the delimiters of the equation if they were already set in the source document,
+// since the templates take care of adding them
+func trimStemDelimiters(notation, content string) string {
+	delimiters := stemBlockDelimiters[notation]
+	if strings.HasPrefix(content, delimiters[0]) && strings.HasSuffix(content, delimiters[1]) && len(content) >= len(delimiters[0])+len(delimiters[1]) {
+		return content[len(delimiters[0]) : len(content)-len(delimiters[1])]
+	}
+	return content
+}
+
+func (r *sgmlRenderer) renderInlineStem(ctx *context, s *types.InlineStem) (string, error) {
+	return r.execute(r.inlineStem, struct {
+		Context  *context
+		Notation string
+		Content  string
+		Source   string
+	}{
+		Context:  ctx,
+		Notation: types.StemNotation(s.Kind, ctx.attributes),
+		Content:  escapeString(s.Content),
+		Source:   s.Content,
+	})
+}
+
+// renderEquationNumbers returns the equation numbering scheme set in the `eqnums` attribute
+// (`none` by default, `AMS` if the attribute is set without a value)
+func (r *sgmlRenderer) renderEquationNumbers(ctx *context) string {
+	if !ctx.attributes.Has(types.AttrEquationNumbers) {
+		return "none"
+	}
+	if eqnums := ctx.attributes.GetAsStringWithDefault(types.AttrEquationNumbers, ""); eqnums != "" {
+		return eqnums
+	}
+	return "AMS"
+}
@@ -24,6 +24,25 @@ var symbols = map[string]string{
 	" -- ": "&#8201;&#8212;&#8201;", // surrounded by thin spaces
 }
 
+// unicodeSymbols the symbols rendered in plain text (see `WithUnicode`)
+var unicodeSymbols = map[string]string{
+	"(C)":  "©",
+	"(R)":  "®",
+	"(TM)": "™",
+	"...":  "…",
+	"'":    "’",
+	"'`":   "‘",
+	"`'":   "’",
+	"\"`":  "“",
+	"`\"":  "”",
+	"->":   "→",
+	"<-":   "←",
+	"=>":   "⇒",
+	"<=":   "⇐",
+	"--":   "—",
+	" -- ": " — ",
+}
+
 func (r *sgmlRenderer) renderSymbol(s *types.Symbol) (string, error) {
 	if str, found := symbols[s.Name]; found {
 		// if s.Prefix != "" {
@@ -125,10 +125,9 @@ func (r *sgmlRenderer) renderTableHeader(ctx *context, h *types.TableRow, cols [
 		return "", nil
 	}
 	content := &strings.Builder{}
-	col := 0
-	for _, cell := range h.Cells {
-		c, err := r.renderTableHeaderCell(ctx, cell, cols[col%len(cols)])
-		col++
+	columns := types.NewTableCellColumns().Next(h)
+	for i, cell := range h.Cells {
+		c, err := r.renderTableHeaderCell(ctx, cell, tableColumn(cols, columns[i]), columns[i])
 		if err != nil {
 			return "", errors.Wrap(err, "unable to render header")
 		}
@@ -141,7 +140,7 @@ func (r *sgmlRenderer) renderTableHeader(ctx *context, h *types.TableRow, cols [
 	})
 }
 
-func (r *sgmlRenderer) renderTableHeaderCell(ctx *context, c *types.TableCell, col *types.TableColumn) (string, error) {
+func (r *sgmlRenderer) renderTableHeaderCell(ctx *context, c *types.TableCell, col *types.TableColumn, index int) (string, error) {
 	// assume that elements to render are within the first element of the cell, which should be a paragraph
 	if len(c.Elements) == 1 {
 		if p, ok := c.Elements[0].(*types.Paragraph); ok {
@@ -152,10 +151,12 @@ func (r *sgmlRenderer) renderTableHeaderCell(ctx *context, c *types.TableCell, c
 			return r.execute(r.tableHeaderCell, struct {
 				HAlign  types.HAlign
 				VAlign  types.VAlign
+				Span    tableCellSpan
 				Content string
 			}{
-				HAlign:  col.HAlign,
-				VAlign:  col.VAlign,
+				HAlign:  cellHAlign(c, col),
+				VAlign:  cellVAlign(c, col),
+				Span:    newTableCellSpan(c, index),
 				Content: content,
 			})
 		}
@@ -168,10 +169,9 @@ func (r *sgmlRenderer) renderTableFooter(ctx *context, f *types.TableRow, cols [
 		return "", nil
 	}
 	content := &strings.Builder{}
-	col := 0
-	for _, cell := range f.Cells {
-		c, err := r.renderTableFooterCell(ctx, cell, cols[col%len(cols)])
-		col++
+	columns := types.NewTableCellColumns().Next(f)
+	for i, cell := range f.Cells {
+		c, err := r.renderTableFooterCell(ctx, cell, tableColumn(cols, columns[i]), columns[i])
 		if err != nil {
 			return "", errors.Wrap(err, "unable to render footer")
 		}
@@ -188,7 +188,7 @@ func (r *sgmlRenderer) renderTableFooter(ctx *context, f *types.TableRow, cols [
 	})
 }
 
-func (r *sgmlRenderer) renderTableFooterCell(ctx *context, c *types.TableCell, col *types.TableColumn) (string, error) {
+func (r *sgmlRenderer) renderTableFooterCell(ctx *context, c *types.TableCell, col *types.TableColumn, index int) (string, error) {
 	// assume that elements to render are within the first element of the cell, which should be a paragraph
 	if len(c.Elements) == 1 {
 		if p, ok := c.Elements[0].(*types.Paragraph); ok {
@@ -199,10 +199,12 @@ func (r *sgmlRenderer) renderTableFooterCell(ctx *context, c *types.TableCell, c
 			return r.execute(r.tableFooterCell, struct {
 				HAlign  types.HAlign
 				VAlign  types.VAlign
+				Span    tableCellSpan
 				Content string
 			}{
-				HAlign:  col.HAlign,
-				VAlign:  col.VAlign,
+				HAlign:  cellHAlign(c, col),
+				VAlign:  cellVAlign(c, col),
+				Span:    newTableCellSpan(c, index),
 				Content: content,
 			})
 		}
@@ -212,8 +214,9 @@ func (r *sgmlRenderer) renderTableFooterCell(ctx *context, c *types.TableCell, c
 
 func (r *sgmlRenderer) renderTableBody(ctx *context, rows []*types.TableRow, columns []*types.TableColumn) (string, error) {
 	content := &strings.Builder{}
+	positions := types.NewTableCellColumns()
 	for _, row := range rows {
-		c, err := r.renderTableRow(ctx, row, columns)
+		c, err := r.renderTableRow(ctx, row, columns, positions.Next(row))
 		if err != nil {
 			return "", errors.Wrap(err, "unable to render body")
 		}
@@ -232,10 +235,10 @@ func (r *sgmlRenderer) renderTableBody(ctx *context, rows []*types.TableRow, col
 	})
 }
 
-func (r *sgmlRenderer) renderTableRow(ctx *context, l *types.TableRow, cols []*types.TableColumn) (string, error) {
+func (r *sgmlRenderer) renderTableRow(ctx *context, l *types.TableRow, cols []*types.TableColumn, columns []int) (string, error) {
 	content := &strings.Builder{}
 	for i, cell := range l.Cells {
-		c, err := r.renderTableCell(ctx, cell, cols[i])
+		c, err := r.renderTableCell(ctx, cell, tableColumn(cols, columns[i]), columns[i])
 		if err != nil {
 			return "", errors.Wrap(err, "unable to render row")
 		}
@@ -252,17 +255,39 @@ func (r *sgmlRenderer) renderTableRow(ctx *context, l *types.TableRow, cols []*t
 	})
 }
 
-func (r *sgmlRenderer) renderTableCell(ctx *context, cell *types.TableCell, col *types.TableColumn) (string, error) {
+func (r *sgmlRenderer) renderTableCell(ctx *context, cell *types.TableCell, col *types.TableColumn, index int) (string, error) {
+	// the style of the cell takes precedence over the style of the column
+	style := cell.Style()
+	if style == "" {
+		style = col.Style
+	}
 	buff := &strings.Builder{}
-	for _, element := range cell.Elements {
-		renderedElement, err := r.renderTableCellBlock(ctx, element)
+	if style == types.AsciidocStyle {
+		// the content of an AsciiDoc cell is rendered as a nested document, within a single wrapper
+		content, err := r.renderElements(ctx, cell.Elements)
 		if err != nil {
-			return "", err
+			return "", errors.Wrap(err, "unable to render table cell content")
+		}
+		block, err := r.execute(r.tableCellBlock, struct {
+			Content string
+		}{
+			Content: content,
+		})
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render table cell content")
+		}
+		buff.WriteString(block)
+	} else {
+		for _, element := range cell.Elements {
+			renderedElement, err := r.renderTableCellBlock(ctx, element, style)
+			if err != nil {
+				return "", err
+			}
+			buff.WriteString(renderedElement)
 		}
-		buff.WriteString(renderedElement)
 	}
 	tmpl := r.tableCell
-	if col.Style == types.HeaderStyle {
+	if style == types.HeaderStyle {
 		tmpl = r.tableHeaderCell
 	}
 	return r.execute(tmpl, struct {
@@ -271,23 +296,56 @@ func (r *sgmlRenderer) renderTableCell(ctx *context, cell *types.TableCell, col
 		Cell    *types.TableCell
 		HAlign  types.HAlign
 		VAlign  types.VAlign
+		Span    tableCellSpan
 	}{
 		Context: ctx,
 		Content: buff.String(),
 		Cell:    cell,
-		HAlign:  col.HAlign,
-		VAlign:  col.VAlign,
+		HAlign:  cellHAlign(cell, col),
+		VAlign:  cellVAlign(cell, col),
+		Span:    newTableCellSpan(cell, index),
 	})
 }
 
-func (r *sgmlRenderer) renderTableCellBlock(ctx *context, element interface{}) (string, error) {
+func (r *sgmlRenderer) renderTableCellBlock(ctx *context, element interface{}, style types.ContentStyle) (string, error) {
 	switch e := element.(type) {
 	case *types.Paragraph:
 		log.Debug("rendering paragraph within table cell")
-		content, err := r.renderElements(ctx, e.Elements)
+		elements := e.Elements
+		switch style {
+		case types.EmphasisStyle:
+			elements = []interface{}{
+				&types.QuotedText{
+					Kind:     types.SingleQuoteItalic,
+					Elements: e.Elements,
+				},
+			}
+		case types.StrongStyle:
+			elements = []interface{}{
+				&types.QuotedText{
+					Kind:     types.SingleQuoteBold,
+					Elements: e.Elements,
+				},
+			}
+		case types.MonospaceStyle:
+			elements = []interface{}{
+				&types.QuotedText{
+					Kind:     types.SingleQuoteMonospace,
+					Elements: e.Elements,
+				},
+			}
+		}
+		content, err := r.renderElements(ctx, elements)
 		if err != nil {
 			return "", errors.Wrap(err, "unable to render table cell paragraph content")
 		}
+		if style == types.VerseStyle {
+			return r.execute(r.tableCellVerse, struct {
+				Content string
+			}{
+				Content: content,
+			})
+		}
 		title, err := r.renderElementTitle(ctx, e.Attributes)
 		if err != nil {
 			return "", errors.Wrap(err, "unable to render table cell paragraph content")
@@ -311,16 +369,77 @@ func (r *sgmlRenderer) renderTableCellBlock(ctx *context, element interface{}) (
 			return "", errors.Wrap(err, "unable to render table cell paragraph content")
 		}
 		return strings.TrimSuffix(result, "\n"), nil
-	default:
-		// Note: Asciidoctor wraps the `<div class=imageblock>` elements within a `<div class="content">`, which we also do here for the sake of compatibility
-		renderedElement, err := r.renderElement(ctx, e)
-		if err != nil {
-			return "", errors.Wrap(err, "unable to render table cell")
+	case *types.DelimitedBlock:
+		if e.Kind == types.Literal && style == types.LiteralStyle {
+			log.Debug("rendering literal content of table cell")
+			content, err := r.renderElements(ctx, e.Elements)
+			if err != nil {
+				return "", errors.Wrap(err, "unable to render table cell literal content")
+			}
+			return r.execute(r.tableCellLiteral, struct {
+				Content string
+			}{
+				Content: content,
+			})
 		}
-		return r.execute(r.tableCellBlock, struct {
-			Content string
-		}{
-			Content: renderedElement,
-		})
+		return r.renderTableCellContent(ctx, e)
+	default:
+		return r.renderTableCellContent(ctx, e)
+	}
+}
+
+func (r *sgmlRenderer) renderTableCellContent(ctx *context, element interface{}) (string, error) {
+	// Note: Asciidoctor wraps the `<div class=imageblock>` elements within a `<div class="content">`, which we also do here for the sake of compatibility
+	renderedElement, err := r.renderElement(ctx, element)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render table cell")
+	}
+	return r.execute(r.tableCellBlock, struct {
+		Content string
+	}{
+		Content: renderedElement,
+	})
+}
+
+// tableColumn returns the definition of the column at the given index
+func tableColumn(cols []*types.TableColumn, index int) *types.TableColumn {
+	return cols[index%len(cols)]
+}
+
+// cellHAlign returns the horizontal alignment of the cell, or the one of its column if the cell has none
+func cellHAlign(c *types.TableCell, col *types.TableColumn) types.HAlign {
+	if h, ok := c.Attributes[types.AttrHAlign].(types.HAlign); ok {
+		return h
 	}
+	return col.HAlign
+}
+
+// cellVAlign returns the vertical alignment of the cell, or the one of its column if the cell has none
+func cellVAlign(c *types.TableCell, col *types.TableColumn) types.VAlign {
+	if v, ok := c.Attributes[types.AttrVAlign].(types.VAlign); ok {
+		return v
+	}
+	return col.VAlign
+}
+
+// tableCellSpan the columns and rows that a cell spans over
+type tableCellSpan struct {
+	Colspan     int
+	Rowspan     int
+	FirstColumn int // 1-based index of the first column covered by the cell
+	LastColumn  int // 1-based index of the last column covered by the cell
+}
+
+func newTableCellSpan(c *types.TableCell, index int) tableCellSpan {
+	return tableCellSpan{
+		Colspan:     c.Colspan(),
+		Rowspan:     c.Rowspan(),
+		FirstColumn: index + 1,
+		LastColumn:  index + c.Colspan(),
+	}
+}
+
+// MoreRows returns the number of rows that the cell spans over, in addition to its own row
+func (s tableCellSpan) MoreRows() int {
+	return s.Rowspan - 1
 }
@@ -137,14 +137,23 @@ func (r *sgmlRenderer) renderTableOfContentsEntry(ctx *context, entry *types.ToC
 	if err != nil {
 		return "", errors.Wrap(err, "unable to render table of contents entry children")
 	}
+	var chunk string
+	if c, found := ctx.chunkOf(entry.ID); found {
+		// the section is in another page
+		chunk = c
+	}
 	return r.execute(r.tocEntry, struct {
 		Number  string
+		Caption string
 		ID      string
+		Chunk   string
 		Title   string
 		Content string
 	}{
 		Number:  entry.Number,
+		Caption: ctx.sectionCaptions[entry.ID],
 		ID:      entry.ID,
+		Chunk:   chunk,
 		Title:   entry.Title,
 		Content: content,
 	})
@@ -7,11 +7,13 @@ type Templates struct {
 	AdmonitionParagraph          string
 	Article                      string
 	ArticleHeader                string
+	AudioBlock                   string
 	BlockImage                   string
 	BoldText                     string
 	CalloutList                  string
 	CalloutListElement           string
 	CalloutRef                   string
+	ChunkNavigation              string
 	EmbeddedParagraph            string
 	DocumentDetails              string
 	DocumentAuthorDetails        string
@@ -25,15 +27,22 @@ type Templates struct {
 	IconFont                     string
 	IconImage                    string
 	IconText                     string
+	Index                        string
+	IndexCategory                string
+	IndexEntry                   string
+	IndexTerm                    string
 	InlineButton                 string
 	InlineIcon                   string
 	InlineImage                  string
 	InlineMenu                   string
+	InlineStem                   string
 	InternalCrossReference       string
 	InvalidFootnote              string
 	ItalicText                   string
 	LabeledList                  string
 	LabeledListElement           string
+	LabeledListGlossary          string
+	LabeledListGlossaryElement   string
 	LabeledListHorizontal        string
 	LabeledListHorizontalElement string
 	LineBreak                    string
@@ -49,6 +58,7 @@ type Templates struct {
 	OrderedList                  string
 	OrderedListElement           string
 	Paragraph                    string
+	PartIntro                    string
 	PassthroughBlock             string
 	Preamble                     string
 	QAndAList                    string
@@ -59,12 +69,15 @@ type Templates struct {
 	SectionTitle                 string
 	SidebarBlock                 string
 	SourceBlock                  string
+	StemBlock                    string
 	SubscriptText                string
 	SuperscriptText              string
 	Table                        string
 	TableBody                    string
 	TableCell                    string
 	TableCellBlock               string
+	TableCellLiteral             string
+	TableCellVerse               string
 	TableHeader                  string
 	TableHeaderCell              string
 	TableFooter                  string
@@ -78,4 +91,5 @@ type Templates struct {
 	UnorderedListElement         string
 	VerseBlock                   string
 	VerseParagraph               string
+	VideoBlock                   string
 }
@@ -0,0 +1,228 @@
+package sgml
+
+import (
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	"github.com/pkg/errors"
+)
+
+func (r *sgmlRenderer) renderVideoBlock(ctx *context, v *types.VideoBlock) (string, error) {
+	title, err := r.renderElementTitle(ctx, v.Attributes)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render video")
+	}
+	roles, err := r.renderMediaRoles(ctx, v.Attributes)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render video")
+	}
+	provider := v.Provider()
+	var src, poster string
+	switch provider {
+	case types.YouTube:
+		src = youTubeSrc(ctx, v)
+	case types.Vimeo:
+		src = vimeoSrc(ctx, v)
+	default:
+		src = r.getMediaSrc(ctx, v.Location) + timeAnchor(v.Attributes)
+		if p := v.Attributes.GetAsStringWithDefault(types.AttrPoster, ""); p != "" {
+			poster = r.getMediaSrc(ctx, &types.Location{Path: p})
+		}
+	}
+	return r.execute(r.videoBlock, struct {
+		Context         *context
+		ID              string
+		Title           string
+		Roles           string
+		Provider        string
+		Src             string
+		Poster          string
+		Width           string
+		Height          string
+		Preload         string
+		Autoplay        bool
+		Controls        bool
+		Loop            bool
+		Muted           bool
+		AllowFullscreen bool
+	}{
+		Context:         ctx,
+		ID:              r.renderElementID(v.Attributes),
+		Title:           title,
+		Roles:           roles,
+		Provider:        provider,
+		Src:             src,
+		Poster:          poster,
+		Width:           v.Attributes.GetAsStringWithDefault(types.AttrWidth, ""),
+		Height:          v.Attributes.GetAsStringWithDefault(types.AttrHeight, ""),
+		Preload:         v.Attributes.GetAsStringWithDefault(types.AttrPreload, ""),
+		Autoplay:        v.Attributes.HasOption("autoplay"),
+		Controls:        !v.Attributes.HasOption("nocontrols"),
+		Loop:            v.Attributes.HasOption("loop"),
+		Muted:           v.Attributes.HasOption("muted"),
+		AllowFullscreen: !v.Attributes.HasOption("nofullscreen"),
+	})
+}
+
+func (r *sgmlRenderer) renderAudioBlock(ctx *context, a *types.AudioBlock) (string, error) {
+	title, err := r.renderElementTitle(ctx, a.Attributes)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render audio")
+	}
+	roles, err := r.renderElementRoles(ctx, a.Attributes)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render audio")
+	}
+	return r.execute(r.audioBlock, struct {
+		Context  *context
+		ID       string
+		Title    string
+		Roles    string
+		Src      string
+		Autoplay bool
+		Controls bool
+		Loop     bool
+	}{
+		Context:  ctx,
+		ID:       r.renderElementID(a.Attributes),
+		Title:    title,
+		Roles:    roles,
+		Src:      r.getMediaSrc(ctx, a.Location) + timeAnchor(a.Attributes),
+		Autoplay: a.Attributes.HasOption("autoplay"),
+		Controls: !a.Attributes.HasOption("nocontrols"),
+		Loop:     a.Attributes.HasOption("loop"),
+	})
+}
+
+// renderMediaRoles returns the roles of a video, including the float and alignment attributes
+func (r *sgmlRenderer) renderMediaRoles(ctx *context, attrs types.Attributes) (string, error) {
+	var result []string
+	if val, found := attrs.GetAsString(types.AttrFloat); found {
+		result = append(result, "float-"+val)
+	}
+	if val, found := attrs.GetAsString(types.AttrImageAlign); found {
+		result = append(result, "text-"+val)
+	}
+	roles, err := r.renderElementRoles(ctx, attrs)
+	if err != nil {
+		return "", err
+	}
+	if roles != "" {
+		result = append(result, roles)
+	}
+	return strings.Join(result, " "), nil
+}
+
+// getMediaSrc returns the location of the video or audio file, relative to the `imagesdir` directory (if set)
+func (r *sgmlRenderer) getMediaSrc(ctx *context, location *types.Location) string {
+	if imagesdir, found := ctx.attributes.GetAsString(types.AttrImagesDir); found {
+		location.SetPathPrefix(imagesdir)
+	}
+	return location.ToString()
+}
+
+// timeAnchor returns the Media Fragment (eg: `#t=60,120`) for the `start` and `end` attributes, if set
+func timeAnchor(attrs types.Attributes) string {
+	start, hasStart := attrs.GetAsString(types.AttrStart)
+	end, hasEnd := attrs.GetAsString(types.AttrEnd)
+	switch {
+	case hasEnd:
+		return "#t=" + start + "," + end
+	case hasStart:
+		return "#t=" + start
+	default:
+		return ""
+	}
+}
+
+// youTubeSrc returns the URL of the YouTube embedded player, with the query parameters matching the video attributes and options.
+// The target of the video may be `video_id`, `video_id/playlist_id` or `video_id1,video_id2,...`
+func youTubeSrc(ctx *context, v *types.VideoBlock) string {
+	params := []string{}
+	if v.Attributes.HasOption("related") {
+		params = append(params, "rel=1")
+	} else {
+		params = append(params, "rel=0")
+	}
+	if start, found := v.Attributes.GetAsString(types.AttrStart); found {
+		params = append(params, "start="+start)
+	}
+	if end, found := v.Attributes.GetAsString(types.AttrEnd); found {
+		params = append(params, "end="+end)
+	}
+	if v.Attributes.HasOption("autoplay") {
+		params = append(params, "autoplay=1")
+	}
+	loop := v.Attributes.HasOption("loop")
+	if loop {
+		params = append(params, "loop=1")
+	}
+	if v.Attributes.HasOption("muted") {
+		params = append(params, "mute=1")
+	}
+	if v.Attributes.HasOption("nocontrols") {
+		params = append(params, "controls=0")
+	}
+	target := v.Location.ToString()
+	if t, list, found := strings.Cut(target, "/"); found {
+		target = t
+		params = append(params, "list="+list)
+	} else if list, found := v.Attributes.GetAsString(types.AttrPlaylist); found {
+		params = append(params, "list="+list)
+	} else if t, playlist, found := strings.Cut(target, ","); found {
+		target = t
+		params = append(params, "playlist="+t+","+playlist)
+	} else if loop {
+		// for the loop to work, the playlist must be specified
+		params = append(params, "playlist="+target)
+	}
+	if v.Attributes.HasOption("nofullscreen") {
+		params = append(params, "fs=0")
+	}
+	if v.Attributes.HasOption("modest") {
+		params = append(params, "modestbranding=1")
+	}
+	if theme, found := v.Attributes.GetAsString(types.AttrTheme); found {
+		params = append(params, "theme="+theme)
+	}
+	if lang, found := v.Attributes.GetAsString(types.AttrLang); found {
+		params = append(params, "hl="+lang)
+	}
+	return assetURIScheme(ctx) + "//www.youtube.com/embed/" + target + "?" + strings.Join(params, "&amp;")
+}
+
+// vimeoSrc returns the URL of the Vimeo embedded player, with the query parameters matching the video options.
+// The target of the video may be `video_id` or `video_id/hash`
+func vimeoSrc(ctx *context, v *types.VideoBlock) string {
+	params := []string{}
+	target := v.Location.ToString()
+	if t, hash, found := strings.Cut(target, "/"); found {
+		target = t
+		params = append(params, "h="+hash)
+	}
+	if v.Attributes.HasOption("autoplay") {
+		params = append(params, "autoplay=1")
+	}
+	if v.Attributes.HasOption("loop") {
+		params = append(params, "loop=1")
+	}
+	if v.Attributes.HasOption("muted") {
+		params = append(params, "muted=1")
+	}
+	result := assetURIScheme(ctx) + "//player.vimeo.com/video/" + target
+	if len(params) > 0 {
+		result += "?" + strings.Join(params, "&amp;")
+	}
+	if start, found := v.Attributes.GetAsString(types.AttrStart); found {
+		result += "#at=" + start
+	}
+	return result
+}
+
+// assetURIScheme returns the scheme of the URLs of the embedded players, given the `asset-uri-scheme` attribute (`https` by default)
+func assetURIScheme(ctx *context) string {
+	if scheme := ctx.attributes.GetAsStringWithDefault("asset-uri-scheme", "https"); scheme != "" {
+		return scheme + ":"
+	}
+	return ""
+}
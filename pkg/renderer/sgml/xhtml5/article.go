@@ -25,6 +25,7 @@ const (
 		"Last updated {{ .LastUpdated }}\n" +
 		"</div>\n" +
 		"</div>\n{{ end }}" +
+		"{{ if .IncludeMathJax }}" + mathJaxTmpl + "{{ end }}" +
 		"</body>\n" +
 		"</html>\n"
 )
@@ -19,6 +19,7 @@ var _ = Describe("file inclusions", func() {
 		defer reset()
 		lastUpdated := time.Now()
 		source := "include::../../../../test/includes/grandchild-include.adoc[]"
+		included := "../../../../test/includes/grandchild-include.adoc" // in the same form as the path of the root document
 		expected := `<div class="sect1">
 <h2 id="_grandchild_title">grandchild title</h2>
 <div class="sectionbody">
@@ -46,6 +47,7 @@ var _ = Describe("file inclusions", func() {
 					},
 				},
 			},
+			Files: []string{included},
 		}))
 		// verify no error/warning in logs
 		Expect(logs).ToNot(ContainAnyMessageWithLevels(log.ErrorLevel, log.WarnLevel))
@@ -713,8 +715,7 @@ func helloworld() {
 		})
 
 		It("file inclusion with unclosed tag", func() {
-			logs, reset := ConfigureLogger(log.WarnLevel)
-			defer reset()
+			diagnostics := types.NewDiagnostics()
 			source := `include::../../../../test/includes/tag-include-unclosed.adoc[tag=unclosed]`
 			expected := `<div class="paragraph">
 <p>content</p>
@@ -723,9 +724,14 @@ func helloworld() {
 <p>end</p>
 </div>
 `
-			Expect(RenderXHTML(source)).To(MatchHTML(expected))
-			// verify error in logs
-			Expect(logs).To(ContainJSONLog(log.WarnLevel, "detected unclosed tag 'unclosed' starting at line 6 of include file: ../../../../test/includes/tag-include-unclosed.adoc"))
+			Expect(RenderXHTML(source, configuration.WithDiagnostics(diagnostics))).To(MatchHTML(expected))
+			// verify warning in diagnostics
+			Expect(diagnostics.All()).To(HaveLen(1))
+			Expect(diagnostics.All()[0].Severity).To(Equal(types.SeverityWarning))
+			Expect(diagnostics.All()[0].Message).To(And(
+				HavePrefix("detected unclosed tag 'unclosed' starting at line 6 of include file: "),
+				HaveSuffix("test/includes/tag-include-unclosed.adoc"),
+			))
 		})
 
 		It("file inclusion with no tag", func() {
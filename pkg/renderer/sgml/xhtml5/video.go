@@ -0,0 +1,44 @@
+package xhtml5
+
+const (
+	videoBlockTmpl = "<div" +
+		"{{ if .ID }} id=\"{{ .ID }}\"{{ end }}" +
+		" class=\"videoblock{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
+		"{{ if .Title }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
+		"<div class=\"content\">\n" +
+		`{{ if .Provider }}<iframe` +
+		`{{ if .Width }} width="{{ .Width }}"{{ end }}` +
+		`{{ if .Height }} height="{{ .Height }}"{{ end }}` +
+		` src="{{ .Src }}" frameborder="0"` +
+		`{{ if .AllowFullscreen }} allowfullscreen="allowfullscreen"{{ end }}` +
+		"></iframe>\n" +
+		`{{ else }}<video src="{{ .Src }}"` +
+		`{{ if .Width }} width="{{ .Width }}"{{ end }}` +
+		`{{ if .Height }} height="{{ .Height }}"{{ end }}` +
+		`{{ if .Poster }} poster="{{ .Poster }}"{{ end }}` +
+		`{{ if .Autoplay }} autoplay="autoplay"{{ end }}` +
+		`{{ if .Controls }} controls="controls"{{ end }}` +
+		`{{ if .Loop }} loop="loop"{{ end }}` +
+		`{{ if .Muted }} muted="muted"{{ end }}` +
+		`{{ if .Preload }} preload="{{ .Preload }}"{{ end }}` +
+		">\n" +
+		"Your browser does not support the video tag.\n" +
+		"</video>\n" +
+		"{{ end }}</div>\n" +
+		"</div>\n"
+
+	audioBlockTmpl = "<div" +
+		"{{ if .ID }} id=\"{{ .ID }}\"{{ end }}" +
+		" class=\"audioblock{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
+		"{{ if .Title }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
+		"<div class=\"content\">\n" +
+		`<audio src="{{ .Src }}"` +
+		`{{ if .Autoplay }} autoplay="autoplay"{{ end }}` +
+		`{{ if .Controls }} controls="controls"{{ end }}` +
+		`{{ if .Loop }} loop="loop"{{ end }}` +
+		">\n" +
+		"Your browser does not support the audio tag.\n" +
+		"</audio>\n" +
+		"</div>\n" +
+		"</div>\n"
+)
@@ -0,0 +1,53 @@
+package xhtml5_test
+
+import (
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("videos", func() {
+
+	It("video file with options", func() {
+		source := `video::intro.mp4[opts="autoplay,loop"]`
+		expected := `<div class="videoblock">
+<div class="content">
+<video src="intro.mp4" autoplay="autoplay" controls="controls" loop="loop">
+Your browser does not support the video tag.
+</video>
+</div>
+</div>
+`
+		Expect(RenderXHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("video on YouTube", func() {
+		source := `video::rPQoq7ThGAU[youtube]`
+		expected := `<div class="videoblock">
+<div class="content">
+<iframe src="https://www.youtube.com/embed/rPQoq7ThGAU?rel=0" frameborder="0" allowfullscreen="allowfullscreen"></iframe>
+</div>
+</div>
+`
+		Expect(RenderXHTML(source)).To(MatchHTML(expected))
+	})
+})
+
+var _ = Describe("audios", func() {
+
+	It("audio file with title", func() {
+		source := `.Ocean waves
+audio::ocean_waves.mp3[]`
+		expected := `<div class="audioblock">
+<div class="title">Ocean waves</div>
+<div class="content">
+<audio src="ocean_waves.mp3" controls="controls">
+Your browser does not support the audio tag.
+</audio>
+</div>
+</div>
+`
+		Expect(RenderXHTML(source)).To(MatchHTML(expected))
+	})
+})
@@ -11,9 +11,20 @@ import (
 
 // Render renders the document to the output, using the SGML renderer configured with the XHTML5 templates
 func Render(doc *types.Document, config *configuration.Configuration, output io.Writer) (types.Metadata, error) {
+	return sgml.Render(doc, config, output, templates())
+}
+
+// RenderChunks renders the document in multiple pages (see `sgml.RenderChunks()`), using the SGML renderer configured with the XHTML5 templates
+func RenderChunks(doc *types.Document, config *configuration.Configuration, level int) ([]sgml.Chunk, types.Metadata, error) {
+	return sgml.RenderChunks(doc, config, templates(), level)
+}
+
+// templates returns the HTML5 templates, along with their XHTML5 overrides
+func templates() sgml.Templates {
 	templates := html5.Templates()
 	// XHTML5 overrides of HTML5.
 	templates.Article = articleTmpl
+	templates.AudioBlock = audioBlockTmpl
 	templates.BlockImage = blockImageTmpl
 	templates.LineBreak = lineBreakTmpl
 	templates.DocumentAuthorDetails = documentAuthorDetailsTmpl
@@ -28,6 +39,6 @@ func Render(doc *types.Document, config *configuration.Configuration, output io.
 	templates.QuoteParagraph = quoteParagraphTmpl
 	templates.VerseBlock = verseBlockTmpl
 	templates.VerseParagraph = verseParagraphTmpl
-
-	return sgml.Render(doc, config, output, templates)
+	templates.VideoBlock = videoBlockTmpl
+	return templates
 }
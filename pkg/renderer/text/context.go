@@ -0,0 +1,89 @@
+package text
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+)
+
+// context carries the document which is being processed, along with the state of the rendering
+type context struct {
+	config            *configuration.Configuration
+	attributes        types.Attributes
+	elementReferences types.ElementReferences
+	footnotes         []*types.Footnote
+	sectionNumbers    types.SectionNumbers
+	// the depth of the list being rendered (0 if not in a list)
+	listDepth int
+	// the number of characters available on each line for the element being rendered (no wrapping if `0`)
+	width int
+}
+
+// newContext returns a new rendering context for the given document.
+func newContext(doc *types.Document, config *configuration.Configuration) (*context, error) {
+	sectionNumbers, err := doc.SectionNumbers()
+	if err != nil {
+		return nil, err
+	}
+	ctx := &context{
+		config:            config,
+		attributes:        config.Attributes,
+		elementReferences: doc.ElementReferences,
+		footnotes:         doc.Footnotes,
+		sectionNumbers:    sectionNumbers,
+	}
+	if header, _ := doc.Header(); header != nil {
+		for _, e := range header.Elements {
+			switch e := e.(type) {
+			case *types.AttributeDeclaration:
+				ctx.attributes[e.Name] = e.Value
+			case *types.AttributeReset:
+				delete(ctx.attributes, e.Name)
+			}
+		}
+		// also, expand authors and revision
+		if authors := header.Authors(); authors != nil {
+			ctx.attributes.AddAll(authors.Expand())
+		}
+		if revision := header.Revision(); revision != nil {
+			ctx.attributes.AddAll(revision.Expand())
+		}
+	}
+	ctx.width = wrapWidth(ctx.attributes)
+	return ctx, nil
+}
+
+// wrapWidth returns the width at which the content is wrapped, given the document attributes
+func wrapWidth(attrs types.Attributes) int {
+	if width := attrs.GetAsIntWithDefault(types.AttrWrapWidth, DefaultWrapWidth); width > 0 {
+		return width
+	}
+	return 0
+}
+
+// setAttribute sets (or resets, if the value is nil) the given document attribute
+func (ctx *context) setAttribute(name string, value interface{}) {
+	if value == nil {
+		delete(ctx.attributes, name)
+	} else {
+		ctx.attributes[name] = value
+	}
+	if name == types.AttrWrapWidth {
+		ctx.width = wrapWidth(ctx.attributes)
+	}
+}
+
+// indented renders an element with the available width reduced by the given indentation
+func (ctx *context) indented(indentation int, render func() (string, error)) (string, error) {
+	if ctx.width == 0 {
+		return render()
+	}
+	width := ctx.width
+	defer func() {
+		ctx.width = width
+	}()
+	ctx.width -= indentation
+	if ctx.width < 1 {
+		ctx.width = 1
+	}
+	return render()
+}
@@ -0,0 +1,162 @@
+package text
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// the indentation of the verbatim blocks (listings, literals, etc.)
+	verbatimIndentation = "    "
+	// the indentation of the quotes, verses and sidebars
+	quoteIndentation = 4
+)
+
+func (r *textRenderer) renderDelimitedBlock(ctx *context, b *types.DelimitedBlock) (string, error) {
+	switch b.Kind {
+	case types.Example:
+		if b.Attributes.Has(types.AttrStyle) {
+			return r.renderAdmonition(ctx, b.Attributes, func() (string, error) {
+				return r.renderElements(ctx, b.Elements)
+			})
+		}
+		return r.renderBlock(ctx, b.Attributes, b.Elements, 0)
+	case types.Sidebar:
+		return r.renderBlock(ctx, b.Attributes, b.Elements, quoteIndentation)
+	case types.Open:
+		return r.renderBlock(ctx, b.Attributes, b.Elements, 0)
+	case types.Fenced, types.Listing, types.Literal, types.Source, types.Stem, types.LatexMath, types.AsciiMath:
+		return r.renderVerbatimBlock(ctx, b.Attributes, b.Elements)
+	case types.Quote, types.MarkdownQuote:
+		content, err := ctx.indented(quoteIndentation, func() (string, error) {
+			return r.renderElements(ctx, b.Elements)
+		})
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render quote block")
+		}
+		return r.renderQuote(ctx, b.Attributes, content)
+	case types.Verse:
+		content, err := r.renderInlineElements(ctx, b.Elements)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render verse block")
+		}
+		return r.renderQuote(ctx, b.Attributes, verse(content))
+	case types.Passthrough:
+		return r.renderPassthrough(ctx, b.Elements)
+	default:
+		return "", fmt.Errorf("unsupported kind of delimited block: '%s'", b.Kind)
+	}
+}
+
+// renderBlock renders the optional title, followed by the elements with the given indentation
+func (r *textRenderer) renderBlock(ctx *context, attrs types.Attributes, elements []interface{}, indentation int) (string, error) {
+	title, err := r.renderElementTitle(ctx, attrs)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render block title")
+	}
+	content, err := ctx.indented(indentation, func() (string, error) {
+		return r.renderElements(ctx, elements)
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render block content")
+	}
+	return title + indent(content, strings.Repeat(" ", indentation)), nil
+}
+
+// renderVerbatimBlock renders the given elements as-is, with an indentation
+func (r *textRenderer) renderVerbatimBlock(ctx *context, attrs types.Attributes, elements []interface{}) (string, error) {
+	title, err := r.renderElementTitle(ctx, attrs)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render verbatim block title")
+	}
+	content, err := r.renderVerbatimElements(elements)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render verbatim block content")
+	}
+	return title + indent(strings.Trim(content, "\n"), verbatimIndentation) + "\n", nil
+}
+
+// renderVerbatimElements renders the given elements without any wrapping
+func (r *textRenderer) renderVerbatimElements(elements []interface{}) (string, error) {
+	result := &strings.Builder{}
+	for _, element := range elements {
+		switch e := element.(type) {
+		case *types.Callout:
+			result.WriteString("(" + strconv.Itoa(e.Ref) + ")")
+		default:
+			content, err := sgml.RenderPlainText(element, sgml.WithoutEscape(), sgml.WithUnicode())
+			if err != nil {
+				return "", err
+			}
+			result.WriteString(content)
+		}
+	}
+	return result.String(), nil
+}
+
+// verse returns the lines of the given content, without wrapping
+func verse(content string) string {
+	lines := strings.Split(strings.Trim(strings.ReplaceAll(content, hardBreak, "\n"), "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimSpace(l)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// renderQuote renders the (already rendered) content of a quote or a verse with an indentation,
+// followed by its attribution
+func (r *textRenderer) renderQuote(ctx *context, attrs types.Attributes, content string) (string, error) {
+	title, err := r.renderElementTitle(ctx, attrs)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render quote title")
+	}
+	result := &strings.Builder{}
+	result.WriteString(content)
+	author := attrs.GetAsStringWithDefault(types.AttrQuoteAuthor, "")
+	citation := attrs.GetAsStringWithDefault(types.AttrQuoteTitle, "")
+	if author != "" || citation != "" {
+		attribution := []string{}
+		if author != "" {
+			attribution = append(attribution, author)
+		}
+		if citation != "" {
+			attribution = append(attribution, citation)
+		}
+		result.WriteString("\n— " + strings.Join(attribution, ", ") + "\n")
+	}
+	return title + indent(result.String(), strings.Repeat(" ", quoteIndentation)), nil
+}
+
+// renderPassthrough renders the given elements as-is
+func (r *textRenderer) renderPassthrough(_ *context, elements []interface{}) (string, error) {
+	content, err := sgml.RenderPlainText(elements, sgml.WithoutEscape())
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render passthrough content")
+	}
+	return strings.Trim(content, "\n") + "\n", nil
+}
+
+// renderImageBlock renders the alt text of the image within brackets
+func (r *textRenderer) renderImageBlock(ctx *context, i *types.ImageBlock) (string, error) {
+	title, err := r.renderElementTitle(ctx, i.Attributes)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render image block")
+	}
+	alt := i.Attributes.GetAsStringWithDefault(types.AttrImageAlt, i.Location.ToDisplayString())
+	return title + wrap("["+alt+"]", ctx.width) + "\n", nil
+}
+
+// renderMediaBlock renders the location of the video or audio within brackets
+func (r *textRenderer) renderMediaBlock(ctx *context, attrs types.Attributes, location string) (string, error) {
+	title, err := r.renderElementTitle(ctx, attrs)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render media block")
+	}
+	return title + wrap("["+location+"]", ctx.width) + "\n", nil
+}
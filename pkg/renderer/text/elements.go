@@ -0,0 +1,99 @@
+package text
+
+import (
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+// renderElements renders the given block elements, separated by a blank line
+func (r *textRenderer) renderElements(ctx *context, elements []interface{}) (string, error) {
+	blocks := make([]string, 0, len(elements))
+	for _, element := range elements {
+		renderedElement, err := r.renderElement(ctx, element)
+		if err != nil {
+			return "", err // no need to wrap the error here
+		}
+		if renderedElement != "" {
+			blocks = append(blocks, renderedElement)
+		}
+	}
+	return strings.Join(blocks, "\n"), nil
+}
+
+// renderElement renders the given block element, with a trailing newline
+//
+//nolint:gocyclo
+func (r *textRenderer) renderElement(ctx *context, element interface{}) (string, error) {
+	switch e := element.(type) {
+	case *types.Section:
+		return r.renderSection(ctx, e)
+	case *types.Preamble:
+		return r.renderElements(ctx, e.Elements)
+	case *types.List:
+		return r.renderList(ctx, e)
+	case *types.Paragraph:
+		return r.renderParagraph(ctx, e)
+	case *types.DelimitedBlock:
+		return r.renderDelimitedBlock(ctx, e)
+	case *types.Table:
+		return r.renderTable(ctx, e)
+	case *types.ImageBlock:
+		return r.renderImageBlock(ctx, e)
+	case *types.VideoBlock:
+		return r.renderMediaBlock(ctx, e.Attributes, e.URL())
+	case *types.AudioBlock:
+		return r.renderMediaBlock(ctx, e.Attributes, e.Location.ToDisplayString())
+	case *types.ThematicBreak:
+		return "* * *\n", nil
+	case *types.UserMacro:
+		return wrap(e.RawText, ctx.width) + "\n", nil
+	case *types.TableOfContents, *types.BlankLine:
+		// not rendered in plain text
+		return "", nil
+	case *types.AttributeDeclaration:
+		ctx.setAttribute(e.Name, e.Value)
+		return "", nil
+	case *types.AttributeReset:
+		ctx.setAttribute(e.Name, nil)
+		return "", nil
+	case *types.FrontMatter:
+		ctx.attributes.AddAll(e.Attributes)
+		return "", nil
+	default:
+		// also support inline elements at the block level (eg: within table cells)
+		result, err := r.renderInlineElement(ctx, element)
+		if err != nil {
+			return "", errors.Errorf("unsupported type of element: %T", element)
+		}
+		return wrap(result, ctx.width) + "\n", nil
+	}
+}
+
+// renderElementTitle renders the title of the element on its own line (if the element has a title)
+func (r *textRenderer) renderElementTitle(ctx *context, attrs types.Attributes) (string, error) {
+	title, err := r.renderAttribute(ctx, attrs[types.AttrTitle])
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render element title")
+	}
+	if title == "" {
+		return "", nil
+	}
+	return wrap(title, ctx.width) + "\n", nil
+}
+
+// renderAttribute renders the value of an attribute, which is either a string or a slice of inline elements
+func (r *textRenderer) renderAttribute(ctx *context, value interface{}) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case []interface{}:
+		return r.renderInlineElements(ctx, value)
+	default:
+		return "", errors.Errorf("unable to render attribute of type '%T'", value)
+	}
+}
@@ -0,0 +1,128 @@
+package text
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+func (r *textRenderer) renderInlineElements(ctx *context, elements []interface{}) (string, error) {
+	buff := &strings.Builder{}
+	for _, element := range elements {
+		renderedElement, err := r.renderInlineElement(ctx, element)
+		if err != nil {
+			return "", err
+		}
+		buff.WriteString(renderedElement)
+	}
+	return buff.String(), nil
+}
+
+// renderInlineElement renders the given inline element. The elements which have no specific rendering
+// in plain text are rendered with `sgml.RenderPlainText`
+//
+//nolint:gocyclo
+func (r *textRenderer) renderInlineElement(ctx *context, element interface{}) (string, error) {
+	switch e := element.(type) {
+	case *types.QuotedText:
+		return r.renderInlineElements(ctx, e.Elements)
+	case *types.InlineLink:
+		// the link text may contain some quoted text with a footnote
+		return sgml.RenderPlainText(e, sgml.WithoutEscape(), sgml.WithUnicode(), sgml.WithLinkURLs())
+	case *types.InternalCrossReference:
+		return r.renderInternalCrossReference(ctx, e)
+	case *types.ExternalCrossReference:
+		return r.renderExternalCrossReference(ctx, e)
+	case *types.InlineImage:
+		return "[" + e.Attributes.GetAsStringWithDefault(types.AttrImageAlt, e.Location.ToDisplayString()) + "]", nil
+	case *types.Icon:
+		return "[" + e.Attributes.GetAsStringWithDefault(types.AttrImageAlt, e.Class) + "]", nil
+	case *types.InlineButton:
+		return "[" + e.Attributes.GetAsStringWithDefault(types.AttrButtonLabel, "") + "]", nil
+	case *types.InlineMenu:
+		return strings.Join(e.Path, " > "), nil
+	case *types.FootnoteReference:
+		if e.ID == types.InvalidFootnoteReference {
+			return "[" + e.Ref + "]", nil
+		}
+		return "[" + strconv.Itoa(e.ID) + "]", nil
+	case *types.Callout:
+		return "(" + strconv.Itoa(e.Ref) + ")", nil
+	case *types.LineBreak:
+		return hardBreak, nil
+	case *types.IndexTerm:
+		return r.renderInlineElements(ctx, e.Term)
+	case *types.ConcealedIndexTerm:
+		return "", nil
+	case *types.UserMacro:
+		return e.RawText, nil
+	case *types.AttributeDeclaration:
+		ctx.setAttribute(e.Name, e.Value)
+		return "", nil
+	case *types.AttributeReset:
+		ctx.setAttribute(e.Name, nil)
+		return "", nil
+	default:
+		return sgml.RenderPlainText(element, sgml.WithoutEscape(), sgml.WithUnicode(), sgml.WithLinkURLs())
+	}
+}
+
+func (r *textRenderer) renderInternalCrossReference(ctx *context, xref *types.InternalCrossReference) (string, error) {
+	id, ok := xref.ID.(string)
+	if !ok {
+		return "", errors.Errorf("unable to process internal cross reference: invalid ID: '%v'", xref.ID)
+	}
+	label, err := r.renderAttribute(ctx, xref.Label)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render internal cross reference")
+	}
+	if label == "" {
+		if label, err = r.renderAttribute(ctx, ctx.elementReferences[id]); err != nil {
+			return "", errors.Wrap(err, "unable to render internal cross reference")
+		}
+	}
+	if label == "" {
+		return "[" + id + "]", nil
+	}
+	return label, nil
+}
+
+func (r *textRenderer) renderExternalCrossReference(ctx *context, xref *types.ExternalCrossReference) (string, error) {
+	label, err := r.renderAttribute(ctx, xref.Attributes[types.AttrXRefLabel])
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render external cross reference")
+	}
+	if label == "" {
+		return xref.Location.ToDisplayString(), nil
+	}
+	return label + " [" + xref.Location.ToDisplayString() + "]", nil
+}
+
+// renderFootnotes renders the footnotes in a `Notes` section at the end of the document
+func (r *textRenderer) renderFootnotes(ctx *context, notes []*types.Footnote) (string, error) {
+	if len(notes) == 0 {
+		return "", nil
+	}
+	result := &strings.Builder{}
+	result.WriteString(heading("Notes", "-"))
+	result.WriteString("\n")
+	for _, note := range notes {
+		marker := "[" + strconv.Itoa(note.ID) + "] "
+		content, err := ctx.indented(textWidth(marker), func() (string, error) {
+			content, err := r.renderInlineElements(ctx, note.Elements)
+			if err != nil {
+				return "", err
+			}
+			return wrap(content, ctx.width), nil
+		})
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render footnote")
+		}
+		result.WriteString(hanging(content, marker) + "\n")
+	}
+	return result.String(), nil
+}
@@ -0,0 +1,222 @@
+package text
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+// the indentation of the lists
+const listIndentation = "  "
+
+func (r *textRenderer) renderList(ctx *context, l *types.List) (string, error) {
+	title, err := r.renderElementTitle(ctx, l.Attributes)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render list title")
+	}
+	// nested lists are already indented within their parent list element
+	indentation := listIndentation
+	if ctx.listDepth > 0 {
+		indentation = ""
+	}
+	ctx.listDepth++
+	defer func() {
+		ctx.listDepth--
+	}()
+	content, err := ctx.indented(len(indentation), func() (string, error) {
+		result := &strings.Builder{}
+		var err error
+		switch l.Kind {
+		case types.OrderedListKind:
+			err = r.renderOrderedList(ctx, result, l)
+		case types.UnorderedListKind:
+			err = r.renderUnorderedList(ctx, result, l)
+		case types.LabeledListKind:
+			err = r.renderLabeledList(ctx, result, l)
+		case types.CalloutListKind:
+			err = r.renderCalloutList(ctx, result, l)
+		default:
+			err = fmt.Errorf("unable to render list of kind '%s'", l.Kind)
+		}
+		return result.String(), err
+	})
+	if err != nil {
+		return "", err
+	}
+	return title + indent(content, indentation), nil
+}
+
+func (r *textRenderer) renderOrderedList(ctx *context, result *strings.Builder, l *types.List) error {
+	start := l.Attributes.GetAsIntWithDefault(types.AttrStart, 1)
+	markers := make([]string, len(l.Elements))
+	markerWidth := 0
+	for i, element := range l.Elements {
+		e, ok := element.(*types.OrderedListElement)
+		if !ok {
+			return errors.Errorf("unable to render ordered list element of type '%T'", element)
+		}
+		markers[i] = numbering(l.Attributes.GetAsStringWithDefault(types.AttrStyle, e.Style), start+i) + "."
+		if len(markers[i]) > markerWidth {
+			markerWidth = len(markers[i])
+		}
+	}
+	for i, element := range l.Elements {
+		// numbers are aligned on the right
+		marker := fmt.Sprintf("%*s ", markerWidth, markers[i])
+		if err := r.renderListElement(ctx, result, marker, element.(*types.OrderedListElement).Elements); err != nil {
+			return errors.Wrap(err, "unable to render ordered list element")
+		}
+	}
+	return nil
+}
+
+// numbering returns the number of an ordered list item, in the given style (eg: `1`, `a`, `ii`)
+func numbering(style string, n int) string {
+	switch style {
+	case types.LowerAlpha:
+		return alpha(n)
+	case types.UpperAlpha:
+		return strings.ToUpper(alpha(n))
+	case types.LowerRoman:
+		return roman(n)
+	case types.UpperRoman:
+		return strings.ToUpper(roman(n))
+	default:
+		return strconv.Itoa(n)
+	}
+}
+
+func alpha(n int) string {
+	result := ""
+	for ; n > 0; n = (n - 1) / 26 {
+		result = string(rune('a'+(n-1)%26)) + result
+	}
+	return result
+}
+
+func roman(n int) string {
+	values := []int{1000, 900, 500, 400, 100, 90, 50, 40, 10, 9, 5, 4, 1}
+	symbols := []string{"m", "cm", "d", "cd", "c", "xc", "l", "xl", "x", "ix", "v", "iv", "i"}
+	result := &strings.Builder{}
+	for i, v := range values {
+		for ; n >= v; n -= v {
+			result.WriteString(symbols[i])
+		}
+	}
+	return result.String()
+}
+
+func (r *textRenderer) renderUnorderedList(ctx *context, result *strings.Builder, l *types.List) error {
+	for _, element := range l.Elements {
+		e, ok := element.(*types.UnorderedListElement)
+		if !ok {
+			return errors.Errorf("unable to render unordered list element of type '%T'", element)
+		}
+		if err := r.renderListElement(ctx, result, "* ", e.Elements); err != nil {
+			return errors.Wrap(err, "unable to render unordered list element")
+		}
+	}
+	return nil
+}
+
+func (r *textRenderer) renderLabeledList(ctx *context, result *strings.Builder, l *types.List) error {
+	for _, element := range l.Elements {
+		e, ok := element.(*types.LabeledListElement)
+		if !ok {
+			return errors.Errorf("unable to render labeled list element of type '%T'", element)
+		}
+		term, err := r.renderInlineElements(ctx, e.Term)
+		if err != nil {
+			return errors.Wrap(err, "unable to render labeled list term")
+		}
+		result.WriteString(wrap(term, ctx.width) + "\n")
+		content, err := ctx.indented(len(labeledListIndentation), func() (string, error) {
+			return r.renderListElementContent(ctx, e.Elements)
+		})
+		if err != nil {
+			return errors.Wrap(err, "unable to render labeled list element")
+		}
+		result.WriteString(indent(content, labeledListIndentation))
+	}
+	return nil
+}
+
+// the indentation of the descriptions in labeled lists
+const labeledListIndentation = "    "
+
+func (r *textRenderer) renderCalloutList(ctx *context, result *strings.Builder, l *types.List) error {
+	for _, element := range l.Elements {
+		e, ok := element.(*types.CalloutListElement)
+		if !ok {
+			return errors.Errorf("unable to render callout list element of type '%T'", element)
+		}
+		if err := r.renderListElement(ctx, result, "("+strconv.Itoa(e.Ref)+") ", e.Elements); err != nil {
+			return errors.Wrap(err, "unable to render callout list element")
+		}
+	}
+	return nil
+}
+
+// renderListElement renders the content of a list element after the given marker, with a hanging indent
+func (r *textRenderer) renderListElement(ctx *context, result *strings.Builder, marker string, elements []interface{}) error {
+	content, err := ctx.indented(textWidth(marker), func() (string, error) {
+		return r.renderListElementContent(ctx, elements)
+	})
+	if err != nil {
+		return err
+	}
+	if content == "" {
+		content = "\n"
+	}
+	result.WriteString(hanging(content, marker))
+	return nil
+}
+
+// renderListElementContent renders the elements of a list element: the nested lists are rendered
+// without a blank line before them, and the checklist items start with their check box
+func (r *textRenderer) renderListElementContent(ctx *context, elements []interface{}) (string, error) {
+	result := &strings.Builder{}
+	for i, element := range elements {
+		if p, ok := element.(*types.Paragraph); ok && i == 0 && !p.Attributes.Has(types.AttrStyle) {
+			check := checkStyle(p.Attributes[types.AttrCheckStyle])
+			content, err := ctx.indented(textWidth(check), func() (string, error) {
+				return r.renderParagraphElements(ctx, p)
+			})
+			if err != nil {
+				return "", err
+			}
+			if check != "" {
+				content = hanging(content, check)
+			}
+			result.WriteString(content + "\n")
+			continue
+		}
+		content, err := r.renderElement(ctx, element)
+		if err != nil {
+			return "", err
+		}
+		if content == "" {
+			continue
+		}
+		if _, ok := element.(*types.List); !ok && i > 0 {
+			result.WriteString("\n")
+		}
+		result.WriteString(content)
+	}
+	return result.String(), nil
+}
+
+func checkStyle(style interface{}) string {
+	switch style {
+	case types.Checked, types.CheckedInteractive:
+		return "[x] "
+	case types.Unchecked, types.UncheckedInteractive:
+		return "[ ] "
+	default:
+		return ""
+	}
+}
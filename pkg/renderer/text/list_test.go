@@ -0,0 +1,68 @@
+package text_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("lists", func() {
+
+	It("nested unordered lists and checklist", func() {
+		source := `* item one
+** nested
+* [x] checked
+* [ ] unchecked`
+		expected := `  * item one
+    * nested
+  * [x] checked
+  * [ ] unchecked
+`
+		Expect(RenderText(source)).To(Equal(expected))
+	})
+
+	It("ordered list with aligned numbers", func() {
+		source := `[start=9]
+. nine
+. ten
++
+with a continuation`
+		expected := `   9. nine
+  10. ten
+
+      with a continuation
+`
+		Expect(RenderText(source)).To(Equal(expected))
+	})
+
+	It("ordered list with style", func() {
+		source := `[upperroman]
+. one
+. two`
+		expected := `   I. one
+  II. two
+`
+		Expect(RenderText(source)).To(Equal(expected))
+	})
+
+	It("list element wrapped with a hanging indent", func() {
+		source := `:wrap-width: 30
+
+* a list element with a content which must be wrapped`
+		expected := `  * a list element with a
+    content which must be
+    wrapped
+`
+		Expect(RenderText(source)).To(Equal(expected))
+	})
+
+	It("labeled list", func() {
+		source := `CPU:: The brain of the computer.
+RAM:: Temporary storage.`
+		expected := `  CPU
+      The brain of the computer.
+  RAM
+      Temporary storage.
+`
+		Expect(RenderText(source)).To(Equal(expected))
+	})
+})
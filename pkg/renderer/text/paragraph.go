@@ -0,0 +1,93 @@
+package text
+
+import (
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+func (r *textRenderer) renderParagraph(ctx *context, p *types.Paragraph) (string, error) {
+	switch p.Attributes[types.AttrStyle] {
+	case types.Listing, types.Source, types.LiteralParagraph, types.Literal, types.Stem, types.LatexMath, types.AsciiMath:
+		return r.renderVerbatimBlock(ctx, p.Attributes, p.Elements)
+	case types.Verse:
+		content, err := r.renderInlineElements(ctx, p.Elements)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render verse paragraph")
+		}
+		return r.renderQuote(ctx, p.Attributes, verse(content))
+	case types.Quote:
+		content, err := ctx.indented(quoteIndentation, func() (string, error) {
+			return r.renderParagraphElements(ctx, p)
+		})
+		if err != nil {
+			return "", err
+		}
+		return r.renderQuote(ctx, p.Attributes, content+"\n")
+	case types.Passthrough:
+		return r.renderPassthrough(ctx, p.Elements)
+	case types.Tip, types.Note, types.Important, types.Warning, types.Caution:
+		return r.renderAdmonition(ctx, p.Attributes, func() (string, error) {
+			content, err := r.renderParagraphElements(ctx, p)
+			if err != nil {
+				return "", err
+			}
+			return content + "\n", nil
+		})
+	default:
+		title, err := r.renderElementTitle(ctx, p.Attributes)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render paragraph")
+		}
+		content, err := r.renderParagraphElements(ctx, p)
+		if err != nil {
+			return "", err
+		}
+		return title + content + "\n", nil
+	}
+}
+
+// renderParagraphElements renders the content of the paragraph, wrapped at the available width
+// and without the trailing newline
+func (r *textRenderer) renderParagraphElements(ctx *context, p *types.Paragraph) (string, error) {
+	content, err := r.renderInlineElements(ctx, p.Elements)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render paragraph content")
+	}
+	if p.Attributes.HasOption(types.AttrHardBreaks) || ctx.attributes.HasOption(types.AttrHardBreaks) {
+		content = strings.ReplaceAll(strings.Trim(content, "\n"), "\n", hardBreak)
+	}
+	return wrap(content, ctx.width), nil
+}
+
+var defaultAdmonitionCaptions = map[string]string{
+	types.Caution:   "Caution",
+	types.Important: "Important",
+	types.Note:      "Note",
+	types.Tip:       "Tip",
+	types.Warning:   "Warning",
+}
+
+// renderAdmonition renders the admonition caption, followed by the content with a hanging indent
+func (r *textRenderer) renderAdmonition(ctx *context, attrs types.Attributes, render func() (string, error)) (string, error) {
+	kind, _ := attrs.GetAsString(types.AttrStyle)
+	caption := ctx.attributes.GetAsStringWithDefault(strings.ToLower(kind)+"-caption", defaultAdmonitionCaptions[kind])
+	caption = attrs.GetAsStringWithDefault(types.AttrCaption, caption) + ": "
+	content, err := ctx.indented(textWidth(caption), func() (string, error) {
+		title, err := r.renderElementTitle(ctx, attrs)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render admonition")
+		}
+		content, err := render()
+		if err != nil {
+			return "", errors.Wrap(err, "unable to render admonition")
+		}
+		return title + content, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hanging(content, caption), nil
+}
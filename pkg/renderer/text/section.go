@@ -0,0 +1,39 @@
+package text
+
+import (
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+// underlines the characters used to underline the section titles, by level
+var underlines = []string{"=", "-", "~", "^", "+"}
+
+// renderSection renders the section title, prefixed with its number (if the sections are numbered)
+// and underlined, followed by the section elements
+func (r *textRenderer) renderSection(ctx *context, s *types.Section) (string, error) {
+	title, err := r.renderInlineElements(ctx, s.Title)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render section title")
+	}
+	if number, found := ctx.sectionNumbers[s.GetID()]; found {
+		title = number + ". " + title
+	}
+	level := s.Level
+	if level >= len(underlines) {
+		level = len(underlines) - 1
+	}
+	result := &strings.Builder{}
+	result.WriteString(heading(wrap(title, ctx.width), underlines[level]))
+	content, err := r.renderElements(ctx, s.Elements)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render section content")
+	}
+	if content != "" {
+		result.WriteString("\n")
+		result.WriteString(content)
+	}
+	return result.String(), nil
+}
@@ -0,0 +1,271 @@
+package text
+
+import (
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+// renderTable renders the table with ASCII borders, and with the content of the cells aligned
+// as specified in the column definitions
+func (r *textRenderer) renderTable(ctx *context, t *types.Table) (string, error) {
+	columns, err := t.Columns()
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render table")
+	}
+	rows := make([]*types.TableRow, 0, len(t.Rows)+2)
+	if t.Header != nil {
+		rows = append(rows, t.Header)
+	}
+	rows = append(rows, t.Rows...)
+	if t.Footer != nil {
+		rows = append(rows, t.Footer)
+	}
+	// the position of each cell, given the cells which span over several columns or rows
+	// (the header and the footer are not affected by the spans of the body)
+	positions := make([][]int, len(rows))
+	body := types.NewTableCellColumns()
+	for i, row := range rows {
+		if (i == 0 && t.Header != nil) || (i == len(rows)-1 && t.Footer != nil) {
+			positions[i] = types.NewTableCellColumns().Next(row)
+		} else {
+			positions[i] = body.Next(row)
+		}
+		// no explicit column definitions: use the number of columns covered by the cells of the rows
+		for c, cell := range row.Cells {
+			for len(columns) < positions[i][c]+cell.Colspan() {
+				columns = append(columns, &types.TableColumn{
+					HAlign: types.HAlignLeft,
+					VAlign: types.VAlignTop,
+				})
+			}
+		}
+	}
+	if len(columns) == 0 {
+		return "", nil
+	}
+	title, err := r.renderElementTitle(ctx, t.Attributes)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to render table title")
+	}
+	// first, render the cells without wrapping, to compute the natural width of each column
+	cells, widths, err := r.renderTableCells(ctx, rows, positions, make([]int, len(columns)))
+	if err != nil {
+		return "", err
+	}
+	// then, if the table is wider than the available width, reduce the columns and wrap the content of the cells
+	if borders := 3*len(columns) + 1; ctx.width > 0 && sum(widths)+borders > ctx.width {
+		wrapWidths := columnWidths(cells, widths, ctx.width-borders)
+		if cells, widths, err = r.renderTableCells(ctx, rows, positions, wrapWidths); err != nil {
+			return "", err
+		}
+	}
+	multiline := false
+	for _, row := range cells {
+		for _, cell := range row {
+			multiline = multiline || len(cell.lines) > 1
+		}
+	}
+	result := &strings.Builder{}
+	result.WriteString(title)
+	result.WriteString(tableBorder(widths, "-", nil))
+	covered := make([]int, len(columns)) // the number of rows still covered by the cells which span over several rows
+	for i, row := range cells {
+		if i > 0 {
+			switch {
+			case i == 1 && t.Header != nil:
+				result.WriteString(tableBorder(widths, "=", nil))
+			case i == len(cells)-1 && t.Footer != nil:
+				result.WriteString(tableBorder(widths, "=", nil))
+			case multiline:
+				result.WriteString(tableBorder(widths, "-", covered))
+			}
+		}
+		starts := make(map[int]*tableCell, len(row))
+		height := 1
+		for _, cell := range row {
+			starts[cell.column] = cell
+			if len(cell.lines) > height {
+				height = len(cell.lines)
+			}
+		}
+		for l := 0; l < height; l++ {
+			result.WriteString("|")
+			for c := 0; c < len(columns); {
+				cell, found := starts[c]
+				if !found {
+					// column covered by a cell of a previous row, or missing cell
+					result.WriteString(" " + strings.Repeat(" ", widths[c]) + " |")
+					c++
+					continue
+				}
+				line := ""
+				if l < len(cell.lines) {
+					line = cell.lines[l]
+				}
+				result.WriteString(" " + align(line, spanWidth(widths, cell.column, cell.colspan), columns[c].HAlign) + " |")
+				c += cell.colspan
+			}
+			result.WriteString("\n")
+		}
+		for c := range covered {
+			if covered[c] > 0 {
+				covered[c]--
+			}
+		}
+		for _, cell := range row {
+			for c := cell.column; c < cell.column+cell.colspan && c < len(covered); c++ {
+				covered[c] = cell.rowspan - 1
+			}
+		}
+	}
+	result.WriteString(tableBorder(widths, "-", nil))
+	return result.String(), nil
+}
+
+// tableCell the rendered lines of a cell, along with the columns and rows it covers
+type tableCell struct {
+	column  int // the (0-based) index of the first column covered by the cell
+	colspan int
+	rowspan int
+	lines   []string
+}
+
+// spanWidth returns the width of the given number of columns from the given column, including the borders between them
+func spanWidth(widths []int, column, colspan int) int {
+	end := column + colspan
+	if end > len(widths) {
+		end = len(widths)
+	}
+	return sum(widths[column:end]) + 3*(end-column-1)
+}
+
+// columnWidths returns the widths of the columns so that the table fits in the available width (if possible):
+// each column is at least as wide as its longest word, and the remaining space is shared between
+// the columns in proportion of the width they would need to render their content without wrapping
+func columnWidths(cells [][]*tableCell, naturalWidths []int, available int) []int {
+	widths := make([]int, len(naturalWidths))
+	for _, row := range cells {
+		for _, cell := range row {
+			if cell.colspan > 1 {
+				// the content of the cells which span over several columns is wrapped at their combined width
+				continue
+			}
+			for _, l := range cell.lines {
+				for _, w := range strings.FieldsFunc(l, isBreakingSpace) {
+					if textWidth(w) > widths[cell.column] {
+						widths[cell.column] = textWidth(w)
+					}
+				}
+			}
+		}
+	}
+	remaining := available - sum(widths)
+	extra := 0
+	for c, w := range naturalWidths {
+		extra += w - widths[c]
+	}
+	if remaining <= 0 || extra <= 0 {
+		return widths
+	}
+	for c, w := range naturalWidths {
+		widths[c] += (w - widths[c]) * remaining / extra
+	}
+	return widths
+}
+
+// renderTableCells renders the lines of each cell of the given rows, wrapped at the given column widths
+// (or not wrapped if the width is 0), and returns them along with the actual width of each column,
+// which is at least the given width. The cells which span over several columns are wrapped at the combined width
+// of these columns, and the last of these columns is widened if needed.
+func (r *textRenderer) renderTableCells(ctx *context, rows []*types.TableRow, positions [][]int, wrapWidths []int) ([][]*tableCell, []int, error) {
+	width := ctx.width
+	defer func() {
+		ctx.width = width
+	}()
+	widths := make([]int, len(wrapWidths))
+	copy(widths, wrapWidths)
+	result := make([][]*tableCell, len(rows))
+	for i, row := range rows {
+		result[i] = make([]*tableCell, len(row.Cells))
+		for c, cell := range row.Cells {
+			tc := &tableCell{
+				column:  positions[i][c],
+				colspan: cell.Colspan(),
+				rowspan: cell.Rowspan(),
+			}
+			ctx.width = 0
+			if wrapWidths[tc.column] > 0 {
+				ctx.width = spanWidth(wrapWidths, tc.column, tc.colspan)
+			}
+			content, err := r.renderElements(ctx, cell.Elements)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "unable to render table cell")
+			}
+			tc.lines = strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+			result[i][c] = tc
+		}
+	}
+	// first, the width of the cells which cover a single column, then the width of the others
+	for _, single := range []bool{true, false} {
+		for _, row := range result {
+			for _, cell := range row {
+				if (cell.colspan == 1) != single {
+					continue
+				}
+				for _, l := range cell.lines {
+					if extra := textWidth(l) - spanWidth(widths, cell.column, cell.colspan); extra > 0 {
+						last := cell.column + cell.colspan - 1
+						if last >= len(widths) {
+							last = len(widths) - 1
+						}
+						widths[last] += extra
+					}
+				}
+			}
+		}
+	}
+	return result, widths, nil
+}
+
+func sum(values []int) int {
+	result := 0
+	for _, v := range values {
+		result += v
+	}
+	return result
+}
+
+// tableBorder returns the horizontal border of a table (eg: `+-----+---+`),
+// with blanks in the columns which are still covered by a cell of the previous rows (if given)
+func tableBorder(widths []int, c string, covered []int) string {
+	result := &strings.Builder{}
+	result.WriteString("+")
+	for i, w := range widths {
+		if covered != nil && covered[i] > 0 {
+			result.WriteString(strings.Repeat(" ", w+2) + "+")
+			continue
+		}
+		result.WriteString(strings.Repeat(c, w+2) + "+")
+	}
+	result.WriteString("\n")
+	return result.String()
+}
+
+// align pads the given line to the given width, according to the given alignment
+func align(line string, width int, alignment types.HAlign) string {
+	padding := width - textWidth(line)
+	if padding <= 0 {
+		return line
+	}
+	switch alignment {
+	case types.HAlignRight:
+		return strings.Repeat(" ", padding) + line
+	case types.HAlignCenter:
+		return strings.Repeat(" ", padding/2) + line + strings.Repeat(" ", padding-padding/2)
+	default:
+		return line + strings.Repeat(" ", padding)
+	}
+}
@@ -0,0 +1,95 @@
+package text_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("tables", func() {
+
+	It("table with header and aligned columns", func() {
+		source := `.Results
+[cols="<,^,>"]
+|===
+|Name |Status |Count
+
+|foo |ok |1
+|bar baz |failed |1000
+|===`
+		expected := `Results
++---------+--------+-------+
+| Name    | Status | Count |
++=========+========+=======+
+| foo     |   ok   |     1 |
+| bar baz | failed |  1000 |
++---------+--------+-------+
+`
+		Expect(RenderText(source)).To(Equal(expected))
+	})
+
+	It("table with footer", func() {
+		source := `[%footer]
+|===
+|a |b
+|total |2
+|===`
+		expected := `+-------+---+
+| a     | b |
++=======+===+
+| total | 2 |
++-------+---+
+`
+		Expect(RenderText(source)).To(Equal(expected))
+	})
+
+	It("table wrapped at the available width", func() {
+		source := `:wrap-width: 30
+
+[cols="2,1"]
+|===
+|a cell with a very long content which must be wrapped |short
+|===`
+		expected := `+--------------------+-------+
+| a cell with a very | short |
+| long content which |       |
+| must be wrapped    |       |
++--------------------+-------+
+`
+		Expect(RenderText(source)).To(Equal(expected))
+	})
+
+	It("table with spans", func() {
+		source := `|===
+|a |b |c
+2+|a much longer spanning cell |d
+.2+|tall +
+line |e |f
+|g |h
+|===`
+		expected := `+------+----------------------+---+
+| a    | b                    | c |
++------+----------------------+---+
+| a much longer spanning cell | d |
++------+----------------------+---+
+| tall | e                    | f |
+| line |                      |   |
++      +----------------------+---+
+|      | g                    | h |
++------+----------------------+---+
+`
+		Expect(RenderText(source)).To(Equal(expected))
+	})
+
+	It("table with spans on single lines", func() {
+		source := `|===
+|a |b |c
+2+|span |d
+|===`
+		expected := `+---+---+---+
+| a | b | c |
+| span  | d |
++---+---+---+
+`
+		Expect(RenderText(source)).To(Equal(expected))
+	})
+})
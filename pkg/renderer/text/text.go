@@ -0,0 +1,65 @@
+// Package text renders documents in plain text, wrapped at the width given by the `wrap-width` attribute
+// (80 characters by default, or no wrapping at all if the width is `0`).
+package text
+
+import (
+	"io"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultWrapWidth the default maximum number of characters per line
+const DefaultWrapWidth = 80
+
+// Render renders the given document in plain text, in the given output writer
+func Render(doc *types.Document, config *configuration.Configuration, output io.Writer) (types.Metadata, error) {
+	r := &textRenderer{}
+	metadata := types.Metadata{
+		LastUpdated:     config.LastUpdated.Format(configuration.LastUpdatedFormat),
+		TableOfContents: doc.TableOfContents,
+	}
+	ctx, err := newContext(doc, config)
+	if err != nil {
+		return metadata, errors.Wrap(err, "unable to render document")
+	}
+	blocks := []string{}
+	if header, _ := doc.Header(); header != nil {
+		title, err := sgml.RenderPlainText(header.Title, sgml.WithoutEscape())
+		if err != nil {
+			return metadata, errors.Wrap(err, "unable to render document title")
+		}
+		metadata.Title = title
+		if config.WrapInHTMLBodyElement && len(header.Title) > 0 {
+			renderedTitle, err := r.renderInlineElements(ctx, header.Title)
+			if err != nil {
+				return metadata, errors.Wrap(err, "unable to render document title")
+			}
+			blocks = append(blocks, heading(wrap(renderedTitle, ctx.width), "="))
+		}
+	}
+	content, err := r.renderElements(ctx, doc.BodyElements())
+	if err != nil {
+		return metadata, errors.Wrap(err, "unable to render document")
+	}
+	if content != "" {
+		blocks = append(blocks, content)
+	}
+	footnotes, err := r.renderFootnotes(ctx, doc.Footnotes)
+	if err != nil {
+		return metadata, errors.Wrap(err, "unable to render footnotes")
+	}
+	if footnotes != "" {
+		blocks = append(blocks, footnotes)
+	}
+	if _, err := io.WriteString(output, strings.Join(blocks, "\n")); err != nil {
+		return metadata, errors.Wrap(err, "unable to render document")
+	}
+	return metadata, nil
+}
+
+type textRenderer struct{}
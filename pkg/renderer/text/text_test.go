@@ -0,0 +1,160 @@
+package text_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("text", func() {
+
+	It("document with title and numbered sections", func() {
+		source := `= Document Title
+:sectnums:
+
+preamble
+
+== Section A
+
+content
+
+=== Section A.1
+
+more content
+
+== Section B`
+		expected := `Document Title
+==============
+
+preamble
+
+1. Section A
+------------
+
+content
+
+1.1. Section A.1
+~~~~~~~~~~~~~~~~
+
+more content
+
+2. Section B
+------------
+`
+		Expect(RenderText(source, configuration.WithHeaderFooter(true))).To(Equal(expected))
+	})
+
+	It("sections without numbers", func() {
+		source := `== Section A
+
+content`
+		expected := `Section A
+---------
+
+content
+`
+		Expect(RenderText(source)).To(Equal(expected))
+	})
+
+	It("paragraph wrapped at the default width", func() {
+		source := `Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt
+ut labore et dolore magna aliqua.`
+		expected := `Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor
+incididunt ut labore et dolore magna aliqua.
+`
+		Expect(RenderText(source)).To(Equal(expected))
+	})
+
+	It("paragraph wrapped at a custom width", func() {
+		source := `:wrap-width: 20
+
+Lorem ipsum dolor sit amet, consectetur adipiscing elit.`
+		expected := `Lorem ipsum dolor
+sit amet,
+consectetur
+adipiscing elit.
+`
+		Expect(RenderText(source)).To(Equal(expected))
+	})
+
+	It("paragraph not wrapped", func() {
+		source := `Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt
+ut labore et dolore magna aliqua.`
+		expected := `Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.
+`
+		Expect(RenderText(source, configuration.WithAttribute("wrap-width", "0"))).To(Equal(expected))
+	})
+
+	It("paragraph with hard breaks", func() {
+		source := `first line +
+second line`
+		expected := `first line
+second line
+`
+		Expect(RenderText(source)).To(Equal(expected))
+	})
+
+	It("quoted text, symbols and links", func() {
+		source := `*bold*, _italic_ and ` + "`mono`" + ` (C) -- see https://example.com[the docs], https://example.org and xref:other.adoc[the other doc]`
+		expected := `bold, italic and mono © — see the docs [https://example.com],
+https://example.org and the other doc [other.adoc]
+`
+		Expect(RenderText(source)).To(Equal(expected))
+	})
+
+	It("footnotes at the end", func() {
+		source := `A statement.footnote:[a note]
+
+== Section
+
+Another statement.footnote:[another note with a very long content which needs to be wrapped on multiple lines]`
+		expected := `A statement.[1]
+
+Section
+-------
+
+Another statement.[2]
+
+Notes
+-----
+
+[1] a note
+[2] another note with a very long content which needs to be wrapped on multiple
+    lines
+`
+		Expect(RenderText(source)).To(Equal(expected))
+	})
+
+	It("admonition", func() {
+		source := `:wrap-width: 30
+
+NOTE: some content which needs to be wrapped`
+		expected := `Note: some content which needs
+      to be wrapped
+`
+		Expect(RenderText(source)).To(Equal(expected))
+	})
+
+	It("listing and quote blocks", func() {
+		source := `.Example
+----
+some  code
+  indented
+----
+
+[quote, John Doe, The Book]
+____
+some *quote*
+____`
+		expected := `Example
+    some  code
+      indented
+
+    some quote
+
+    — John Doe, The Book
+`
+		Expect(RenderText(source)).To(Equal(expected))
+	})
+})
@@ -0,0 +1,80 @@
+package text
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// hardBreak the marker of a forced line break within a paragraph, which is retained when the content is wrapped
+const hardBreak = "\u2028"
+
+// wrap reflows the given text so that the lines do not exceed the given width (if the width is positive).
+// The newlines in the text are replaced with spaces, but the hard breaks are retained.
+func wrap(text string, width int) string {
+	lines := strings.Split(text, hardBreak)
+	for i, line := range lines {
+		lines[i] = wrapLine(strings.FieldsFunc(line, isBreakingSpace), width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isBreakingSpace returns true if the given character is a space at which a line can be wrapped
+// (ie, not a non-breaking space)
+func isBreakingSpace(c rune) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func wrapLine(words []string, width int) string {
+	result := &strings.Builder{}
+	length := 0
+	for _, w := range words {
+		l := utf8.RuneCountInString(w)
+		switch {
+		case length == 0:
+			// first word on the line, even if it is longer than the width
+		case width > 0 && length+1+l > width:
+			result.WriteString("\n")
+			length = 0
+		default:
+			result.WriteString(" ")
+			length++
+		}
+		result.WriteString(w)
+		length += l
+	}
+	return result.String()
+}
+
+// indent indents all non-empty lines of the given content with the given prefix
+func indent(content, prefix string) string {
+	lines := strings.Split(content, "\n")
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = prefix + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hanging prefixes the first line of the given content with the given marker,
+// and indents the other lines to the width of the marker
+func hanging(content, marker string) string {
+	padding := strings.Repeat(" ", utf8.RuneCountInString(marker))
+	return marker + strings.TrimPrefix(indent(content, padding), padding)
+}
+
+// heading returns the given title underlined with the given character
+func heading(title, underline string) string {
+	width := 0
+	for _, l := range strings.Split(title, "\n") {
+		if w := utf8.RuneCountInString(l); w > width {
+			width = w
+		}
+	}
+	return title + "\n" + strings.Repeat(underline, width) + "\n"
+}
+
+// textWidth returns the number of characters in the given text
+func textWidth(s string) int {
+	return utf8.RuneCountInString(s)
+}
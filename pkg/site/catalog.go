@@ -0,0 +1,168 @@
+package site
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/parser"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+// entry a document of the site, along with the IDs of its elements
+type entry struct {
+	path   string                 // the path of the document, relative to the source directory (with slashes)
+	title  []interface{}          // the title of the document (nil if the document has no title)
+	labels map[string]interface{} // the default label of the cross references to the elements with an ID, indexed by ID (nil if the element has no title)
+	files  []string               // the absolute paths of the files included in the document
+}
+
+// catalog the documents of the site, and the IDs of their elements
+type catalog struct {
+	entries    map[string]*entry   // indexed by path
+	ids        map[string][]string // the paths of the pages which contain an element with the given ID, indexed by ID
+	includedIn map[string]string   // the path of the document in which each document is included (if any)
+}
+
+// newCatalog parses the given documents (whose paths are relative to the source directory),
+// and collects their titles and the IDs of their elements
+func newCatalog(sourceDir string, paths []string, settings []configuration.Setting) (*catalog, error) {
+	c := &catalog{
+		entries:    map[string]*entry{},
+		ids:        map[string][]string{},
+		includedIn: map[string]string{},
+	}
+	absPaths := map[string]string{}
+	for _, p := range paths {
+		e, err := parseEntry(sourceDir, p, settings)
+		if err != nil {
+			return nil, err
+		}
+		c.entries[p] = e
+		abs, _ := filepath.Abs(filepath.Join(sourceDir, filepath.FromSlash(p)))
+		absPaths[abs] = p
+	}
+	for _, p := range paths {
+		for _, f := range c.entries[p].files {
+			if included, found := absPaths[f]; found && included != p {
+				if _, found := c.includedIn[included]; !found {
+					c.includedIn[included] = p
+				}
+			}
+		}
+	}
+	for _, p := range paths {
+		if c.isPage(p) {
+			for id := range c.entries[p].labels {
+				c.ids[id] = append(c.ids[id], p)
+			}
+		}
+	}
+	return c, nil
+}
+
+func parseEntry(sourceDir, p string, settings []configuration.Setting) (*entry, error) {
+	filename := filepath.Join(sourceDir, filepath.FromSlash(p))
+	config := configuration.NewConfiguration(append(append([]configuration.Setting{}, settings...),
+		withOwnAttributes(),
+		configuration.WithFilename(filename),
+		// the problems are reported when the document is converted
+		configuration.WithDiagnostics(types.NewDiagnostics()))...)
+	source, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read '%s'", filename)
+	}
+	content, sources, err := parser.PreprocessWithSourceMap(strings.NewReader(string(source)), config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse '%s'", filename)
+	}
+	doc, err := parser.ParseDocument(strings.NewReader(content), config, parser.WithSourceMap(sources))
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse '%s'", filename)
+	}
+	e := &entry{
+		path:   p,
+		labels: map[string]interface{}{},
+	}
+	if header, _ := doc.Header(); header != nil {
+		e.title = header.Title
+	}
+	types.Walk(doc, func(element interface{}) bool {
+		if el, ok := element.(types.WithAttributes); ok {
+			if id, ok := el.GetAttributes()[types.AttrID].(string); ok {
+				e.labels[id] = doc.ElementReferences[id]
+			}
+		}
+		return true
+	})
+	for id, label := range doc.ElementReferences {
+		e.labels[id] = label
+	}
+	for _, f := range sources.Files() {
+		if abs, err := filepath.Abs(f); err == nil {
+			e.files = append(e.files, abs)
+		}
+	}
+	return e, nil
+}
+
+// label returns the default label of the cross references to the element with the given ID.
+// Since the IDs of the sections are rendered in lower case, the ID is also matched regardless of its case.
+func (e *entry) label(id string) (interface{}, bool) {
+	if l, found := e.labels[id]; found {
+		return l, true
+	}
+	for i, l := range e.labels {
+		if strings.EqualFold(i, id) {
+			return l, true
+		}
+	}
+	return nil, false
+}
+
+// isPage returns true if the document with the given path is rendered in a page of its own,
+// ie, if it is not included in another document
+func (c *catalog) isPage(p string) bool {
+	_, included := c.includedIn[p]
+	return !included
+}
+
+// page returns the path of the page in which the document with the given path is rendered
+func (c *catalog) page(p string) (string, bool) {
+	if _, found := c.entries[p]; !found {
+		return "", false
+	}
+	for i := 0; i < len(c.entries); i++ {
+		parent, found := c.includedIn[p]
+		if !found {
+			return p, true
+		}
+		p = parent
+	}
+	// circular inclusions
+	return "", false
+}
+
+// lookup returns the path of the page which contains the element with the given ID,
+// or `false` if there is no such page, or more than one
+func (c *catalog) lookup(id string) (string, bool) {
+	if pages := c.ids[id]; len(pages) == 1 {
+		return pages[0], true
+	}
+	return "", false
+}
+
+// title returns the title of the document with the given path in plain text, or the name of the document if it has no title
+func (c *catalog) title(p string) string {
+	if e, found := c.entries[p]; found && e.title != nil {
+		if title, err := sgml.RenderPlainText(e.title); err == nil && title != "" {
+			return title
+		}
+	}
+	return strings.TrimSuffix(path.Base(p), path.Ext(p))
+}
@@ -0,0 +1,107 @@
+package site
+
+import (
+	"path"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/parser"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+)
+
+// resolveCrossReferences returns a tree processor which resolves the cross references to the other documents
+// in the document with the given path
+func (b *builder) resolveCrossReferences(doc string) configuration.TreeProcessor {
+	return func(d *types.Document) error {
+		local := b.catalog.entries[doc].labels
+		return parser.ReplaceElements(d, func(element interface{}) (interface{}, bool, error) {
+			switch e := element.(type) {
+			case *types.InternalCrossReference:
+				id, ok := e.ID.(string)
+				if !ok {
+					return nil, false, nil
+				}
+				if _, found := local[id]; found {
+					return nil, false, nil
+				}
+				xref := &types.ExternalCrossReference{
+					Positioned: e.Positioned,
+					Attributes: types.Attributes{},
+				}
+				if e.Label != nil {
+					xref.Attributes[types.AttrXRefLabel] = e.Label
+				}
+				if p, _ := splitFragment(id); path.Ext(p) == ".adoc" {
+					// eg: `<<other.adoc#id>>`
+					xref.Location = &types.Location{
+						Path: id,
+					}
+				} else if target, found := b.catalog.lookup(id); found {
+					xref.Location = &types.Location{
+						Path: relativePath(doc, target) + "#" + id,
+					}
+				} else {
+					// unknown ID, reported when the cross references are checked
+					return nil, false, nil
+				}
+				b.resolve(doc, xref)
+				return xref, true, nil
+			case *types.ExternalCrossReference:
+				b.resolve(doc, e)
+			}
+			return nil, false, nil
+		})
+	}
+}
+
+// resolve checks that the target of the given cross reference exists, and sets its location relative to the page
+// containing the document with the given path. Also sets its label if it has none.
+func (b *builder) resolve(doc string, xref *types.ExternalCrossReference) {
+	if xref.Location == nil || xref.Location.Scheme != "" {
+		return
+	}
+	loc := xref.Location.ToString()
+	p, fragment := splitFragment(loc)
+	if path.Ext(p) != ".adoc" {
+		return
+	}
+	target, found := b.catalog.page(path.Clean(path.Join(path.Dir(doc), p)))
+	if !found {
+		b.diagnostics.Warnf(types.DiagnosticUnresolvedCrossReference, xref.SourceRange, "unable to find the document targeted by the cross reference: %s", loc)
+		return
+	}
+	var label interface{}
+	if fragment == "" {
+		if title := b.catalog.entries[target].title; title != nil {
+			label = title
+		}
+	} else {
+		l, found := b.catalog.entries[target].label(fragment)
+		if !found {
+			b.diagnostics.Warnf(types.DiagnosticUnresolvedCrossReference, xref.SourceRange, "unable to find the element targeted by the cross reference: %s", loc)
+		}
+		label = l
+	}
+	if _, found := xref.Attributes[types.AttrXRefLabel]; !found && label != nil {
+		if xref.Attributes == nil {
+			xref.Attributes = types.Attributes{}
+		}
+		xref.Attributes[types.AttrXRefLabel] = label
+	}
+	location := relativePath(doc, target)
+	if fragment != "" {
+		// same as the internal cross references
+		location += "#" + strings.ToLower(fragment)
+	}
+	xref.Location = &types.Location{
+		Path: location,
+	}
+}
+
+// splitFragment splits the given location into a path and a fragment (without the `#` character)
+func splitFragment(location string) (string, string) {
+	if i := strings.Index(location, "#"); i >= 0 {
+		return location[:i], location[i+1:]
+	}
+	return location, ""
+}
@@ -0,0 +1,48 @@
+package site
+
+import (
+	"net/url"
+	"path"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+)
+
+// collectImages returns the paths of the local images in the document, relative to the document
+// (and including the `imagesdir` prefix, if set in the given attributes or in the document)
+func collectImages(doc *types.Document, attributes types.Attributes) []string {
+	result := []string{}
+	// attributes set in the configuration take precedence over the attributes declared in the document
+	imagesdir, overridden := attributes.GetAsString(types.AttrImagesDir)
+	types.Walk(doc, func(element interface{}) bool {
+		switch e := element.(type) {
+		case *types.AttributeDeclaration:
+			if e.Name == types.AttrImagesDir && !overridden {
+				imagesdir, _ = e.Value.(string)
+			}
+		case *types.AttributeReset:
+			if e.Name == types.AttrImagesDir && !overridden {
+				imagesdir = ""
+			}
+		case *types.ImageBlock:
+			result = appendImage(result, e.Location, imagesdir)
+		case *types.InlineImage:
+			result = appendImage(result, e.Location, imagesdir)
+		}
+		return true
+	})
+	return result
+}
+
+func appendImage(images []string, location *types.Location, imagesdir string) []string {
+	if location == nil || location.Scheme != "" {
+		return images
+	}
+	p := location.ToString()
+	if u, err := url.Parse(p); err != nil || u.Scheme != "" || path.IsAbs(p) {
+		return images
+	}
+	if u, err := url.Parse(imagesdir); err != nil || u.Scheme != "" || path.IsAbs(imagesdir) {
+		return images
+	}
+	return append(images, path.Join(imagesdir, p))
+}
@@ -0,0 +1,60 @@
+package site
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// writeNavigation writes the navigation index of the given pages in the output directory,
+// and returns its path (relative to the output directory).
+// The index is written in `index.html`, unless a page already uses this name, in which case it is written in `nav.html`.
+func (b *builder) writeNavigation(pages []Page) (string, error) {
+	name := "index.html"
+	for _, p := range pages {
+		if p.Output == name {
+			name = "nav.html"
+			break
+		}
+	}
+	out := &strings.Builder{}
+	out.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"UTF-8\">\n<title>Index</title>\n</head>\n<body>\n<nav>\n<ul>\n")
+	dirs := []string{} // the directories which are currently open
+	for _, p := range pages {
+		segments := []string{}
+		if dir := path.Dir(p.Output); dir != "." {
+			segments = strings.Split(dir, "/")
+		}
+		// close the directories which do not contain the page
+		common := 0
+		for common < len(dirs) && common < len(segments) && dirs[common] == segments[common] {
+			common++
+		}
+		for i := len(dirs); i > common; i-- {
+			out.WriteString("</ul>\n</li>\n")
+		}
+		// open the directories which contain the page
+		for _, s := range segments[common:] {
+			fmt.Fprintf(out, "<li>%s/\n<ul>\n", html.EscapeString(s))
+		}
+		dirs = segments
+		fmt.Fprintf(out, "<li><a href=\"%s\">%s</a></li>\n", html.EscapeString(p.Output), p.Title)
+	}
+	for range dirs {
+		out.WriteString("</ul>\n</li>\n")
+	}
+	out.WriteString("</ul>\n</nav>\n</body>\n</html>\n")
+	filename := filepath.Join(b.outputDir, name)
+	if err := os.MkdirAll(b.outputDir, 0755); err != nil {
+		return "", errors.Wrapf(err, "unable to create '%s'", b.outputDir)
+	}
+	if err := os.WriteFile(filename, []byte(out.String()), 0644); err != nil {
+		return "", errors.Wrapf(err, "unable to write '%s'", filename)
+	}
+	return name, nil
+}
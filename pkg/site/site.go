@@ -0,0 +1,225 @@
+// Package site converts a tree of Asciidoc documents into a site of HTML pages,
+// in which the cross references between the documents are resolved.
+package site
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc"
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Page a page of the site, generated from a document
+type Page struct {
+	Source string // the path of the document, relative to the source directory
+	Output string // the path of the page, relative to the output directory
+	Title  string // the title of the document (in plain text)
+}
+
+// Result the pages of the site, and the problems found while building it
+type Result struct {
+	Pages       []Page
+	Navigation  string // the path of the navigation index, relative to the output directory
+	Images      []string
+	Diagnostics []types.Diagnostic
+}
+
+// Build converts the Asciidoc documents in the given source directory into HTML pages in the given output directory,
+// along with the images they refer to, and a navigation index of the pages.
+// The documents which are included in other documents are not converted into pages of their own.
+// The cross references to other documents (`xref:other.adoc#id[]` or `<<other.adoc#id>>`) and to the IDs
+// defined in a single other document (`<<id>>`) are resolved with a catalog of the IDs of all the documents:
+// they link to the page containing the target, and use its title as their default label.
+// The cross references whose target cannot be found are reported in the diagnostics of the result.
+func Build(sourceDir, outputDir string, settings ...configuration.Setting) (Result, error) {
+	paths, err := documents(sourceDir, outputDir)
+	if err != nil {
+		return Result{}, err
+	}
+	c, err := newCatalog(sourceDir, paths, settings)
+	if err != nil {
+		return Result{}, err
+	}
+	b := &builder{
+		sourceDir:   sourceDir,
+		outputDir:   outputDir,
+		settings:    settings,
+		catalog:     c,
+		diagnostics: types.NewDiagnostics(),
+		images:      map[string]bool{},
+	}
+	result := Result{}
+	for _, p := range paths {
+		if !c.isPage(p) {
+			log.Debugf("skipping '%s' which is included in '%s'", p, c.includedIn[p])
+			continue
+		}
+		page, err := b.convert(p)
+		if err != nil {
+			return Result{}, err
+		}
+		result.Pages = append(result.Pages, page)
+	}
+	if result.Navigation, err = b.writeNavigation(result.Pages); err != nil {
+		return Result{}, err
+	}
+	for image := range b.images {
+		result.Images = append(result.Images, image)
+	}
+	sort.Strings(result.Images)
+	result.Diagnostics = b.diagnostics.All()
+	return result, nil
+}
+
+// documents returns the paths (relative to the source directory) of the Asciidoc documents in the source directory,
+// excluding the hidden directories and the output directory
+func documents(sourceDir, outputDir string) ([]string, error) {
+	absOutputDir, err := filepath.Abs(outputDir)
+	if err != nil {
+		return nil, err
+	}
+	result := []string{}
+	err = filepath.WalkDir(sourceDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if abs, _ := filepath.Abs(p); abs == absOutputDir || (p != sourceDir && strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(p) == ".adoc" {
+			rel, err := filepath.Rel(sourceDir, p)
+			if err != nil {
+				return err
+			}
+			result = append(result, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to list the documents in '%s'", sourceDir)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+type builder struct {
+	sourceDir   string
+	outputDir   string
+	settings    []configuration.Setting
+	catalog     *catalog
+	diagnostics *types.Diagnostics
+	images      map[string]bool // the images which were copied, relative to the output directory
+}
+
+// convert converts the document with the given path into a page
+func (b *builder) convert(p string) (Page, error) {
+	page := Page{
+		Source: p,
+		Output: outputPath(p),
+		Title:  b.catalog.title(p),
+	}
+	filename := filepath.Join(b.sourceDir, filepath.FromSlash(p))
+	outname := filepath.Join(b.outputDir, filepath.FromSlash(page.Output))
+	if err := os.MkdirAll(filepath.Dir(outname), 0755); err != nil {
+		return Page{}, errors.Wrapf(err, "unable to create the directory of '%s'", outname)
+	}
+	out, err := os.Create(outname)
+	if err != nil {
+		return Page{}, errors.Wrapf(err, "unable to create '%s'", outname)
+	}
+	defer out.Close()
+	var config *configuration.Configuration
+	var images []string
+	config = configuration.NewConfiguration(append(append([]configuration.Setting{
+		configuration.WithHeaderFooter(true),
+	}, b.settings...),
+		withOwnAttributes(),
+		configuration.WithFilename(filename),
+		configuration.WithDiagnostics(b.diagnostics),
+		configuration.WithTreeProcessor(b.resolveCrossReferences(p)),
+		configuration.WithTreeProcessor(func(doc *types.Document) error {
+			images = collectImages(doc, config.Attributes)
+			return nil
+		}))...)
+	if _, err := libasciidoc.ConvertFile(out, config); err != nil {
+		return Page{}, errors.Wrapf(err, "unable to convert '%s'", filename)
+	}
+	for _, image := range images {
+		b.copyImage(p, image)
+	}
+	return page, nil
+}
+
+// withOwnAttributes gives the configuration its own copy of the attributes,
+// since the attributes declared in a document are added to the attributes of the configuration when the document is rendered
+func withOwnAttributes() configuration.Setting {
+	return func(config *configuration.Configuration) {
+		config.Attributes = config.Attributes.Clone()
+	}
+}
+
+// copyImage copies the image with the given path (relative to the document with the given path) in the output directory
+func (b *builder) copyImage(doc, image string) {
+	p := path.Clean(path.Join(path.Dir(doc), image))
+	if b.images[p] {
+		return
+	}
+	if p == ".." || strings.HasPrefix(p, "../") {
+		b.diagnostics.Warnf(types.DiagnosticImageNotFound, types.SourceRange{}, "image outside of the source directory: %s (in %s)", image, doc)
+		return
+	}
+	src := filepath.Join(b.sourceDir, filepath.FromSlash(p))
+	dst := filepath.Join(b.outputDir, filepath.FromSlash(p))
+	if err := copyFile(src, dst); err != nil {
+		b.diagnostics.Warnf(types.DiagnosticImageNotFound, types.SourceRange{}, "unable to copy image %s (in %s): %v", image, doc, err)
+		return
+	}
+	b.images[p] = true
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// outputPath returns the path of the page generated from the document with the given path
+func outputPath(p string) string {
+	return strings.TrimSuffix(p, path.Ext(p)) + ".html"
+}
+
+// relativePath returns the path of the target, relative to the directory of the given document (both paths are relative to the source directory)
+func relativePath(from, target string) string {
+	rel, err := filepath.Rel(filepath.FromSlash(path.Dir(from)), filepath.FromSlash(target))
+	if err != nil {
+		return target
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,202 @@
+package site_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/site"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("site", func() {
+
+	var sourceDir, outputDir string
+
+	BeforeEach(func() {
+		dir, err := os.MkdirTemp("", "libasciidoc-site")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+		sourceDir = filepath.Join(dir, "src")
+		outputDir = filepath.Join(dir, "out")
+		Expect(os.MkdirAll(filepath.Join(sourceDir, "guide", "images"), 0700)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(sourceDir, ".hidden"), 0700)).To(Succeed())
+		for name, content := range map[string]string{
+			"index.adoc": `= Home
+
+See xref:guide/install.adoc#_setup[] and <<guide/install.adoc#,the guide>>.
+
+Also <<usage>>.
+
+image::logo.png[]`,
+			"logo.png": "PNG",
+			"guide/install.adoc": `= Installation
+:imagesdir: images
+
+== Setup
+
+image::screenshot.png[]
+
+include::usage.adoc[]
+
+Back to xref:../index.adoc[].`,
+			"guide/usage.adoc": `[[usage]]
+== Usage Notes
+
+content`,
+			"guide/images/screenshot.png": "PNG",
+			".hidden/draft.adoc":          "= Draft",
+		} {
+			Expect(os.WriteFile(filepath.Join(sourceDir, filepath.FromSlash(name)), []byte(content), 0600)).To(Succeed())
+		}
+	})
+
+	read := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(outputDir, filepath.FromSlash(name)))
+		Expect(err).NotTo(HaveOccurred())
+		return string(content)
+	}
+
+	It("should generate the pages", func() {
+		// when
+		result, err := site.Build(sourceDir, outputDir)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Pages).To(Equal([]site.Page{
+			{
+				Source: "guide/install.adoc",
+				Output: "guide/install.html",
+				Title:  "Installation",
+			},
+			{
+				Source: "index.adoc",
+				Output: "index.html",
+				Title:  "Home",
+			},
+		}))
+		Expect(result.Diagnostics).To(BeEmpty())
+		// the included document has no page of its own, and the hidden directories are skipped
+		Expect(filepath.Join(outputDir, "guide", "usage.html")).NotTo(BeAnExistingFile())
+		Expect(filepath.Join(outputDir, ".hidden")).NotTo(BeAnExistingFile())
+	})
+
+	It("should resolve the cross references to other documents", func() {
+		// when
+		_, err := site.Build(sourceDir, outputDir)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		index := read("index.html")
+		Expect(index).To(ContainSubstring(`<a href="guide/install.html#_setup">Setup</a>`))
+		Expect(index).To(ContainSubstring(`<a href="guide/install.html">the guide</a>`))
+		// the ID is defined in a single other document
+		Expect(index).To(ContainSubstring(`<a href="guide/install.html#usage">Usage Notes</a>`))
+		Expect(read("guide/install.html")).To(ContainSubstring(`<a href="../index.html">Home</a>`))
+	})
+
+	It("should report the cross references whose target cannot be found", func() {
+		// given
+		Expect(os.WriteFile(filepath.Join(sourceDir, "broken.adoc"), []byte(`= Broken
+
+See xref:unknown.adoc[], xref:index.adoc#unknown[] and <<unknown>>.`), 0600)).To(Succeed())
+		// when
+		result, err := site.Build(sourceDir, outputDir)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		messages := []string{}
+		for _, d := range result.Diagnostics {
+			Expect(d.Code).To(Equal(types.DiagnosticUnresolvedCrossReference))
+			Expect(d.Range.Start.File).To(Equal(filepath.Join(sourceDir, "broken.adoc")))
+			messages = append(messages, d.Message)
+		}
+		Expect(messages).To(ConsistOf(
+			"unable to find the document targeted by the cross reference: unknown.adoc",
+			"unable to find the element targeted by the cross reference: index.adoc#unknown",
+			"possible invalid reference: unknown",
+		))
+	})
+
+	It("should not resolve the IDs defined in more than one other document", func() {
+		// given
+		Expect(os.WriteFile(filepath.Join(sourceDir, "other.adoc"), []byte(`= Other
+
+[[usage]]
+== Usage`), 0600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(sourceDir, "ref.adoc"), []byte(`See <<usage>>.`), 0600)).To(Succeed())
+		// when
+		result, err := site.Build(sourceDir, outputDir)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(read("ref.html")).To(ContainSubstring(`<a href="#usage">[usage]</a>`))
+		Expect(read("index.html")).To(ContainSubstring(`<a href="#usage">[usage]</a>`))
+		files := []string{}
+		for _, d := range result.Diagnostics {
+			Expect(d.Message).To(Equal("possible invalid reference: usage"))
+			files = append(files, filepath.Base(d.Range.Start.File))
+		}
+		Expect(files).To(ConsistOf("index.adoc", "ref.adoc"))
+	})
+
+	It("should copy the images", func() {
+		// when
+		result, err := site.Build(sourceDir, outputDir)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Images).To(Equal([]string{"guide/images/screenshot.png", "logo.png"}))
+		Expect(read("logo.png")).To(Equal("PNG"))
+		Expect(read("guide/images/screenshot.png")).To(Equal("PNG"))
+		Expect(read("guide/install.html")).To(ContainSubstring(`<img src="images/screenshot.png" alt="screenshot">`))
+	})
+
+	It("should report the missing images", func() {
+		// given
+		Expect(os.Remove(filepath.Join(sourceDir, "logo.png"))).To(Succeed())
+		// when
+		result, err := site.Build(sourceDir, outputDir)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Images).To(Equal([]string{"guide/images/screenshot.png"}))
+		Expect(result.Diagnostics).To(HaveLen(1))
+		Expect(result.Diagnostics[0].Code).To(Equal(types.DiagnosticImageNotFound))
+	})
+
+	It("should generate the navigation index", func() {
+		// when
+		result, err := site.Build(sourceDir, outputDir)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		// `index.html` is already used by a page
+		Expect(result.Navigation).To(Equal("nav.html"))
+		Expect(read("nav.html")).To(ContainSubstring(`<nav>
+<ul>
+<li>guide/
+<ul>
+<li><a href="guide/install.html">Installation</a></li>
+</ul>
+</li>
+<li><a href="index.html">Home</a></li>
+</ul>
+</nav>`))
+	})
+
+	It("should generate the navigation index in index.html", func() {
+		// given
+		Expect(os.Rename(filepath.Join(sourceDir, "index.adoc"), filepath.Join(sourceDir, "home.adoc"))).To(Succeed())
+		// when
+		result, err := site.Build(sourceDir, outputDir)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Navigation).To(Equal("index.html"))
+		Expect(read("index.html")).To(ContainSubstring(`<li><a href="home.html">Home</a></li>`))
+	})
+
+	It("should apply the settings", func() {
+		// when
+		_, err := site.Build(sourceDir, outputDir, configuration.WithCSS([]string{"style.css"}))
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(read("index.html")).To(ContainSubstring(`<link type="text/css" rel="stylesheet" href="style.css">`))
+	})
+})
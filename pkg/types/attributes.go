@@ -86,7 +86,7 @@ const (
 	// AttrInteractive the attribute to mark the first element of an unordered list item as n interactive checkbox or not
 	// (paired with `AttrCheckStyle`)
 	AttrInteractive = "interactive"
-	// AttrStart the `start` attribute in an ordered list
+	// AttrStart the `start` attribute in an ordered list, or the time (in seconds) at which a video or an audio starts playing
 	AttrStart = "start"
 	// AttrLevelOffset the `leveloffset` attribute used in file inclusions
 	AttrLevelOffset = "leveloffset"
@@ -134,6 +134,18 @@ const (
 	AttrCols = "cols"
 	// AttrAutoWidth the `autowidth` attribute on a table
 	AttrAutoWidth = "autowidth"
+	// AttrColspan the number of columns that a table cell spans over
+	AttrColspan = "colspan"
+	// AttrRowspan the number of rows that a table cell spans over
+	AttrRowspan = "rowspan"
+	// AttrHAlign the horizontal alignment of a table cell
+	AttrHAlign = "halign"
+	// AttrVAlign the vertical alignment of a table cell
+	AttrVAlign = "valign"
+	// AttrFormat the `format` attribute on a table (psv, csv, tsv or dsv)
+	AttrFormat = "format"
+	// AttrSeparator the `separator` attribute on a table
+	AttrSeparator = "separator"
 	// AttrPositionalIndex positional parameter index
 	AttrPositionalIndex = "@positional-"
 	// AttrPositional1 positional parameter 1
@@ -160,6 +172,14 @@ const (
 	AttrTipCaption = "tip-caption"
 	// AttrWarningCaption is the TIP caption
 	AttrWarningCaption = "warning-caption"
+	// AttrAppendixCaption is the caption of the appendix sections
+	AttrAppendixCaption = "appendix-caption"
+	// AttrChapterSignifier is the label prepended to the number of the chapters in a book
+	AttrChapterSignifier = "chapter-signifier"
+	// AttrPartSignifier is the label prepended to the number of the parts in a book
+	AttrPartSignifier = "part-signifier"
+	// AttrPartNumbering the `partnums` attribute to trigger part numbering in a book
+	AttrPartNumbering = "partnums"
 	// AttrSubstitutions the "subs" attribute to configure substitutions on delimited blocks and paragraphs
 	AttrSubstitutions = "subs"
 	// AttrImagesDir the `imagesdir` attribute
@@ -172,6 +192,32 @@ const (
 	AttrButtonLabel = "label"
 	// AttrHardBreaks the attribute to set on a paragraph to render with hard breaks on each line
 	AttrHardBreaks = "hardbreaks"
+	// AttrManName the `manname` attribute, the name of the command documented in a manpage
+	AttrManName = "manname"
+	// AttrManVolNum the `manvolnum` attribute, the section of the manual in which a manpage belongs (eg: `1`)
+	AttrManVolNum = "manvolnum"
+	// AttrManSource the `mansource` attribute, the source (eg: name and version of the software) of a manpage
+	AttrManSource = "mansource"
+	// AttrManManual the `manmanual` attribute, the title of the manual in which a manpage belongs
+	AttrManManual = "manmanual"
+	// AttrWrapWidth the `wrap-width` attribute, the maximum number of characters per line in the plain text output
+	AttrWrapWidth = "wrap-width"
+	// AttrStem the `stem` attribute, which enables the STEM support and sets the default notation (`asciimath` or `latexmath`)
+	AttrStem = "stem"
+	// AttrEquationNumbers the `eqnums` attribute, the equation numbering scheme of LaTeX math equations (eg: `AMS`)
+	AttrEquationNumbers = "eqnums"
+	// AttrPoster the `poster` attribute of a video, ie, the image to display before the video is played, or the `youtube` or `vimeo` provider
+	AttrPoster = "poster"
+	// AttrEnd the `end` attribute, the time (in seconds) at which a video or an audio stops playing
+	AttrEnd = "end"
+	// AttrTheme the `theme` attribute of a YouTube video (`dark` or `light`)
+	AttrTheme = "theme"
+	// AttrLang the `lang` attribute of a YouTube video, the language of the player interface
+	AttrLang = "lang"
+	// AttrPlaylist the `list` attribute of a YouTube video, the ID of the playlist to which the video belongs
+	AttrPlaylist = "list"
+	// AttrPreload the `preload` attribute of a video (`auto`, `metadata` or `none`)
+	AttrPreload = "preload"
 )
 
 // Attribute is a key/value pair wrapper
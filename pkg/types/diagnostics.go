@@ -0,0 +1,243 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// ------------------------------------------
+// Diagnostics
+// ------------------------------------------
+
+// Severity the severity of a diagnostic
+type Severity int
+
+const (
+	// SeverityInfo the severity of a diagnostic which is purely informative
+	SeverityInfo Severity = iota
+	// SeverityWarning the severity of a diagnostic about some content which may not be rendered as expected
+	SeverityWarning
+	// SeverityError the severity of a diagnostic about some content which could not be processed
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// MarshalText returns the name of the severity
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText parses the name of the severity
+func (s *Severity) UnmarshalText(text []byte) error {
+	v, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
+
+// ParseSeverity returns the severity with the given name (`info`, `warning` or `error`)
+func ParseSeverity(name string) (Severity, error) {
+	switch strings.ToLower(name) {
+	case "info":
+		return SeverityInfo, nil
+	case "warning", "warn":
+		return SeverityWarning, nil
+	case "error":
+		return SeverityError, nil
+	default:
+		return SeverityInfo, errors.Errorf("unknown severity: '%s'", name)
+	}
+}
+
+// the codes of the diagnostics
+const (
+	// DiagnosticParseError the code of the diagnostics about some content which could not be parsed
+	DiagnosticParseError string = "parse-error"
+	// DiagnosticUnresolvedInclude the code of the diagnostics about a file inclusion which could not be resolved
+	DiagnosticUnresolvedInclude string = "unresolved-include"
+	// DiagnosticUnclosedTag the code of the diagnostics about a tag which is not closed in a file to include
+	DiagnosticUnclosedTag string = "unclosed-tag"
+	// DiagnosticUnresolvedAttribute the code of the diagnostics about a reference to an undefined attribute
+	DiagnosticUnresolvedAttribute string = "unresolved-attribute"
+	// DiagnosticUnclosedBlock the code of the diagnostics about a delimited block which is not closed
+	DiagnosticUnclosedBlock string = "unclosed-block"
+	// DiagnosticDuplicateID the code of the diagnostics about an ID which is assigned to more than one element
+	DiagnosticDuplicateID string = "duplicate-id"
+	// DiagnosticUnresolvedCrossReference the code of the diagnostics about a cross reference to an unknown ID
+	DiagnosticUnresolvedCrossReference string = "unresolved-xref"
+	// DiagnosticUnresolvedFootnote the code of the diagnostics about a reference to an unknown footnote
+	DiagnosticUnresolvedFootnote string = "unresolved-footnote"
+	// DiagnosticUnsupportedElement the code of the diagnostics about an element which cannot be rendered as-is with the backend
+	DiagnosticUnsupportedElement string = "unsupported-element"
+	// DiagnosticInvalidDocument the code of the diagnostics about a document which does not match the structure of its doctype
+	DiagnosticInvalidDocument string = "invalid-document"
+	// DiagnosticImageNotFound the code of the diagnostics about an image which could not be read
+	DiagnosticImageNotFound string = "image-not-found"
+)
+
+// Diagnostic a problem found while processing a document
+type Diagnostic struct {
+	Severity Severity `json:"severity"`
+	Code     string   `json:"code"`
+	Message  string   `json:"message"`
+	// Range the range of the content which caused the problem (zero if unknown)
+	Range SourceRange `json:"range"`
+	// IncludedFrom the positions of the `include::` directives through which
+	// the file containing the problem was included, from the innermost to the outermost
+	IncludedFrom []SourcePosition `json:"includedFrom,omitempty"`
+}
+
+func (d Diagnostic) String() string {
+	buf := &strings.Builder{}
+	if !d.Range.IsZero() {
+		buf.WriteString(d.Range.Start.String())
+		buf.WriteString(": ")
+	}
+	fmt.Fprintf(buf, "%s: %s", d.Severity, d.Message)
+	if d.Code != "" {
+		fmt.Fprintf(buf, " [%s]", d.Code)
+	}
+	for _, p := range d.IncludedFrom {
+		fmt.Fprintf(buf, "\n    included from %s", p)
+	}
+	return buf.String()
+}
+
+// Diagnostics collects the problems found while processing a document.
+// A nil `*Diagnostics` is valid, in which case the problems are only logged.
+type Diagnostics struct {
+	mutex      *sync.Mutex
+	items      []Diagnostic
+	inclusions map[string][]SourcePosition
+}
+
+// NewDiagnostics returns a new, empty collector of diagnostics
+func NewDiagnostics() *Diagnostics {
+	return &Diagnostics{
+		mutex:      &sync.Mutex{},
+		items:      []Diagnostic{},
+		inclusions: map[string][]SourcePosition{},
+	}
+}
+
+// Report records the given diagnostic, unless the same diagnostic was already reported.
+// The chain of file inclusions is set from the file of the range, unless it was already set.
+func (d *Diagnostics) Report(diagnostic Diagnostic) {
+	if d == nil {
+		logDiagnostic(diagnostic)
+		return
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if len(diagnostic.IncludedFrom) == 0 {
+		diagnostic.IncludedFrom = d.inclusions[diagnostic.Range.Start.File]
+	}
+	for _, item := range d.items {
+		if item.Severity == diagnostic.Severity && item.Code == diagnostic.Code && item.Message == diagnostic.Message && item.Range == diagnostic.Range {
+			return
+		}
+	}
+	if log.IsLevelEnabled(log.DebugLevel) {
+		log.Debugf("reporting diagnostic: %s", diagnostic)
+	}
+	d.items = append(d.items, diagnostic)
+}
+
+// Errorf reports an error with the given code and range
+func (d *Diagnostics) Errorf(code string, r SourceRange, format string, args ...interface{}) {
+	d.Report(Diagnostic{
+		Severity: SeverityError,
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+		Range:    r,
+	})
+}
+
+// Warnf reports a warning with the given code and range
+func (d *Diagnostics) Warnf(code string, r SourceRange, format string, args ...interface{}) {
+	d.Report(Diagnostic{
+		Severity: SeverityWarning,
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+		Range:    r,
+	})
+}
+
+// RecordInclusion records the positions of the `include::` directives (from the innermost to the outermost)
+// through which the given file was included, so that the diagnostics in this file can refer to them.
+// Only the first inclusion of a file is retained.
+func (d *Diagnostics) RecordInclusion(filename string, from []SourcePosition) {
+	if d == nil {
+		return
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if _, found := d.inclusions[filename]; !found {
+		d.inclusions[filename] = from
+	}
+}
+
+// IncludedFrom returns the positions of the `include::` directives (from the innermost to the outermost)
+// through which the given file was included (or nil if the file was not included)
+func (d *Diagnostics) IncludedFrom(filename string) []SourcePosition {
+	if d == nil {
+		return nil
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.inclusions[filename]
+}
+
+// All returns all the diagnostics which were reported, in order (or nil if there is none)
+func (d *Diagnostics) All() []Diagnostic {
+	if d == nil {
+		return nil
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if len(d.items) == 0 {
+		return nil
+	}
+	result := make([]Diagnostic, len(d.items))
+	copy(result, d.items)
+	return result
+}
+
+// Has returns true if at least one diagnostic with the given severity (or higher) was reported
+func (d *Diagnostics) Has(severity Severity) bool {
+	for _, item := range d.All() {
+		if item.Severity >= severity {
+			return true
+		}
+	}
+	return false
+}
+
+func logDiagnostic(d Diagnostic) {
+	switch d.Severity {
+	case SeverityError:
+		log.Error(d.String())
+	case SeverityWarning:
+		log.Warn(d.String())
+	default:
+		log.Info(d.String())
+	}
+}
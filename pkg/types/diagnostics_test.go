@@ -0,0 +1,85 @@
+package types_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("diagnostics", func() {
+
+	position := func(file string, line int) types.SourcePosition {
+		return types.SourcePosition{
+			File:   file,
+			Line:   line,
+			Column: 1,
+		}
+	}
+
+	It("should collect diagnostics once", func() {
+		d := types.NewDiagnostics()
+		r := types.SourceRange{
+			Start: position("test.adoc", 1),
+			End:   position("test.adoc", 2),
+		}
+		d.Warnf(types.DiagnosticUnresolvedAttribute, r, "unknown attribute '%s'", "foo")
+		d.Warnf(types.DiagnosticUnresolvedAttribute, r, "unknown attribute '%s'", "foo")
+		d.Errorf(types.DiagnosticParseError, types.SourceRange{}, "oops")
+		Expect(d.All()).To(Equal([]types.Diagnostic{
+			{
+				Severity: types.SeverityWarning,
+				Code:     types.DiagnosticUnresolvedAttribute,
+				Message:  "unknown attribute 'foo'",
+				Range:    r,
+			},
+			{
+				Severity: types.SeverityError,
+				Code:     types.DiagnosticParseError,
+				Message:  "oops",
+			},
+		}))
+		Expect(d.Has(types.SeverityWarning)).To(BeTrue())
+		Expect(d.Has(types.SeverityError)).To(BeTrue())
+	})
+
+	It("should set the chain of inclusions", func() {
+		d := types.NewDiagnostics()
+		d.RecordInclusion("grandchild.adoc", []types.SourcePosition{
+			position("child.adoc", 3),
+			position("main.adoc", 5),
+		})
+		d.Warnf(types.DiagnosticUnresolvedCrossReference, types.SourceRange{
+			Start: position("grandchild.adoc", 2),
+			End:   position("grandchild.adoc", 2),
+		}, "possible invalid reference: foo")
+		Expect(d.All()).To(HaveLen(1))
+		Expect(d.All()[0].String()).To(Equal(`grandchild.adoc:2:1: warning: possible invalid reference: foo [unresolved-xref]
+    included from child.adoc:3:1
+    included from main.adoc:5:1`))
+	})
+
+	It("should support nil collector", func() {
+		var d *types.Diagnostics
+		d.Warnf(types.DiagnosticUnresolvedAttribute, types.SourceRange{}, "unknown attribute")
+		Expect(d.All()).To(BeNil())
+		Expect(d.Has(types.SeverityInfo)).To(BeFalse())
+	})
+
+	DescribeTable("severities",
+		func(name string, expected types.Severity) {
+			s, err := types.ParseSeverity(name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s).To(Equal(expected))
+			Expect(s.String()).To(Equal(expected.String()))
+		},
+		Entry("info", "info", types.SeverityInfo),
+		Entry("warning", "warning", types.SeverityWarning),
+		Entry("warn", "WARN", types.SeverityWarning),
+		Entry("error", "error", types.SeverityError),
+	)
+
+	It("should not parse unknown severity", func() {
+		_, err := types.ParseSeverity("fatal")
+		Expect(err).To(MatchError("unknown severity: 'fatal'"))
+	})
+})
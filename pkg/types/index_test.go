@@ -0,0 +1,114 @@
+package types_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("document index", func() {
+
+	It("should sort and group entries by letter", func() {
+		// given
+		index := types.NewIndex()
+		for _, t := range []interface{}{
+			&types.IndexTerm{
+				Term: []interface{}{
+					&types.StringElement{Content: "banana"},
+				},
+			},
+			&types.ConcealedIndexTerm{
+				Term1: "Apple",
+				Term2: "red",
+			},
+			&types.ConcealedIndexTerm{
+				Term1: "apricot",
+			},
+			&types.ConcealedIndexTerm{
+				Term1: "Apple",
+				Term2: "green ",
+			},
+			&types.ConcealedIndexTerm{
+				Term1: "2nd",
+			},
+		} {
+			index.Reference(t, nil)
+		}
+
+		// when
+		categories := index.Categories()
+
+		// then
+		Expect(categories).To(HaveLen(3))
+		Expect(categories[0].Letter).To(Equal("#"))
+		Expect(keys(categories[0].Entries)).To(Equal([]string{"2nd"}))
+		Expect(categories[1].Letter).To(Equal("A"))
+		Expect(keys(categories[1].Entries)).To(Equal([]string{"Apple", "apricot"}))
+		Expect(keys(categories[1].Entries[0].Entries)).To(Equal([]string{"green", "red"}))
+		Expect(categories[1].Entries[0].Entries[0].Occurrences).To(Equal([]*types.IndexOccurrence{
+			{
+				ID: "_indexterm_4",
+			},
+		}))
+		Expect(categories[2].Letter).To(Equal("B"))
+		Expect(keys(categories[2].Entries)).To(Equal([]string{"banana"}))
+	})
+
+	It("should group the terms regardless of their case", func() {
+		// given
+		index := types.NewIndex()
+		title := []interface{}{
+			&types.StringElement{Content: "Fruits"},
+		}
+		for _, t := range []interface{}{
+			&types.IndexTerm{
+				Term: []interface{}{
+					&types.StringElement{Content: "Apple"},
+				},
+			},
+			&types.ConcealedIndexTerm{
+				Term1: "apple",
+				Term2: "Green",
+			},
+			&types.ConcealedIndexTerm{
+				Term1: "APPLE",
+				Term2: "green",
+			},
+		} {
+			index.Reference(t, title)
+		}
+
+		// when
+		categories := index.Categories()
+
+		// then
+		Expect(categories).To(HaveLen(1))
+		Expect(keys(categories[0].Entries)).To(Equal([]string{"Apple"}))
+		Expect(categories[0].Entries[0].Occurrences).To(Equal([]*types.IndexOccurrence{
+			{
+				ID:    "_indexterm_1",
+				Title: title,
+			},
+		}))
+		Expect(keys(categories[0].Entries[0].Entries)).To(Equal([]string{"Green"}))
+		Expect(categories[0].Entries[0].Entries[0].Occurrences).To(Equal([]*types.IndexOccurrence{
+			{
+				ID:    "_indexterm_2",
+				Title: title,
+			},
+			{
+				ID:    "_indexterm_3",
+				Title: title,
+			},
+		}))
+	})
+})
+
+func keys(entries []*types.IndexEntry) []string {
+	result := make([]string, len(entries))
+	for i, e := range entries {
+		result[i] = e.Key()
+	}
+	return result
+}
@@ -34,6 +34,11 @@ var predefinedAttributes = []string{
 	"cpp",
 }
 
+// PredefinedAttributes returns the names of the predefined document attributes
+func PredefinedAttributes() []string {
+	return append([]string{}, predefinedAttributes...)
+}
+
 func isPrefedinedAttribute(a string) bool {
 	for _, v := range predefinedAttributes {
 		if v == a {
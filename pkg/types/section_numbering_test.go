@@ -259,4 +259,173 @@ var _ = Describe("section numbering", func() {
 		Expect(n["_introduction"]).To(Equal("1.1"))
 		Expect(n["_download_and_install"]).To(Equal("1.2"))
 	})
+
+	Context("in a book", func() {
+
+		It("should number chapters across parts and appendices with letters", func() {
+			// given
+			doc := &types.Document{
+				Elements: []interface{}{
+					&types.DocumentHeader{
+						Elements: []interface{}{
+							&types.AttributeDeclaration{
+								Name:  types.AttrDocType,
+								Value: "book",
+							},
+							&types.AttributeDeclaration{
+								Name: types.AttrSectionNumbering,
+							},
+						},
+					},
+					&types.Section{
+						Level: 1,
+						Attributes: types.Attributes{
+							types.AttrID:    "_preface",
+							types.AttrStyle: "preface",
+						},
+					},
+					&types.Section{
+						Level: 0,
+						Attributes: types.Attributes{
+							types.AttrID: "_part_1",
+						},
+						Elements: []interface{}{
+							&types.Section{
+								Level: 1,
+								Attributes: types.Attributes{
+									types.AttrID: "_chapter_1",
+								},
+								Elements: []interface{}{
+									&types.Section{
+										Level: 2,
+										Attributes: types.Attributes{
+											types.AttrID: "_section_1_1",
+										},
+									},
+								},
+							},
+						},
+					},
+					&types.Section{
+						Level: 0,
+						Attributes: types.Attributes{
+							types.AttrID: "_part_2",
+						},
+						Elements: []interface{}{
+							&types.Section{
+								Level: 1,
+								Attributes: types.Attributes{
+									types.AttrID: "_chapter_2",
+								},
+							},
+							&types.Section{
+								Level: 1,
+								Attributes: types.Attributes{
+									types.AttrID:    "_appendix_a",
+									types.AttrStyle: "appendix",
+								},
+								Elements: []interface{}{
+									&types.Section{
+										Level: 2,
+										Attributes: types.Attributes{
+											types.AttrID: "_appendix_section",
+										},
+									},
+								},
+							},
+							&types.Section{
+								Level: 1,
+								Attributes: types.Attributes{
+									types.AttrID:    "_appendix_b",
+									types.AttrStyle: "appendix",
+								},
+							},
+							&types.Section{
+								Level: 1,
+								Attributes: types.Attributes{
+									types.AttrID:    "_glossary",
+									types.AttrStyle: "glossary",
+								},
+							},
+						},
+					},
+				},
+			}
+			// when
+			n, err := doc.SectionNumbers()
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(types.SectionNumbers{
+				"_chapter_1":        "1",
+				"_section_1_1":      "1.1",
+				"_chapter_2":        "2",
+				"_appendix_a":       "A",
+				"_appendix_section": "A.1",
+				"_appendix_b":       "B",
+			}))
+		})
+
+		It("should number parts with roman numerals when enabled", func() {
+			// given
+			doc := &types.Document{
+				Elements: []interface{}{
+					&types.DocumentHeader{
+						Elements: []interface{}{
+							&types.AttributeDeclaration{
+								Name:  types.AttrDocType,
+								Value: "book",
+							},
+							&types.AttributeDeclaration{
+								Name: types.AttrPartNumbering,
+							},
+						},
+					},
+					&types.Section{
+						Level: 0,
+						Attributes: types.Attributes{
+							types.AttrID: "_part_1",
+						},
+						Elements: []interface{}{
+							&types.Section{
+								Level: 1,
+								Attributes: types.Attributes{
+									types.AttrID: "_chapter_1",
+								},
+							},
+						},
+					},
+					&types.Section{
+						Level: 0,
+						Attributes: types.Attributes{
+							types.AttrID: "_part_2",
+						},
+					},
+					&types.Section{
+						Level: 0,
+						Attributes: types.Attributes{
+							types.AttrID: "_part_3",
+						},
+					},
+					&types.Section{
+						Level: 0,
+						Attributes: types.Attributes{
+							types.AttrID: "_part_4",
+						},
+					},
+				},
+			}
+			// when
+			n, err := doc.SectionNumbers()
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(types.SectionNumbers{
+				"_part_1": "I",
+				"_part_2": "II",
+				"_part_3": "III",
+				"_part_4": "IV",
+			}))
+		})
+	})
 })
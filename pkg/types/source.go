@@ -0,0 +1,380 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// ------------------------------------------
+// Source positions
+// ------------------------------------------
+
+// SourcePosition a position in a source file
+type SourcePosition struct {
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line"`   // 1-based
+	Column int    `json:"column"` // 1-based, counted in characters (not bytes)
+}
+
+// IsZero returns true if the position is unknown
+func (p SourcePosition) IsZero() bool {
+	return p.Line == 0
+}
+
+func (p SourcePosition) String() string {
+	if p.File == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// SourceRange the range of an element in a source file,
+// from its start position (inclusive) to its end position (exclusive)
+type SourceRange struct {
+	Start SourcePosition `json:"start"`
+	End   SourcePosition `json:"end"`
+}
+
+// IsZero returns true if the range is unknown
+func (r SourceRange) IsZero() bool {
+	return r.Start.IsZero()
+}
+
+// Contains returns true if the given position is within this range
+func (r SourceRange) Contains(p SourcePosition) bool {
+	if r.IsZero() || p.File != r.Start.File {
+		return false
+	}
+	return !p.before(r.Start) && (r.End.IsZero() || p.before(r.End))
+}
+
+func (p SourcePosition) before(other SourcePosition) bool {
+	return p.Line < other.Line || (p.Line == other.Line && p.Column < other.Column)
+}
+
+func (r SourceRange) String() string {
+	if r.IsZero() {
+		return "<unknown>"
+	}
+	return fmt.Sprintf("%s-%d:%d", r.Start, r.End.Line, r.End.Column)
+}
+
+// WithSourceRange the interface for the elements which carry their range in the source
+type WithSourceRange interface {
+	GetSourceRange() SourceRange
+	SetSourceRange(SourceRange)
+}
+
+// Positioned the range of an element in the source, to embed in the element types
+// so that they implement the `WithSourceRange` interface
+type Positioned struct {
+	SourceRange SourceRange
+}
+
+// GetSourceRange returns the range of the element in the source
+func (p *Positioned) GetSourceRange() SourceRange {
+	return p.SourceRange
+}
+
+// SetSourceRange sets the range of the element in the source
+func (p *Positioned) SetSourceRange(r SourceRange) {
+	p.SourceRange = r
+}
+
+// ExtendSourceRange extends the range of the element up to the end of the given (child) element,
+// if both elements are in the same file. Sets the range of the element if it was unknown.
+func (p *Positioned) ExtendSourceRange(element interface{}) {
+	r, ok := SourceRangeOf(element)
+	if !ok {
+		return
+	}
+	if p.SourceRange.IsZero() {
+		p.SourceRange = r
+		return
+	}
+	if r.End.File == p.SourceRange.Start.File && p.SourceRange.End.before(r.End) {
+		p.SourceRange.End = r.End
+	}
+}
+
+// SourceRangeOf returns the range of the given element in the source,
+// or `false` if the element does not carry its range or if the range is unknown
+func SourceRangeOf(element interface{}) (SourceRange, bool) {
+	if e, ok := element.(WithSourceRange); ok {
+		r := e.GetSourceRange()
+		return r, !r.IsZero()
+	}
+	return SourceRange{}, false
+}
+
+// SourceRangeOfElements returns the range which spans from the start
+// of the first element to the end of the last element (whose ranges are known)
+func SourceRangeOfElements(elements []interface{}) (SourceRange, bool) {
+	var result SourceRange
+	for _, e := range elements {
+		if r, ok := SourceRangeOf(e); ok {
+			if result.IsZero() {
+				result.Start = r.Start
+			}
+			if r.End.File == result.Start.File {
+				result.End = r.End
+			}
+		}
+	}
+	return result, !result.IsZero()
+}
+
+// SetSourceRangeOfElements sets the range of the given element to span over its child elements,
+// unless the range of the element is already known
+func SetSourceRangeOfElements(element WithSourceRange, elements []interface{}) {
+	if !element.GetSourceRange().IsZero() {
+		return
+	}
+	if r, ok := SourceRangeOfElements(elements); ok {
+		element.SetSourceRange(r)
+	}
+}
+
+// FillSourceRanges sets the range of the `StringElement`s which have none, given the range of all the elements:
+// each of these `StringElement`s spans from the end of the previous element to the start of the next one,
+// since their content was merged from the text in between.
+func FillSourceRanges(r SourceRange, elements []interface{}) {
+	if r.IsZero() {
+		return
+	}
+	start := r.Start
+	for i, e := range elements {
+		if er, ok := SourceRangeOf(e); ok {
+			start = er.End
+			continue
+		}
+		s, ok := e.(*StringElement)
+		if !ok || start.IsZero() {
+			start = SourcePosition{} // unknown until the next element with a range
+			continue
+		}
+		end := r.End
+		for _, next := range elements[i+1:] {
+			if nr, ok := SourceRangeOf(next); ok {
+				end = nr.Start
+				break
+			}
+		}
+		if end.File != start.File || end.before(start) {
+			start = SourcePosition{}
+			continue
+		}
+		s.SetSourceRange(SourceRange{
+			Start: start,
+			End:   end,
+		})
+		start = end
+	}
+}
+
+// ------------------------------------------
+// Source map
+// ------------------------------------------
+
+// SourceMap maps the offsets in a content derived from one or more source files
+// (eg: the preprocessed document, in which the files to include have been inlined)
+// to their position in these source files.
+type SourceMap struct {
+	content  []byte
+	segments []sourceSegment
+}
+
+// sourceSegment a portion of the content, which starts at the given offset,
+// and which comes from the given position in a source file (unless the position is zero)
+type sourceSegment struct {
+	offset int
+	source SourcePosition
+}
+
+// NewSourceMap returns a new, empty SourceMap for the given content
+func NewSourceMap(content []byte) *SourceMap {
+	return &SourceMap{
+		content:  content,
+		segments: []sourceSegment{},
+	}
+}
+
+// NewIdentitySourceMap returns a new SourceMap in which each line of the content
+// maps to the same line of the given file
+func NewIdentitySourceMap(filename string, content []byte) *SourceMap {
+	m := NewSourceMap(content)
+	line := 1
+	m.Add(0, SourcePosition{
+		File:   filename,
+		Line:   line,
+		Column: 1,
+	})
+	for i, b := range content {
+		if b == '\n' {
+			line++
+			m.Add(i+1, SourcePosition{
+				File:   filename,
+				Line:   line,
+				Column: 1,
+			})
+		}
+	}
+	return m
+}
+
+// SetContent sets the content of the map
+func (m *SourceMap) SetContent(content []byte) {
+	m.content = content
+}
+
+// Matches returns true if the content of this map is the same as the given content
+func (m *SourceMap) Matches(content []byte) bool {
+	return bytes.Equal(m.content, content)
+}
+
+// Covers returns true if the given text is the portion of the content of this map which starts at the given offset
+// (ie, the text is a slice of the same underlying data)
+func (m *SourceMap) Covers(offset int, text []byte) bool {
+	if len(text) == 0 || offset < 0 || offset+len(text) > len(m.content) {
+		return false
+	}
+	return &m.content[offset] == &text[0]
+}
+
+// Add records that the content starting at the given offset comes from the given position in a source file,
+// or from an unknown position if the source position is zero.
+// Offsets must be added in increasing order.
+func (m *SourceMap) Add(offset int, source SourcePosition) {
+	if l := len(m.segments); l > 0 && m.segments[l-1].offset == offset {
+		// override the previous segment, which was empty
+		m.segments[l-1].source = source
+		return
+	}
+	m.segments = append(m.segments, sourceSegment{
+		offset: offset,
+		source: source,
+	})
+}
+
+// Append appends the segments of the other map, whose content starts at the given offset in the content of this map.
+func (m *SourceMap) Append(offset int, other *SourceMap) {
+	for _, s := range other.segments {
+		m.Add(offset+s.offset, s.source)
+	}
+}
+
+// Files returns the names of the source files from which the content comes, in the order in which they first appear
+func (m *SourceMap) Files() []string {
+	if m == nil {
+		return nil
+	}
+	result := []string{}
+	found := map[string]bool{}
+	for _, s := range m.segments {
+		if s.source.IsZero() || s.source.File == "" || found[s.source.File] {
+			continue
+		}
+		found[s.source.File] = true
+		result = append(result, s.source.File)
+	}
+	return result
+}
+
+// Position returns the position in a source file of the content at the given offset,
+// or a zero position if it is unknown.
+func (m *SourceMap) Position(offset int) SourcePosition {
+	if m == nil {
+		return SourcePosition{}
+	}
+	// find the last segment which starts at or before the offset
+	i := sort.Search(len(m.segments), func(i int) bool {
+		return m.segments[i].offset > offset
+	}) - 1
+	if i < 0 || m.segments[i].source.IsZero() {
+		return SourcePosition{}
+	}
+	s := m.segments[i]
+	p := s.source
+	// move forward within the segment
+	end := min(offset, len(m.content))
+	if s.offset > end {
+		return p
+	}
+	for _, r := range string(m.content[s.offset:end]) {
+		if r == '\n' {
+			p.Line++
+			p.Column = 1
+			continue
+		}
+		p.Column++
+	}
+	return p
+}
+
+// Lines returns the position in a source file of the start of each line of the content
+// (or a zero position if it is unknown)
+func (m *SourceMap) Lines() []SourcePosition {
+	if m == nil {
+		return nil
+	}
+	result := []SourcePosition{}
+	s := -1 // the index of the segment in which the current line starts
+	var p SourcePosition
+	for start := 0; ; {
+		if s+1 < len(m.segments) && m.segments[s+1].offset <= start {
+			for s+1 < len(m.segments) && m.segments[s+1].offset <= start {
+				s++
+			}
+			p = m.Position(start)
+		}
+		result = append(result, p)
+		i := bytes.IndexByte(m.content[start:], '\n')
+		if i < 0 {
+			return result
+		}
+		start += i + 1
+		if !p.IsZero() {
+			p.Line++
+			p.Column = 1
+		}
+	}
+}
+
+// Range returns the range in a source file of the content between the given start (inclusive) and end (exclusive) offsets,
+// or a zero range if it is unknown
+func (m *SourceMap) Range(start, end int) SourceRange {
+	r := SourceRange{
+		Start: m.Position(start),
+	}
+	if r.Start.IsZero() {
+		return SourceRange{}
+	}
+	end = min(end, len(m.content))
+	// exclude the trailing newline, unless there is nothing else
+	for end > start+1 && (m.content[end-1] == '\n' || m.content[end-1] == '\r') {
+		end--
+	}
+	if end > start {
+		// the end of the range is the position after the last character
+		// (within the same file as the start)
+		_, w := utf8.DecodeLastRune(m.content[start:end])
+		last := m.Position(end - w)
+		if last.File == r.Start.File {
+			last.Column++
+			r.End = last
+		}
+	}
+	if r.End.IsZero() {
+		r.End = r.Start
+	}
+	return r
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
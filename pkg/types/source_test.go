@@ -0,0 +1,178 @@
+package types_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("source maps", func() {
+
+	Context("identity", func() {
+
+		m := types.NewIdentitySourceMap("test.adoc", []byte("hello\nwörld\n"))
+
+		It("should return position on first line", func() {
+			Expect(m.Position(2)).To(Equal(types.SourcePosition{
+				File:   "test.adoc",
+				Line:   1,
+				Column: 3,
+			}))
+		})
+
+		It("should return position with multibyte characters", func() {
+			Expect(m.Position(len("hello\nwö"))).To(Equal(types.SourcePosition{
+				File:   "test.adoc",
+				Line:   2,
+				Column: 3,
+			}))
+		})
+
+		It("should return range without trailing newline", func() {
+			Expect(m.Range(6, len("hello\nwörld\n"))).To(Equal(types.SourceRange{
+				Start: types.SourcePosition{
+					File:   "test.adoc",
+					Line:   2,
+					Column: 1,
+				},
+				End: types.SourcePosition{
+					File:   "test.adoc",
+					Line:   2,
+					Column: 6,
+				},
+			}))
+		})
+	})
+
+	Context("with segments", func() {
+
+		// content of `main.adoc`, in which line 3 of `other.adoc` was included at line 2
+		content := []byte("first\nincluded\nlast")
+		m := types.NewSourceMap(content)
+		m.Add(0, types.SourcePosition{File: "main.adoc", Line: 1, Column: 1})
+		m.Add(6, types.SourcePosition{File: "other.adoc", Line: 3, Column: 1})
+		m.Add(15, types.SourcePosition{File: "main.adoc", Line: 3, Column: 1})
+		m.Add(17, types.SourcePosition{}) // unknown
+
+		It("should return position in included file", func() {
+			Expect(m.Position(8)).To(Equal(types.SourcePosition{
+				File:   "other.adoc",
+				Line:   3,
+				Column: 3,
+			}))
+		})
+
+		It("should return position after included file", func() {
+			Expect(m.Position(16)).To(Equal(types.SourcePosition{
+				File:   "main.adoc",
+				Line:   3,
+				Column: 2,
+			}))
+		})
+
+		It("should return unknown position", func() {
+			Expect(m.Position(18).IsZero()).To(BeTrue())
+		})
+
+		It("should return positions of lines", func() {
+			Expect(m.Lines()).To(Equal([]types.SourcePosition{
+				{File: "main.adoc", Line: 1, Column: 1},
+				{File: "other.adoc", Line: 3, Column: 1},
+				{File: "main.adoc", Line: 3, Column: 1},
+			}))
+		})
+
+		It("should return range within the same file only", func() {
+			Expect(m.Range(0, 10)).To(Equal(types.SourceRange{
+				Start: types.SourcePosition{
+					File:   "main.adoc",
+					Line:   1,
+					Column: 1,
+				},
+				End: types.SourcePosition{
+					File:   "main.adoc",
+					Line:   1,
+					Column: 1,
+				},
+			}))
+		})
+	})
+})
+
+var _ = Describe("source ranges", func() {
+
+	position := func(line, column int) types.SourcePosition {
+		return types.SourcePosition{
+			File:   "test.adoc",
+			Line:   line,
+			Column: column,
+		}
+	}
+
+	It("should contain position", func() {
+		r := types.SourceRange{
+			Start: position(1, 3),
+			End:   position(2, 5),
+		}
+		Expect(r.Contains(position(1, 3))).To(BeTrue())
+		Expect(r.Contains(position(2, 1))).To(BeTrue())
+		Expect(r.Contains(position(2, 5))).To(BeFalse())
+		Expect(r.Contains(position(1, 1))).To(BeFalse())
+	})
+
+	It("should fill ranges of merged string elements", func() {
+		elements := []interface{}{
+			&types.StringElement{
+				Content: "some ",
+			},
+			&types.QuotedText{
+				Positioned: types.Positioned{
+					SourceRange: types.SourceRange{
+						Start: position(1, 6),
+						End:   position(1, 12),
+					},
+				},
+				Kind: types.SingleQuoteBold,
+			},
+			&types.StringElement{
+				Content: " content",
+			},
+		}
+		types.FillSourceRanges(types.SourceRange{
+			Start: position(1, 1),
+			End:   position(1, 20),
+		}, elements)
+		Expect(elements[0].(*types.StringElement).SourceRange).To(Equal(types.SourceRange{
+			Start: position(1, 1),
+			End:   position(1, 6),
+		}))
+		Expect(elements[2].(*types.StringElement).SourceRange).To(Equal(types.SourceRange{
+			Start: position(1, 12),
+			End:   position(1, 20),
+		}))
+	})
+
+	It("should extend range to child element", func() {
+		s := &types.Section{
+			Positioned: types.Positioned{
+				SourceRange: types.SourceRange{
+					Start: position(1, 1),
+					End:   position(1, 10),
+				},
+			},
+		}
+		err := s.AddElement(&types.Paragraph{
+			Positioned: types.Positioned{
+				SourceRange: types.SourceRange{
+					Start: position(3, 1),
+					End:   position(4, 8),
+				},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.GetSourceRange()).To(Equal(types.SourceRange{
+			Start: position(1, 1),
+			End:   position(4, 8),
+		}))
+	})
+})
@@ -1,12 +1,15 @@
 package types
 
 import (
+	"encoding/csv"
 	"fmt"
-	"math"
+	"io"
 	"net/url"
 	"sort"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/pkg/errors"
@@ -94,6 +97,7 @@ type Document struct {
 	ElementReferences ElementReferences
 	Footnotes         []*Footnote
 	TableOfContents   *TableOfContents
+	Index             *Index
 }
 
 // FrontMatter returns the FrontMatter element if it is in the first position
@@ -150,51 +154,122 @@ func (d *Document) AddElement(element interface{}) error {
 
 type SectionNumbers map[string]string // assigned number by section id
 
+// SectionNumbers returns the numbers assigned to the sections of the document.
+// Sections are numbered when the `sectnums` (or `numbered`) attribute is set.
+// In a book, chapters are numbered continuously across parts, parts are only numbered (with roman numerals)
+// when the `partnums` attribute is set and special sections are not numbered.
+// Appendices are always numbered, with letters.
 func (d *Document) SectionNumbers() (SectionNumbers, error) {
-	enabled := false
+	n := &sectionNumbering{
+		numbers: SectionNumbers{},
+	}
 	if header, _ := d.Header(); header != nil {
-		// lookup the `sectnums` or `numbered` attribute in the header
-		var err error
-		if _, enabled, err = traverseElements(header.Elements, false, ""); err != nil {
-			return nil, err
-		}
+		// lookup the `sectnums`, `numbered`, `partnums` and `doctype` attributes in the header
+		n.traverse(header.Elements, "")
 	}
-	numbers, _, err := traverseElements(d.Elements, enabled, "") // disabled by default
-	return numbers, err
+	n.traverse(d.Elements, "") // disabled by default
+	return n.numbers, nil
 }
 
-func traverseElements(elements []interface{}, enabled bool, prefix string) (map[string]string, bool, error) {
-	result := map[string]string{}
+type sectionNumbering struct {
+	numbers    SectionNumbers
+	enabled    bool
+	partnums   bool
+	doctype    string
+	parts      int
+	chapters   int
+	appendices int
+}
+
+func (n *sectionNumbering) traverse(elements []interface{}, prefix string) {
 	counter := 0
 	for _, e := range elements {
 		switch e := e.(type) {
 		case *AttributeDeclaration:
-			if e.Name == AttrSectionNumbering || e.Name == AttrNumbered {
-				enabled = true
+			switch e.Name {
+			case AttrSectionNumbering, AttrNumbered:
+				n.enabled = true
+			case AttrPartNumbering:
+				n.partnums = true
+			case AttrDocType:
+				n.doctype = stringify(e.Value)
 			}
 		case *AttributeReset:
-			if e.Name == AttrSectionNumbering || e.Name == AttrNumbered {
-				enabled = false
+			switch e.Name {
+			case AttrSectionNumbering, AttrNumbered:
+				n.enabled = false
+			case AttrPartNumbering:
+				n.partnums = false
 			}
 		case *Section:
-			var n string
-			if enabled {
-				id := e.GetID()
-				counter++
-				n = prefix + strconv.Itoa(counter)
-				result[id] = n
+			var number string
+			switch e.Kind(n.doctype) {
+			case AppendixKind:
+				n.appendices++
+				number = appendixNumber(n.appendices)
+			case PartKind:
+				if n.partnums {
+					n.parts++
+					number = romanNumber(n.parts)
+				}
+			case ChapterKind:
+				if n.enabled {
+					n.chapters++
+					number = strconv.Itoa(n.chapters)
+				}
+			case SectionKind:
+				if n.enabled {
+					counter++
+					number = prefix + strconv.Itoa(counter)
+				}
 			}
-			numbers, en, err := traverseElements(e.Elements, enabled, n+".")
-			if err != nil {
-				return nil, false, err
+			if number != "" {
+				n.numbers[e.GetID()] = number
 			}
-			enabled = en
-			for id, n := range numbers {
-				result[id] = n
+			switch {
+			case e.Level == 0 && n.doctype == Book:
+				// chapters within a part are not prefixed with the part number
+				n.traverse(e.Elements, "")
+			case number == "" && e.IsSpecial():
+				// subsections of an unnumbered special section are not numbered either
+				enabled := n.enabled
+				n.enabled = false
+				n.traverse(e.Elements, "")
+				n.enabled = enabled
+			default:
+				n.traverse(e.Elements, number+".")
 			}
 		}
 	}
-	return result, enabled, nil
+}
+
+// appendixNumber returns the letter(s) for the given appendix number (`A`, `B`, ..., `Z`, `AA`, etc.)
+func appendixNumber(i int) string {
+	result := ""
+	for ; i > 0; i = (i - 1) / 26 {
+		result = string(rune('A'+(i-1)%26)) + result
+	}
+	return result
+}
+
+var romanNumerals = []struct {
+	value  int
+	symbol string
+}{
+	{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"},
+	{100, "C"}, {90, "XC"}, {50, "L"}, {40, "XL"},
+	{10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+}
+
+// romanNumber returns the given number in roman numerals
+func romanNumber(i int) string {
+	result := strings.Builder{}
+	for _, n := range romanNumerals {
+		for ; i >= n.value; i -= n.value {
+			result.WriteString(n.symbol)
+		}
+	}
+	return result.String()
 }
 
 // ------------------------------------------
@@ -208,6 +283,8 @@ type Metadata struct {
 	TableOfContents *TableOfContents
 	Authors         []*DocumentAuthor
 	Revision        DocumentRevision
+	Diagnostics     []Diagnostic // the problems found while processing the document
+	Files           []string     // the files from which the document was read (ie, the document itself and the included files)
 }
 
 func NewTableOfContents(maxDepth int) *TableOfContents {
@@ -247,8 +324,8 @@ func (t *TableOfContents) Add(s *Section) {
 	if len(t.Sections) == 0 {
 		t.Sections = []*ToCSection{ts}
 		return
-	} else if s.Level == t.Sections[0].Level {
-		// add at top level
+	} else if s.Level <= t.Sections[len(t.Sections)-1].Level {
+		// add at top level (also applies to the parts of a book which follow a preface)
 		t.Sections = append(t.Sections, ts)
 		return
 	}
@@ -256,7 +333,7 @@ func (t *TableOfContents) Add(s *Section) {
 	parent := t.Sections[len(t.Sections)-1]
 	for {
 		if len(parent.Children) == 0 ||
-			parent.Children[0].Level == s.Level {
+			parent.Children[len(parent.Children)-1].Level >= s.Level {
 			// (first) child level matches section level
 			// or no child beneath current parent
 			break
@@ -281,6 +358,7 @@ type DocumentElement interface {
 // ------------------------------------------
 
 type DocumentHeader struct {
+	Positioned
 	Title      []interface{}
 	Attributes Attributes
 	Elements   []interface{}
@@ -619,6 +697,7 @@ func (r *DocumentRevision) Expand() Attributes {
 
 // AttributeDeclaration the type for Document Attribute Declarations
 type AttributeDeclaration struct {
+	Positioned
 	Name    string
 	Value   interface{}
 	rawText string
@@ -642,6 +721,7 @@ func (a *AttributeDeclaration) RawText() string {
 
 // AttributeReset the type for AttributeReset
 type AttributeReset struct {
+	Positioned
 	Name    string
 	rawText string
 }
@@ -661,6 +741,7 @@ func (a *AttributeReset) RawText() string {
 
 // AttributeReference the type for AttributeReference
 type AttributeReference struct {
+	Positioned
 	Name    string
 	rawText string
 }
@@ -686,6 +767,7 @@ type PredefinedAttribute AttributeReference
 // CounterSubstitution is a counter, that may increment when it is substituted.
 // If Increment is set, then it will increment before being expanded.
 type CounterSubstitution struct {
+	Positioned
 	Name    string
 	Hidden  bool
 	Value   interface{} // may be a byte for character
@@ -711,6 +793,7 @@ func NewCounterSubstitution(name string, hidden bool, val interface{}, rawText s
 
 // Preamble the structure for document Preamble
 type Preamble struct {
+	Positioned
 	Elements        []interface{}
 	TableOfContents *TableOfContents
 }
@@ -735,6 +818,7 @@ func (p *Preamble) HasContent() bool {
 
 // FrontMatter the structure for document front-matter
 type FrontMatter struct {
+	Positioned
 	Attributes map[string]interface{}
 }
 
@@ -771,6 +855,9 @@ func addToListElement(e ListElement, element interface{}) error {
 	if log.IsLevelEnabled(log.DebugLevel) {
 		log.Debugf("adding element of type '%T' to '%T'", element, e)
 	}
+	if e, ok := e.(interface{ ExtendSourceRange(interface{}) }); ok {
+		e.ExtendSourceRange(element)
+	}
 	switch element := element.(type) {
 	case *RawLine:
 		// append to last element of this OrderedListElement if it's a Paragraph,
@@ -778,11 +865,13 @@ func addToListElement(e ListElement, element interface{}) error {
 		if p, ok := e.LastElement().(*Paragraph); ok {
 			return p.AddElement(element)
 		}
-		return e.SetElements(append(e.GetElements(), &Paragraph{
+		p := &Paragraph{
 			Elements: []interface{}{
 				element,
 			},
-		}))
+		}
+		p.ExtendSourceRange(element)
+		return e.SetElements(append(e.GetElements(), p))
 
 	default:
 		return e.SetElements(append(e.GetElements(), element))
@@ -790,6 +879,7 @@ func addToListElement(e ListElement, element interface{}) error {
 }
 
 type List struct {
+	Positioned
 	Kind       ListKind
 	Attributes Attributes
 	Elements   []ListElement
@@ -846,6 +936,7 @@ func (l *List) AddElement(element interface{}) error {
 	if log.IsLevelEnabled(log.DebugLevel) {
 		log.Debugf("adding element of type '%T' to list of kind '%s'", element, l.Kind)
 	}
+	l.ExtendSourceRange(element)
 	switch e := element.(type) {
 	case ListElement:
 		if e.ListKind() == l.Kind {
@@ -1003,6 +1094,7 @@ func NewList(element ListElement) (*List, error) {
 			element,
 		},
 	}
+	list.ExtendSourceRange(element)
 	// interactive unordered list elements (applies to all elements of the list)
 	// TODO: move into `types.GenericList.AddElement()`` ?
 	if u, ok := element.(*UnorderedListElement); ok && list.Attributes.HasOption(AttrInteractive) {
@@ -1013,6 +1105,7 @@ func NewList(element ListElement) (*List, error) {
 }
 
 type ListContinuation struct {
+	Positioned
 	Offset  int
 	Element interface{}
 }
@@ -1039,6 +1132,7 @@ func (c *ListContinuation) AddElement(element interface{}) error {
 
 // Callout a reference at the end of a line in a delimited block with verbatim content (eg: listing, source code)
 type Callout struct {
+	Positioned
 	Ref int
 }
 
@@ -1051,6 +1145,7 @@ func NewCallout(ref int) (*Callout, error) {
 
 // CalloutListElement the description of a call out which will appear as an ordered list item after the delimited block
 type CalloutListElement struct {
+	Positioned
 	Attributes Attributes
 	Ref        int
 	Elements   []interface{}
@@ -1173,6 +1268,7 @@ const (
 
 // OrderedListElement the structure for the ordered list items
 type OrderedListElement struct {
+	Positioned
 	Attributes Attributes
 	Style      string // TODO: rename to `OrderedListElementNumberingStyle`? TODO: define as an attribute instead?
 	Elements   []interface{}
@@ -1305,6 +1401,7 @@ func NewOrderedListElementPrefix(s string) (OrderedListElementPrefix, error) {
 
 // UnorderedListElement the structure for the unordered list items
 type UnorderedListElement struct {
+	Positioned
 	BulletStyle UnorderedListElementBulletStyle
 	CheckStyle  UnorderedListElementCheckStyle
 	Attributes  Attributes
@@ -1582,6 +1679,7 @@ func toLabeledListElementStyle(level int) (LabeledListElementStyle, error) {
 
 // LabeledListElement an item in a labeled
 type LabeledListElement struct {
+	Positioned
 	Term       []interface{}
 	Attributes Attributes
 	Style      LabeledListElementStyle
@@ -1728,6 +1826,7 @@ func (e *LabeledListElement) SubstituteFootnotes(notes *Footnotes) {
 
 // Paragraph the structure for the paragraphs
 type Paragraph struct {
+	Positioned
 	Attributes Attributes
 	Elements   []interface{}
 }
@@ -1771,6 +1870,7 @@ func (p *Paragraph) AddElement(e interface{}) error {
 	if r, ok := p.Elements[len(p.Elements)-1].(*RawLine); ok {
 		r.Append("\n")
 	}
+	p.ExtendSourceRange(e)
 	p.Elements = append(p.Elements, e)
 	return nil
 }
@@ -1875,6 +1975,7 @@ func NewInlineElements(elements ...interface{}) ([]interface{}, error) {
 
 // InternalCrossReference the struct for Cross References
 type InternalCrossReference struct {
+	Positioned
 	ID    interface{}
 	Label interface{}
 }
@@ -1916,6 +2017,7 @@ func (x *InternalCrossReference) ResolveID(attrs Attributes) error {
 
 // ExternalCrossReference the struct for Cross References
 type ExternalCrossReference struct {
+	Positioned
 	Location   *Location
 	Attributes Attributes
 }
@@ -1959,6 +2061,7 @@ func (x *ExternalCrossReference) SetAttributes(attributes Attributes) {
 
 // InlineButton a button (requires `experimental` doc attribute to be set)
 type InlineButton struct {
+	Positioned
 	Attributes Attributes
 }
 
@@ -1979,6 +2082,7 @@ func NewInlineButton(attrs Attributes) (*InlineButton, error) {
 
 // InlineMenu a menu with optional subpaths defined in its attributes (requires `experimental` doc attribute to be set)
 type InlineMenu struct {
+	Positioned
 	Path []string
 }
 
@@ -2002,6 +2106,7 @@ func NewInlineMenu(id string, attrs Attributes) (*InlineMenu, error) {
 
 // ImageBlock the structure for the block images
 type ImageBlock struct {
+	Positioned
 	Location   *Location
 	Attributes Attributes
 }
@@ -2057,6 +2162,7 @@ func (i *ImageBlock) Reference(refs ElementReferences) {
 
 // InlineImage the structure for the inline image macros
 type InlineImage struct {
+	Positioned
 	Location   *Location
 	Attributes Attributes
 }
@@ -2097,12 +2203,154 @@ func (i *InlineImage) GetLocation() *Location {
 	return i.Location
 }
 
+// ------------------------------------------
+// Videos and audios
+// ------------------------------------------
+
+const (
+	// YouTube the `youtube` video provider
+	YouTube = "youtube"
+	// Vimeo the `vimeo` video provider
+	Vimeo = "vimeo"
+)
+
+// VideoBlock the structure for the video block macros
+type VideoBlock struct {
+	Positioned
+	Location   *Location
+	Attributes Attributes
+}
+
+// NewVideoBlock initializes a new `VideoBlock`
+func NewVideoBlock(location *Location, inlineAttributes Attributes) (*VideoBlock, error) {
+	attrs := Attributes{}
+	attrs.SetAll(inlineAttributes)
+	attrs = toAttributesWithMapping(attrs, map[string]string{
+		AttrPositional1: AttrPoster,
+		AttrPositional2: AttrWidth,
+		AttrPositional3: AttrHeight,
+	})
+	return &VideoBlock{
+		Location:   location,
+		Attributes: attrs,
+	}, nil
+}
+
+// Provider returns the provider of the video (`youtube` or `vimeo`), or an empty string if the location refers to a video file
+func (v *VideoBlock) Provider() string {
+	switch p := v.Attributes.GetAsStringWithDefault(AttrPoster, ""); p {
+	case YouTube, Vimeo:
+		return p
+	default:
+		return ""
+	}
+}
+
+// URL returns the URL of the page of the video on YouTube or Vimeo, or the location of the video file
+func (v *VideoBlock) URL() string {
+	target := v.Location.ToDisplayString()
+	switch v.Provider() {
+	case YouTube:
+		id, _, _ := strings.Cut(target, "/")
+		id, _, _ = strings.Cut(id, ",")
+		return "https://www.youtube.com/watch?v=" + id
+	case Vimeo:
+		return "https://vimeo.com/" + target
+	default:
+		return target
+	}
+}
+
+var _ WithAttributes = &VideoBlock{}
+
+// GetAttributes returns this element's attributes
+func (v *VideoBlock) GetAttributes() Attributes {
+	return v.Attributes
+}
+
+// AddAttributes adds the attributes of this element
+func (v *VideoBlock) AddAttributes(attributes Attributes) {
+	v.Attributes = v.Attributes.AddAll(attributes)
+}
+
+// SetAttributes sets the attributes in this element
+func (v *VideoBlock) SetAttributes(attributes Attributes) {
+	v.Attributes = attributes
+}
+
+var _ WithLocation = &VideoBlock{}
+
+func (v *VideoBlock) GetLocation() *Location {
+	return v.Location
+}
+
+var _ Referencable = &VideoBlock{}
+
+func (v *VideoBlock) Reference(refs ElementReferences) {
+	id := v.Attributes.GetAsStringWithDefault(AttrID, "")
+	title := v.Attributes.GetAsStringWithDefault(AttrTitle, "")
+	if id != "" && title != "" {
+		refs[id] = title
+	}
+}
+
+// AudioBlock the structure for the audio block macros
+type AudioBlock struct {
+	Positioned
+	Location   *Location
+	Attributes Attributes
+}
+
+// NewAudioBlock initializes a new `AudioBlock`
+func NewAudioBlock(location *Location, inlineAttributes Attributes) (*AudioBlock, error) {
+	attrs := Attributes{}
+	attrs.SetAll(inlineAttributes)
+	return &AudioBlock{
+		Location:   location,
+		Attributes: attrs,
+	}, nil
+}
+
+var _ WithAttributes = &AudioBlock{}
+
+// GetAttributes returns this element's attributes
+func (a *AudioBlock) GetAttributes() Attributes {
+	return a.Attributes
+}
+
+// AddAttributes adds the attributes of this element
+func (a *AudioBlock) AddAttributes(attributes Attributes) {
+	a.Attributes = a.Attributes.AddAll(attributes)
+}
+
+// SetAttributes sets the attributes in this element
+func (a *AudioBlock) SetAttributes(attributes Attributes) {
+	a.Attributes = attributes
+}
+
+var _ WithLocation = &AudioBlock{}
+
+func (a *AudioBlock) GetLocation() *Location {
+	return a.Location
+}
+
+var _ Referencable = &AudioBlock{}
+
+func (a *AudioBlock) Reference(refs ElementReferences) {
+	id := a.Attributes.GetAsStringWithDefault(AttrID, "")
+	title := a.Attributes.GetAsStringWithDefault(AttrTitle, "")
+	if id != "" && title != "" {
+		refs[id] = title
+	}
+}
+
 // ------------------------------------------
 // Icons
 // ------------------------------------------
 
 // Icon an icon
 type Icon struct {
+	Positioned
 	Class      string
 	Attributes Attributes
 }
@@ -2127,6 +2375,7 @@ func NewIcon(class string, attributes interface{}) (*Icon, error) {
 // Footnote a foot note, without or without explicit reference (an explicit reference is used to refer
 // multiple times to the same footnote across the document)
 type Footnote struct {
+	Positioned
 	// ID is only set during document processing
 	ID int
 	// Ref the optional reference
@@ -2155,6 +2404,7 @@ func NewFootnote(ref interface{}, elements []interface{}) (*Footnote, error) {
 // FootnoteReference a footnote reference. Substitutes the actual footnote in the document,
 // and only contains a generated, sequential ID (which will be displayed)
 type FootnoteReference struct {
+	Positioned
 	ID        int
 	Ref       string // the user-specified reference (optional)
 	Duplicate bool   // indicates if this reference targets an already-existing footnote // TODO: find a better name?
@@ -2169,8 +2419,9 @@ type WithFootnotes interface {
 // during the parsing phase and displayed at the bottom of the document
 // during the rendering.
 type Footnotes struct {
-	sequence *sequence
-	Notes    []*Footnote
+	sequence    *sequence
+	Notes       []*Footnote
+	Diagnostics *Diagnostics // collects the references to unknown footnotes
 }
 
 // NewFootnotes initializes a new Footnotes
@@ -2211,7 +2462,7 @@ func (f *Footnotes) Reference(note *Footnote) *FootnoteReference {
 		r.Duplicate = true
 	} else {
 		r.ID = InvalidFootnoteReference
-		log.Warnf("no footnote with reference '%s'", note.Ref)
+		f.Diagnostics.Warnf(DiagnosticUnresolvedFootnote, note.SourceRange, "no footnote with reference '%s'", note.Ref)
 	}
 	r.Ref = note.Ref
 	return r
@@ -2260,8 +2511,40 @@ const (
 	Source string = "source"
 	// Passthrough a passthrough block
 	Passthrough string = "pass"
+	// Stem a stem block, using the notation set in the `stem` document attribute
+	Stem string = "stem"
+	// LatexMath a stem block using the LaTeX math notation
+	LatexMath string = "latexmath"
+	// AsciiMath a stem block using the AsciiMath notation
+	AsciiMath string = "asciimath"
+	// PartIntro the introduction of a part in a book (open block or paragraph style)
+	PartIntro string = "partintro"
 )
 
+// IsStem returns `true` if the given block kind or style is one of `stem`, `latexmath` or `asciimath`
+func IsStem(kind string) bool {
+	switch kind {
+	case Stem, LatexMath, AsciiMath:
+		return true
+	default:
+		return false
+	}
+}
+
+// StemNotation returns the notation (`latexmath` or `asciimath`) of a stem block or macro of the given kind.
+// The `stem` kind uses the notation set in the `stem` document attribute, and defaults to `asciimath`
+func StemNotation(kind string, attrs Attributes) string {
+	if kind == Stem {
+		kind = attrs.GetAsStringWithDefault(AttrStem, "")
+	}
+	switch kind {
+	case LatexMath, "latex", "tex":
+		return LatexMath
+	default:
+		return AsciiMath
+	}
+}
+
 // LiteralParagraph custom type to retain the number of spaces on the first line (needed during rendering)
 
 type BlockDelimiter struct { // TODO: use string directly?
@@ -2297,6 +2580,7 @@ func (b *BlockDelimiter) RawText() string {
 
 // DelimitedBlock the structure for the Listing blocks
 type DelimitedBlock struct {
+	Positioned
 	Kind       string
 	Attributes Attributes
 	Elements   []interface{}
@@ -2381,7 +2665,7 @@ func (b *DelimitedBlock) mapAttributes() {
 		if style, exists := b.Attributes[AttrStyle].(string); exists {
 			b.Kind = style
 		}
-	case Example:
+	case Example, Open:
 		b.Attributes = toAttributesWithMapping(b.Attributes, map[string]string{
 			AttrPositional1: AttrStyle,
 		})
@@ -2391,6 +2675,14 @@ func (b *DelimitedBlock) mapAttributes() {
 			AttrPositional2: AttrLanguage,
 			AttrPositional3: AttrLineNums,
 		})
+	case Passthrough:
+		b.Attributes = toAttributesWithMapping(b.Attributes, map[string]string{
+			AttrPositional1: AttrStyle,
+		})
+		// override the `kind` with `style` attribute if this is a stem block
+		if style, exists := b.Attributes[AttrStyle].(string); exists && IsStem(style) {
+			b.Kind = style
+		}
 	}
 }
 
@@ -2416,6 +2708,7 @@ func (b *DelimitedBlock) Reference(refs ElementReferences) {
 
 // RawSection the structure for a rawText section, using during preparsing (needed to support level offsets)
 type RawSection struct {
+	Positioned
 	Level   int
 	RawText string
 }
@@ -2436,8 +2729,38 @@ func (s *RawSection) Stringify() string {
 	return s.RawText
 }
 
+// Book the `book` doctype
+const Book = "book"
+
+// Section names (a.k.a, kinds), derived from the section level and style
+const (
+	SectionKind         = "section"
+	PartKind            = "part"
+	ChapterKind         = "chapter"
+	AbstractKind        = "abstract"
+	AppendixKind        = "appendix"
+	PrefaceKind         = "preface"
+	GlossaryKind        = "glossary"
+	BibliographyKind    = "bibliography"
+	IndexKind           = "index"
+	ColophonKind        = "colophon"
+	DedicationKind      = "dedication"
+	AcknowledgmentsKind = "acknowledgments"
+)
+
+// isSpecialSectionStyle returns true if the given style is one of a special section
+func isSpecialSectionStyle(style string) bool {
+	switch style {
+	case AbstractKind, AppendixKind, PrefaceKind, GlossaryKind, BibliographyKind, IndexKind, ColophonKind, DedicationKind, AcknowledgmentsKind:
+		return true
+	default:
+		return false
+	}
+}
+
 // Section the structure for a section
 type Section struct {
+	Positioned
 	Level      int
 	Attributes Attributes
 	Title      []interface{}
@@ -2459,6 +2782,29 @@ func (s *Section) GetID() string {
 	return id
 }
 
+// Kind returns the name of this section given its level, its style and the doctype
+// (`part`, `chapter`, `appendix`, `preface`, etc. for a book, `section` or the special section style for an article)
+func (s *Section) Kind(doctype string) string {
+	style := s.Attributes.GetAsStringWithDefault(AttrStyle, "")
+	if isSpecialSectionStyle(style) {
+		return style
+	}
+	if doctype == Book {
+		switch s.Level {
+		case 0:
+			return PartKind
+		case 1:
+			return ChapterKind
+		}
+	}
+	return SectionKind
+}
+
+// IsSpecial returns true if this section has a special style (`preface`, `appendix`, `glossary`, etc.)
+func (s *Section) IsSpecial() bool {
+	return isSpecialSectionStyle(s.Attributes.GetAsStringWithDefault(AttrStyle, ""))
+}
+
 var _ WithElements = &Section{}
 
 // GetElements returns this Section's elements
@@ -2504,6 +2850,7 @@ func (s *Section) GetAttributes() Attributes {
 // AddAttributes adds the attributes of this element
 func (s *Section) AddAttributes(attributes Attributes) {
 	s.Attributes = s.Attributes.AddAll(attributes)
+	s.mapAttributes()
 	// if _, exists := s.Attributes[AttrID]; exists {
 	// 	// needed to track custom ID during rendering
 	// 	s.Attributes[AttrCustomID] = true
@@ -2513,12 +2860,19 @@ func (s *Section) AddAttributes(attributes Attributes) {
 // SetAttributes sets the attributes in this element
 func (s *Section) SetAttributes(attributes Attributes) {
 	s.Attributes = attributes
+	s.mapAttributes()
 	// if _, exists := s.Attributes[AttrID]; exists {
 	// 	// needed to track custom ID during rendering
 	// 	s.Attributes[AttrCustomID] = true
 	// }
 }
 
+func (s *Section) mapAttributes() {
+	s.Attributes = toAttributesWithMapping(s.Attributes, map[string]string{
+		AttrPositional1: AttrStyle,
+	})
+}
+
 // ResolveID resolves/updates the "ID" attribute in the section (in case the title changed after some document attr substitution)
 func (s *Section) ResolveID(attrs Attributes, refs ElementReferences) error {
 	base, err := s.resolveID(attrs)
@@ -2575,6 +2929,7 @@ var _ WithElementAddition = &Section{}
 
 // AddElement adds the given child element to this section
 func (s *Section) AddElement(e interface{}) error {
+	s.ExtendSourceRange(e)
 	s.Elements = append(s.Elements, e)
 	return nil
 }
@@ -2598,6 +2953,7 @@ func (s *Section) SubstituteFootnotes(notes *Footnotes) {
 // TableOfContentsPlaceHolder a place holder for Table of Contents, so
 // the renderer knows when to render it.
 type TableOfContentsPlaceHolder struct {
+	Positioned
 }
 
 // ------------------------------------------
@@ -2605,7 +2961,9 @@ type TableOfContentsPlaceHolder struct {
 // ------------------------------------------
 
 // ThematicBreak a thematic break
-type ThematicBreak struct{}
+type ThematicBreak struct {
+	Positioned
+}
 
 // NewThematicBreak returns a new ThematicBreak
 func NewThematicBreak() (*ThematicBreak, error) {
@@ -2628,6 +2986,7 @@ type MacroKind string
 
 // UserMacro the structure for User Macro
 type UserMacro struct {
+	Positioned
 	Kind       MacroKind
 	Name       string
 	Value      string
@@ -2663,6 +3022,7 @@ func NewInlineUserMacro(name, value string, attributes Attributes, rawText strin
 
 // BlankLine the structure for the empty lines, which are used to separate logical blocks
 type BlankLine struct {
+	Positioned
 }
 
 // NewBlankLine initializes a new `BlankLine`
@@ -2676,6 +3036,7 @@ func NewBlankLine() (*BlankLine, error) {
 
 // SinglelineComment a single line comment
 type SinglelineComment struct {
+	Positioned
 	Content string
 }
 
@@ -2693,6 +3054,7 @@ func NewSinglelineComment(content string) (*SinglelineComment, error) {
 
 // StringElement the structure for strings
 type StringElement struct {
+	Positioned
 	Content string
 }
 
@@ -2713,7 +3075,9 @@ func (s StringElement) RawText() (string, error) {
 // ------------------------------------------
 
 // LineBreak an explicit line break in a paragraph
-type LineBreak struct{}
+type LineBreak struct {
+	Positioned
+}
 
 // NewLineBreak returns a new line break, that's all.
 func NewLineBreak() (*LineBreak, error) {
@@ -2726,6 +3090,7 @@ func NewLineBreak() (*LineBreak, error) {
 
 // QuotedText the structure for quoted text
 type QuotedText struct {
+	Positioned
 	Kind       QuotedTextKind
 	Elements   []interface{}
 	Attributes Attributes
@@ -2841,6 +3206,7 @@ func NewEscapedQuotedText(backslashes string, marker string, content interface{}
 
 // InlinePassthrough the structure for Passthroughs
 type InlinePassthrough struct {
+	Positioned
 	Kind     PassthroughKind
 	Elements []interface{} // TODO: refactor to `Content string` ?
 }
@@ -2865,12 +3231,32 @@ func NewInlinePassthrough(kind PassthroughKind, elements []interface{}) (*Inline
 	}, nil
 }
 
+// ------------------------------------------
+// Inline Stem
+// ------------------------------------------
+
+// InlineStem the structure for the `stem:[]`, `latexmath:[]` and `asciimath:[]` inline macros
+type InlineStem struct {
+	Positioned
+	Kind    string // `stem`, `latexmath` or `asciimath`
+	Content string
+}
+
+// NewInlineStem returns a new InlineStem, in which the escaped closing brackets (`\]`) are unescaped
+func NewInlineStem(kind, content string) (*InlineStem, error) {
+	return &InlineStem{
+		Kind:    kind,
+		Content: strings.ReplaceAll(content, `\]`, "]"),
+	}, nil
+}
+
 // ------------------------------------------
 // Inline Links
 // ------------------------------------------
 
 // InlineLink the structure for the external links
 type InlineLink struct {
+	Positioned
 	Attributes Attributes
 	Location   *Location
 }
@@ -3233,6 +3619,7 @@ func NewEndOfCondition() (*EndOfCondition, error) {
 
 // FileInclusion the structure for the file inclusions
 type FileInclusion struct {
+	Positioned
 	Attributes Attributes
 	Location   *Location
 	RawText    string
@@ -3275,6 +3662,7 @@ func (f *FileInclusion) SetAttributes(attributes Attributes) {
 // Raw Line
 // -------------------------------------------------------------------------------------
 type RawLine struct {
+	Positioned
 	Content string
 }
 
@@ -3623,6 +4011,8 @@ func (l *Location) ToDisplayString() string {
 
 // IndexTerm a index term, with a single term
 type IndexTerm struct {
+	Positioned
+	ID   string // the ID of the anchor to this occurrence, assigned during document processing
 	Term []interface{}
 }
 
@@ -3635,6 +4025,8 @@ func NewIndexTerm(term []interface{}) (*IndexTerm, error) {
 
 // ConcealedIndexTerm a concealed index term, with 1 required and 2 optional terms
 type ConcealedIndexTerm struct {
+	Positioned
+	ID    string // the ID of the anchor to this occurrence, assigned during document processing
 	Term1 interface{}
 	Term2 interface{}
 	Term3 interface{}
@@ -3649,6 +4041,170 @@ func NewConcealedIndexTerm(term1, term2, term3 interface{}) (*ConcealedIndexTerm
 	}, nil
 }
 
+// Terms returns the primary, secondary and tertiary terms (if defined)
+func (t *ConcealedIndexTerm) Terms() [][]interface{} {
+	terms := make([][]interface{}, 0, 3)
+	for _, term := range []interface{}{t.Term1, t.Term2, t.Term3} {
+		if term == nil {
+			break
+		}
+		terms = append(terms, []interface{}{
+			&StringElement{
+				Content: strings.TrimSpace(stringify(term)),
+			},
+		})
+	}
+	return terms
+}
+
+// Index the document-level index, with an entry for each (primary) term found in the document
+type Index struct {
+	sequence *sequence
+	Entries  []*IndexEntry
+}
+
+// NewIndex initializes a new Index
+func NewIndex() *Index {
+	return &Index{
+		sequence: &sequence{},
+		Entries:  []*IndexEntry{},
+	}
+}
+
+// IndexEntry an entry in the document index, with the occurrences of its term in the document
+// and the entries of its secondary (or tertiary) terms
+type IndexEntry struct {
+	Term        []interface{}
+	Occurrences []*IndexOccurrence
+	Entries     []*IndexEntry
+}
+
+// IndexOccurrence an occurrence of an index term in the document
+type IndexOccurrence struct {
+	ID    string        // the ID of the anchor to the term
+	Title []interface{} // the title of the section (or of the document) in which the term occurs
+}
+
+// Key returns the plain text of the term, used to merge and sort the entries
+func (e *IndexEntry) Key() string {
+	return plainText(e.Term)
+}
+
+// Reference assigns an ID to the given index term (`*IndexTerm` or `*ConcealedIndexTerm`)
+// and records this occurrence in the index, along with the title of the section in which it occurs
+func (i *Index) Reference(term interface{}, title []interface{}) {
+	switch t := term.(type) {
+	case *IndexTerm:
+		t.ID = "_indexterm_" + strconv.Itoa(i.sequence.nextVal())
+		i.Entries = addIndexOccurrence(i.Entries, [][]interface{}{t.Term}, &IndexOccurrence{
+			ID:    t.ID,
+			Title: title,
+		})
+	case *ConcealedIndexTerm:
+		t.ID = "_indexterm_" + strconv.Itoa(i.sequence.nextVal())
+		i.Entries = addIndexOccurrence(i.Entries, t.Terms(), &IndexOccurrence{
+			ID:    t.ID,
+			Title: title,
+		})
+	}
+}
+
+// addIndexOccurrence records the occurrence in the entry of the first term (or in its sub-entries, if there are more terms).
+// As in Asciidoctor, the terms which only differ by their case are grouped in the same entry,
+// which retains the term of the first occurrence.
+func addIndexOccurrence(entries []*IndexEntry, terms [][]interface{}, occurrence *IndexOccurrence) []*IndexEntry {
+	if len(terms) == 0 {
+		return entries
+	}
+	key := plainText(terms[0])
+	var entry *IndexEntry
+	for _, e := range entries {
+		if strings.EqualFold(e.Key(), key) {
+			entry = e
+			break
+		}
+	}
+	if entry == nil {
+		entry = &IndexEntry{
+			Term: terms[0],
+		}
+		entries = append(entries, entry)
+	}
+	if len(terms) == 1 {
+		entry.Occurrences = append(entry.Occurrences, occurrence)
+	} else {
+		entry.Entries = addIndexOccurrence(entry.Entries, terms[1:], occurrence)
+	}
+	return entries
+}
+
+// IndexCategory the entries of the index whose term starts with the same letter
+type IndexCategory struct {
+	Letter  string
+	Entries []*IndexEntry
+}
+
+// Categories returns the entries of the index, sorted alphabetically (ignoring case) and grouped by their first letter.
+// Entries whose term does not start with a letter are grouped in a `#` category, before the letters.
+func (i *Index) Categories() []*IndexCategory {
+	entries := sortIndexEntries(i.Entries)
+	categories := []*IndexCategory{}
+	for _, e := range entries {
+		letter := "#"
+		if r := []rune(strings.ToUpper(e.Key())); len(r) > 0 && unicode.IsLetter(r[0]) {
+			letter = string(r[0])
+		}
+		if len(categories) == 0 || categories[len(categories)-1].Letter != letter {
+			categories = append(categories, &IndexCategory{
+				Letter: letter,
+			})
+		}
+		c := categories[len(categories)-1]
+		c.Entries = append(c.Entries, e)
+	}
+	return categories
+}
+
+func sortIndexEntries(entries []*IndexEntry) []*IndexEntry {
+	result := make([]*IndexEntry, len(entries))
+	for i, e := range entries {
+		result[i] = &IndexEntry{
+			Term:        e.Term,
+			Occurrences: e.Occurrences,
+			Entries:     sortIndexEntries(e.Entries),
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		ki, kj := strings.ToLower(result[i].Key()), strings.ToLower(result[j].Key())
+		// non-letters first
+		li, lj := startsWithLetter(ki), startsWithLetter(kj)
+		if li != lj {
+			return lj
+		}
+		return ki < kj
+	})
+	return result
+}
+
+func startsWithLetter(s string) bool {
+	r := []rune(s)
+	return len(r) > 0 && unicode.IsLetter(r[0])
+}
+
+// plainText returns the text of the given elements, without any formatting
+func plainText(elements []interface{}) string {
+	result := strings.Builder{}
+	for _, e := range elements {
+		switch e := e.(type) {
+		case WithElements:
+			result.WriteString(plainText(e.GetElements()))
+		default:
+			result.WriteString(stringify(e))
+		}
+	}
+	return result.String()
+}
+
 // ------------------------------------------------------------------------------------
 // Special Characters
 // They need to be identified as they may have a special treatment during the rendering
@@ -3657,6 +4213,7 @@ func NewConcealedIndexTerm(term1, term2, term3 interface{}) (*ConcealedIndexTerm
 // SpecialCharacter a special character, which may get a special treatment later during rendering
 // Eg `<`, `>`, `&`
 type SpecialCharacter struct {
+	Positioned
 	Name string
 }
 
@@ -3673,6 +4230,7 @@ func NewSpecialCharacter(name string) (*SpecialCharacter, error) {
 // Symbol a sequence of characters, which may get a special treatment later during rendering
 // Eg: `(C)`, `(TM)`, `...`, etc.
 type Symbol struct {
+	Positioned
 	Name string
 }
 
@@ -3712,6 +4270,7 @@ func (p *ElementPlaceHolder) String() string {
 
 // Table the structure for the tables
 type Table struct {
+	Positioned
 	Attributes Attributes
 	Header     *TableRow
 	Footer     *TableRow
@@ -3732,12 +4291,164 @@ func NewTable(lines []interface{}) (*Table, error) {
 	}, nil
 }
 
+// Table data formats
+const (
+	// PSVFormat the default table format (prefix-separated values)
+	PSVFormat = "psv"
+	// CSVFormat the comma-separated values table format
+	CSVFormat = "csv"
+	// TSVFormat the tab-separated values table format
+	TSVFormat = "tsv"
+	// DSVFormat the delimiter-separated values table format
+	DSVFormat = "dsv"
+)
+
+// NewDataTable returns a new table whose content in the given `csv`, `tsv` or `dsv` format
+// is split in rows and cells, using the default separator of the format unless a custom one is given.
+// As with `psv` tables, the first row is the header if it is followed by a blank line.
+func NewDataTable(format, separator string, lines []interface{}) (*Table, error) {
+	if log.IsLevelEnabled(log.DebugLevel) {
+		log.Debugf("new %s table from %s", format, spew.Sdump(lines))
+	}
+	content := make([]string, len(lines))
+	for i, l := range lines {
+		s, ok := l.(string)
+		if !ok {
+			return nil, errors.Errorf("unexpected type of table line: '%T'", l)
+		}
+		content[i] = s
+	}
+	var records [][]string
+	var header bool
+	var err error
+	switch format {
+	case CSVFormat, TSVFormat:
+		records, header, err = readCSVRecords(format, separator, content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read content of %s table", format)
+		}
+	case DSVFormat:
+		records, header = readDSVRecords(separator, content)
+	default:
+		return nil, errors.Errorf("unsupported table format: '%s'", format)
+	}
+	if len(records) == 0 {
+		return &Table{}, nil
+	}
+	rows := make([]*TableRow, len(records))
+	for i, record := range records {
+		cells := make([]*TableCell, len(record))
+		for j, field := range record {
+			c, err := newDataTableCell(field)
+			if err != nil {
+				return nil, err
+			}
+			cells[j] = c
+		}
+		rows[i] = &TableRow{
+			Cells: cells,
+		}
+	}
+	if header && len(rows) > 1 {
+		return &Table{
+			Header: rows[0],
+			Rows:   rows[1:],
+		}, nil
+	}
+	return &Table{
+		Rows: rows,
+	}, nil
+}
+
+// newDataTableCell returns a new cell whose content is the given field,
+// with one RawLine per line if the field spans multiple lines (eg: a quoted CSV field)
+func newDataTableCell(field string) (*TableCell, error) {
+	lines := strings.Split(strings.TrimSpace(field), "\n")
+	elements := make([]interface{}, len(lines))
+	for i, l := range lines {
+		r, err := NewRawLine(l)
+		if err != nil {
+			return nil, err
+		}
+		elements[i] = r
+	}
+	cells, err := NewMultilineTableCell(nil, elements)
+	if err != nil {
+		return nil, err
+	}
+	return cells[0], nil
+}
+
+func readCSVRecords(format, separator string, lines []string) ([][]string, bool, error) {
+	r := csv.NewReader(strings.NewReader(strings.Join(lines, "\n")))
+	r.FieldsPerRecord = -1 // rows may have different number of cells
+	r.LazyQuotes = true
+	r.TrimLeadingSpace = true
+	switch {
+	case separator != "":
+		r.Comma, _ = utf8.DecodeRuneInString(separator)
+	case format == TSVFormat:
+		r.Comma = '\t'
+	}
+	records := [][]string{}
+	header := false
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		if len(records) == 0 {
+			// first record is the header if the line after its last field is blank
+			line, _ := r.FieldPos(len(record) - 1)
+			next := line + strings.Count(record[len(record)-1], "\n") // `line` is 1-based
+			header = next < len(lines) && strings.TrimSpace(lines[next]) == ""
+		}
+		records = append(records, record)
+	}
+	return records, header, nil
+}
+
+func readDSVRecords(separator string, lines []string) ([][]string, bool) {
+	if separator == "" {
+		separator = ":"
+	}
+	records := make([][]string, 0, len(lines))
+	for _, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		record := []string{}
+		field := &strings.Builder{}
+		for len(l) > 0 {
+			switch {
+			case strings.HasPrefix(l, "\\"+separator): // escaped separator
+				field.WriteString(separator)
+				l = l[len(separator)+1:]
+			case strings.HasPrefix(l, separator):
+				record = append(record, field.String())
+				field.Reset()
+				l = l[len(separator):]
+			default:
+				_, size := utf8.DecodeRuneInString(l)
+				field.WriteString(l[:size])
+				l = l[size:]
+			}
+		}
+		records = append(records, append(record, field.String()))
+	}
+	header := len(lines) > 1 && strings.TrimSpace(lines[0]) != "" && strings.TrimSpace(lines[1]) == ""
+	return records, header
+}
+
 func scanTableElements(rows []interface{}) (*TableRow, []*TableRow) {
 	// check first 2 elements, expecting a row followed by a blankline as the optional header row
 	if len(rows) > 2 {
 		if header, ok := rows[0].(*TableRow); ok {
 			if _, ok := rows[1].(*BlankLine); ok {
-				rows := organizeTableCells(rows[2:], len(header.Cells))
+				rows := organizeTableCells(rows[2:], header.width())
 				return header, rows
 			}
 		}
@@ -3745,7 +4456,7 @@ func scanTableElements(rows []interface{}) (*TableRow, []*TableRow) {
 	rowLength := 1
 	for _, e := range rows {
 		if r, ok := e.(*TableRow); ok {
-			rowLength = len(r.Cells)
+			rowLength = r.width()
 			break
 		}
 	}
@@ -3761,15 +4472,48 @@ func organizeTableCells(elements []interface{}, rowLength int) []*TableRow {
 			cells = append(cells, e.Cells...)
 		}
 	}
-	log.Debugf("dispatching %d cells in rows of %d cells", len(cells), rowLength)
+	if rowLength < 1 {
+		rowLength = 1
+	}
+	log.Debugf("dispatching %d cells in rows of %d columns", len(cells), rowLength)
 	rows := make([]*TableRow, 0, int(len(cells)/rowLength)+1)
+	// number of rows in which each column is still covered by a cell of a previous row
+	covered := make([]int, rowLength)
+	var r *TableRow
+	col := 0
 	for len(cells) > 0 {
-		r := &TableRow{}
-		rows = append(rows, r)
-		l := int(math.Min(float64(len(cells)), float64(rowLength)))
-		r.Cells = make([]*TableCell, l)
-		copy(r.Cells, cells[:l])
-		cells = cells[l:]
+		if r == nil {
+			r = &TableRow{
+				Cells: []*TableCell{},
+			}
+			rows = append(rows, r)
+			col = 0
+		}
+		for col < rowLength && covered[col] > 0 {
+			col++
+		}
+		if col < rowLength {
+			c := cells[0]
+			cells = cells[1:]
+			r.Cells = append(r.Cells, c)
+			r.ExtendSourceRange(c)
+			for i := col; i < col+c.Colspan() && i < rowLength; i++ {
+				covered[i] = c.Rowspan()
+			}
+			col += c.Colspan()
+			for col < rowLength && covered[col] > 0 {
+				col++
+			}
+		}
+		if col >= rowLength {
+			// end of row
+			for i := range covered {
+				if covered[i] > 0 {
+					covered[i]--
+				}
+			}
+			r = nil
+		}
 	}
 	return rows
 }
@@ -3940,6 +4684,7 @@ const (
 	LiteralStyle   ContentStyle = "l"
 	MonospaceStyle ContentStyle = "m"
 	StrongStyle    ContentStyle = "s"
+	VerseStyle     ContentStyle = "v"
 )
 
 type TableColumn struct {
@@ -4008,8 +4753,8 @@ func (t *Table) Columns() ([]*TableColumn, error) {
 	}
 	// add empty entries if the first row has more cells than the number of column than what's specified in the `cols` attribute
 	// (also works when the `cols` attribute is missing/empty)
-	if len(t.Rows) > 0 && len(t.Rows[0].Cells) > len(result) {
-		m := len(t.Rows[0].Cells) - len(result)
+	if len(t.Rows) > 0 && t.Rows[0].width() > len(result) {
+		m := t.Rows[0].width() - len(result)
 		for i := 0; i < m; i++ {
 			col := newDefaultTableColumn()
 			result = append(result, col)
@@ -4057,15 +4802,18 @@ func (t *Table) Columns() ([]*TableColumn, error) {
 
 // TableRow a table line is made of columns, each column being a group of []interface{} (to support quoted text, etc.)
 type TableRow struct {
+	Positioned
 	Cells []*TableCell
 }
 
 func NewTableRow(elements []interface{}) (*TableRow, error) {
-	cells := make([]*TableCell, len(elements))
-	for i, e := range elements {
+	cells := make([]*TableCell, 0, len(elements))
+	for _, e := range elements {
 		switch e := e.(type) {
 		case *TableCell:
-			cells[i] = e
+			cells = append(cells, e)
+		case []*TableCell: // duplicated cells
+			cells = append(cells, e...)
 		default:
 			return nil, fmt.Errorf("unexpected type of table cell: '%T'", e)
 		}
@@ -4075,6 +4823,15 @@ func NewTableRow(elements []interface{}) (*TableRow, error) {
 	}, nil
 }
 
+// width returns the number of columns covered by the cells of this row
+func (r *TableRow) width() int {
+	w := 0
+	for _, c := range r.Cells {
+		w += c.Colspan()
+	}
+	return w
+}
+
 var _ WithElements = &TableRow{}
 
 func (r *TableRow) GetAttributes() Attributes {
@@ -4111,44 +4868,220 @@ func (r *TableRow) SetElements(elements []interface{}) error {
 }
 
 type TableCell struct {
-	Format   string
-	Elements []interface{}
+	Positioned
+	Attributes Attributes
+	Elements   []interface{}
+	// Format the style of the content of the cell (eg: `a` for an AsciiDoc cell), as set in the cell specifier.
+	//
+	// Deprecated: use `Style()` or the `AttrStyle` attribute instead. The parser still sets this field,
+	// and `Style()` returns it when the `AttrStyle` attribute is not set.
+	Format string
 }
 
-func NewInlineTableCell(content *RawLine) (*TableCell, error) {
-	return &TableCell{
-		Elements: []interface{}{
-			content,
-		},
-	}, nil
+// NewInlineTableCell returns the cell(s) with the given content on a single line,
+// multiple cells if the optional specifier has a duplication factor
+func NewInlineTableCell(specifier interface{}, content *RawLine) ([]*TableCell, error) {
+	return newTableCells(specifier, []interface{}{content})
 }
 
-func NewMultilineTableCell(elements []interface{}, format interface{}) (*TableCell, error) {
+// NewMultilineTableCell returns the cell(s) with the given content on multiple lines,
+// multiple cells if the optional specifier has a duplication factor
+func NewMultilineTableCell(specifier interface{}, elements []interface{}) ([]*TableCell, error) {
 	for i, l := range elements {
 		// append `\n` unless the we're on the last element
 		if l, ok := l.(*RawLine); ok && i < len(elements)-1 {
 			l.Append("\n")
 		}
 	}
-	c := &TableCell{
-		Elements: elements,
+	return newTableCells(specifier, elements)
+}
+
+// AppendTableCellLines appends the given lines to the content of the last of the given cells,
+// when the content of this latter continues on the lines following a row of cells
+func AppendTableCellLines(cells []interface{}, lines []interface{}) ([]interface{}, error) {
+	if len(cells) == 0 || len(lines) == 0 {
+		return cells, nil
 	}
-	if format, ok := format.(string); ok {
-		c.Format = format
+	last, ok := cells[len(cells)-1].([]*TableCell)
+	if !ok || len(last) == 0 {
+		return nil, errors.Errorf("unexpected type of table cell: '%T'", cells[len(cells)-1])
 	}
-	return c, nil
+	c := last[len(last)-1]
+	if len(c.Elements) > 0 {
+		if l, ok := c.Elements[len(c.Elements)-1].(*RawLine); ok {
+			l.Append("\n")
+		}
+	}
+	for i, l := range lines {
+		// append `\n` unless the we're on the last element
+		if l, ok := l.(*RawLine); ok && i < len(lines)-1 {
+			l.Append("\n")
+		}
+	}
+	c.Elements = append(c.Elements, lines...)
+	c.ExtendSourceRange(lines[len(lines)-1])
+	return cells, nil
+}
+
+func newTableCells(specifier interface{}, elements []interface{}) ([]*TableCell, error) {
+	spec, ok := specifier.(*TableCellSpecifier)
+	if !ok {
+		return []*TableCell{
+			{
+				Elements: elements,
+			},
+		}, nil
+	}
+	duplication := spec.Duplication
+	if duplication < 1 {
+		duplication = 1
+	}
+	cells := make([]*TableCell, duplication)
+	for i := range cells {
+		c := &TableCell{
+			Elements: cloneRawLines(elements),
+		}
+		if spec.Attributes != nil {
+			c.Attributes = spec.Attributes.Clone()
+		}
+		c.Format = string(c.Style())
+		cells[i] = c
+	}
+	return cells, nil
+}
+
+// cloneRawLines returns a copy of the given elements, in which the RawLines
+// are also copied since they are later parsed and substituted on a per-cell basis
+func cloneRawLines(elements []interface{}) []interface{} {
+	result := make([]interface{}, len(elements))
+	for i, e := range elements {
+		if l, ok := e.(*RawLine); ok {
+			result[i] = &RawLine{
+				Content: l.Content,
+			}
+			continue
+		}
+		result[i] = e
+	}
+	return result
+}
+
+// Colspan returns the number of columns that this cell spans over (1 by default)
+func (c *TableCell) Colspan() int {
+	if n, ok := c.Attributes[AttrColspan].(int); ok && n > 1 {
+		return n
+	}
+	return 1
+}
+
+// Rowspan returns the number of rows that this cell spans over (1 by default)
+func (c *TableCell) Rowspan() int {
+	if n, ok := c.Attributes[AttrRowspan].(int); ok && n > 1 {
+		return n
+	}
+	return 1
+}
+
+// Style returns the content style of this cell, or an empty value if none was specified
+func (c *TableCell) Style() ContentStyle {
+	if style, ok := c.Attributes[AttrStyle].(ContentStyle); ok {
+		return style
+	}
+	return ContentStyle(c.Format)
 }
 
 var _ WithElements = &TableCell{}
 
 func (c *TableCell) GetAttributes() Attributes {
-	return nil
+	return c.Attributes
+}
+
+func (c *TableCell) AddAttributes(attributes Attributes) {
+	c.Attributes = c.Attributes.AddAll(attributes)
+}
+
+func (c *TableCell) SetAttributes(attributes Attributes) {
+	c.Attributes = attributes
 }
 
-func (c *TableCell) AddAttributes(_ Attributes) {
+// TableCellColumns keeps track of the columns covered by the cells which span over multiple rows,
+// in order to find the column of each cell of the subsequent rows
+type TableCellColumns struct {
+	covered map[int]int
 }
 
-func (c *TableCell) SetAttributes(_ Attributes) {
+// NewTableCellColumns returns a new TableCellColumns, to call on each row of a table, in order
+func NewTableCellColumns() *TableCellColumns {
+	return &TableCellColumns{
+		covered: map[int]int{},
+	}
+}
+
+// Next returns the (0-based) index of the column of each cell in the given row
+func (t *TableCellColumns) Next(row *TableRow) []int {
+	result := make([]int, len(row.Cells))
+	col := 0
+	for i, c := range row.Cells {
+		for t.covered[col] > 0 {
+			col++
+		}
+		result[i] = col
+		for j := col; j < col+c.Colspan(); j++ {
+			t.covered[j] = c.Rowspan()
+		}
+		col += c.Colspan()
+	}
+	for j := range t.covered {
+		if t.covered[j] > 0 {
+			t.covered[j]--
+		}
+	}
+	return result
+}
+
+// TableCellSpecifier the optional specifier before a cell separator, which defines how many times
+// the cell is duplicated, how many columns and rows it spans over, its alignment and its style (eg: `2+^.>a|`)
+type TableCellSpecifier struct {
+	Duplication int
+	Attributes  Attributes
+}
+
+// NewTableCellSpan returns a cell specifier with the given colspan and/or rowspan (eg: `2.3+`)
+func NewTableCellSpan(colspan, rowspan interface{}) (*TableCellSpecifier, error) {
+	spec := &TableCellSpecifier{}
+	if colspan, ok := colspan.(int); ok && colspan > 1 {
+		spec.Attributes = spec.Attributes.Set(AttrColspan, colspan)
+	}
+	if rowspan, ok := rowspan.(int); ok && rowspan > 1 {
+		spec.Attributes = spec.Attributes.Set(AttrRowspan, rowspan)
+	}
+	return spec, nil
+}
+
+// NewTableCellDuplication returns a cell specifier with the given duplication factor (eg: `3*`)
+func NewTableCellDuplication(duplication int) (*TableCellSpecifier, error) {
+	return &TableCellSpecifier{
+		Duplication: duplication,
+	}, nil
+}
+
+// NewTableCellSpecifier returns a cell specifier which combines the optional span or duplication factor
+// with the optional alignments and content style
+func NewTableCellSpecifier(factor, halign, valign, style interface{}) (*TableCellSpecifier, error) {
+	spec, ok := factor.(*TableCellSpecifier)
+	if !ok {
+		spec = &TableCellSpecifier{}
+	}
+	if halign, ok := halign.(HAlign); ok {
+		spec.Attributes = spec.Attributes.Set(AttrHAlign, halign)
+	}
+	if valign, ok := valign.(VAlign); ok {
+		spec.Attributes = spec.Attributes.Set(AttrVAlign, valign)
+	}
+	if style, ok := style.(ContentStyle); ok {
+		spec.Attributes = spec.Attributes.Set(AttrStyle, style)
+	}
+	return spec, nil
 }
 
 func (c *TableCell) GetElements() []interface{} {
@@ -1046,3 +1046,24 @@ var _ = DescribeTable("ifeval operands",
 	Entry(`"2"<="2"`, types.LessOrEqualOperand, "2", "2", true),
 	Entry(`"2"<="1"`, types.LessOrEqualOperand, "2", "1", false),
 )
+
+var _ = DescribeTable("table cell styles",
+	func(cell *types.TableCell, expected types.ContentStyle) {
+		Expect(cell.Style()).To(Equal(expected))
+	},
+	Entry("none", &types.TableCell{}, types.ContentStyle("")),
+	Entry("style attribute", &types.TableCell{
+		Attributes: types.Attributes{
+			types.AttrStyle: types.AsciidocStyle,
+		},
+	}, types.AsciidocStyle),
+	Entry("deprecated format", &types.TableCell{
+		Format: "a",
+	}, types.AsciidocStyle),
+	Entry("style attribute over deprecated format", &types.TableCell{
+		Attributes: types.Attributes{
+			types.AttrStyle: types.LiteralStyle,
+		},
+		Format: "a",
+	}, types.LiteralStyle),
+)
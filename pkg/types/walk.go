@@ -0,0 +1,43 @@
+package types
+
+// Walk visits the given element and its children, depth-first and in the order in which they appear in the document
+// (eg: the title of a section before its elements). The children of an element are not visited
+// if the `visit` func returns `false` for this element.
+func Walk(element interface{}, visit func(element interface{}) bool) {
+	if elements, ok := element.([]interface{}); ok {
+		for _, e := range elements {
+			Walk(e, visit)
+		}
+		return
+	}
+	if !visit(element) {
+		return
+	}
+	switch e := element.(type) {
+	case *Document:
+		Walk(e.Elements, visit)
+		for _, note := range e.Footnotes {
+			Walk(note, visit)
+		}
+	case *Preamble:
+		Walk(e.Elements, visit)
+	case *Footnote:
+		Walk(e.Elements, visit)
+	case *LabeledListElement:
+		Walk(e.Term, visit)
+		Walk(e.Elements, visit)
+	case *Table:
+		if e.Header != nil {
+			Walk(e.Header, visit)
+		}
+		Walk(e.GetElements(), visit)
+		if e.Footer != nil {
+			Walk(e.Footer, visit)
+		}
+	case WithElements:
+		if t, ok := e.(WithTitle); ok {
+			Walk(t.GetTitle(), visit)
+		}
+		Walk(e.GetElements(), visit)
+	}
+}
@@ -0,0 +1,120 @@
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	"github.com/pkg/errors"
+)
+
+// Rule a linter rule, which checks a parsed document and reports the problems it finds
+type Rule struct {
+	// ID the unique ID of the rule, used as the code of the problems it reports,
+	// and to enable or disable the rule in the configuration
+	ID string
+	// Description a short description of the problems reported by the rule
+	Description string
+	// Severity the severity of the problems reported by the rule
+	Severity types.Severity
+	// Check checks the document and reports the problems via the given context
+	Check func(ctx *RuleContext)
+}
+
+// RuleContext the context in which a rule checks a document
+type RuleContext struct {
+	Document *types.Document
+	Config   *configuration.Configuration
+	rule     Rule
+	problems []types.Diagnostic
+}
+
+// Report reports a problem found by the current rule in the given range of the source
+func (c *RuleContext) Report(r types.SourceRange, format string, args ...interface{}) {
+	c.problems = append(c.problems, types.Diagnostic{
+		Severity:     c.rule.Severity,
+		Code:         c.rule.ID,
+		Message:      fmt.Sprintf(format, args...),
+		Range:        r,
+		IncludedFrom: c.Config.Diagnostics.IncludedFrom(r.Start.File),
+	})
+}
+
+var rules = &ruleRegistry{
+	mutex: &sync.RWMutex{},
+	rules: builtinRules(),
+}
+
+type ruleRegistry struct {
+	mutex *sync.RWMutex
+	rules []Rule
+}
+
+// RegisterRule registers a custom linter rule, which will be checked after the built-in rules.
+// Returns an error if another rule with the same ID was already registered.
+func RegisterRule(rule Rule) error {
+	if rule.ID == "" || rule.Check == nil {
+		return errors.New("unable to register linter rule: missing ID or check func")
+	}
+	rules.mutex.Lock()
+	defer rules.mutex.Unlock()
+	for _, r := range rules.rules {
+		if r.ID == rule.ID {
+			return errors.Errorf("unable to register linter rule: duplicate ID '%s'", rule.ID)
+		}
+	}
+	rules.rules = append(rules.rules, rule)
+	return nil
+}
+
+// Rules returns all the registered linter rules, in the order in which they are checked
+func Rules() []Rule {
+	rules.mutex.RLock()
+	defer rules.mutex.RUnlock()
+	result := make([]Rule, len(rules.rules))
+	copy(result, rules.rules)
+	return result
+}
+
+// LookupRule returns the registered linter rule with the given ID
+func LookupRule(id string) (Rule, bool) {
+	for _, r := range Rules() {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Lint checks the given document with all the linter rules which are enabled in the configuration,
+// and returns the problems that were found, sorted by position in the source.
+// Some rules rely on the diagnostics collected in `config.Diagnostics` while the document was parsed.
+func Lint(doc *types.Document, config *configuration.Configuration) []types.Diagnostic {
+	problems := []types.Diagnostic{}
+	for _, rule := range Rules() {
+		if enabled, found := config.LintRules[rule.ID]; found && !enabled {
+			continue
+		}
+		ctx := &RuleContext{
+			Document: doc,
+			Config:   config,
+			rule:     rule,
+		}
+		rule.Check(ctx)
+		problems = append(problems, ctx.problems...)
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		a, b := problems[i].Range.Start, problems[j].Range.Start
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return problems
+}
@@ -0,0 +1,234 @@
+package validator_test
+
+import (
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	"github.com/bytesparadise/libasciidoc/pkg/validator"
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("linter", func() {
+
+	problem := func(severity types.Severity, rule, message string, line, column int) types.Diagnostic {
+		return types.Diagnostic{
+			Severity: severity,
+			Code:     rule,
+			Message:  message,
+			Range: types.SourceRange{
+				Start: types.SourcePosition{
+					File:   "test.adoc",
+					Line:   line,
+					Column: column,
+				},
+			},
+		}
+	}
+
+	// only compares the start of the range of the problems
+	lint := func(source string, settings ...configuration.Setting) []types.Diagnostic {
+		problems, err := LintDocument(source, settings...)
+		Expect(err).NotTo(HaveOccurred())
+		for i := range problems {
+			problems[i].Range.End = types.SourcePosition{}
+		}
+		return problems
+	}
+
+	It("should not report problems", func() {
+		source := `= Title
+
+== Section
+
+* an item
+* another item
+
+see <<_Section>>`
+		Expect(lint(source)).To(BeEmpty())
+	})
+
+	It("should report duplicate IDs", func() {
+		source := `[#dup]
+== Section
+
+[[dup]]a paragraph
+
+[#dup]
+== Other Section
+
+content`
+		Expect(lint(source)).To(Equal([]types.Diagnostic{
+			problem(types.SeverityWarning, validator.RuleDuplicateID, "duplicate ID 'dup' (first assigned at test.adoc:2:1)", 4, 1),
+			problem(types.SeverityWarning, validator.RuleDuplicateID, "id assigned to section already in use: dup", 7, 1),
+		}))
+	})
+
+	It("should report dangling cross references", func() {
+		source := `see <<unknown>>`
+		Expect(lint(source)).To(Equal([]types.Diagnostic{
+			problem(types.SeverityWarning, validator.RuleDanglingCrossReference, "possible invalid reference: unknown", 1, 5),
+		}))
+	})
+
+	It("should report skipped section levels", func() {
+		source := `=== Section
+
+content
+
+== Other Section
+
+==== Sub Section
+
+content`
+		Expect(lint(source)).To(Equal([]types.Diagnostic{
+			problem(types.SeverityWarning, validator.RuleSkippedSectionLevel, "section title out of sequence: expected level 1, got level 2", 1, 1),
+			problem(types.SeverityWarning, validator.RuleSkippedSectionLevel, "section title out of sequence: expected level 2, got level 3", 7, 1),
+		}))
+	})
+
+	It("should report empty sections", func() {
+		source := `== Section *1*
+
+== Section 2
+
+=== Section 2.1
+
+content`
+		Expect(lint(source)).To(Equal([]types.Diagnostic{
+			problem(types.SeverityInfo, validator.RuleEmptySection, "section 'Section 1' is empty", 1, 1),
+		}))
+	})
+
+	It("should report missing images", func() {
+		source := `:imagesdir: ../../test/images
+
+image::favicon-glasses-16x16.png[]
+
+image::unknown.png[]
+
+an image:https://example.com/unknown.png[] and an image:/path/to/unknown.png[]`
+		Expect(lint(source)).To(Equal([]types.Diagnostic{
+			problem(types.SeverityWarning, validator.RuleMissingImage, "image not found: ../../test/images/unknown.png", 5, 1),
+			problem(types.SeverityWarning, validator.RuleMissingImage, "image not found: /path/to/unknown.png", 7, 51),
+		}))
+	})
+
+	It("should report undefined attributes", func() {
+		source := `a {defined} and {undefined} attribute`
+		Expect(lint(source, configuration.WithAttribute("defined", "value"))).To(Equal([]types.Diagnostic{
+			problem(types.SeverityWarning, validator.RuleUndefinedAttribute, "unable to find entry for attribute with key 'undefined' in context", 1, 17),
+		}))
+	})
+
+	It("should report unclosed blocks", func() {
+		source := `****
+a sidebar
+
+....
+a literal block
+****`
+		Expect(lint(source)).To(Equal([]types.Diagnostic{
+			problem(types.SeverityError, validator.RuleUnclosedBlock, "unterminated sidebar block", 1, 1),
+			problem(types.SeverityError, validator.RuleUnclosedBlock, "unterminated literal block", 4, 1),
+		}))
+	})
+
+	It("should report unused footnote references", func() {
+		source := `a footnote:used[a note], another footnote:unused[another note] and footnote:used[]`
+		Expect(lint(source)).To(Equal([]types.Diagnostic{
+			problem(types.SeverityInfo, validator.RuleUnusedFootnoteReference, "footnote reference 'unused' is never used", 1, 34),
+		}))
+	})
+
+	It("should report inconsistent list markers", func() {
+		source := `* item
+** nested item
+
+a paragraph
+
+- item
+* nested item`
+		Expect(lint(source)).To(Equal([]types.Diagnostic{
+			problem(types.SeverityInfo, validator.RuleInconsistentListMarker, "list marker '-' is not the same as the marker '*' of the first list (at test.adoc:1:1)", 6, 1),
+		}))
+	})
+
+	It("should report invalid manpage", func() {
+		source := `= git-foo(1)
+
+== Synopsis
+
+content`
+		Expect(lint(source, configuration.WithAttribute(types.AttrDocType, "manpage"))).To(Equal([]types.Diagnostic{
+			problem(types.SeverityError, validator.RuleManpageStructure, "manpage document is missing the 'Name' section", 3, 1),
+		}))
+	})
+
+	It("should not report problems of disabled rules", func() {
+		source := `== Empty Section
+
+see <<unknown>>`
+		Expect(lint(source, configuration.WithLintRule(validator.RuleEmptySection, false))).To(Equal([]types.Diagnostic{
+			problem(types.SeverityWarning, validator.RuleDanglingCrossReference, "possible invalid reference: unknown", 3, 5),
+		}))
+	})
+
+	Context("rules", func() {
+
+		It("should list the built-in rules", func() {
+			ids := []string{}
+			for _, r := range validator.Rules() {
+				ids = append(ids, r.ID)
+			}
+			Expect(ids).To(ContainElements(
+				validator.RuleDuplicateID,
+				validator.RuleDanglingCrossReference,
+				validator.RuleSkippedSectionLevel,
+				validator.RuleEmptySection,
+				validator.RuleMissingImage,
+				validator.RuleUndefinedAttribute,
+				validator.RuleUnclosedBlock,
+				validator.RuleUnusedFootnoteReference,
+				validator.RuleInconsistentListMarker,
+				validator.RuleManpageStructure,
+			))
+		})
+
+		It("should register and check a custom rule", func() {
+			err := validator.RegisterRule(validator.Rule{
+				ID:          "no-todo",
+				Description: "TODO markers",
+				Severity:    types.SeverityWarning,
+				Check: func(ctx *validator.RuleContext) {
+					types.Walk(ctx.Document, func(element interface{}) bool {
+						if s, ok := element.(*types.StringElement); ok && strings.Contains(s.Content, "TODO") {
+							ctx.Report(s.SourceRange, "remaining TODO marker")
+						}
+						return true
+					})
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			source := `a paragraph
+
+TODO: another paragraph`
+			Expect(lint(source)).To(Equal([]types.Diagnostic{
+				problem(types.SeverityWarning, "no-todo", "remaining TODO marker", 3, 1),
+			}))
+			// also, the rule can be disabled
+			Expect(lint(source, configuration.WithLintRule("no-todo", false))).To(BeEmpty())
+		})
+
+		It("should not register a rule with a duplicate ID", func() {
+			err := validator.RegisterRule(validator.Rule{
+				ID:    validator.RuleEmptySection,
+				Check: func(ctx *validator.RuleContext) {},
+			})
+			Expect(err).To(MatchError("unable to register linter rule: duplicate ID 'empty-section'"))
+		})
+	})
+})
@@ -0,0 +1,280 @@
+package validator
+
+import (
+	"net/url"
+	"path/filepath"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+)
+
+// the IDs of the built-in linter rules
+const (
+	// RuleDuplicateID the ID of the rule which reports the IDs assigned to more than one element
+	RuleDuplicateID string = "duplicate-id"
+	// RuleDanglingCrossReference the ID of the rule which reports the cross references to unknown IDs
+	RuleDanglingCrossReference string = "dangling-xref"
+	// RuleSkippedSectionLevel the ID of the rule which reports the sections whose level is not the next level of their parent
+	RuleSkippedSectionLevel string = "skipped-section-level"
+	// RuleEmptySection the ID of the rule which reports the sections without any content
+	RuleEmptySection string = "empty-section"
+	// RuleMissingImage the ID of the rule which reports the images whose file does not exist
+	RuleMissingImage string = "missing-image"
+	// RuleUndefinedAttribute the ID of the rule which reports the references to undefined attributes
+	RuleUndefinedAttribute string = "undefined-attribute"
+	// RuleUnclosedBlock the ID of the rule which reports the delimited blocks which are not closed
+	RuleUnclosedBlock string = "unclosed-block"
+	// RuleUnusedFootnoteReference the ID of the rule which reports the footnote references which are never used
+	RuleUnusedFootnoteReference string = "unused-footnote-ref"
+	// RuleInconsistentListMarker the ID of the rule which reports the unordered lists which do not use the same marker as the first one
+	RuleInconsistentListMarker string = "inconsistent-list-marker"
+	// RuleManpageStructure the ID of the rule which reports the manpage documents which do not have the expected structure
+	RuleManpageStructure string = "manpage-structure"
+)
+
+func builtinRules() []Rule {
+	return []Rule{
+		{
+			ID:          RuleDuplicateID,
+			Description: "IDs assigned to more than one element",
+			Severity:    types.SeverityWarning,
+			Check:       checkDuplicateIDs,
+		},
+		{
+			ID:          RuleDanglingCrossReference,
+			Description: "cross references to IDs which are not defined in the document",
+			Severity:    types.SeverityWarning,
+			Check:       reportDiagnostics(types.DiagnosticUnresolvedCrossReference),
+		},
+		{
+			ID:          RuleSkippedSectionLevel,
+			Description: "sections whose level is more than one level below their parent",
+			Severity:    types.SeverityWarning,
+			Check:       checkSectionLevels,
+		},
+		{
+			ID:          RuleEmptySection,
+			Description: "sections without any content",
+			Severity:    types.SeverityInfo,
+			Check:       checkEmptySections,
+		},
+		{
+			ID:          RuleMissingImage,
+			Description: "images whose file does not exist",
+			Severity:    types.SeverityWarning,
+			Check:       checkImages,
+		},
+		{
+			ID:          RuleUndefinedAttribute,
+			Description: "references to attributes which are not defined",
+			Severity:    types.SeverityWarning,
+			Check:       reportDiagnostics(types.DiagnosticUnresolvedAttribute),
+		},
+		{
+			ID:          RuleUnclosedBlock,
+			Description: "delimited blocks which are not closed",
+			Severity:    types.SeverityError,
+			Check:       reportDiagnostics(types.DiagnosticUnclosedBlock),
+		},
+		{
+			ID:          RuleUnusedFootnoteReference,
+			Description: "footnotes with a reference which is never used",
+			Severity:    types.SeverityInfo,
+			Check:       checkFootnoteReferences,
+		},
+		{
+			ID:          RuleInconsistentListMarker,
+			Description: "unordered lists which do not use the same marker as the first one in the document",
+			Severity:    types.SeverityInfo,
+			Check:       checkListMarkers,
+		},
+		{
+			ID:          RuleManpageStructure,
+			Description: "manpage documents without the expected header and sections",
+			Severity:    types.SeverityError,
+			Check:       checkManpage,
+		},
+	}
+}
+
+// reportDiagnostics returns a check func which reports the problems already found while parsing the document,
+// ie, the diagnostics with the given code
+func reportDiagnostics(code string) func(ctx *RuleContext) {
+	return func(ctx *RuleContext) {
+		for _, d := range ctx.Config.Diagnostics.All() {
+			if d.Code == code {
+				ctx.Report(d.Range, "%s", d.Message)
+			}
+		}
+	}
+}
+
+func checkDuplicateIDs(ctx *RuleContext) {
+	ids := map[string]types.SourceRange{}
+	types.Walk(ctx.Document, func(element interface{}) bool {
+		e, ok := element.(types.WithAttributes)
+		if !ok {
+			return true
+		}
+		id, ok := e.GetAttributes()[types.AttrID].(string)
+		if !ok || id == "" {
+			return true
+		}
+		if first, found := ids[id]; found {
+			ctx.Report(sourceRangeOf(element), "duplicate ID '%s' (first assigned at %s)", id, first.Start)
+			return true
+		}
+		ids[id] = sourceRangeOf(element)
+		return true
+	})
+	// the duplicate IDs of sections were already replaced with unique values during parsing
+	reportDiagnostics(types.DiagnosticDuplicateID)(ctx)
+}
+
+func checkSectionLevels(ctx *RuleContext) {
+	var check func(maxLevel int, elements []interface{})
+	check = func(maxLevel int, elements []interface{}) {
+		for _, element := range elements {
+			if s, ok := element.(*types.Section); ok {
+				if s.Level > maxLevel {
+					ctx.Report(s.SourceRange, "section title out of sequence: expected level %d, got level %d", maxLevel, s.Level)
+				}
+				check(s.Level+1, s.Elements)
+			}
+		}
+	}
+	// sections at the root of the document are expected to be of level 1 (or 0 for the parts of a book)
+	check(1, ctx.Document.Elements)
+}
+
+func checkEmptySections(ctx *RuleContext) {
+	types.Walk(ctx.Document, func(element interface{}) bool {
+		if s, ok := element.(*types.Section); ok && len(s.Elements) == 0 {
+			title, _ := sgml.RenderPlainText(s.Title)
+			ctx.Report(s.SourceRange, "section '%s' is empty", title)
+		}
+		return true
+	})
+}
+
+func checkImages(ctx *RuleContext) {
+	// attributes set in the configuration take precedence over the attributes declared in the document
+	attrs := types.Attributes{}
+	attrs.SetAll(ctx.Config.Attributes)
+	dir := filepath.Dir(ctx.Config.Filename)
+	types.Walk(ctx.Document, func(element interface{}) bool {
+		switch e := element.(type) {
+		case *types.AttributeDeclaration:
+			if _, found := ctx.Config.Attributes[e.Name]; !found {
+				attrs.Set(e.Name, e.Value)
+			}
+		case *types.AttributeReset:
+			if _, found := ctx.Config.Attributes[e.Name]; !found {
+				attrs.Unset(e.Name)
+			}
+		case *types.ImageBlock:
+			checkImage(ctx, e.Location, e.SourceRange, dir, attrs.GetAsStringWithDefault(types.AttrImagesDir, ""))
+		case *types.InlineImage:
+			checkImage(ctx, e.Location, e.SourceRange, dir, attrs.GetAsStringWithDefault(types.AttrImagesDir, ""))
+		}
+		return true
+	})
+}
+
+// checkImage reports the image if it is a local file which does not exist.
+// Remote images (or images in a remote `imagesdir`) are not checked.
+func checkImage(ctx *RuleContext, location *types.Location, r types.SourceRange, dir, imagesdir string) {
+	if location == nil || location.Scheme != "" {
+		return
+	}
+	path := location.ToString()
+	if u, err := url.Parse(path); err != nil || u.Scheme != "" {
+		return
+	}
+	if u, err := url.Parse(imagesdir); err != nil || u.Scheme != "" {
+		return
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(imagesdir, path)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	f, err := configuration.OpenFile(ctx.Config.FS, path)
+	if err != nil {
+		ctx.Report(r, "image not found: %s", path)
+		return
+	}
+	f.Close()
+}
+
+func checkFootnoteReferences(ctx *RuleContext) {
+	used := map[string]bool{}
+	types.Walk(ctx.Document, func(element interface{}) bool {
+		if r, ok := element.(*types.FootnoteReference); ok && r.Duplicate {
+			used[r.Ref] = true
+		}
+		return true
+	})
+	for _, note := range ctx.Document.Footnotes {
+		if note.Ref != "" && !used[note.Ref] {
+			ctx.Report(note.SourceRange, "footnote reference '%s' is never used", note.Ref)
+		}
+	}
+}
+
+// checkListMarkers reports the unordered lists whose marker is not the same as the first list in the document.
+// Lists nested in other unordered lists are not checked, since their marker is adjusted during parsing.
+func checkListMarkers(ctx *RuleContext) {
+	var first *types.UnorderedListElement
+	nested := map[*types.List]bool{}
+	types.Walk(ctx.Document, func(element interface{}) bool {
+		l, ok := element.(*types.List)
+		if !ok || l.Kind != types.UnorderedListKind || len(l.Elements) == 0 {
+			return true
+		}
+		for _, e := range l.Elements {
+			for _, child := range e.GetElements() {
+				if c, ok := child.(*types.List); ok && c.Kind == types.UnorderedListKind {
+					nested[c] = true
+				}
+			}
+		}
+		e, ok := l.Elements[0].(*types.UnorderedListElement)
+		switch {
+		case !ok || nested[l]:
+			// skip
+		case first == nil:
+			first = e
+		case e.BulletStyle != first.BulletStyle:
+			ctx.Report(e.SourceRange, "list marker '%s' is not the same as the marker '%s' of the first list (at %s)", markerOf(e.BulletStyle), markerOf(first.BulletStyle), first.SourceRange.Start)
+		}
+		return true
+	})
+}
+
+func markerOf(style types.UnorderedListElementBulletStyle) string {
+	switch style {
+	case types.Dash:
+		return "-"
+	case types.OneAsterisk:
+		return "*"
+	case types.TwoAsterisks:
+		return "**"
+	case types.ThreeAsterisks:
+		return "***"
+	case types.FourAsterisks:
+		return "****"
+	default:
+		return "*****"
+	}
+}
+
+func checkManpage(ctx *RuleContext) {
+	doctype := ctx.Config.Attributes.GetAsStringWithDefault(types.AttrDocType, "article")
+	problems, _ := Validate(ctx.Document, doctype)
+	for _, p := range problems {
+		ctx.Report(p.Range, "%s", p.Message)
+	}
+}
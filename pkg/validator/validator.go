@@ -16,11 +16,12 @@ func Validate(doc *types.Document, doctype string) ([]Problem, error) {
 }
 
 // Problem a problem detected during validation
-// Must have a severity and an associated message
-// TODO: include element position once available in the AST.
+// Must have a severity and an associated message, and may have the range
+// in the source of the element which caused the problem
 type Problem struct {
 	Severity Severity
 	Message  string
+	Range    types.SourceRange
 }
 
 // Severity the problem severity
@@ -53,21 +54,30 @@ func validateManpage(doc *types.Document) []Problem {
 		problems = append(problems, Problem{
 			Severity: Error,
 			Message:  "manpage document is missing the 'Name' section",
+			Range:    sourceRangeOf(elements[0]),
 		})
 	} else if ok := assertThatElements(nameSection.Elements).haveCount(1); !ok {
 		problems = append(problems, Problem{
 			Severity: Error,
 			Message:  "'Name' section should contain a single paragraph",
+			Range:    sourceRangeOf(nameSection),
 		})
 	} else if _, ok := assertThatElement(elements[1]).isSection(withLevel(1), withTitle("synopsis")); !ok {
 		problems = append(problems, Problem{
 			Severity: Error,
 			Message:  "manpage document is missing the 'Synopsis' section",
+			Range:    sourceRangeOf(elements[1]),
 		})
 	}
 	return problems
 }
 
+// sourceRangeOf returns the range of the element in the source, or a zero range if it is unknown
+func sourceRangeOf(element interface{}) types.SourceRange {
+	r, _ := types.SourceRangeOf(element)
+	return r
+}
+
 // assert performs a set of assertions on a given element
 func assertThatElement(element interface{}) elementAssertion {
 	return elementAssertion{
@@ -326,6 +326,49 @@ var _ = Describe("document validator", func() {
 				}))
 			})
 
+			It("missing name section - invalid elements with source range", func() {
+				// given
+				r := types.SourceRange{
+					Start: types.SourcePosition{File: "test.adoc", Line: 3, Column: 1},
+					End:   types.SourcePosition{File: "test.adoc", Line: 3, Column: 8},
+				}
+				doc := &types.Document{
+					Elements: []interface{}{
+						&types.DocumentHeader{
+							Title: []interface{}{
+								&types.StringElement{
+									Content: "foo",
+								},
+							},
+						},
+						&types.Section{
+							Positioned: types.Positioned{
+								SourceRange: r,
+							},
+							Attributes: types.Attributes{},
+							Level:      1,
+							Title: []interface{}{
+								&types.StringElement{
+									Content: "Name",
+								},
+							},
+							Elements: []interface{}{}, // invalid length
+						},
+					},
+				}
+
+				// when
+				problems, err := Validate(doc, "manpage")
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+				Expect(problems).To(ContainElement(Problem{
+					Severity: Error,
+					Message:  "'Name' section should contain a single paragraph",
+					Range:    r,
+				}))
+			})
+
 			It("missing synopsis section - invalid level", func() {
 				// given
 				doc := &types.Document{
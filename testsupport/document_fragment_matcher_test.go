@@ -7,6 +7,7 @@ import (
 	"github.com/bytesparadise/libasciidoc/testsupport"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -53,7 +54,7 @@ var _ = Describe("document fragments matcher", func() {
 		// then
 		Expect(err).ToNot(HaveOccurred())
 		Expect(result).To(BeFalse())
-		diffs := cmp.Diff(expected, actual)
+		diffs := cmp.Diff(expected, actual, cmpopts.IgnoreTypes(types.SourceRange{}))
 		Expect(matcher.FailureMessage(actual)).To(Equal(fmt.Sprintf("expected document fragments to match:\n%s", diffs)))
 		Expect(matcher.NegatedFailureMessage(actual)).To(Equal(fmt.Sprintf("expected document fragments not to match:\n%s", diffs)))
 	})
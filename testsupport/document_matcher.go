@@ -26,17 +26,22 @@ type documentMatcher struct {
 	diffs    string
 }
 
-var opts = []cmp.Option{cmpopts.IgnoreUnexported(
-	types.List{},
-	types.DelimitedBlock{},
-	types.Footnotes{},
-	types.TableOfContents{},
-	types.AttributeDeclaration{},
-	types.AttributeReference{},
-	types.AttributeReset{},
-	types.CounterSubstitution{},
-	types.PredefinedAttribute{},
-)}
+var opts = []cmp.Option{
+	cmpopts.IgnoreUnexported(
+		types.List{},
+		types.DelimitedBlock{},
+		types.Footnotes{},
+		types.Index{},
+		types.TableOfContents{},
+		types.AttributeDeclaration{},
+		types.AttributeReference{},
+		types.AttributeReset{},
+		types.CounterSubstitution{},
+		types.PredefinedAttribute{},
+	),
+	// positions in the source are verified in dedicated tests
+	cmpopts.IgnoreTypes(types.SourceRange{}),
+}
 
 func (m *documentMatcher) Match(actual interface{}) (success bool, err error) {
 	if _, ok := actual.(*types.Document); !ok {
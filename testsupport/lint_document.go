@@ -0,0 +1,16 @@
+package testsupport
+
+import (
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc"
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+)
+
+// LintDocument checks the given source with the linter rules and returns the problems that were found
+func LintDocument(actual string, settings ...configuration.Setting) ([]types.Diagnostic, error) {
+	allSettings := append([]configuration.Setting{configuration.WithFilename("test.adoc")}, settings...)
+	config := configuration.NewConfiguration(allSettings...)
+	return libasciidoc.Lint(strings.NewReader(actual), config)
+}
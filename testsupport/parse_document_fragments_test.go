@@ -18,8 +18,20 @@ var _ = Describe("parse document fragment groups", func() {
 			},
 			Elements: []interface{}{
 				&types.Paragraph{
+					Positioned: types.Positioned{
+						SourceRange: types.SourceRange{
+							Start: types.SourcePosition{Line: 1, Column: 1},
+							End:   types.SourcePosition{Line: 1, Column: 14},
+						},
+					},
 					Elements: []interface{}{
 						&types.RawLine{
+							Positioned: types.Positioned{
+								SourceRange: types.SourceRange{
+									Start: types.SourcePosition{Line: 1, Column: 1},
+									End:   types.SourcePosition{Line: 1, Column: 14},
+								},
+							},
 							Content: "hello, world!",
 						},
 					},
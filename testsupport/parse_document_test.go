@@ -13,8 +13,20 @@ var _ = Describe("parse document", func() {
 	expected := &types.Document{
 		Elements: []interface{}{
 			&types.Paragraph{
+				Positioned: types.Positioned{
+					SourceRange: types.SourceRange{
+						Start: types.SourcePosition{File: "test.adoc", Line: 1, Column: 1},
+						End:   types.SourcePosition{File: "test.adoc", Line: 1, Column: 14},
+					},
+				},
 				Elements: []interface{}{
 					&types.StringElement{
+						Positioned: types.Positioned{
+							SourceRange: types.SourceRange{
+								Start: types.SourcePosition{File: "test.adoc", Line: 1, Column: 1},
+								End:   types.SourcePosition{File: "test.adoc", Line: 1, Column: 14},
+							},
+						},
 						Content: "hello, world!",
 					},
 				},
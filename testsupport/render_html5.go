@@ -7,6 +7,7 @@ import (
 
 	"github.com/bytesparadise/libasciidoc"
 	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 	log "github.com/sirupsen/logrus"
 )
@@ -34,6 +35,18 @@ func RenderHTMLWithMetadata(actual string, settings ...configuration.Setting) (s
 	return resultWriter.String(), metadata, nil
 }
 
+// RenderHTMLChunks renders the HTML bodies of the pages of the given source, split at the sections of the given level
+func RenderHTMLChunks(actual string, level int, settings ...configuration.Setting) ([]sgml.Chunk, error) {
+	allSettings := append([]configuration.Setting{configuration.WithFilename("test.adoc"), configuration.WithBackEnd("html5")}, settings...)
+	config := configuration.NewConfiguration(allSettings...)
+	chunks, _, err := libasciidoc.ConvertToChunks(strings.NewReader(actual), config, level)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return chunks, nil
+}
+
 // RenderHTML renders the HTML body using the given source
 func RenderHTMLFromFile(filename string, settings ...configuration.Setting) (string, types.Metadata, error) {
 	info, err := os.Stat(filename)
@@ -22,4 +22,16 @@ var _ = Describe("html5 body renderer", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(result).To(Equal(expected))
 	})
+
+	It("should match chunks", func() {
+		// given
+		actual := "hello, world!\n\n== Section A\n\ncontent"
+		// when
+		result, err := testsupport.RenderHTMLChunks(actual, 1)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].Name).To(Equal("test.html"))
+		Expect(result[1].Name).To(Equal("section_a.html"))
+	})
 })